@@ -19,6 +19,22 @@ type Exemplars struct {
 	Enabled bool `mapstructure:"enabled"`
 }
 
+// Fingerprint defines the configuration for grouping the exceptions into issues.
+type Fingerprint struct {
+	// Enabled adds the exception.fingerprint attribute to the metrics and logs, and the
+	// metrics tracking the occurrences of each fingerprint.
+	Enabled bool `mapstructure:"enabled"`
+	// MaxFrames is the number of top in-app frames of the stack trace used to build the fingerprint.
+	MaxFrames int `mapstructure:"max_frames"`
+	// InAppPatterns are regular expressions matching the in-app frames of the stack traces.
+	// When empty, all the frames are in-app except the ones of the well-known runtimes and dependencies.
+	InAppPatterns []string `mapstructure:"in_app_patterns"`
+	// NormalizeMessage replaces the exception.message dimension of the exceptions metric with
+	// the normalized message, so that the identifiers held by the messages don't make every
+	// exception unique. The issue metrics always hold the normalized message.
+	NormalizeMessage bool `mapstructure:"normalize_message"`
+}
+
 // Config defines the configuration options for exceptionsconnector
 type Config struct {
 	// Dimensions defines the list of additional dimensions on top of the provided:
@@ -31,6 +47,8 @@ type Config struct {
 	Dimensions []Dimension `mapstructure:"dimensions"`
 	// Exemplars defines the configuration for exemplars.
 	Exemplars Exemplars `mapstructure:"exemplars"`
+	// Fingerprint defines the configuration for grouping the exceptions into issues.
+	Fingerprint Fingerprint `mapstructure:"fingerprint"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -43,13 +61,21 @@ func (c Config) Validate() error {
 	if err != nil {
 		return err
 	}
+	if c.Fingerprint.Enabled {
+		if c.Fingerprint.MaxFrames <= 0 {
+			return fmt.Errorf("invalid fingerprint max_frames: %d, the number should be positive", c.Fingerprint.MaxFrames)
+		}
+		if _, err := compileInAppPatterns(c.Fingerprint.InAppPatterns); err != nil {
+			return fmt.Errorf("invalid fingerprint in_app_patterns: %w", err)
+		}
+	}
 	return nil
 }
 
 // validateDimensions checks duplicates for reserved dimensions and additional dimensions.
 func validateDimensions(dimensions []Dimension) error {
 	labelNames := make(map[string]struct{})
-	for _, key := range []string{serviceNameKey, spanKindKey, spanNameKey, statusCodeKey} {
+	for _, key := range []string{serviceNameKey, spanKindKey, spanNameKey, statusCodeKey, exceptionFingerprintKey} {
 		labelNames[key] = struct{}{}
 	}
 
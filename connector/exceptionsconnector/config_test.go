@@ -40,6 +40,12 @@ func TestLoadConfig(t *testing.T) {
 				Exemplars: Exemplars{
 					Enabled: false,
 				},
+				Fingerprint: Fingerprint{
+					Enabled:          true,
+					MaxFrames:        3,
+					InAppPatterns:    []string{`^com\.example\.`},
+					NormalizeMessage: true,
+				},
 			},
 		},
 	}
@@ -102,3 +108,39 @@ func TestValidateDimensions(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateFingerprint(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		fingerprint Fingerprint
+		expectedErr string
+	}{
+		{
+			name:        "disabled",
+			fingerprint: Fingerprint{MaxFrames: 0},
+		},
+		{
+			name:        "valid",
+			fingerprint: Fingerprint{Enabled: true, MaxFrames: 5, InAppPatterns: []string{`^com\.example\.`}},
+		},
+		{
+			name:        "invalid max frames",
+			fingerprint: Fingerprint{Enabled: true},
+			expectedErr: "invalid fingerprint max_frames: 0, the number should be positive",
+		},
+		{
+			name:        "invalid in-app pattern",
+			fingerprint: Fingerprint{Enabled: true, MaxFrames: 5, InAppPatterns: []string{"("}},
+			expectedErr: "invalid fingerprint in_app_patterns: error parsing regexp: missing closing ): `(`",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Config{Fingerprint: tc.fingerprint}.Validate()
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -20,6 +20,10 @@ const (
 	spanNameKey   = "span.name"   // OpenTelemetry non-standard constant.
 	statusCodeKey = "status.code" // OpenTelemetry non-standard constant.
 	eventNameExc  = "exception"   // OpenTelemetry non-standard constant.
+	// exceptionFingerprintKey identifies the issue of the exception.
+	exceptionFingerprintKey = "exception.fingerprint" // OpenTelemetry non-standard constant.
+
+	defaultFingerprintMaxFrames = 5
 )
 
 func newDimensions(cfgDims []Dimension) []pdatautil.Dimension {
@@ -37,6 +41,23 @@ func newDimensions(cfgDims []Dimension) []pdatautil.Dimension {
 	return dims
 }
 
+// newFingerprinterIfEnabled returns the fingerprinter of the exceptions, nil when the
+// fingerprinting is disabled.
+func newFingerprinterIfEnabled(cfg Fingerprint) (*fingerprinter, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	return newFingerprinter(cfg)
+}
+
+// exceptionFingerprint returns the fingerprint of the exception described by the event attributes.
+func exceptionFingerprint(f *fingerprinter, eventAttrs pcommon.Map) string {
+	excType, _ := pdatautil.GetAttributeValue(exceptionTypeKey, eventAttrs)
+	message, _ := pdatautil.GetAttributeValue(exceptionMessageKey, eventAttrs)
+	stacktrace, _ := pdatautil.GetAttributeValue(exceptionStacktraceKey, eventAttrs)
+	return f.fingerprint(excType, message, stacktrace)
+}
+
 // getDimensionValue gets the dimension value for the given configured dimension.
 // It searches through the span's attributes first, being the more specific;
 // falling back to searching in resource attributes if it can't be found in the span.
@@ -24,6 +24,9 @@ type logsConnector struct {
 	// Additional dimensions to add to logs.
 	dimensions []pdatautil.Dimension
 
+	// Fingerprinter of the exceptions, nil when the fingerprinting is disabled.
+	fingerprinter *fingerprinter
+
 	logsConsumer consumer.Logs
 	component.StartFunc
 	component.ShutdownFunc
@@ -31,14 +34,20 @@ type logsConnector struct {
 	logger *zap.Logger
 }
 
-func newLogsConnector(logger *zap.Logger, config component.Config) *logsConnector {
+func newLogsConnector(logger *zap.Logger, config component.Config) (*logsConnector, error) {
 	cfg := config.(*Config)
 
-	return &logsConnector{
-		logger:     logger,
-		config:     *cfg,
-		dimensions: newDimensions(cfg.Dimensions),
+	f, err := newFingerprinterIfEnabled(cfg.Fingerprint)
+	if err != nil {
+		return nil, err
 	}
+
+	return &logsConnector{
+		logger:        logger,
+		config:        *cfg,
+		dimensions:    newDimensions(cfg.Dimensions),
+		fingerprinter: f,
+	}, nil
 }
 
 // Capabilities implements the consumer interface.
@@ -122,5 +131,9 @@ func (c *logsConnector) attrToLogRecord(sl plog.ScopeLogs, serviceName string, s
 	// Add stacktrace to the log record.
 	attrVal, _ := pdatautil.GetAttributeValue(exceptionStacktraceKey, eventAttrs)
 	logRecord.Attributes().PutStr(exceptionStacktraceKey, attrVal)
+
+	if c.fingerprinter != nil {
+		logRecord.Attributes().PutStr(exceptionFingerprintKey, exceptionFingerprint(c.fingerprinter, eventAttrs))
+	}
 	return logRecord
 }
@@ -6,6 +6,7 @@ package exceptionsconnector
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -54,7 +55,34 @@ func newTestLogsConnector(lcon consumer.Logs, logger *zap.Logger) *logsConnector
 		},
 	}
 
-	c := newLogsConnector(logger, cfg)
+	c, _ := newLogsConnector(logger, cfg)
 	c.logsConsumer = lcon
 	return c
 }
+
+func TestConnectorLogConsumeTracesFingerprint(t *testing.T) {
+	lsink := new(consumertest.LogsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Fingerprint.Enabled = true
+	p, err := newLogsConnector(zaptest.NewLogger(t), cfg)
+	require.NoError(t, err)
+	p.logsConsumer = lsink
+
+	traces := buildFingerprintTrace(time.Unix(1700000000, 0), "order 12345 not found", "order 678 not found")
+	require.NoError(t, p.ConsumeTraces(context.Background(), traces))
+
+	logs := lsink.AllLogs()
+	require.Len(t, logs, 1)
+	records := logs[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, records.Len())
+	var fingerprints []string
+	for i := 0; i < records.Len(); i++ {
+		fingerprint, ok := records.At(i).Attributes().Get(exceptionFingerprintKey)
+		require.True(t, ok)
+		fingerprints = append(fingerprints, fingerprint.Str())
+		// The log records keep the original message.
+		message, _ := records.At(i).Attributes().Get(exceptionMessageKey)
+		assert.Contains(t, message.Str(), "not found")
+	}
+	assert.Equal(t, fingerprints[0], fingerprints[1])
+}
@@ -40,10 +40,16 @@ type metricsConnector struct {
 
 	exceptions map[string]*exception
 
+	// Fingerprinter of the exceptions, nil when the fingerprinting is disabled.
+	fingerprinter *fingerprinter
+	// Issues of the exceptions, by service and fingerprint.
+	issues map[string]*issue
+
 	logger *zap.Logger
 
 	// The starting time of the data points.
 	startTimestamp pcommon.Timestamp
+	timeNow        func() time.Time
 }
 
 type exception struct {
@@ -52,9 +58,22 @@ type exception struct {
 	exemplars pmetric.ExemplarSlice
 }
 
-func newMetricsConnector(logger *zap.Logger, config component.Config) *metricsConnector {
+// issue groups the exceptions of a service sharing the same fingerprint.
+type issue struct {
+	count     int
+	attrs     pcommon.Map
+	firstSeen pcommon.Timestamp
+	lastSeen  pcommon.Timestamp
+}
+
+func newMetricsConnector(logger *zap.Logger, config component.Config) (*metricsConnector, error) {
 	cfg := config.(*Config)
 
+	f, err := newFingerprinterIfEnabled(cfg.Fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
 	return &metricsConnector{
 		logger:         logger,
 		config:         *cfg,
@@ -62,7 +81,10 @@ func newMetricsConnector(logger *zap.Logger, config component.Config) *metricsCo
 		keyBuf:         bytes.NewBuffer(make([]byte, 0, 1024)),
 		startTimestamp: pcommon.NewTimestampFromTime(time.Now()),
 		exceptions:     make(map[string]*exception),
-	}
+		fingerprinter:  f,
+		issues:         make(map[string]*issue),
+		timeNow:        time.Now,
+	}, nil
 }
 
 // Capabilities implements the consumer interface.
@@ -92,11 +114,28 @@ func (c *metricsConnector) ConsumeTraces(ctx context.Context, traces ptrace.Trac
 					if event.Name() == eventNameExc {
 						eventAttrs := event.Attributes()
 
+						var fingerprint string
+						if c.fingerprinter != nil {
+							fingerprint = exceptionFingerprint(c.fingerprinter, eventAttrs)
+							if c.config.Fingerprint.NormalizeMessage {
+								// The messages of the exceptions are normalized so that the identifiers
+								// they contain don't make every exception unique.
+								eventAttrs = normalizeEventAttributes(eventAttrs)
+							}
+						}
+
 						c.keyBuf.Reset()
 						buildKey(c.keyBuf, serviceName, span, c.dimensions, eventAttrs, resourceAttr)
+						if fingerprint != "" {
+							concatDimensionValue(c.keyBuf, fingerprint, true)
+						}
 						key := c.keyBuf.String()
 
 						attrs := buildDimensionKVs(c.dimensions, serviceName, span, eventAttrs, resourceAttr)
+						if fingerprint != "" {
+							attrs.PutStr(exceptionFingerprintKey, fingerprint)
+							c.addIssue(serviceName, fingerprint, eventAttrs, event.Timestamp())
+						}
 						exc := c.addException(key, attrs)
 						c.addExemplar(exc, span.TraceID(), span.SpanID())
 					}
@@ -117,6 +156,9 @@ func (c *metricsConnector) exportMetrics(ctx context.Context) error {
 		c.lock.Unlock()
 		return err
 	}
+	if c.fingerprinter != nil {
+		c.collectIssues(ilm)
+	}
 	c.lock.Unlock()
 
 	if err := c.metricsConsumer.ConsumeMetrics(ctx, m); err != nil {
@@ -134,7 +176,7 @@ func (c *metricsConnector) collectExceptions(ilm pmetric.ScopeMetrics) error {
 	mCalls.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
 	dps := mCalls.Sum().DataPoints()
 	dps.EnsureCapacity(len(c.exceptions))
-	timestamp := pcommon.NewTimestampFromTime(time.Now())
+	timestamp := pcommon.NewTimestampFromTime(c.timeNow())
 	for _, exc := range c.exceptions {
 		dp := dps.AppendEmpty()
 		dp.SetStartTimestamp(c.startTimestamp)
@@ -152,6 +194,77 @@ func (c *metricsConnector) collectExceptions(ilm pmetric.ScopeMetrics) error {
 	return nil
 }
 
+// collectIssues writes the number of occurrences of each issue, and when it was first and last seen,
+// into the metrics object. The start timestamp of the occurrences is the first time the issue was seen.
+func (c *metricsConnector) collectIssues(ilm pmetric.ScopeMetrics) {
+	mOccurrences := ilm.Metrics().AppendEmpty()
+	mOccurrences.SetName("exceptions.occurrences")
+	mOccurrences.SetEmptySum().SetIsMonotonic(true)
+	mOccurrences.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	occurrences := mOccurrences.Sum().DataPoints()
+	occurrences.EnsureCapacity(len(c.issues))
+
+	mFirstSeen := ilm.Metrics().AppendEmpty()
+	mFirstSeen.SetName("exceptions.first_seen")
+	mFirstSeen.SetUnit("s")
+	firstSeen := mFirstSeen.SetEmptyGauge().DataPoints()
+	firstSeen.EnsureCapacity(len(c.issues))
+
+	mLastSeen := ilm.Metrics().AppendEmpty()
+	mLastSeen.SetName("exceptions.last_seen")
+	mLastSeen.SetUnit("s")
+	lastSeen := mLastSeen.SetEmptyGauge().DataPoints()
+	lastSeen.EnsureCapacity(len(c.issues))
+
+	timestamp := pcommon.NewTimestampFromTime(c.timeNow())
+	for _, iss := range c.issues {
+		dp := occurrences.AppendEmpty()
+		dp.SetStartTimestamp(iss.firstSeen)
+		dp.SetTimestamp(timestamp)
+		dp.SetIntValue(int64(iss.count))
+		iss.attrs.CopyTo(dp.Attributes())
+
+		dp = firstSeen.AppendEmpty()
+		dp.SetTimestamp(timestamp)
+		dp.SetIntValue(iss.firstSeen.AsTime().Unix())
+		iss.attrs.CopyTo(dp.Attributes())
+
+		dp = lastSeen.AppendEmpty()
+		dp.SetTimestamp(timestamp)
+		dp.SetIntValue(iss.lastSeen.AsTime().Unix())
+		iss.attrs.CopyTo(dp.Attributes())
+	}
+}
+
+func (c *metricsConnector) addIssue(serviceName, fingerprint string, eventAttrs pcommon.Map, seen pcommon.Timestamp) {
+	if seen == 0 {
+		seen = pcommon.NewTimestampFromTime(c.timeNow())
+	}
+	issueKey := serviceName + metricKeySeparator + fingerprint
+	iss, ok := c.issues[issueKey]
+	if !ok {
+		attrs := pcommon.NewMap()
+		attrs.PutStr(serviceNameKey, serviceName)
+		attrs.PutStr(exceptionFingerprintKey, fingerprint)
+		if v, ok := eventAttrs.Get(exceptionTypeKey); ok {
+			v.CopyTo(attrs.PutEmpty(exceptionTypeKey))
+		}
+		// The message of the first occurrence stands for the whole issue once normalized.
+		if v, ok := eventAttrs.Get(exceptionMessageKey); ok {
+			attrs.PutStr(exceptionMessageKey, normalizeMessage(v.AsString()))
+		}
+		iss = &issue{attrs: attrs, firstSeen: seen, lastSeen: seen}
+		c.issues[issueKey] = iss
+	}
+	iss.count++
+	if seen < iss.firstSeen {
+		iss.firstSeen = seen
+	}
+	if seen > iss.lastSeen {
+		iss.lastSeen = seen
+	}
+}
+
 func (c *metricsConnector) addException(excKey string, attrs pcommon.Map) *exception {
 	exc, ok := c.exceptions[excKey]
 	if !ok {
@@ -176,6 +289,18 @@ func (c *metricsConnector) addExemplar(exc *exception, traceID pcommon.TraceID,
 	e.SetDoubleValue(float64(exc.count))
 }
 
+// normalizeEventAttributes returns a copy of the event attributes holding the normalized message of the exception.
+func normalizeEventAttributes(eventAttrs pcommon.Map) pcommon.Map {
+	message, ok := eventAttrs.Get(exceptionMessageKey)
+	if !ok {
+		return eventAttrs
+	}
+	attrs := pcommon.NewMap()
+	eventAttrs.CopyTo(attrs)
+	attrs.PutStr(exceptionMessageKey, normalizeMessage(message.AsString()))
+	return attrs
+}
+
 func buildDimensionKVs(dimensions []pdatautil.Dimension, serviceName string, span ptrace.Span, eventAttrs pcommon.Map, resourceAttrs pcommon.Map) pcommon.Map {
 	dims := pcommon.NewMap()
 	dims.EnsureCapacity(3 + len(dimensions))
@@ -144,7 +144,7 @@ func newTestMetricsConnector(mcon consumer.Metrics, defaultNullValue *string, lo
 			Enabled: true,
 		},
 	}
-	c := newMetricsConnector(logger, cfg)
+	c, _ := newMetricsConnector(logger, cfg)
 	c.metricsConsumer = mcon
 	return c
 }
@@ -342,3 +342,113 @@ func TestBuildKeyWithDimensions(t *testing.T) {
 		})
 	}
 }
+
+func TestConnectorConsumeTracesFingerprint(t *testing.T) {
+	msink := &consumertest.MetricsSink{}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Fingerprint.Enabled = true
+	cfg.Fingerprint.NormalizeMessage = true
+	p, err := newMetricsConnector(zaptest.NewLogger(t), cfg)
+	require.NoError(t, err)
+	p.metricsConsumer = msink
+
+	t0 := time.Unix(1700000000, 0)
+	traces := buildFingerprintTrace(t0, "order 12345 not found", "order 678 not found")
+	require.NoError(t, p.ConsumeTraces(context.Background(), traces))
+
+	metrics := msink.AllMetrics()
+	require.Len(t, metrics, 1)
+	ms := metrics[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 4, ms.Len())
+
+	exceptions := ms.At(0)
+	assert.Equal(t, "exceptions", exceptions.Name())
+	require.Equal(t, 1, exceptions.Sum().DataPoints().Len())
+	dp := exceptions.Sum().DataPoints().At(0)
+	assert.Equal(t, int64(2), dp.IntValue())
+	message, _ := dp.Attributes().Get(exceptionMessageKey)
+	assert.Equal(t, "order <num> not found", message.Str())
+	fingerprint, ok := dp.Attributes().Get(exceptionFingerprintKey)
+	require.True(t, ok)
+	assert.Len(t, fingerprint.Str(), 32)
+
+	issueAttrs := map[string]any{
+		serviceNameKey:          "service-a",
+		exceptionFingerprintKey: fingerprint.Str(),
+		exceptionTypeKey:        "OrderNotFound",
+		exceptionMessageKey:     "order <num> not found",
+	}
+
+	occurrences := ms.At(1)
+	assert.Equal(t, "exceptions.occurrences", occurrences.Name())
+	require.Equal(t, 1, occurrences.Sum().DataPoints().Len())
+	dp = occurrences.Sum().DataPoints().At(0)
+	assert.Equal(t, int64(2), dp.IntValue())
+	assert.Equal(t, pcommon.NewTimestampFromTime(t0), dp.StartTimestamp())
+	assert.Equal(t, issueAttrs, dp.Attributes().AsRaw())
+
+	firstSeen := ms.At(2)
+	assert.Equal(t, "exceptions.first_seen", firstSeen.Name())
+	require.Equal(t, 1, firstSeen.Gauge().DataPoints().Len())
+	assert.Equal(t, t0.Unix(), firstSeen.Gauge().DataPoints().At(0).IntValue())
+	assert.Equal(t, issueAttrs, firstSeen.Gauge().DataPoints().At(0).Attributes().AsRaw())
+
+	lastSeen := ms.At(3)
+	assert.Equal(t, "exceptions.last_seen", lastSeen.Name())
+	require.Equal(t, 1, lastSeen.Gauge().DataPoints().Len())
+	assert.Equal(t, t0.Add(time.Minute).Unix(), lastSeen.Gauge().DataPoints().At(0).IntValue())
+}
+
+func TestConnectorConsumeTracesFingerprintRawMessage(t *testing.T) {
+	msink := &consumertest.MetricsSink{}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Fingerprint.Enabled = true
+	p, err := newMetricsConnector(zaptest.NewLogger(t), cfg)
+	require.NoError(t, err)
+	p.metricsConsumer = msink
+
+	traces := buildFingerprintTrace(time.Unix(1700000000, 0), "order 12345 not found", "order 678 not found")
+	require.NoError(t, p.ConsumeTraces(context.Background(), traces))
+
+	metrics := msink.AllMetrics()
+	require.Len(t, metrics, 1)
+	ms := metrics[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 4, ms.Len())
+
+	exceptions := ms.At(0)
+	require.Equal(t, 2, exceptions.Sum().DataPoints().Len())
+	var messages []string
+	for i := 0; i < exceptions.Sum().DataPoints().Len(); i++ {
+		message, _ := exceptions.Sum().DataPoints().At(i).Attributes().Get(exceptionMessageKey)
+		messages = append(messages, message.Str())
+	}
+	assert.ElementsMatch(t, []string{"order 12345 not found", "order 678 not found"}, messages)
+
+	occurrences := ms.At(1)
+	require.Equal(t, 1, occurrences.Sum().DataPoints().Len())
+	dp := occurrences.Sum().DataPoints().At(0)
+	assert.Equal(t, int64(2), dp.IntValue())
+	message, _ := dp.Attributes().Get(exceptionMessageKey)
+	assert.Equal(t, "order <num> not found", message.Str())
+}
+
+// buildFingerprintTrace builds a trace with a span of service-a for each message, holding
+// an OrderNotFound exception event. The exceptions occur a minute apart from t0.
+func buildFingerprintTrace(t0 time.Time, messages ...string) ptrace.Traces {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr(serviceNameKey, "service-a")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	for i, message := range messages {
+		span := spans.AppendEmpty()
+		span.SetName("GET /orders")
+		span.SetKind(ptrace.SpanKindServer)
+		span.Status().SetCode(ptrace.StatusCodeError)
+		e := span.Events().AppendEmpty()
+		e.SetName(eventNameExc)
+		e.SetTimestamp(pcommon.NewTimestampFromTime(t0.Add(time.Duration(i) * time.Minute)))
+		e.Attributes().PutStr(exceptionTypeKey, "OrderNotFound")
+		e.Attributes().PutStr(exceptionMessageKey, message)
+	}
+	return traces
+}
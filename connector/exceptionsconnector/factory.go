@@ -31,17 +31,26 @@ func createDefaultConfig() component.Config {
 			{Name: exceptionTypeKey},
 			{Name: exceptionMessageKey},
 		},
+		Fingerprint: Fingerprint{
+			MaxFrames: defaultFingerprintMaxFrames,
+		},
 	}
 }
 
 func createTracesToMetricsConnector(_ context.Context, params connector.Settings, cfg component.Config, nextConsumer consumer.Metrics) (connector.Traces, error) {
-	mc := newMetricsConnector(params.Logger, cfg)
+	mc, err := newMetricsConnector(params.Logger, cfg)
+	if err != nil {
+		return nil, err
+	}
 	mc.metricsConsumer = nextConsumer
 	return mc, nil
 }
 
 func createTracesToLogsConnector(_ context.Context, params connector.Settings, cfg component.Config, nextConsumer consumer.Logs) (connector.Traces, error) {
-	lc := newLogsConnector(params.Logger, cfg)
+	lc, err := newLogsConnector(params.Logger, cfg)
+	if err != nil {
+		return nil, err
+	}
 	lc.logsConsumer = nextConsumer
 	return lc, nil
 }
@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exceptionsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/exceptionsconnector"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const pythonTracebackHeader = "Traceback (most recent call last):"
+
+var (
+	uuidRegexp    = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+	addressRegexp = regexp.MustCompile(`0[xX][0-9a-fA-F]+`)
+	hashRegexp    = regexp.MustCompile(`\b[0-9a-fA-F]{8,}\b`)
+	numberRegexp  = regexp.MustCompile(`\d+`)
+	spacesRegexp  = regexp.MustCompile(`\s+`)
+
+	// lineNumberRegexp matches the line and column numbers of a frame, e.g. Main.java:42,
+	// app.js:10:5 or `line 10` in Python stack traces.
+	lineNumberRegexp = regexp.MustCompile(`(:\d+)+|,? line \d+`)
+	// goFrameArgsRegexp matches the arguments of the function lines of Go stack traces.
+	goFrameArgsRegexp = regexp.MustCompile(`\(.*\)$`)
+	// frameAddressRegexp matches the addresses of the frames, e.g. the program counter
+	// offsets of Go stack traces.
+	frameAddressRegexp = regexp.MustCompile(`\+?0[xX][0-9a-fA-F]+`)
+)
+
+// defaultNotInAppFramePrefixes are the prefixes of the frames of the runtimes and of the
+// common dependencies, which are not in-app frames when no in-app pattern is configured.
+var defaultNotInAppFramePrefixes = []string{
+	"java.", "javax.", "jdk.", "sun.", "kotlin.", "scala.",
+	"System.", "Microsoft.",
+	"runtime.", "net/http.", "testing.",
+	"node:", "internal/",
+}
+
+// defaultNotInAppFrameParts are the parts of the paths of the frames of the dependencies.
+var defaultNotInAppFrameParts = []string{
+	"(node:", "/node_modules/", "/site-packages/", "/dist-packages/", "/lib/python", "/go/pkg/mod/", "/usr/local/go/src/", "<frozen ",
+}
+
+// fingerprinter groups the exceptions into issues identified by a fingerprint, built from
+// the type of the exception and either the top in-app frames of its stack trace or, when it
+// has no stack trace, its normalized message.
+type fingerprinter struct {
+	maxFrames     int
+	inAppPatterns []*regexp.Regexp
+}
+
+func newFingerprinter(cfg Fingerprint) (*fingerprinter, error) {
+	patterns, err := compileInAppPatterns(cfg.InAppPatterns)
+	if err != nil {
+		return nil, err
+	}
+	return &fingerprinter{
+		maxFrames:     cfg.MaxFrames,
+		inAppPatterns: patterns,
+	}, nil
+}
+
+func compileInAppPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// fingerprint returns the fingerprint of the exception as a hex string.
+func (f *fingerprinter) fingerprint(excType, message, stacktrace string) string {
+	h := sha256.New()
+	h.Write([]byte(excType))
+	h.Write([]byte{0})
+	if frames := f.topFrames(stacktrace); len(frames) > 0 {
+		h.Write([]byte(strings.Join(frames, "\n")))
+	} else {
+		h.Write([]byte(normalizeMessage(message)))
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// topFrames returns the top in-app frames of the stack trace, normalized. The frames of the
+// stack traces of Java, .NET, JavaScript, Python and Go are recognized.
+func (f *fingerprinter) topFrames(stacktrace string) []string {
+	var frames []string
+	lines := strings.Split(stacktrace, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		var frame string
+		switch {
+		case strings.HasPrefix(line, "at "):
+			frame = strings.TrimPrefix(line, "at ")
+		case strings.HasPrefix(line, `File "`):
+			frame = line
+		case isGoFunctionLine(lines, i):
+			// Go stack traces hold the function of the frame, followed by its file
+			// indented by a tab.
+			frame = goFrameArgsRegexp.ReplaceAllString(line, "") + " " + strings.TrimSpace(lines[i+1])
+			i++
+		default:
+			continue
+		}
+		if !f.isInApp(frame) {
+			continue
+		}
+		frames = append(frames, normalizeFrame(frame))
+	}
+
+	// Python stack traces start with the outermost frame.
+	if strings.Contains(stacktrace, pythonTracebackHeader) {
+		slices.Reverse(frames)
+	}
+	if len(frames) > f.maxFrames {
+		frames = frames[:f.maxFrames]
+	}
+	return frames
+}
+
+// isGoFunctionLine returns whether the line i is the function of a frame of a Go stack trace.
+func isGoFunctionLine(lines []string, i int) bool {
+	return i+1 < len(lines) &&
+		!strings.HasPrefix(lines[i], "\t") && strings.HasSuffix(strings.TrimSpace(lines[i]), ")") &&
+		strings.HasPrefix(lines[i+1], "\t") && !strings.HasPrefix(strings.TrimSpace(lines[i+1]), "at ")
+}
+
+func (f *fingerprinter) isInApp(frame string) bool {
+	if len(f.inAppPatterns) > 0 {
+		for _, re := range f.inAppPatterns {
+			if re.MatchString(frame) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, prefix := range defaultNotInAppFramePrefixes {
+		if strings.HasPrefix(frame, prefix) {
+			return false
+		}
+	}
+	for _, part := range defaultNotInAppFrameParts {
+		if strings.Contains(frame, part) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeFrame strips the line numbers and the addresses of the frame, which change
+// between the builds of an application.
+func normalizeFrame(frame string) string {
+	frame = lineNumberRegexp.ReplaceAllString(frame, "")
+	frame = frameAddressRegexp.ReplaceAllString(frame, "")
+	return strings.Join(strings.Fields(frame), " ")
+}
+
+// normalizeMessage replaces the variable parts of the message, like identifiers and
+// addresses, by placeholders.
+func normalizeMessage(message string) string {
+	message = uuidRegexp.ReplaceAllString(message, "<uuid>")
+	message = addressRegexp.ReplaceAllString(message, "<addr>")
+	message = hashRegexp.ReplaceAllStringFunc(message, func(s string) string {
+		// Words made of hexadecimal letters only are kept.
+		if strings.ContainsAny(s, "0123456789") {
+			return "<hash>"
+		}
+		return s
+	})
+	message = numberRegexp.ReplaceAllString(message, "<num>")
+	return strings.TrimSpace(spacesRegexp.ReplaceAllString(message, " "))
+}
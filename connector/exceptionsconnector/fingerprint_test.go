@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exceptionsconnector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	javaStacktrace = `java.lang.IllegalStateException: order 12345 not found
+	at com.example.orders.OrderService.find(OrderService.java:42)
+	at com.example.orders.OrderController.get(OrderController.java:17)
+	at java.base/java.lang.Thread.run(Thread.java:833)`
+	pythonStacktrace = `Traceback (most recent call last):
+  File "/usr/lib/python3.12/site-packages/flask/app.py", line 880, in full_dispatch_request
+    rv = self.dispatch_request()
+  File "/app/orders/views.py", line 21, in get_order
+    return find(order_id)
+  File "/app/orders/service.py", line 8, in find
+    raise KeyError(order_id)
+KeyError: '12345'`
+	goStacktrace = `goroutine 1 [running]:
+main.find(0xc000012345)
+	/app/orders/service.go:42 +0x1d
+main.handler({0x1, 0x2})
+	/app/orders/handler.go:17 +0x2a
+net/http.HandlerFunc.ServeHTTP(0xc000010000, {0x7f, 0xc0}, 0xc0)
+	/usr/local/go/src/net/http/server.go:2136 +0x29`
+	jsStacktrace = `TypeError: Cannot read properties of undefined (reading 'id')
+    at findOrder (/app/src/orders.js:10:5)
+    at Layer.handle (/app/node_modules/express/lib/router/layer.js:95:5)
+    at processTicksAndRejections (node:internal/process/task_queues:95:5)`
+)
+
+func TestTopFrames(t *testing.T) {
+	f, err := newFingerprinter(Fingerprint{MaxFrames: 5})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		stacktrace string
+		want       []string
+	}{
+		{
+			name:       "java",
+			stacktrace: javaStacktrace,
+			want: []string{
+				"com.example.orders.OrderService.find(OrderService.java)",
+				"com.example.orders.OrderController.get(OrderController.java)",
+			},
+		},
+		{
+			name:       "python",
+			stacktrace: pythonStacktrace,
+			want: []string{
+				`File "/app/orders/service.py", in find`,
+				`File "/app/orders/views.py", in get_order`,
+			},
+		},
+		{
+			name:       "go",
+			stacktrace: goStacktrace,
+			want: []string{
+				"main.find /app/orders/service.go",
+				"main.handler /app/orders/handler.go",
+			},
+		},
+		{
+			name:       "javascript",
+			stacktrace: jsStacktrace,
+			want:       []string{"findOrder (/app/src/orders.js)"},
+		},
+		{
+			name:       "no frames",
+			stacktrace: "Exception stacktrace",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, f.topFrames(tt.stacktrace))
+		})
+	}
+}
+
+func TestTopFramesLimits(t *testing.T) {
+	f, err := newFingerprinter(Fingerprint{MaxFrames: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"com.example.orders.OrderService.find(OrderService.java)"}, f.topFrames(javaStacktrace))
+
+	f, err = newFingerprinter(Fingerprint{MaxFrames: 5, InAppPatterns: []string{`Controller`}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"com.example.orders.OrderController.get(OrderController.java)"}, f.topFrames(javaStacktrace))
+}
+
+func TestNormalizeMessage(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{message: "order 12345 not found", want: "order <num> not found"},
+		{message: "user 4b1e6b1c-0e62-4c48-9d5c-5d0bcb2f4f0a is  locked", want: "user <uuid> is locked"},
+		{message: "invalid memory address 0xc000012345", want: "invalid memory address <addr>"},
+		{message: "object 9f86d081884c7d65 is stale", want: "object <hash> is stale"},
+		{message: "deadbeefcafe is a word", want: "deadbeefcafe is a word"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			assert.Equal(t, tt.want, normalizeMessage(tt.message))
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	f, err := newFingerprinter(Fingerprint{MaxFrames: 5})
+	require.NoError(t, err)
+
+	fp := f.fingerprint("IllegalStateException", "order 12345 not found", javaStacktrace)
+	assert.Len(t, fp, 32)
+	// The fingerprint doesn't depend on the message nor on the line numbers when the stack trace has frames.
+	assert.Equal(t, fp, f.fingerprint("IllegalStateException", "order 678 not found",
+		"at com.example.orders.OrderService.find(OrderService.java:43)\n\tat com.example.orders.OrderController.get(OrderController.java:18)"))
+	assert.NotEqual(t, fp, f.fingerprint("NullPointerException", "order 12345 not found", javaStacktrace))
+	assert.NotEqual(t, fp, f.fingerprint("IllegalStateException", "order 12345 not found", goStacktrace))
+
+	// The normalized message is used without frames.
+	assert.Equal(t, f.fingerprint("KeyError", "order 12345 not found", ""), f.fingerprint("KeyError", "order 678 not found", ""))
+	assert.NotEqual(t, f.fingerprint("KeyError", "order 12345 not found", ""), f.fingerprint("KeyError", "user 12345 not found", ""))
+}
@@ -39,6 +39,10 @@ type Config struct {
 	// Table contains the routing table for this processor.
 	// Required.
 	Table []RoutingTableItem `mapstructure:"table"`
+	// Functions declares reusable OTTL functions which can be called from the conditions and
+	// statements of any route, like any other converter or editor.
+	// Optional.
+	Functions []ottl.FunctionDefinition `mapstructure:"functions"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -42,6 +42,7 @@ func newLogsConnector(
 	r, err := newRouter(
 		cfg.Table,
 		cfg.DefaultPipelines,
+		cfg.Functions,
 		lr.Consumer,
 		set.TelemetrySettings)
 	if err != nil {
@@ -20,6 +20,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/plogutiltest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 )
 
@@ -391,6 +392,79 @@ func TestLogsResourceAttributeDroppedByOTTL(t *testing.T) {
 	)
 }
 
+func TestLogsRoutedWithUserDefinedFunctions(t *testing.T) {
+	logsDefault := pipeline.NewIDWithName(pipeline.SignalLogs, "default")
+	logs0 := pipeline.NewIDWithName(pipeline.SignalLogs, "0")
+	logs1 := pipeline.NewIDWithName(pipeline.SignalLogs, "1")
+
+	cfg := &Config{
+		DefaultPipelines: []pipeline.ID{logsDefault},
+		Table: []RoutingTableItem{
+			{
+				Condition: `IsTenant(attributes["X-Tenant"], "acme")`,
+				Pipelines: []pipeline.ID{logs0},
+			},
+			{
+				Context:   "log",
+				Condition: `IsTenant(resource.attributes["X-Tenant"], "ecorp")`,
+				Pipelines: []pipeline.ID{logs1},
+			},
+		},
+		Functions: []ottl.FunctionDefinition{
+			{
+				Name: "IsTenant",
+				Params: []ottl.ParameterDefinition{
+					{Name: "value", Type: ottl.AnyParameter},
+					{Name: "tenant", Type: ottl.StringParameter},
+				},
+				Expression: `value == tenant`,
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var defaultSink, sink0, sink1 consumertest.LogsSink
+
+	router := connector.NewLogsRouter(map[pipeline.ID]consumer.Logs{
+		logsDefault: &defaultSink,
+		logs0:       &sink0,
+		logs1:       &sink1,
+	})
+
+	conn, err := NewFactory().CreateLogsToLogs(
+		context.Background(),
+		connectortest.NewNopSettings(metadata.Type),
+		cfg,
+		router.(consumer.Logs),
+	)
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, conn.Shutdown(context.Background()))
+	}()
+
+	l := plog.NewLogs()
+	for _, tenant := range []string{"acme", "ecorp", "acme-other"} {
+		rl := l.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("X-Tenant", tenant)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(tenant)
+	}
+	require.NoError(t, conn.ConsumeLogs(context.Background(), l))
+
+	bodies := func(sink *consumertest.LogsSink) []string {
+		var result []string
+		for _, logs := range sink.AllLogs() {
+			for i := 0; i < logs.ResourceLogs().Len(); i++ {
+				result = append(result, logs.ResourceLogs().At(i).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+			}
+		}
+		return result
+	}
+	assert.Equal(t, []string{"acme"}, bodies(&sink0))
+	assert.Equal(t, []string{"ecorp"}, bodies(&sink1))
+	assert.Equal(t, []string{"acme-other"}, bodies(&defaultSink))
+}
+
 func TestLogsConnectorCapabilities(t *testing.T) {
 	logsDefault := pipeline.NewIDWithName(pipeline.SignalLogs, "default")
 	logsOther := pipeline.NewIDWithName(pipeline.SignalLogs, "other")
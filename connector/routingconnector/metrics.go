@@ -43,6 +43,7 @@ func newMetricsConnector(
 	r, err := newRouter(
 		cfg.Table,
 		cfg.DefaultPipelines,
+		cfg.Functions,
 		mr.Consumer,
 		set.TelemetrySettings)
 	if err != nil {
@@ -50,6 +50,7 @@ type router[C any] struct {
 func newRouter[C any](
 	table []RoutingTableItem,
 	defaultPipelineIDs []pipeline.ID,
+	functions []ottl.FunctionDefinition,
 	provider consumerProvider[C],
 	settings component.TelemetrySettings,
 ) (*router[C], error) {
@@ -60,7 +61,7 @@ func newRouter[C any](
 		consumerProvider: provider,
 	}
 
-	if err := r.buildParsers(table, settings); err != nil {
+	if err := r.buildParsers(table, functions, settings); err != nil {
 		return nil, err
 	}
 
@@ -82,7 +83,7 @@ type routingItem[C any] struct {
 	statementContext   string
 }
 
-func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.TelemetrySettings) error {
+func (r *router[C]) buildParsers(table []RoutingTableItem, functions []ottl.FunctionDefinition, settings component.TelemetrySettings) error {
 	var buildResource, buildSpan, buildMetric, buildDataPoint, buildLog bool
 	for _, item := range table {
 		switch item.Context {
@@ -104,6 +105,7 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 		parser, err := ottlresource.NewParser(
 			common.Functions[ottlresource.TransformContext](),
 			settings,
+			ottl.WithFunctionDefinitions[ottlresource.TransformContext](functions),
 		)
 		if err == nil {
 			r.resourceParser = parser
@@ -115,6 +117,7 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 		parser, err := ottlspan.NewParser(
 			common.Functions[ottlspan.TransformContext](),
 			settings,
+			ottl.WithFunctionDefinitions[ottlspan.TransformContext](functions),
 		)
 		if err == nil {
 			r.spanParser = parser
@@ -126,6 +129,7 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 		parser, err := ottlmetric.NewParser(
 			common.Functions[ottlmetric.TransformContext](),
 			settings,
+			ottl.WithFunctionDefinitions[ottlmetric.TransformContext](functions),
 		)
 		if err == nil {
 			r.metricParser = parser
@@ -137,6 +141,7 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 		parser, err := ottldatapoint.NewParser(
 			common.Functions[ottldatapoint.TransformContext](),
 			settings,
+			ottl.WithFunctionDefinitions[ottldatapoint.TransformContext](functions),
 		)
 		if err == nil {
 			r.dataPointParser = parser
@@ -148,6 +153,7 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 		parser, err := ottllog.NewParser(
 			common.Functions[ottllog.TransformContext](),
 			settings,
+			ottl.WithFunctionDefinitions[ottllog.TransformContext](functions),
 		)
 		if err == nil {
 			r.logParser = parser
@@ -42,6 +42,7 @@ func newTracesConnector(
 	r, err := newRouter(
 		cfg.Table,
 		cfg.DefaultPipelines,
+		cfg.Functions,
 		tr.Consumer,
 		set.TelemetrySettings)
 	if err != nil {
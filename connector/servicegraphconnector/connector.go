@@ -64,9 +64,13 @@ type serviceGraphConnector struct {
 	config          *Config
 	logger          *zap.Logger
 	metricsConsumer consumer.Metrics
+	logsConsumer    consumer.Logs
 
 	store *store.Store
 
+	// topology is only tracked when the connector emits topology snapshots as logs.
+	topology *topology
+
 	startTime time.Time
 
 	seriesMutex                          sync.Mutex
@@ -177,15 +181,28 @@ func (p *serviceGraphConnector) metricFlushLoop(flushInterval time.Duration) {
 	for {
 		select {
 		case <-ticker.C:
-			if err := p.flushMetrics(context.Background()); err != nil {
-				p.logger.Error("failed to flush metrics", zap.Error(err))
-			}
+			p.flush(context.Background())
 		case <-p.shutdownCh:
 			return
 		}
 	}
 }
 
+// flush sends the metrics or the topology snapshot, depending on the pipeline
+// the connector emits to.
+func (p *serviceGraphConnector) flush(ctx context.Context) {
+	if p.metricsConsumer != nil {
+		if err := p.flushMetrics(ctx); err != nil {
+			p.logger.Error("failed to flush metrics", zap.Error(err))
+		}
+	}
+	if p.logsConsumer != nil {
+		if err := p.flushTopology(ctx); err != nil {
+			p.logger.Error("failed to flush topology", zap.Error(err))
+		}
+	}
+}
+
 func (p *serviceGraphConnector) flushMetrics(ctx context.Context) error {
 	md, err := p.buildMetrics()
 	if err != nil {
@@ -201,6 +218,17 @@ func (p *serviceGraphConnector) flushMetrics(ctx context.Context) error {
 	return p.metricsConsumer.ConsumeMetrics(ctx, md)
 }
 
+func (p *serviceGraphConnector) flushTopology(ctx context.Context) error {
+	ld := p.topology.snapshot(time.Now())
+
+	// Skip empty topologies.
+	if ld.LogRecordCount() == 0 {
+		return nil
+	}
+
+	return p.logsConsumer.ConsumeLogs(ctx, ld)
+}
+
 func (p *serviceGraphConnector) Shutdown(_ context.Context) error {
 	p.logger.Info("Shutting down servicegraphconnector")
 	close(p.shutdownCh)
@@ -216,12 +244,10 @@ func (p *serviceGraphConnector) ConsumeTraces(ctx context.Context, td ptrace.Tra
 		return fmt.Errorf("failed to aggregate metrics: %w", err)
 	}
 
-	// If metricsFlushInterval is not set, flush metrics immediately.
+	// If metricsFlushInterval is not set, flush immediately.
 	if *p.config.MetricsFlushInterval <= 0 {
-		if err := p.flushMetrics(ctx); err != nil {
-			// Not return error here to avoid impacting traces.
-			p.logger.Error("failed to flush metrics", zap.Error(err))
-		}
+		// Errors are only logged to avoid impacting traces.
+		p.flush(ctx)
 	}
 
 	return nil
@@ -346,7 +372,12 @@ func (p *serviceGraphConnector) onComplete(e *store.Edge) {
 		zap.String("connection_type", string(e.ConnectionType)),
 		zap.Stringer("trace_id", e.TraceID),
 	)
-	p.aggregateMetricsForEdge(e)
+	if p.metricsConsumer != nil {
+		p.aggregateMetricsForEdge(e)
+	}
+	if p.topology != nil {
+		p.topology.recordEdge(e, time.Now())
+	}
 }
 
 func (p *serviceGraphConnector) onExpire(e *store.Edge) {
@@ -364,17 +395,15 @@ func (p *serviceGraphConnector) onExpire(e *store.Edge) {
 		e.ConnectionType = store.VirtualNode
 		if len(e.ClientService) == 0 && e.Key.SpanIDIsEmpty() {
 			e.ClientService = "user"
-			if p.config.VirtualNodeExtraLabel {
-				e.VirtualNodeLabel = store.ClientVirtualNode
-			}
+			// The label is only added to the metrics when VirtualNodeExtraLabel is set,
+			// it is always needed to tell the virtual node apart in the topology.
+			e.VirtualNodeLabel = store.ClientVirtualNode
 			p.onComplete(e)
 		}
 
 		if len(e.ServerService) == 0 {
 			e.ServerService = p.getPeerHost(p.config.VirtualNodePeerAttributes, e.Peer)
-			if p.config.VirtualNodeExtraLabel {
-				e.VirtualNodeLabel = store.ServerVirtualNode
-			}
+			e.VirtualNodeLabel = store.ServerVirtualNode
 			p.onComplete(e)
 		}
 	}
@@ -665,7 +694,7 @@ func (p *serviceGraphConnector) cacheLoop(d time.Duration) {
 	}
 }
 
-// cleanCache removes series that have not been updated in 15 minutes
+// cleanCache removes series, nodes and edges that have not been updated in 15 minutes
 func (p *serviceGraphConnector) cleanCache() {
 	var staleSeries []string
 	p.metricMutex.RLock()
@@ -694,6 +723,10 @@ func (p *serviceGraphConnector) cleanCache() {
 		delete(p.keyToMetric, key)
 	}
 	p.metricMutex.Unlock()
+
+	if p.topology != nil {
+		p.topology.removeStale(time.Now().Add(-15 * time.Minute))
+	}
 }
 
 // spanDuration returns the duration of the given span in seconds (legacy ms).
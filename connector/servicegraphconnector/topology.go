@@ -0,0 +1,197 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package servicegraphconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector"
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector/internal/store"
+)
+
+const (
+	topologyEventName = "servicegraph.topology"
+
+	serviceNodeType  = "service"
+	databaseNodeType = "database"
+	virtualNodeType  = "virtual"
+)
+
+type topologyNodeKey struct {
+	name     string
+	nodeType string
+}
+
+type topologyNode struct {
+	firstSeen time.Time
+	lastSeen  time.Time
+}
+
+type topologyEdgeKey struct {
+	client         string
+	server         string
+	connectionType store.ConnectionType
+}
+
+type topologyEdge struct {
+	firstSeen    time.Time
+	lastSeen     time.Time
+	requestCount int64
+	failedCount  int64
+}
+
+// topology keeps track of the nodes and edges of the service graph, so that
+// snapshots of it can be emitted as logs.
+type topology struct {
+	mu    sync.Mutex
+	nodes map[topologyNodeKey]*topologyNode
+	edges map[topologyEdgeKey]*topologyEdge
+}
+
+func newTopology() *topology {
+	return &topology{
+		nodes: make(map[topologyNodeKey]*topologyNode),
+		edges: make(map[topologyEdgeKey]*topologyEdge),
+	}
+}
+
+// recordEdge adds the completed edge and its nodes to the topology.
+func (t *topology) recordEdge(e *store.Edge, now time.Time) {
+	// Expired edges can be completed before their server side is known.
+	if e.ClientService == "" || e.ServerService == "" {
+		return
+	}
+
+	clientType, serverType := serviceNodeType, serviceNodeType
+	switch {
+	case e.ConnectionType == store.Database:
+		serverType = databaseNodeType
+	case e.ConnectionType == store.VirtualNode && e.VirtualNodeLabel == store.ClientVirtualNode:
+		clientType = virtualNodeType
+	case e.ConnectionType == store.VirtualNode:
+		serverType = virtualNodeType
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.touchNode(topologyNodeKey{name: e.ClientService, nodeType: clientType}, now)
+	t.touchNode(topologyNodeKey{name: e.ServerService, nodeType: serverType}, now)
+
+	key := topologyEdgeKey{client: e.ClientService, server: e.ServerService, connectionType: e.ConnectionType}
+	edge, ok := t.edges[key]
+	if !ok {
+		edge = &topologyEdge{firstSeen: now}
+		t.edges[key] = edge
+	}
+	edge.lastSeen = now
+	edge.requestCount++
+	if e.Failed {
+		edge.failedCount++
+	}
+}
+
+func (t *topology) touchNode(key topologyNodeKey, now time.Time) {
+	node, ok := t.nodes[key]
+	if !ok {
+		node = &topologyNode{firstSeen: now}
+		t.nodes[key] = node
+	}
+	node.lastSeen = now
+}
+
+// removeStale removes the nodes and edges which have not been seen since the cutoff.
+func (t *topology) removeStale(cutoff time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for key, node := range t.nodes {
+		if node.lastSeen.Before(cutoff) {
+			delete(t.nodes, key)
+		}
+	}
+	for key, edge := range t.edges {
+		if edge.lastSeen.Before(cutoff) {
+			delete(t.edges, key)
+		}
+	}
+}
+
+// snapshot returns a log record describing the current topology. No log record
+// is returned while the topology is empty.
+func (t *topology) snapshot(now time.Time) plog.Logs {
+	ld := plog.NewLogs()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.nodes) == 0 {
+		return ld
+	}
+
+	sl := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("traces_service_graph")
+
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetEventName(topologyEventName)
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(now))
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(now))
+	lr.Attributes().PutInt("servicegraph.nodes", int64(len(t.nodes)))
+	lr.Attributes().PutInt("servicegraph.edges", int64(len(t.edges)))
+
+	body := lr.Body().SetEmptyMap()
+
+	nodeKeys := make([]topologyNodeKey, 0, len(t.nodes))
+	for key := range t.nodes {
+		nodeKeys = append(nodeKeys, key)
+	}
+	sort.Slice(nodeKeys, func(i, j int) bool {
+		if nodeKeys[i].name != nodeKeys[j].name {
+			return nodeKeys[i].name < nodeKeys[j].name
+		}
+		return nodeKeys[i].nodeType < nodeKeys[j].nodeType
+	})
+	nodes := body.PutEmptySlice("nodes")
+	nodes.EnsureCapacity(len(nodeKeys))
+	for _, key := range nodeKeys {
+		node := t.nodes[key]
+		m := nodes.AppendEmpty().SetEmptyMap()
+		m.PutStr("name", key.name)
+		m.PutStr("type", key.nodeType)
+		m.PutStr("first_seen", node.firstSeen.UTC().Format(time.RFC3339Nano))
+		m.PutStr("last_seen", node.lastSeen.UTC().Format(time.RFC3339Nano))
+	}
+
+	edgeKeys := make([]topologyEdgeKey, 0, len(t.edges))
+	for key := range t.edges {
+		edgeKeys = append(edgeKeys, key)
+	}
+	sort.Slice(edgeKeys, func(i, j int) bool {
+		if edgeKeys[i].client != edgeKeys[j].client {
+			return edgeKeys[i].client < edgeKeys[j].client
+		}
+		if edgeKeys[i].server != edgeKeys[j].server {
+			return edgeKeys[i].server < edgeKeys[j].server
+		}
+		return edgeKeys[i].connectionType < edgeKeys[j].connectionType
+	})
+	edges := body.PutEmptySlice("edges")
+	edges.EnsureCapacity(len(edgeKeys))
+	for _, key := range edgeKeys {
+		edge := t.edges[key]
+		m := edges.AppendEmpty().SetEmptyMap()
+		m.PutStr("client", key.client)
+		m.PutStr("server", key.server)
+		m.PutStr("connection_type", string(key.connectionType))
+		m.PutInt("request_count", edge.requestCount)
+		m.PutInt("failed_count", edge.failedCount)
+		m.PutStr("first_seen", edge.firstSeen.UTC().Format(time.RFC3339Nano))
+		m.PutStr("last_seen", edge.lastSeen.UTC().Format(time.RFC3339Nano))
+	}
+
+	return ld
+}
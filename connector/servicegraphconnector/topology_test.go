@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package servicegraphconnector
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/servicegraphconnector/internal/store"
+)
+
+func TestTopologySnapshot(t *testing.T) {
+	t0 := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	t1 := t0.Add(time.Minute)
+
+	topo := newTopology()
+	assert.Equal(t, 0, topo.snapshot(t0).LogRecordCount())
+
+	topo.recordEdge(&store.Edge{ClientService: "frontend", ServerService: "backend"}, t0)
+	topo.recordEdge(&store.Edge{ClientService: "frontend", ServerService: "backend", Failed: true}, t1)
+	topo.recordEdge(&store.Edge{ClientService: "backend", ServerService: "orders", ConnectionType: store.Database}, t0)
+	topo.recordEdge(&store.Edge{ClientService: "backend", ServerService: "queue", ConnectionType: store.MessagingSystem}, t0)
+	topo.recordEdge(&store.Edge{ClientService: "user", ServerService: "frontend", ConnectionType: store.VirtualNode, VirtualNodeLabel: store.ClientVirtualNode}, t1)
+	topo.recordEdge(&store.Edge{ClientService: "backend", ServerService: "cache", ConnectionType: store.VirtualNode, VirtualNodeLabel: store.ServerVirtualNode}, t1)
+	// Edges missing one of their sides are not part of the topology.
+	topo.recordEdge(&store.Edge{ClientService: "user", ConnectionType: store.VirtualNode, VirtualNodeLabel: store.ClientVirtualNode}, t1)
+
+	ld := topo.snapshot(t1)
+	require.Equal(t, 1, ld.LogRecordCount())
+	sl := ld.ResourceLogs().At(0).ScopeLogs().At(0)
+	assert.Equal(t, "traces_service_graph", sl.Scope().Name())
+	lr := sl.LogRecords().At(0)
+	assert.Equal(t, topologyEventName, lr.EventName())
+	assert.Equal(t, pcommon.NewTimestampFromTime(t1), lr.Timestamp())
+	assert.Equal(t, map[string]any{
+		"servicegraph.nodes": int64(6),
+		"servicegraph.edges": int64(5),
+	}, lr.Attributes().AsRaw())
+
+	first, last := t0.Format(time.RFC3339Nano), t1.Format(time.RFC3339Nano)
+	assert.Equal(t, map[string]any{
+		"nodes": []any{
+			map[string]any{"name": "backend", "type": "service", "first_seen": first, "last_seen": last},
+			map[string]any{"name": "cache", "type": "virtual", "first_seen": last, "last_seen": last},
+			map[string]any{"name": "frontend", "type": "service", "first_seen": first, "last_seen": last},
+			map[string]any{"name": "orders", "type": "database", "first_seen": first, "last_seen": first},
+			map[string]any{"name": "queue", "type": "service", "first_seen": first, "last_seen": first},
+			map[string]any{"name": "user", "type": "virtual", "first_seen": last, "last_seen": last},
+		},
+		"edges": []any{
+			map[string]any{"client": "backend", "server": "cache", "connection_type": "virtual_node", "request_count": int64(1), "failed_count": int64(0), "first_seen": last, "last_seen": last},
+			map[string]any{"client": "backend", "server": "orders", "connection_type": "database", "request_count": int64(1), "failed_count": int64(0), "first_seen": first, "last_seen": first},
+			map[string]any{"client": "backend", "server": "queue", "connection_type": "messaging_system", "request_count": int64(1), "failed_count": int64(0), "first_seen": first, "last_seen": first},
+			map[string]any{"client": "frontend", "server": "backend", "connection_type": "", "request_count": int64(2), "failed_count": int64(1), "first_seen": first, "last_seen": last},
+			map[string]any{"client": "user", "server": "frontend", "connection_type": "virtual_node", "request_count": int64(1), "failed_count": int64(0), "first_seen": last, "last_seen": last},
+		},
+	}, lr.Body().Map().AsRaw())
+}
+
+func TestTopologyRemoveStale(t *testing.T) {
+	t0 := time.Date(2022, 1, 2, 3, 4, 5, 0, time.UTC)
+	t1 := t0.Add(time.Hour)
+
+	topo := newTopology()
+	topo.recordEdge(&store.Edge{ClientService: "frontend", ServerService: "backend"}, t0)
+	topo.recordEdge(&store.Edge{ClientService: "backend", ServerService: "orders", ConnectionType: store.Database}, t1)
+
+	topo.removeStale(t1.Add(-15 * time.Minute))
+
+	assert.Len(t, topo.nodes, 2)
+	assert.Contains(t, topo.nodes, topologyNodeKey{name: "backend", nodeType: serviceNodeType})
+	assert.Contains(t, topo.nodes, topologyNodeKey{name: "orders", nodeType: databaseNodeType})
+	assert.Len(t, topo.edges, 1)
+	assert.Contains(t, topo.edges, topologyEdgeKey{client: "backend", server: "orders", connectionType: store.Database})
+
+	topo.removeStale(t1.Add(time.Minute))
+	assert.Equal(t, 0, topo.snapshot(t1).LogRecordCount())
+}
+
+func TestConnectorConsumeTopology(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.Store = StoreConfig{MaxItems: 10, TTL: time.Nanosecond}
+	cfg.MetricsFlushInterval = ptr(time.Duration(0))
+
+	sink := new(consumertest.LogsSink)
+	traceConnector, err := factory.CreateTracesToLogs(context.Background(), connectortest.NewNopSettings(metadata.Type), cfg, sink)
+	require.NoError(t, err)
+	conn := traceConnector.(*serviceGraphConnector)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, conn.Shutdown(context.Background())) }()
+
+	// Nothing is emitted until an edge is completed.
+	require.NoError(t, conn.ConsumeTraces(context.Background(), incompleteClientTraces()))
+	assert.Empty(t, sink.AllLogs())
+
+	if runtime.GOOS == "windows" {
+		// On Windows timing doesn't tick forward quickly for the store data to expire, force a wait before expiring.
+		time.Sleep(time.Second)
+	}
+	conn.store.Expire()
+	conn.flush(context.Background())
+	require.NoError(t, conn.ConsumeTraces(context.Background(), buildSampleTrace(t, "val")))
+
+	// The metrics of the connector are not computed when emitting logs.
+	assert.Empty(t, conn.reqTotal)
+
+	logs := sink.AllLogs()
+	require.Len(t, logs, 2)
+	assert.Equal(t, []string{"some-client-service", "AuthTokenCache"}, topologyEdges(t, logs[0]))
+	assert.Equal(t, []string{"some-client-service", "AuthTokenCache", "some-service", "some-service"}, topologyEdges(t, logs[1]))
+}
+
+// topologyEdges returns the client and server of each edge of the topology snapshot.
+func topologyEdges(t *testing.T, ld plog.Logs) []string {
+	require.Equal(t, 1, ld.LogRecordCount())
+	edges, ok := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Map().Get("edges")
+	require.True(t, ok)
+	var names []string
+	for i := 0; i < edges.Slice().Len(); i++ {
+		edge := edges.Slice().At(i).Map()
+		client, _ := edge.Get("client")
+		server, _ := edge.Get("server")
+		names = append(names, client.Str(), server.Str())
+	}
+	return names
+}
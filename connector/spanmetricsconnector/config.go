@@ -10,6 +10,7 @@ import (
 
 	"go.opentelemetry.io/collector/confmap/xconfmap"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.27.0"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metrics"
 )
@@ -92,6 +93,14 @@ type Config struct {
 	IncludeInstrumentationScope []string `mapstructure:"include_instrumentation_scope"`
 
 	AggregationCardinalityLimit int `mapstructure:"aggregation_cardinality_limit"`
+
+	// WeightByAdjustedCount weights the calls, the durations and the events of each span by
+	// its adjusted count, read from the sampling threshold of its tracestate, so that the
+	// metrics account for the spans dropped by consistent probability samplers.
+	WeightByAdjustedCount bool `mapstructure:"weight_by_adjusted_count"`
+
+	// RouteTemplating defines the configuration for the route templating.
+	RouteTemplating RouteTemplatingConfig `mapstructure:"route_templating"`
 }
 
 type HistogramConfig struct {
@@ -107,6 +116,9 @@ type HistogramConfig struct {
 type ExemplarsConfig struct {
 	Enabled         bool `mapstructure:"enabled"`
 	MaxPerDataPoint *int `mapstructure:"max_per_data_point"`
+	// Prioritize keeps the exemplars of the error spans, then of the slowest spans, once a
+	// data point holds MaxPerDataPoint exemplars. By default the first exemplars are kept.
+	Prioritize bool `mapstructure:"prioritize"`
 }
 
 type ExponentialHistogramConfig struct {
@@ -125,6 +137,26 @@ type EventsConfig struct {
 	Dimensions []Dimension `mapstructure:"dimensions"`
 }
 
+// RouteTemplatingConfig defines the configuration for adding the route of the spans as the
+// http.route dimension of the calls and duration metrics. The route of the spans without
+// an http.route attribute is templated from their URL path, replacing its variable segments
+// by placeholders.
+type RouteTemplatingConfig struct {
+	// Enabled is a flag to enable the route templating.
+	Enabled bool `mapstructure:"enabled"`
+	// Attributes is the list of span attributes holding the URL path, the first one
+	// set on a span is used. Optional. See defaultRouteTemplatingAttributes for the default value.
+	Attributes []string `mapstructure:"attributes"`
+	// MaxDistinctSegments is the number of distinct values observed at the same position of the
+	// URL paths after which the values are replaced by a placeholder.
+	// Optional. See defaultMaxDistinctSegments for the default value.
+	MaxDistinctSegments int `mapstructure:"max_distinct_segments"`
+	// MaxSegments is the maximum number of segments learned for each service, the segments of the
+	// URL paths which can't be learned anymore are replaced by placeholders.
+	// Optional. See defaultMaxSegments for the default value.
+	MaxSegments int `mapstructure:"max_segments"`
+}
+
 var _ xconfmap.Validator = (*Config)(nil)
 
 // Validate checks if the processor configuration is valid
@@ -159,6 +191,10 @@ func (c Config) Validate() error {
 		return fmt.Errorf("invalid aggregation_cardinality_limit: %v, the limit should be positive", c.AggregationCardinalityLimit)
 	}
 
+	if err := validateRouteTemplating(c.RouteTemplating, c.Dimensions); err != nil {
+		return fmt.Errorf("failed validating route templating: %w", err)
+	}
+
 	return nil
 }
 
@@ -205,3 +241,23 @@ func validateEventDimensions(enabled bool, dimensions []Dimension) error {
 	}
 	return validateDimensions(dimensions)
 }
+
+// validateRouteTemplating checks the route templating configuration and that the http.route
+// dimension is not configured on top of it.
+func validateRouteTemplating(cfg RouteTemplatingConfig, dimensions []Dimension) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.MaxDistinctSegments < 0 {
+		return fmt.Errorf("invalid max_distinct_segments: %v, the number should be positive", cfg.MaxDistinctSegments)
+	}
+	if cfg.MaxSegments < 0 {
+		return fmt.Errorf("invalid max_segments: %v, the number should be positive", cfg.MaxSegments)
+	}
+	for _, d := range dimensions {
+		if d.Name == conventions.AttributeHTTPRoute {
+			return fmt.Errorf("the %s dimension is added by the route templating", conventions.AttributeHTTPRoute)
+		}
+	}
+	return nil
+}
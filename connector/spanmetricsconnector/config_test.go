@@ -119,6 +119,38 @@ func TestLoadConfig(t *testing.T) {
 				Namespace:                DefaultNamespace,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "adjusted_count"),
+			expected: &Config{
+				AggregationTemporality:   "AGGREGATION_TEMPORALITY_CUMULATIVE",
+				ResourceMetricsCacheSize: defaultResourceMetricsCacheSize,
+				MetricsFlushInterval:     60 * time.Second,
+				Histogram:                HistogramConfig{Disable: false, Unit: defaultUnit},
+				Exemplars:                ExemplarsConfig{Enabled: true, MaxPerDataPoint: &defaultMaxPerDatapoint, Prioritize: true},
+				Namespace:                DefaultNamespace,
+				WeightByAdjustedCount:    true,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "route_templating"),
+			expected: &Config{
+				AggregationTemporality:   "AGGREGATION_TEMPORALITY_CUMULATIVE",
+				ResourceMetricsCacheSize: defaultResourceMetricsCacheSize,
+				MetricsFlushInterval:     60 * time.Second,
+				Histogram:                HistogramConfig{Disable: false, Unit: defaultUnit},
+				Namespace:                DefaultNamespace,
+				RouteTemplating: RouteTemplatingConfig{
+					Enabled:             true,
+					Attributes:          []string{"url.path"},
+					MaxDistinctSegments: 50,
+					MaxSegments:         500,
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_route_templating"),
+			errorMessage: "failed validating route templating: the http.route dimension is added by the route templating",
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "resource_metrics_key_attributes"),
 			expected: &Config{
@@ -6,6 +6,8 @@ package spanmetricsconnector // import "github.com/open-telemetry/opentelemetry-
 import (
 	"bytes"
 	"context"
+	"maps"
+	"slices"
 	"sync"
 	"time"
 
@@ -22,9 +24,11 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metrics"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/routetemplate"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
 	utilattri "github.com/open-telemetry/opentelemetry-collector-contrib/internal/pdatautil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 )
 
 const (
@@ -42,12 +46,22 @@ const (
 	metricNameCalls    = "calls"
 	metricNameEvents   = "events"
 
+	metricNameRouteTemplates = "route.templates"
+
+	defaultMaxDistinctSegments = 20
+	defaultMaxSegments         = 1000
+
 	defaultUnit = metrics.Milliseconds
 
+	// http.target is the deprecated attribute holding the URL path and query.
+	httpTargetKey = "http.target"
+
 	// https://github.com/open-telemetry/opentelemetry-go/blob/3ae002c3caf3e44387f0554dfcbbde2c5aab7909/sdk/metric/internal/aggregate/limit.go#L11C36-L11C50
 	overflowKey = "otel.metric.overflow"
 )
 
+var defaultRouteTemplatingAttributes = []string{conventions.AttributeURLPath, httpTargetKey}
+
 type connectorImp struct {
 	lock   sync.Mutex
 	logger *zap.Logger
@@ -82,10 +96,19 @@ type connectorImp struct {
 
 	events EventsConfig
 
+	// Route templates learned for each service, used when the route templating is enabled.
+	routeLearners *simplelru.LRU[string, *routeLearner]
+
 	// Tracks the last TimestampUnixNano for delta metrics so that they represent an uninterrupted series. Unused for cumulative span metrics.
 	lastDeltaTimestamps *simplelru.LRU[metrics.Key, pcommon.Timestamp]
 }
 
+type routeLearner struct {
+	*routetemplate.Learner
+	// lastSeen captures when the last URL path of the service was templated.
+	lastSeen time.Time
+}
+
 type resourceMetrics struct {
 	histograms metrics.HistogramMetrics
 	sums       metrics.SumMetrics
@@ -138,6 +161,15 @@ func newConnector(logger *zap.Logger, config component.Config, clock clockwork.C
 		}
 	}
 
+	var routeLearners *simplelru.LRU[string, *routeLearner]
+	if cfg.RouteTemplating.Enabled {
+		// The route templates are learned per service, so at most one learner is kept per resource.
+		routeLearners, err = simplelru.NewLRU[string, *routeLearner](cfg.ResourceMetricsCacheSize, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &connectorImp{
 		logger:                       logger,
 		config:                       *cfg,
@@ -153,6 +185,7 @@ func newConnector(logger *zap.Logger, config component.Config, clock clockwork.C
 		callsDimensions:              newDimensions(cfg.CallsDimensions),
 		durationDimensions:           newDimensions(cfg.Histogram.Dimensions),
 		events:                       cfg.Events,
+		routeLearners:                routeLearners,
 	}, nil
 }
 
@@ -165,7 +198,7 @@ func initHistogramMetrics(cfg Config) metrics.HistogramMetrics {
 		if cfg.Histogram.Exponential.MaxSize != 0 {
 			maxSize = cfg.Histogram.Exponential.MaxSize
 		}
-		return metrics.NewExponentialHistogramMetrics(maxSize, cfg.Exemplars.MaxPerDataPoint, cfg.AggregationCardinalityLimit)
+		return metrics.NewExponentialHistogramMetrics(maxSize, cfg.Exemplars.MaxPerDataPoint, cfg.Exemplars.Prioritize, cfg.AggregationCardinalityLimit)
 	}
 
 	var bounds []float64
@@ -183,7 +216,7 @@ func initHistogramMetrics(cfg Config) metrics.HistogramMetrics {
 		}
 	}
 
-	return metrics.NewExplicitHistogramMetrics(bounds, cfg.Exemplars.MaxPerDataPoint, cfg.AggregationCardinalityLimit)
+	return metrics.NewExplicitHistogramMetrics(bounds, cfg.Exemplars.MaxPerDataPoint, cfg.Exemplars.Prioritize, cfg.AggregationCardinalityLimit)
 }
 
 // unitDivider returns a unit divider to convert nanoseconds to milliseconds or seconds.
@@ -269,6 +302,13 @@ func (p *connectorImp) buildMetrics() pmetric.Metrics {
 	m := pmetric.NewMetrics()
 	timestamp := pcommon.NewTimestampFromTime(p.clock.Now())
 
+	metricsNamespace := p.config.Namespace
+	if legacyMetricNamesFeatureGate.IsEnabled() && metricsNamespace == DefaultNamespace {
+		metricsNamespace = ""
+	}
+
+	// The route templates are learned per service, so they are emitted once for all the resources of the service.
+	routeServices := make(map[string]struct{})
 	p.resourceMetrics.ForEach(func(_ resourceKey, rawMetrics *resourceMetrics) {
 		rm := m.ResourceMetrics().AppendEmpty()
 		rawMetrics.attributes.CopyTo(rm.Resource().Attributes())
@@ -293,11 +333,6 @@ func (p *connectorImp) buildMetrics() pmetric.Metrics {
 			return startTime
 		}
 
-		metricsNamespace := p.config.Namespace
-		if legacyMetricNamesFeatureGate.IsEnabled() && metricsNamespace == DefaultNamespace {
-			metricsNamespace = ""
-		}
-
 		sums := rawMetrics.sums
 		metric := sm.Metrics().AppendEmpty()
 		metric.SetName(buildMetricName(metricsNamespace, metricNameCalls))
@@ -318,16 +353,53 @@ func (p *connectorImp) buildMetrics() pmetric.Metrics {
 			events.BuildMetrics(metric, timestamp, timeStampGenerator, p.config.GetAggregationTemporality())
 		}
 
+		if serviceAttr, ok := rawMetrics.attributes.Get(conventions.AttributeServiceName); ok && p.config.RouteTemplating.Enabled {
+			routeServices[serviceAttr.Str()] = struct{}{}
+		}
+
 		for mk := range deltaMetricKeys {
 			// For delta metrics, cache the current data point's timestamp, which will be the start timestamp for the next data points in the series
 			p.lastDeltaTimestamps.Add(mk, timestamp)
 		}
 	})
 
+	for _, service := range slices.Sorted(maps.Keys(routeServices)) {
+		p.buildRouteTemplatesMetric(m, metricsNamespace, service, timestamp)
+	}
+
 	return m
 }
 
+// buildRouteTemplatesMetric adds a resource of the service holding a gauge of the route templates
+// learned for the service, the value of each data point being the number of spans matched by the route.
+// The learner holds a single template per route, so each route gets a single data point.
+func (p *connectorImp) buildRouteTemplatesMetric(m pmetric.Metrics, metricsNamespace string, service string, timestamp pcommon.Timestamp) {
+	learner, ok := p.routeLearners.Peek(service)
+	if !ok {
+		return
+	}
+
+	rm := m.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr(conventions.AttributeServiceName, service)
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("spanmetricsconnector")
+
+	metric := sm.Metrics().AppendEmpty()
+	metric.SetName(buildMetricName(metricsNamespace, metricNameRouteTemplates))
+	dps := metric.SetEmptyGauge().DataPoints()
+	for _, template := range learner.Templates() {
+		dp := dps.AppendEmpty()
+		dp.SetTimestamp(timestamp)
+		dp.SetIntValue(int64(template.Matches))
+		dp.Attributes().PutStr(conventions.AttributeHTTPRoute, template.Route)
+	}
+}
+
 func (p *connectorImp) resetState() {
+	if p.config.RouteTemplating.Enabled && p.config.MetricsExpiration > 0 {
+		p.removeExpiredRouteLearners(p.clock.Now())
+	}
+
 	// If delta metrics, reset accumulated data
 	if p.config.GetAggregationTemporality() == pmetric.AggregationTemporalityDelta {
 		p.resourceMetrics.Purge()
@@ -361,6 +433,16 @@ func (p *connectorImp) resetState() {
 	}
 }
 
+// removeExpiredRouteLearners removes the route templates learned for the services whose URL paths
+// haven't been seen for longer than the metrics expiration period.
+func (p *connectorImp) removeExpiredRouteLearners(now time.Time) {
+	for _, service := range p.routeLearners.Keys() {
+		if learner, ok := p.routeLearners.Peek(service); ok && now.Sub(learner.lastSeen) >= p.config.MetricsExpiration {
+			p.routeLearners.Remove(service)
+		}
+	}
+}
+
 // aggregateMetrics aggregates the raw metrics from the input trace data.
 //
 // Metrics are grouped by resource attributes.
@@ -397,6 +479,12 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 				if endTime > startTime {
 					duration = float64(endTime-startTime) / float64(unitDivider)
 				}
+				weight := float64(1)
+				if p.config.WeightByAdjustedCount {
+					weight = adjustedCount(span)
+				}
+				isError := span.Status().Code() == ptrace.StatusCodeError
+				route, hasRoute := p.spanRoute(serviceName, span)
 
 				callsDimensions := p.dimensions
 				callsDimensions = append(callsDimensions, p.callsDimensions...)
@@ -404,13 +492,16 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 				attributesFun := func() pcommon.Map {
 					return p.buildAttributes(serviceName, span, resourceAttr, callsDimensions, ils.Scope())
 				}
+				if hasRoute {
+					key, attributesFun = withRoute(key, attributesFun, route)
+				}
 
 				// aggregate sums metrics
 				s, limitReached := sums.GetOrCreate(key, attributesFun, startTimestamp)
 				if !limitReached && p.config.Exemplars.Enabled && !span.TraceID().IsEmpty() {
-					s.AddExemplar(span.TraceID(), span.SpanID(), duration)
+					s.AddExemplar(span.TraceID(), span.SpanID(), duration, isError)
 				}
-				s.Add(1)
+				s.AddWeighted(weight)
 
 				// aggregate histogram metrics
 				if !p.config.Histogram.Disable {
@@ -420,11 +511,14 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 					attributesFun = func() pcommon.Map {
 						return p.buildAttributes(serviceName, span, resourceAttr, durationDimensions, ils.Scope())
 					}
+					if hasRoute {
+						durationKey, attributesFun = withRoute(durationKey, attributesFun, route)
+					}
 					h, durationLimitReached := histograms.GetOrCreate(durationKey, attributesFun, startTimestamp)
 					if !durationLimitReached && p.config.Exemplars.Enabled && !span.TraceID().IsEmpty() {
-						p.addExemplar(span, duration, h)
+						p.addExemplar(span, duration, isError, h)
 					}
-					h.Observe(duration)
+					h.ObserveWeighted(duration, weight)
 				}
 
 				// aggregate events metrics
@@ -450,9 +544,9 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 						}
 						e, eventLimitReached := events.GetOrCreate(eKey, attributesFun, startTimestamp)
 						if !eventLimitReached && p.config.Exemplars.Enabled && !span.TraceID().IsEmpty() {
-							e.AddExemplar(span.TraceID(), span.SpanID(), duration)
+							e.AddExemplar(span.TraceID(), span.SpanID(), duration, isError)
 						}
-						e.Add(1)
+						e.AddWeighted(weight)
 					}
 				}
 			}
@@ -460,7 +554,57 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 	}
 }
 
-func (p *connectorImp) addExemplar(span ptrace.Span, duration float64, h metrics.Histogram) {
+// spanRoute returns the route of the span when the route templating is enabled: its http.route
+// attribute when set, the template of its URL path otherwise.
+func (p *connectorImp) spanRoute(serviceName string, span ptrace.Span) (string, bool) {
+	if !p.config.RouteTemplating.Enabled {
+		return "", false
+	}
+	if route, ok := span.Attributes().Get(conventions.AttributeHTTPRoute); ok {
+		return route.AsString(), true
+	}
+
+	attributes := p.config.RouteTemplating.Attributes
+	if len(attributes) == 0 {
+		attributes = defaultRouteTemplatingAttributes
+	}
+	for _, attr := range attributes {
+		path, ok := span.Attributes().Get(attr)
+		if !ok || path.Type() != pcommon.ValueTypeStr || path.Str() == "" {
+			continue
+		}
+		learner, ok := p.routeLearners.Get(serviceName)
+		if !ok {
+			maxDistinctSegments := defaultMaxDistinctSegments
+			if p.config.RouteTemplating.MaxDistinctSegments != 0 {
+				maxDistinctSegments = p.config.RouteTemplating.MaxDistinctSegments
+			}
+			maxSegments := defaultMaxSegments
+			if p.config.RouteTemplating.MaxSegments != 0 {
+				maxSegments = p.config.RouteTemplating.MaxSegments
+			}
+			learner = &routeLearner{Learner: routetemplate.NewLearner(maxDistinctSegments, maxSegments)}
+			p.routeLearners.Add(serviceName, learner)
+		}
+		// If expiration is enabled, track the last seen time.
+		if p.config.MetricsExpiration > 0 {
+			learner.lastSeen = p.clock.Now()
+		}
+		return learner.Template(path.Str()), true
+	}
+	return "", false
+}
+
+// withRoute adds the route to the metric key and to the attributes of the metric.
+func withRoute(key metrics.Key, attributesFun func() pcommon.Map, route string) (metrics.Key, func() pcommon.Map) {
+	return key + metrics.Key(metricKeySeparator+conventions.AttributeHTTPRoute+metricKeySeparator+route), func() pcommon.Map {
+		attrs := attributesFun()
+		attrs.PutStr(conventions.AttributeHTTPRoute, route)
+		return attrs
+	}
+}
+
+func (p *connectorImp) addExemplar(span ptrace.Span, duration float64, isError bool, h metrics.Histogram) {
 	if !p.config.Exemplars.Enabled {
 		return
 	}
@@ -468,7 +612,26 @@ func (p *connectorImp) addExemplar(span ptrace.Span, duration float64, h metrics
 		return
 	}
 
-	h.AddExemplar(span.TraceID(), span.SpanID(), duration)
+	h.AddExemplar(span.TraceID(), span.SpanID(), duration, isError)
+}
+
+// adjustedCount returns the number of spans represented by the span, according to the
+// sampling threshold of its tracestate. Spans without a sampling threshold represent
+// themselves only.
+func adjustedCount(span ptrace.Span) float64 {
+	rawTraceState := span.TraceState().AsRaw()
+	if rawTraceState == "" {
+		return 1
+	}
+	w3cTraceState, err := sampling.NewW3CTraceState(rawTraceState)
+	if err != nil {
+		return 1
+	}
+	otTraceState := w3cTraceState.OTelValue()
+	if otTraceState == nil || len(otTraceState.TValue()) == 0 {
+		return 1
+	}
+	return otTraceState.AdjustedCount()
 }
 
 type resourceKey [16]byte
@@ -492,8 +655,8 @@ func (p *connectorImp) getOrCreateResourceMetrics(attr pcommon.Map) *resourceMet
 	if !ok {
 		v = &resourceMetrics{
 			histograms: initHistogramMetrics(p.config),
-			sums:       metrics.NewSumMetrics(p.config.Exemplars.MaxPerDataPoint, p.config.AggregationCardinalityLimit),
-			events:     metrics.NewSumMetrics(p.config.Exemplars.MaxPerDataPoint, p.config.AggregationCardinalityLimit),
+			sums:       metrics.NewSumMetrics(p.config.Exemplars.MaxPerDataPoint, p.config.Exemplars.Prioritize, p.config.AggregationCardinalityLimit),
+			events:     metrics.NewSumMetrics(p.config.Exemplars.MaxPerDataPoint, p.config.Exemplars.Prioritize, p.config.AggregationCardinalityLimit),
 			attributes: attr,
 		}
 		p.resourceMetrics.Add(key, v)
@@ -1408,7 +1408,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 		{
 			name:   "initialize histogram with no config provided",
 			config: Config{},
-			want:   metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs, nil, 0),
+			want:   metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs, nil, false, 0),
 		},
 		{
 			name: "Disable histogram",
@@ -1426,7 +1426,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					Unit: metrics.Milliseconds,
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs, nil, 0),
+			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsMs, nil, false, 0),
 		},
 		{
 			name: "initialize explicit histogram with default bounds (seconds)",
@@ -1435,7 +1435,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					Unit: metrics.Seconds,
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsSeconds, nil, 0),
+			want: metrics.NewExplicitHistogramMetrics(defaultHistogramBucketsSeconds, nil, false, 0),
 		},
 		{
 			name: "initialize explicit histogram with bounds (seconds)",
@@ -1450,7 +1450,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					},
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics([]float64{0.1, 1}, nil, 0),
+			want: metrics.NewExplicitHistogramMetrics([]float64{0.1, 1}, nil, false, 0),
 		},
 		{
 			name: "initialize explicit histogram with bounds (ms)",
@@ -1465,7 +1465,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					},
 				},
 			},
-			want: metrics.NewExplicitHistogramMetrics([]float64{100, 1000}, nil, 0),
+			want: metrics.NewExplicitHistogramMetrics([]float64{100, 1000}, nil, false, 0),
 		},
 		{
 			name: "initialize exponential histogram",
@@ -1477,7 +1477,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					},
 				},
 			},
-			want: metrics.NewExponentialHistogramMetrics(10, nil, 0),
+			want: metrics.NewExponentialHistogramMetrics(10, nil, false, 0),
 		},
 		{
 			name: "initialize exponential histogram with default max buckets count",
@@ -1487,7 +1487,7 @@ func TestConnector_initHistogramMetrics(t *testing.T) {
 					Exponential: &ExponentialHistogramConfig{},
 				},
 			},
-			want: metrics.NewExponentialHistogramMetrics(structure.DefaultMaxSize, nil, 0),
+			want: metrics.NewExponentialHistogramMetrics(structure.DefaultMaxSize, nil, false, 0),
 		},
 	}
 	for _, tt := range tests {
@@ -2256,3 +2256,277 @@ func TestConnectorWithCardinalityLimitForEvents(t *testing.T) {
 	assert.Equal(t, 2, normalCount, "expected 2 normal metrics")
 	assert.Equal(t, 1, overflowCount, "expected 1 overflow metric")
 }
+
+func TestConnectorWeightByAdjustedCount(t *testing.T) {
+	for _, tt := range []struct {
+		name                  string
+		weightByAdjustedCount bool
+		wantCount             uint64
+	}{
+		{name: "disabled", wantCount: 4},
+		{name: "enabled", weightByAdjustedCount: true, wantCount: 8},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.WeightByAdjustedCount = tt.weightByAdjustedCount
+			cfg.Events = EventsConfig{Enabled: true, Dimensions: []Dimension{{Name: "exception.type"}}}
+
+			connector, err := newConnector(zaptest.NewLogger(t), cfg, clockwork.NewFakeClock())
+			require.NoError(t, err)
+
+			traces := ptrace.NewTraces()
+			rspans := traces.ResourceSpans().AppendEmpty()
+			rspans.Resource().Attributes().PutStr(serviceNameKey, "service-a")
+			spans := rspans.ScopeSpans().AppendEmpty().Spans()
+			// Sampled at 100%, 50% and 25%, and an unsampled span without tracestate.
+			for _, traceState := range []string{"ot=th:0", "ot=th:8", "ot=th:c;rv:fe123456789abc", ""} {
+				span := spans.AppendEmpty()
+				span.SetName("/ping")
+				span.SetKind(ptrace.SpanKindServer)
+				span.TraceState().FromRaw(traceState)
+				span.SetStartTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, 0)))
+				span.SetEndTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, 0).Add(10 * time.Millisecond)))
+				span.Events().AppendEmpty().Attributes().PutStr("exception.type", "NullPointerException")
+			}
+			// Ignore the data points of the first flush, which have zero values.
+			require.NoError(t, connector.ConsumeTraces(context.Background(), traces))
+			_ = connector.buildMetrics()
+
+			metrics := connector.buildMetrics().ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			require.Equal(t, 3, metrics.Len())
+			for i := 0; i < metrics.Len(); i++ {
+				metric := metrics.At(i)
+				switch metric.Name() {
+				case buildMetricName(DefaultNamespace, metricNameCalls), buildMetricName(DefaultNamespace, metricNameEvents):
+					require.Equal(t, 1, metric.Sum().DataPoints().Len())
+					assert.Equal(t, int64(tt.wantCount), metric.Sum().DataPoints().At(0).IntValue(), metric.Name())
+				case buildMetricName(DefaultNamespace, metricNameDuration):
+					require.Equal(t, 1, metric.Histogram().DataPoints().Len())
+					dp := metric.Histogram().DataPoints().At(0)
+					assert.Equal(t, tt.wantCount, dp.Count())
+					assert.InDelta(t, float64(10*tt.wantCount), dp.Sum(), 1e-9)
+				default:
+					assert.Fail(t, "unexpected metric", metric.Name())
+				}
+			}
+		})
+	}
+}
+
+func TestConnectorPrioritizedExemplars(t *testing.T) {
+	maxPerDataPoint := 2
+	cfg := createDefaultConfig().(*Config)
+	cfg.Exemplars = ExemplarsConfig{Enabled: true, MaxPerDataPoint: &maxPerDataPoint, Prioritize: true}
+	cfg.ExcludeDimensions = []string{statusCodeKey}
+
+	connector, err := newConnector(zaptest.NewLogger(t), cfg, clockwork.NewFakeClock())
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	rspans := traces.ResourceSpans().AppendEmpty()
+	rspans.Resource().Attributes().PutStr(serviceNameKey, "service-a")
+	spans := rspans.ScopeSpans().AppendEmpty().Spans()
+	for i, s := range []struct {
+		duration time.Duration
+		status   ptrace.StatusCode
+	}{
+		{duration: 5 * time.Millisecond},
+		{duration: 50 * time.Millisecond},
+		{duration: time.Millisecond, status: ptrace.StatusCodeError},
+		{duration: 20 * time.Millisecond},
+		{duration: 2 * time.Millisecond},
+	} {
+		span := spans.AppendEmpty()
+		span.SetName("/ping")
+		span.SetKind(ptrace.SpanKindServer)
+		span.SetTraceID(pcommon.TraceID{byte(i + 1)})
+		span.SetSpanID(pcommon.SpanID{byte(i + 1)})
+		span.Status().SetCode(s.status)
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, 0)))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, 0).Add(s.duration)))
+	}
+	require.NoError(t, connector.ConsumeTraces(context.Background(), traces))
+
+	metrics := connector.buildMetrics().ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+	for i := 0; i < metrics.Len(); i++ {
+		var exemplars pmetric.ExemplarSlice
+		switch metric := metrics.At(i); metric.Type() {
+		case pmetric.MetricTypeSum:
+			exemplars = metric.Sum().DataPoints().At(0).Exemplars()
+		case pmetric.MetricTypeHistogram:
+			exemplars = metric.Histogram().DataPoints().At(0).Exemplars()
+		}
+		// The exemplars of the error span and of the slowest span are kept.
+		var spanIDs []pcommon.SpanID
+		for j := 0; j < exemplars.Len(); j++ {
+			spanIDs = append(spanIDs, exemplars.At(j).SpanID())
+		}
+		assert.ElementsMatch(t, []pcommon.SpanID{{2}, {3}}, spanIDs)
+	}
+}
+
+func TestConnectorRouteTemplating(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.RouteTemplating = RouteTemplatingConfig{Enabled: true, MaxDistinctSegments: 3}
+	cfg.ExcludeDimensions = []string{spanNameKey}
+
+	connector, err := newConnector(zaptest.NewLogger(t), cfg, clockwork.NewFakeClock())
+	require.NoError(t, err)
+	learnRoutes(t, connector, "service-a", 3, "/users/1", "/orders/4b1e6b1c-0e62-4c48-9d5c-5d0bcb2f4f0a")
+
+	traces := ptrace.NewTraces()
+	rspans := traces.ResourceSpans().AppendEmpty()
+	rspans.Resource().Attributes().PutStr(serviceNameKey, "service-a")
+	spans := rspans.ScopeSpans().AppendEmpty().Spans()
+	for _, attrs := range []map[string]any{
+		{"url.path": "/users/12345"},
+		{"url.path": "/users/67890"},
+		{"http.target": "/users/42?verbose=true"},
+		{"url.path": "/orders/4b1e6b1c-0e62-4c48-9d5c-5d0bcb2f4f0a"},
+		// The route set by the instrumentation is used as is.
+		{"url.path": "/orders/1", "http.route": "/orders/:id"},
+		// Spans without URL path are not given a route.
+		{},
+	} {
+		span := spans.AppendEmpty()
+		span.SetName("GET")
+		span.SetKind(ptrace.SpanKindServer)
+		require.NoError(t, span.Attributes().FromRaw(attrs))
+	}
+	// Ignore the data points of the first flush, which have zero values.
+	require.NoError(t, connector.ConsumeTraces(context.Background(), traces))
+	_ = connector.buildMetrics()
+
+	resourceMetrics := connector.buildMetrics().ResourceMetrics()
+	require.Equal(t, 2, resourceMetrics.Len())
+	metrics := resourceMetrics.At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+
+	calls := metrics.At(0)
+	assert.Equal(t, buildMetricName(DefaultNamespace, metricNameCalls), calls.Name())
+	assert.Equal(t, map[string]int64{
+		"/users/{id}":    3,
+		"/orders/{uuid}": 1,
+		"/orders/:id":    1,
+		"":               1,
+	}, routeCounts(calls.Sum().DataPoints()))
+
+	duration := metrics.At(1)
+	assert.Equal(t, buildMetricName(DefaultNamespace, metricNameDuration), duration.Name())
+	assert.Equal(t, 4, duration.Histogram().DataPoints().Len())
+
+	assert.Equal(t, map[string]any{serviceNameKey: "service-a"}, resourceMetrics.At(1).Resource().Attributes().AsRaw())
+	templates := resourceMetrics.At(1).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, templates.Len())
+	assert.Equal(t, buildMetricName(DefaultNamespace, metricNameRouteTemplates), templates.At(0).Name())
+	assert.Equal(t, map[string]int64{
+		"/users/{id}":    6,
+		"/orders/{uuid}": 4,
+	}, routeCounts(templates.At(0).Gauge().DataPoints()))
+}
+
+func TestConnectorRouteTemplatingWhileLearning(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.RouteTemplating = RouteTemplatingConfig{Enabled: true}
+	cfg.ExcludeDimensions = []string{spanNameKey}
+
+	connector, err := newConnector(zaptest.NewLogger(t), cfg, clockwork.NewFakeClock())
+	require.NoError(t, err)
+
+	// The literal segments are replaced by the param placeholder until they are stable, so
+	// the values observed while learning don't create metric series of their own.
+	traces := ptrace.NewTraces()
+	rspans := traces.ResourceSpans().AppendEmpty()
+	rspans.Resource().Attributes().PutStr(serviceNameKey, "service-a")
+	spans := rspans.ScopeSpans().AppendEmpty().Spans()
+	for _, user := range []string{"alice", "bob", "carol"} {
+		span := spans.AppendEmpty()
+		span.SetName("GET")
+		span.SetKind(ptrace.SpanKindServer)
+		span.Attributes().PutStr("url.path", "/users/"+user)
+	}
+	// Ignore the data points of the first flush, which have zero values.
+	require.NoError(t, connector.ConsumeTraces(context.Background(), traces))
+	_ = connector.buildMetrics()
+
+	calls := connector.buildMetrics().ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, map[string]int64{"/{param}/{param}": 3}, routeCounts(calls.Sum().DataPoints()))
+}
+
+func TestConnectorRouteTemplatingExpiration(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.RouteTemplating = RouteTemplatingConfig{Enabled: true}
+	cfg.MetricsExpiration = time.Minute
+
+	clock := clockwork.NewFakeClock()
+	connector, err := newConnector(zaptest.NewLogger(t), cfg, clock)
+	require.NoError(t, err)
+	learnRoutes(t, connector, "service-a", 1, "/users/1")
+	clock.Advance(30 * time.Second)
+	learnRoutes(t, connector, "service-b", 1, "/users/1")
+
+	// test
+	clock.Advance(30 * time.Second)
+	connector.resetState()
+
+	// verify
+	assert.Equal(t, []string{"service-b"}, connector.routeLearners.Keys())
+}
+
+// learnRoutes templates the URL paths for the service the given number of times.
+func learnRoutes(t *testing.T, connector *connectorImp, service string, times int, paths ...string) {
+	span := ptrace.NewSpan()
+	for i := 0; i < times; i++ {
+		for _, path := range paths {
+			span.Attributes().PutStr("url.path", path)
+			_, ok := connector.spanRoute(service, span)
+			require.True(t, ok)
+		}
+	}
+}
+
+func TestConnectorRouteTemplatingMultipleResources(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.RouteTemplating = RouteTemplatingConfig{Enabled: true}
+
+	connector, err := newConnector(zaptest.NewLogger(t), cfg, clockwork.NewFakeClock())
+	require.NoError(t, err)
+
+	// Two instances of the same service
+	traces := ptrace.NewTraces()
+	for _, host := range []string{"host-a", "host-b"} {
+		rspans := traces.ResourceSpans().AppendEmpty()
+		rspans.Resource().Attributes().PutStr(serviceNameKey, "service-a")
+		rspans.Resource().Attributes().PutStr("host.name", host)
+		span := rspans.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetName("GET")
+		span.SetKind(ptrace.SpanKindServer)
+		span.Attributes().PutStr("url.path", "/users/12345")
+	}
+	require.NoError(t, connector.ConsumeTraces(context.Background(), traces))
+
+	resourceMetrics := connector.buildMetrics().ResourceMetrics()
+	require.Equal(t, 3, resourceMetrics.Len())
+	var templates []pmetric.Metric
+	for i := 0; i < resourceMetrics.Len(); i++ {
+		ms := resourceMetrics.At(i).ScopeMetrics().At(0).Metrics()
+		for j := 0; j < ms.Len(); j++ {
+			if ms.At(j).Name() == buildMetricName(DefaultNamespace, metricNameRouteTemplates) {
+				templates = append(templates, ms.At(j))
+			}
+		}
+	}
+	require.Len(t, templates, 1)
+	require.Equal(t, 1, templates[0].Gauge().DataPoints().Len())
+	assert.Equal(t, map[string]int64{"/{param}/{id}": 2}, routeCounts(templates[0].Gauge().DataPoints()))
+}
+
+func routeCounts(dps pmetric.NumberDataPointSlice) map[string]int64 {
+	counts := make(map[string]int64)
+	for i := 0; i < dps.Len(); i++ {
+		route, _ := dps.At(i).Attributes().Get("http.route")
+		counts[route.Str()] += dps.At(i).IntValue()
+	}
+	return counts
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metrics"
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// addExemplar adds an exemplar to the slice, holding at most maxCount exemplars when set.
+// Once the slice is full, the new exemplar is dropped unless the exemplars are prioritized:
+// it then replaces the exemplar with the lowest priority when it has a higher priority.
+// Exemplars of errors have a higher priority than the others, then the higher values
+// have a higher priority. errs tracks whether each prioritized exemplar is an error.
+func addExemplar(
+	exemplars pmetric.ExemplarSlice,
+	errs *[]bool,
+	maxCount *int,
+	prioritize bool,
+	traceID pcommon.TraceID,
+	spanID pcommon.SpanID,
+	value float64,
+	isError bool,
+) {
+	if maxCount == nil || exemplars.Len() < *maxCount {
+		e := exemplars.AppendEmpty()
+		e.SetTraceID(traceID)
+		e.SetSpanID(spanID)
+		e.SetDoubleValue(value)
+		if prioritize {
+			*errs = append(*errs, isError)
+		}
+		return
+	}
+	if !prioritize || exemplars.Len() == 0 {
+		return
+	}
+
+	isErrorAt := func(i int) bool { return i < len(*errs) && (*errs)[i] }
+	lowest := 0
+	for i := 1; i < exemplars.Len(); i++ {
+		if lowerPriority(isErrorAt(i), exemplars.At(i).DoubleValue(), isErrorAt(lowest), exemplars.At(lowest).DoubleValue()) {
+			lowest = i
+		}
+	}
+	if !lowerPriority(isErrorAt(lowest), exemplars.At(lowest).DoubleValue(), isError, value) {
+		return
+	}
+
+	e := exemplars.At(lowest)
+	e.SetTraceID(traceID)
+	e.SetSpanID(spanID)
+	e.SetDoubleValue(value)
+	for len(*errs) < exemplars.Len() {
+		*errs = append(*errs, false)
+	}
+	(*errs)[lowest] = isError
+}
+
+// lowerPriority returns whether the exemplar a has a lower priority than the exemplar b.
+func lowerPriority(aIsError bool, aValue float64, bIsError bool, bValue float64) bool {
+	if aIsError != bIsError {
+		return bIsError
+	}
+	return aValue < bValue
+}
+
+// weightCarry turns weights into integer increments, carrying over their fractional
+// part so that the sum of the increments follows the sum of the weights.
+type weightCarry float64
+
+// weightEpsilon absorbs the floating point errors accumulated in the carry.
+const weightEpsilon = 1e-9
+
+// next returns the increment to apply for the weight.
+func (c *weightCarry) next(weight float64) uint64 {
+	if weight <= 0 {
+		return 0
+	}
+	*c += weightCarry(weight)
+	n := math.Floor(float64(*c) + weightEpsilon)
+	*c -= weightCarry(n)
+	if *c < 0 {
+		*c = 0
+	}
+	return uint64(n)
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestAddExemplar(t *testing.T) {
+	maxCount := 2
+	type exemplar struct {
+		value   float64
+		isError bool
+	}
+	tests := []struct {
+		name       string
+		maxCount   *int
+		prioritize bool
+		add        []exemplar
+		want       []float64
+	}{
+		{
+			name: "no max count",
+			add:  []exemplar{{value: 1}, {value: 2}, {value: 3}},
+			want: []float64{1, 2, 3},
+		},
+		{
+			name:     "first exemplars are kept",
+			maxCount: &maxCount,
+			add:      []exemplar{{value: 1}, {value: 2}, {value: 3, isError: true}},
+			want:     []float64{1, 2},
+		},
+		{
+			name:       "slowest exemplars are kept",
+			maxCount:   &maxCount,
+			prioritize: true,
+			add:        []exemplar{{value: 3}, {value: 1}, {value: 2}, {value: 0.5}},
+			want:       []float64{3, 2},
+		},
+		{
+			name:       "error exemplars are kept",
+			maxCount:   &maxCount,
+			prioritize: true,
+			add:        []exemplar{{value: 3}, {value: 1, isError: true}, {value: 2}, {value: 0.5, isError: true}, {value: 4}},
+			want:       []float64{0.5, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exemplars := pmetric.NewExemplarSlice()
+			var errs []bool
+			for i, e := range tt.add {
+				addExemplar(exemplars, &errs, tt.maxCount, tt.prioritize, pcommon.TraceID{byte(i + 1)}, pcommon.SpanID{byte(i + 1)}, e.value, e.isError)
+			}
+			var got []float64
+			for i := 0; i < exemplars.Len(); i++ {
+				got = append(got, exemplars.At(i).DoubleValue())
+			}
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
+
+func TestWeightCarry(t *testing.T) {
+	var c weightCarry
+	var total uint64
+	for i := 0; i < 30; i++ {
+		total += c.next(10.0 / 3)
+	}
+	assert.Equal(t, uint64(100), total)
+
+	c = 0
+	assert.Equal(t, uint64(1), c.next(1))
+	assert.Equal(t, uint64(0), c.next(0))
+	assert.Equal(t, uint64(0), c.next(0.5))
+	assert.Equal(t, uint64(1), c.next(0.5))
+}
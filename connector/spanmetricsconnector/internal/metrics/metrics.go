@@ -24,34 +24,43 @@ type HistogramMetrics interface {
 
 type Histogram interface {
 	Observe(value float64)
-	AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64)
+	// ObserveWeighted observes the value as many times as the weight, which is the
+	// adjusted count of a sampled span.
+	ObserveWeighted(value float64, weight float64)
+	AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64, isError bool)
 }
 
 type explicitHistogramMetrics struct {
-	metrics          map[Key]*explicitHistogram
-	bounds           []float64
-	maxExemplarCount *int
-	cardinalityLimit int
+	metrics             map[Key]*explicitHistogram
+	bounds              []float64
+	maxExemplarCount    *int
+	prioritizeExemplars bool
+	cardinalityLimit    int
 }
 
 type exponentialHistogramMetrics struct {
-	metrics          map[Key]*exponentialHistogram
-	maxSize          int32
-	maxExemplarCount *int
-	cardinalityLimit int
+	metrics             map[Key]*exponentialHistogram
+	maxSize             int32
+	maxExemplarCount    *int
+	prioritizeExemplars bool
+	cardinalityLimit    int
 }
 
 type explicitHistogram struct {
 	attributes pcommon.Map
 	exemplars  pmetric.ExemplarSlice
+	// exemplarErrors tracks the exemplars of errors when they are prioritized.
+	exemplarErrors []bool
 
 	bucketCounts []uint64
 	count        uint64
 	sum          float64
+	carry        weightCarry
 
 	bounds []float64
 
-	maxExemplarCount *int
+	maxExemplarCount    *int
+	prioritizeExemplars bool
 
 	startTimestamp pcommon.Timestamp
 }
@@ -59,31 +68,37 @@ type explicitHistogram struct {
 type exponentialHistogram struct {
 	attributes pcommon.Map
 	exemplars  pmetric.ExemplarSlice
+	// exemplarErrors tracks the exemplars of errors when they are prioritized.
+	exemplarErrors []bool
 
 	histogram *structure.Histogram[float64]
+	carry     weightCarry
 
-	maxExemplarCount *int
+	maxExemplarCount    *int
+	prioritizeExemplars bool
 
 	startTimestamp pcommon.Timestamp
 }
 
 type BuildAttributesFun func() pcommon.Map
 
-func NewExponentialHistogramMetrics(maxSize int32, maxExemplarCount *int, cardinalityLimit int) HistogramMetrics {
+func NewExponentialHistogramMetrics(maxSize int32, maxExemplarCount *int, prioritizeExemplars bool, cardinalityLimit int) HistogramMetrics {
 	return &exponentialHistogramMetrics{
-		metrics:          make(map[Key]*exponentialHistogram),
-		maxSize:          maxSize,
-		maxExemplarCount: maxExemplarCount,
-		cardinalityLimit: cardinalityLimit,
+		metrics:             make(map[Key]*exponentialHistogram),
+		maxSize:             maxSize,
+		maxExemplarCount:    maxExemplarCount,
+		prioritizeExemplars: prioritizeExemplars,
+		cardinalityLimit:    cardinalityLimit,
 	}
 }
 
-func NewExplicitHistogramMetrics(bounds []float64, maxExemplarCount *int, cardinalityLimit int) HistogramMetrics {
+func NewExplicitHistogramMetrics(bounds []float64, maxExemplarCount *int, prioritizeExemplars bool, cardinalityLimit int) HistogramMetrics {
 	return &explicitHistogramMetrics{
-		metrics:          make(map[Key]*explicitHistogram),
-		bounds:           bounds,
-		maxExemplarCount: maxExemplarCount,
-		cardinalityLimit: cardinalityLimit,
+		metrics:             make(map[Key]*explicitHistogram),
+		bounds:              bounds,
+		maxExemplarCount:    maxExemplarCount,
+		prioritizeExemplars: prioritizeExemplars,
+		cardinalityLimit:    cardinalityLimit,
 	}
 }
 
@@ -113,12 +128,13 @@ func (m *explicitHistogramMetrics) GetOrCreate(key Key, attributesFun BuildAttri
 		}
 
 		h = &explicitHistogram{
-			attributes:       attributes,
-			exemplars:        pmetric.NewExemplarSlice(),
-			bounds:           m.bounds,
-			bucketCounts:     make([]uint64, len(m.bounds)+1),
-			maxExemplarCount: m.maxExemplarCount,
-			startTimestamp:   startTimestamp,
+			attributes:          attributes,
+			exemplars:           pmetric.NewExemplarSlice(),
+			bounds:              m.bounds,
+			bucketCounts:        make([]uint64, len(m.bounds)+1),
+			maxExemplarCount:    m.maxExemplarCount,
+			prioritizeExemplars: m.prioritizeExemplars,
+			startTimestamp:      startTimestamp,
 		}
 		m.metrics[key] = h
 	}
@@ -154,6 +170,7 @@ func (m *explicitHistogramMetrics) BuildMetrics(
 func (m *explicitHistogramMetrics) ClearExemplars() {
 	for _, h := range m.metrics {
 		h.exemplars = pmetric.NewExemplarSlice()
+		h.exemplarErrors = nil
 	}
 }
 
@@ -189,11 +206,12 @@ func (m *exponentialHistogramMetrics) GetOrCreate(key Key, attributesFun BuildAt
 		}
 
 		h = &exponentialHistogram{
-			histogram:        histogram,
-			attributes:       attributes,
-			exemplars:        pmetric.NewExemplarSlice(),
-			maxExemplarCount: m.maxExemplarCount,
-			startTimestamp:   startTimeStamp,
+			histogram:           histogram,
+			attributes:          attributes,
+			exemplars:           pmetric.NewExemplarSlice(),
+			maxExemplarCount:    m.maxExemplarCount,
+			prioritizeExemplars: m.prioritizeExemplars,
+			startTimestamp:      startTimeStamp,
 		}
 		m.metrics[key] = h
 	}
@@ -257,6 +275,7 @@ func expoHistToExponentialDataPoint(agg *structure.Histogram[float64], dp pmetri
 func (m *exponentialHistogramMetrics) ClearExemplars() {
 	for _, m := range m.metrics {
 		m.exemplars = pmetric.NewExemplarSlice()
+		m.exemplarErrors = nil
 	}
 }
 
@@ -269,36 +288,47 @@ func (h *explicitHistogram) Observe(value float64) {
 	h.bucketCounts[index]++
 }
 
-func (h *explicitHistogram) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64) {
-	if h.maxExemplarCount != nil && h.exemplars.Len() >= *h.maxExemplarCount {
+func (h *explicitHistogram) ObserveWeighted(value float64, weight float64) {
+	n := h.carry.next(weight)
+	if n == 0 {
 		return
 	}
-	e := h.exemplars.AppendEmpty()
-	e.SetTraceID(traceID)
-	e.SetSpanID(spanID)
-	e.SetDoubleValue(value)
+	h.sum += value * float64(n)
+	h.count += n
+
+	// Binary search to find the value bucket index.
+	index := sort.SearchFloat64s(h.bounds, value)
+	h.bucketCounts[index] += n
+}
+
+func (h *explicitHistogram) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64, isError bool) {
+	addExemplar(h.exemplars, &h.exemplarErrors, h.maxExemplarCount, h.prioritizeExemplars, traceID, spanID, value, isError)
 }
 
 func (h *exponentialHistogram) Observe(value float64) {
 	h.histogram.Update(value)
 }
 
-func (h *exponentialHistogram) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64) {
-	if h.maxExemplarCount != nil && h.exemplars.Len() >= *h.maxExemplarCount {
-		return
+func (h *exponentialHistogram) ObserveWeighted(value float64, weight float64) {
+	if n := h.carry.next(weight); n > 0 {
+		h.histogram.UpdateByIncr(value, n)
 	}
-	e := h.exemplars.AppendEmpty()
-	e.SetTraceID(traceID)
-	e.SetSpanID(spanID)
-	e.SetDoubleValue(value)
+}
+
+func (h *exponentialHistogram) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64, isError bool) {
+	addExemplar(h.exemplars, &h.exemplarErrors, h.maxExemplarCount, h.prioritizeExemplars, traceID, spanID, value, isError)
 }
 
 type Sum struct {
 	attributes pcommon.Map
 	count      uint64
+	carry      weightCarry
 
-	exemplars        pmetric.ExemplarSlice
-	maxExemplarCount *int
+	exemplars pmetric.ExemplarSlice
+	// exemplarErrors tracks the exemplars of errors when they are prioritized.
+	exemplarErrors      []bool
+	maxExemplarCount    *int
+	prioritizeExemplars bool
 
 	startTimestamp pcommon.Timestamp
 	// isFirst is used to track if this datapoint is new to the Sum. This
@@ -312,18 +342,25 @@ func (s *Sum) Add(value uint64) {
 	s.count += value
 }
 
-func NewSumMetrics(maxExemplarCount *int, cardinalityLimit int) SumMetrics {
+// AddWeighted adds the weight, which is the adjusted count of a sampled span, to the sum.
+func (s *Sum) AddWeighted(weight float64) {
+	s.count += s.carry.next(weight)
+}
+
+func NewSumMetrics(maxExemplarCount *int, prioritizeExemplars bool, cardinalityLimit int) SumMetrics {
 	return SumMetrics{
-		metrics:          make(map[Key]*Sum),
-		maxExemplarCount: maxExemplarCount,
-		cardinalityLimit: cardinalityLimit,
+		metrics:             make(map[Key]*Sum),
+		maxExemplarCount:    maxExemplarCount,
+		prioritizeExemplars: prioritizeExemplars,
+		cardinalityLimit:    cardinalityLimit,
 	}
 }
 
 type SumMetrics struct {
-	metrics          map[Key]*Sum
-	maxExemplarCount *int
-	cardinalityLimit int
+	metrics             map[Key]*Sum
+	maxExemplarCount    *int
+	prioritizeExemplars bool
+	cardinalityLimit    int
 }
 
 func (m *SumMetrics) IsCardinalityLimitReached() bool {
@@ -353,11 +390,12 @@ func (m *SumMetrics) GetOrCreate(key Key, attributesFun BuildAttributesFun, star
 		}
 
 		s = &Sum{
-			attributes:       attributes,
-			exemplars:        pmetric.NewExemplarSlice(),
-			maxExemplarCount: m.maxExemplarCount,
-			startTimestamp:   startTimestamp,
-			isFirst:          true,
+			attributes:          attributes,
+			exemplars:           pmetric.NewExemplarSlice(),
+			maxExemplarCount:    m.maxExemplarCount,
+			prioritizeExemplars: m.prioritizeExemplars,
+			startTimestamp:      startTimestamp,
+			isFirst:             true,
 		}
 		m.metrics[key] = s
 	}
@@ -365,14 +403,8 @@ func (m *SumMetrics) GetOrCreate(key Key, attributesFun BuildAttributesFun, star
 	return s, limitReached
 }
 
-func (s *Sum) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64) {
-	if s.maxExemplarCount != nil && s.exemplars.Len() >= *s.maxExemplarCount {
-		return
-	}
-	e := s.exemplars.AppendEmpty()
-	e.SetTraceID(traceID)
-	e.SetSpanID(spanID)
-	e.SetDoubleValue(value)
+func (s *Sum) AddExemplar(traceID pcommon.TraceID, spanID pcommon.SpanID, value float64, isError bool) {
+	addExemplar(s.exemplars, &s.exemplarErrors, s.maxExemplarCount, s.prioritizeExemplars, traceID, spanID, value, isError)
 }
 
 func (m *SumMetrics) BuildMetrics(
@@ -408,5 +440,6 @@ func (m *SumMetrics) BuildMetrics(
 func (m *SumMetrics) ClearExemplars() {
 	for _, sum := range m.metrics {
 		sum.exemplars = pmetric.NewExemplarSlice()
+		sum.exemplarErrors = nil
 	}
 }
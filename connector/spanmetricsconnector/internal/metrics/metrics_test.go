@@ -160,7 +160,7 @@ func TestSum_AddExemplar(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.input.AddExemplar(pcommon.TraceID{}, pcommon.SpanID{}, 4)
+			tt.input.AddExemplar(pcommon.TraceID{}, pcommon.SpanID{}, 4, false)
 			assert.Equal(t, tt.want, tt.input.exemplars.Len())
 		})
 	}
@@ -221,7 +221,7 @@ func TestExplicitHistogram_AddExemplar(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.input.AddExemplar(pcommon.TraceID{}, pcommon.SpanID{}, 4)
+			tt.input.AddExemplar(pcommon.TraceID{}, pcommon.SpanID{}, 4, false)
 			assert.Equal(t, tt.want, tt.input.exemplars.Len())
 		})
 	}
@@ -282,7 +282,7 @@ func TestExponentialHistogram_AddExemplar(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.input.AddExemplar(pcommon.TraceID{}, pcommon.SpanID{}, 4)
+			tt.input.AddExemplar(pcommon.TraceID{}, pcommon.SpanID{}, 4, false)
 			assert.Equal(t, tt.want, tt.input.exemplars.Len())
 		})
 	}
@@ -323,6 +323,40 @@ func TestSum_Add(t *testing.T) {
 	}
 }
 
+func TestSum_AddWeighted(t *testing.T) {
+	sum := Sum{}
+	for i := 0; i < 4; i++ {
+		sum.AddWeighted(2.5)
+	}
+	assert.Equal(t, uint64(10), sum.count)
+}
+
+func TestExplicitHistogram_ObserveWeighted(t *testing.T) {
+	h := explicitHistogram{
+		bounds:       []float64{1, 10},
+		bucketCounts: make([]uint64, 3),
+	}
+	h.ObserveWeighted(5, 4)
+	h.ObserveWeighted(20, 1)
+	h.ObserveWeighted(0.5, 1.5)
+	h.ObserveWeighted(0.5, 1.5)
+
+	assert.Equal(t, uint64(8), h.count)
+	assert.InDelta(t, 41.5, h.sum, 1e-9)
+	assert.Equal(t, []uint64{3, 4, 1}, h.bucketCounts)
+}
+
+func TestExponentialHistogram_ObserveWeighted(t *testing.T) {
+	h := exponentialHistogram{
+		histogram: structure.NewFloat64(structure.NewConfig(structure.WithMaxSize(10))),
+	}
+	h.ObserveWeighted(2, 4)
+	h.ObserveWeighted(4, 0.5)
+
+	assert.Equal(t, uint64(4), h.histogram.Count())
+	assert.InDelta(t, 8, h.histogram.Sum(), 1e-9)
+}
+
 func TestSumMetrics_IsCardinalityLimitReached(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -0,0 +1,302 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routetemplate // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/routetemplate"
+
+import (
+	"sort"
+	"strings"
+)
+
+// Placeholders replacing the variable segments of the URL paths.
+const (
+	IDPlaceholder    = "{id}"
+	UUIDPlaceholder  = "{uuid}"
+	HashPlaceholder  = "{hash}"
+	ParamPlaceholder = "{param}"
+)
+
+// minHashLength is the minimum length of the hexadecimal segments considered as hashes.
+const minHashLength = 16
+
+// Template is a route template learned from the observed URL paths.
+type Template struct {
+	// Route is the templated route, e.g. /users/{id}.
+	Route string
+	// Matches is the number of URL paths matched by the route since it was learned.
+	Matches uint64
+}
+
+type node struct {
+	children map[string]*node
+	// literals is the number of children which are not placeholders.
+	literals int
+	// collapsed is set once too many distinct literal segments were observed after
+	// this node, the segments are then all matched by the param placeholder.
+	collapsed bool
+	// confirmed is set on the literal nodes once their segment is considered stable,
+	// the param placeholder is used in their place until then.
+	confirmed bool
+	// sinceNewLiteral is the number of URL paths which went through this node since
+	// the last new literal segment was observed after it.
+	sinceNewLiteral int
+	// matches is the number of URL paths ending at this node.
+	matches uint64
+}
+
+func newNode() *node {
+	return &node{children: make(map[string]*node)}
+}
+
+// Learner learns route templates from URL paths. The segments made of numbers, UUIDs
+// or hashes are always replaced by placeholders. The other segments are replaced by
+// the param placeholder while they are learned: they are kept as they are once
+// maxDistinctSegments URL paths went through the same position without any new value,
+// and all replaced by the param placeholder once more than maxDistinctSegments distinct
+// values are observed at the same position. At most maxSegments segments are learned,
+// the segments of the URL paths which can't be learned anymore are replaced by
+// placeholders.
+//
+// Important: This implementation is non-thread safe.
+type Learner struct {
+	root                *node
+	maxDistinctSegments int
+	maxSegments         int
+	// segments is the number of nodes of the tree, the root excluded.
+	segments int
+}
+
+// NewLearner creates a Learner.
+func NewLearner(maxDistinctSegments, maxSegments int) *Learner {
+	return &Learner{
+		root:                newNode(),
+		maxDistinctSegments: maxDistinctSegments,
+		maxSegments:         maxSegments,
+	}
+}
+
+// Template returns the route template of the URL path, learning from it. The query
+// and the fragment of the path are ignored.
+func (l *Learner) Template(path string) string {
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+
+	n := l.root
+	var b strings.Builder
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" {
+			continue
+		}
+		key := classify(segment)
+		b.WriteByte('/')
+		if n == nil {
+			// The path can't be learned anymore, its segments are not kept as they are.
+			if key == segment {
+				key = ParamPlaceholder
+			}
+			b.WriteString(key)
+			continue
+		}
+
+		n, key = l.learn(n, key, key == segment)
+		if !isPlaceholder(key) && (n == nil || !n.confirmed) {
+			key = ParamPlaceholder
+		}
+		b.WriteString(key)
+	}
+	if n != nil {
+		n.matches++
+	}
+
+	if b.Len() == 0 {
+		return "/"
+	}
+	return b.String()
+}
+
+// learn returns the child of the node matching the key, creating it when needed, and
+// the key of the child. The child is nil when the maximum number of segments is reached.
+func (l *Learner) learn(n *node, key string, literal bool) (*node, string) {
+	if literal && n.collapsed {
+		key = ParamPlaceholder
+		literal = false
+	}
+
+	child, ok := n.children[key]
+	if !ok && literal && n.literals >= l.maxDistinctSegments {
+		l.collapse(n)
+		key = ParamPlaceholder
+		literal = false
+		child, ok = n.children[key]
+	}
+	if !ok {
+		if l.segments >= l.maxSegments {
+			return nil, key
+		}
+		child = newNode()
+		n.children[key] = child
+		l.segments++
+		if literal {
+			n.literals++
+			n.sinceNewLiteral = 0
+			return child, key
+		}
+	}
+
+	n.sinceNewLiteral++
+	if n.sinceNewLiteral == l.maxDistinctSegments {
+		for k, c := range n.children {
+			if !isPlaceholder(k) {
+				c.confirmed = true
+			}
+		}
+	}
+	return child, key
+}
+
+// collapse merges the literal children of the node into its param placeholder child,
+// the param placeholder matches their segments from now on.
+func (l *Learner) collapse(n *node) {
+	param := n.children[ParamPlaceholder]
+	for key, child := range n.children {
+		if isPlaceholder(key) {
+			continue
+		}
+		delete(n.children, key)
+		if param == nil {
+			param = child
+			n.children[ParamPlaceholder] = param
+			continue
+		}
+		l.merge(param, child)
+	}
+	n.literals = 0
+	n.collapsed = true
+}
+
+// merge moves the matches and the children of src into dst, src is discarded.
+func (l *Learner) merge(dst, src *node) {
+	l.segments--
+	dst.matches += src.matches
+	dst.confirmed = dst.confirmed || src.confirmed
+	for key, child := range src.children {
+		existing, ok := dst.children[key]
+		if !ok {
+			dst.children[key] = child
+			if !isPlaceholder(key) {
+				dst.literals++
+			}
+			continue
+		}
+		l.merge(existing, child)
+	}
+	if src.collapsed || (dst.collapsed && dst.literals > 0) || dst.literals > l.maxDistinctSegments {
+		l.collapse(dst)
+	}
+}
+
+// Templates returns the learned route templates sorted by route.
+func (l *Learner) Templates() []Template {
+	matches := make(map[string]uint64)
+	var walk func(n *node, prefix string)
+	walk = func(n *node, prefix string) {
+		if n.matches > 0 {
+			route := prefix
+			if route == "" {
+				route = "/"
+			}
+			matches[route] += n.matches
+		}
+		for key, child := range n.children {
+			if !isPlaceholder(key) && !child.confirmed {
+				key = ParamPlaceholder
+			}
+			walk(child, prefix+"/"+key)
+		}
+	}
+	walk(l.root, "")
+
+	templates := make([]Template, 0, len(matches))
+	for route, m := range matches {
+		templates = append(templates, Template{Route: route, Matches: m})
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Route < templates[j].Route
+	})
+	return templates
+}
+
+// classify returns the placeholder of the segment when it is made of a number, a UUID
+// or a hash, the segment itself otherwise.
+func classify(segment string) string {
+	switch {
+	case isNumber(segment):
+		return IDPlaceholder
+	case isUUID(segment):
+		return UUIDPlaceholder
+	case isHash(segment):
+		return HashPlaceholder
+	default:
+		return segment
+	}
+}
+
+func isPlaceholder(key string) bool {
+	switch key {
+	case IDPlaceholder, UUIDPlaceholder, HashPlaceholder, ParamPlaceholder:
+		return true
+	default:
+		return false
+	}
+}
+
+func isNumber(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if !isHex(s[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isHash returns whether the segment is a long hexadecimal string. Hexadecimal words
+// made of letters only are not considered as hashes.
+func isHash(s string) bool {
+	if len(s) < minHashLength {
+		return false
+	}
+	hasDigit := false
+	for i := 0; i < len(s); i++ {
+		if !isHex(s[i]) {
+			return false
+		}
+		if s[i] >= '0' && s[i] <= '9' {
+			hasDigit = true
+		}
+	}
+	return hasDigit
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,132 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package routetemplate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplate(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "", want: "/"},
+		{path: "/", want: "/"},
+		{path: "/users", want: "/users"},
+		{path: "/users/", want: "/users"},
+		{path: "/users/12345", want: "/users/{id}"},
+		{path: "/users/12345/orders/67?expand=true", want: "/users/{id}/orders/{id}"},
+		{path: "/orders/4b1e6b1c-0e62-4c48-9d5c-5d0bcb2f4f0a#items", want: "/orders/{uuid}"},
+		{path: "/blobs/9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", want: "/blobs/{hash}"},
+		{path: "/blobs/deadbeefdeadbeef", want: "/blobs/deadbeefdeadbeef"},
+		{path: "/v2//items", want: "/v2/items"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			l := NewLearner(10, 100)
+			for i := 0; i < 10; i++ {
+				l.Template(tt.path)
+			}
+			assert.Equal(t, tt.want, l.Template(tt.path))
+		})
+	}
+}
+
+func TestTemplateWhileLearning(t *testing.T) {
+	l := NewLearner(3, 100)
+
+	// The literal segments are replaced until enough paths went through their position without any new value.
+	assert.Equal(t, "/{param}/{id}", l.Template("/users/1"))
+	assert.Equal(t, "/{param}/{id}", l.Template("/users/2"))
+	assert.Equal(t, "/{param}/{id}", l.Template("/users/3"))
+	assert.Equal(t, "/users/{id}", l.Template("/users/4"))
+
+	// A new value is replaced until it is stable as well, without affecting the stable ones.
+	assert.Equal(t, "/{param}", l.Template("/orders"))
+	assert.Equal(t, "/users/{id}", l.Template("/users/5"))
+	assert.Equal(t, "/{param}", l.Template("/orders"))
+	assert.Equal(t, "/orders", l.Template("/orders"))
+
+	assert.Equal(t, []Template{
+		{Route: "/orders", Matches: 3},
+		{Route: "/users/{id}", Matches: 5},
+	}, l.Templates())
+}
+
+func TestTemplateCollapsesDistinctSegments(t *testing.T) {
+	l := NewLearner(3, 100)
+
+	for i := 0; i < 4; i++ {
+		for _, name := range []string{"alice", "bob", "carol"} {
+			l.Template("/users/" + name + "/profile")
+		}
+	}
+	assert.Equal(t, "/users/alice/profile", l.Template("/users/alice/profile"))
+	assert.Equal(t, "/users/{param}/profile", l.Template("/users/dave/profile"))
+	// The segments learned before collapsing are matched by the placeholder as well.
+	assert.Equal(t, "/users/{param}/profile", l.Template("/users/alice/profile"))
+
+	assert.Equal(t, []Template{
+		{Route: "/users/{param}/profile", Matches: 15},
+	}, l.Templates())
+}
+
+func TestTemplateCollapseKeepsSubtrees(t *testing.T) {
+	l := NewLearner(3, 100)
+
+	for i := 0; i < 4; i++ {
+		l.Template("/tenants/acme/orders")
+		l.Template("/tenants/globex/invoices")
+		l.Template("/tenants/initech/orders")
+	}
+	// The fourth tenant collapses the position, the segments learned after the previous tenants are kept.
+	assert.Equal(t, "/tenants/{param}/{param}", l.Template("/tenants/umbrella/settings"))
+	assert.Equal(t, "/tenants/{param}/orders", l.Template("/tenants/umbrella/orders"))
+	assert.Equal(t, "/tenants/{param}/invoices", l.Template("/tenants/acme/invoices"))
+
+	assert.Equal(t, []Template{
+		{Route: "/tenants/{param}/invoices", Matches: 5},
+		{Route: "/tenants/{param}/orders", Matches: 9},
+		{Route: "/tenants/{param}/{param}", Matches: 1},
+	}, l.Templates())
+}
+
+func TestTemplateMaxSegments(t *testing.T) {
+	l := NewLearner(1, 2)
+	for i := 0; i < 2; i++ {
+		l.Template("/users/1")
+	}
+	assert.Equal(t, "/users/{id}", l.Template("/users/1"))
+
+	// The segments which can't be learned anymore are replaced by placeholders.
+	assert.Equal(t, "/users/{id}/{param}/{uuid}", l.Template("/users/1/orders/4b1e6b1c-0e62-4c48-9d5c-5d0bcb2f4f0a"))
+	assert.Equal(t, "/users/{param}", l.Template("/users/alice"))
+
+	assert.Equal(t, []Template{
+		{Route: "/users/{id}", Matches: 3},
+	}, l.Templates())
+}
+
+func TestTemplates(t *testing.T) {
+	l := NewLearner(20, 100)
+	assert.Empty(t, l.Templates())
+
+	for i := 0; i < 25; i++ {
+		l.Template(fmt.Sprintf("/users/%d", i))
+	}
+	l.Template("/users")
+	l.Template("/")
+	l.Template("/health?verbose=1")
+
+	assert.Equal(t, []Template{
+		{Route: "/", Matches: 1},
+		{Route: "/users", Matches: 1},
+		{Route: "/users/{id}", Matches: 25},
+		{Route: "/{param}", Matches: 1},
+	}, l.Templates())
+}
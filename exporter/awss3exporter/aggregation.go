@@ -0,0 +1,243 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3exporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter/internal/upload"
+)
+
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID, signalType string) (storage.Client, error) {
+	extension, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindExporter, componentID, signalType)
+}
+
+// startAggregation starts buffering the data of each partition, and recovers the data buffered
+// in storage by a previous run.
+func (e *s3Exporter) startAggregation(ctx context.Context, host component.Host, up upload.Manager) error {
+	var encode upload.EncodeFunc
+	switch e.signalType {
+	case "logs":
+		encode = e.encodeLogs
+	case "metrics":
+		encode = e.encodeMetrics
+	case "traces":
+		encode = e.encodeTraces
+	default:
+		return fmt.Errorf("aggregation is not supported for %s", e.signalType)
+	}
+
+	settings := upload.AggregatorSettings{
+		MaxSize:         e.config.Aggregation.MaxSize,
+		MaxRecords:      e.config.Aggregation.MaxRecords,
+		MaxAge:          e.config.Aggregation.MaxAge,
+		MaxBufferedSize: e.config.Aggregation.MaxBufferedSize,
+		Manifest:        e.config.Aggregation.Manifest,
+	}
+	if e.config.Aggregation.Storage != nil {
+		client, err := getStorageClient(ctx, host, e.config.Aggregation.Storage, e.id, e.signalType)
+		if err != nil {
+			return err
+		}
+		e.storageClient = client
+		settings.Storage = client
+	}
+
+	aggregator, err := upload.NewAggregator(up, encode, settings, e.telemetry)
+	if err != nil {
+		return err
+	}
+	if err := aggregator.Start(ctx); err != nil {
+		return err
+	}
+	e.aggregator = aggregator
+	return nil
+}
+
+func (e *s3Exporter) aggregateLogs(ctx context.Context, logs plog.Logs) error {
+	data, err := (&plog.ProtoMarshaler{}).MarshalLogs(logs)
+	if err != nil {
+		return err
+	}
+
+	var r timeRange
+	rls := logs.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				if lr.Timestamp() != 0 {
+					r.add(lr.Timestamp())
+				} else {
+					r.add(lr.ObservedTimestamp())
+				}
+			}
+		}
+	}
+
+	return e.aggregator.Add(ctx, r.chunk(data, logs.LogRecordCount()), e.getUploadOpts(rls.At(0).Resource()))
+}
+
+func (e *s3Exporter) aggregateMetrics(ctx context.Context, md pmetric.Metrics) error {
+	data, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(md)
+	if err != nil {
+		return err
+	}
+
+	var r timeRange
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				r.addMetric(ms.At(k))
+			}
+		}
+	}
+
+	return e.aggregator.Add(ctx, r.chunk(data, md.DataPointCount()), e.getUploadOpts(rms.At(0).Resource()))
+}
+
+func (e *s3Exporter) aggregateTraces(ctx context.Context, traces ptrace.Traces) error {
+	data, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(traces)
+	if err != nil {
+		return err
+	}
+
+	var r timeRange
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				r.add(spans.At(k).StartTimestamp())
+				r.add(spans.At(k).EndTimestamp())
+			}
+		}
+	}
+
+	return e.aggregator.Add(ctx, r.chunk(data, traces.SpanCount()), e.getUploadOpts(rss.At(0).Resource()))
+}
+
+// encodeLogs merges the buffered logs and marshals them with the configured marshaler.
+func (e *s3Exporter) encodeLogs(chunks [][]byte) ([]byte, error) {
+	unmarshaler := &plog.ProtoUnmarshaler{}
+	merged := plog.NewLogs()
+	for _, chunk := range chunks {
+		logs, err := unmarshaler.UnmarshalLogs(chunk)
+		if err != nil {
+			return nil, err
+		}
+		logs.ResourceLogs().MoveAndAppendTo(merged.ResourceLogs())
+	}
+	return e.marshaler.MarshalLogs(merged)
+}
+
+// encodeMetrics merges the buffered metrics and marshals them with the configured marshaler.
+func (e *s3Exporter) encodeMetrics(chunks [][]byte) ([]byte, error) {
+	unmarshaler := &pmetric.ProtoUnmarshaler{}
+	merged := pmetric.NewMetrics()
+	for _, chunk := range chunks {
+		md, err := unmarshaler.UnmarshalMetrics(chunk)
+		if err != nil {
+			return nil, err
+		}
+		md.ResourceMetrics().MoveAndAppendTo(merged.ResourceMetrics())
+	}
+	return e.marshaler.MarshalMetrics(merged)
+}
+
+// encodeTraces merges the buffered traces and marshals them with the configured marshaler.
+func (e *s3Exporter) encodeTraces(chunks [][]byte) ([]byte, error) {
+	unmarshaler := &ptrace.ProtoUnmarshaler{}
+	merged := ptrace.NewTraces()
+	for _, chunk := range chunks {
+		traces, err := unmarshaler.UnmarshalTraces(chunk)
+		if err != nil {
+			return nil, err
+		}
+		traces.ResourceSpans().MoveAndAppendTo(merged.ResourceSpans())
+	}
+	return e.marshaler.MarshalTraces(merged)
+}
+
+// timeRange tracks the range of the timestamps of a chunk, ignoring the unset timestamps.
+type timeRange struct {
+	minTimestamp, maxTimestamp time.Time
+}
+
+func (r *timeRange) add(ts pcommon.Timestamp) {
+	if ts == 0 {
+		return
+	}
+	t := ts.AsTime()
+	if r.minTimestamp.IsZero() || t.Before(r.minTimestamp) {
+		r.minTimestamp = t
+	}
+	if t.After(r.maxTimestamp) {
+		r.maxTimestamp = t
+	}
+}
+
+func (r *timeRange) addMetric(m pmetric.Metric) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			r.add(dps.At(i).Timestamp())
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			r.add(dps.At(i).Timestamp())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			r.add(dps.At(i).Timestamp())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			r.add(dps.At(i).Timestamp())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			r.add(dps.At(i).Timestamp())
+		}
+	}
+}
+
+func (r *timeRange) chunk(data []byte, records int) upload.Chunk {
+	return upload.Chunk{
+		Data:         data,
+		Records:      records,
+		MinTimestamp: r.minTimestamp,
+		MaxTimestamp: r.maxTimestamp,
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3exporter
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter/internal/metadata"
+)
+
+func newAggregationConfig(t *testing.T) (*Config, map[string][]byte, *sync.Mutex) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "access-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret-key")
+
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+	s := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		objects[r.URL.Path] = body
+	}))
+	t.Cleanup(s.Close)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.S3Uploader.Region = "local"
+	cfg.S3Uploader.S3Bucket = "my-bucket"
+	cfg.S3Uploader.Endpoint = s.URL
+	cfg.S3Uploader.S3ForcePathStyle = true
+	cfg.Aggregation.Enabled = true
+	return cfg, objects, &mu
+}
+
+func TestAggregatedLogs(t *testing.T) {
+	cfg, objects, mu := newAggregationConfig(t)
+	exp, err := createLogsExporter(context.Background(), exportertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	ts := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		logs := plog.NewLogs()
+		lr := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(ts.Add(time.Duration(i) * time.Second)))
+		lr.Body().SetStr("message")
+		require.NoError(t, exp.ConsumeLogs(context.Background(), logs))
+	}
+	mu.Lock()
+	assert.Empty(t, objects)
+	mu.Unlock()
+
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, objects, 2)
+	var logsObject, manifestObject string
+	for path := range objects {
+		if strings.HasSuffix(path, ".manifest.json") {
+			manifestObject = path
+		} else {
+			logsObject = path
+		}
+	}
+	require.True(t, strings.HasPrefix(logsObject, "/my-bucket/year="), logsObject)
+	require.True(t, strings.HasSuffix(logsObject, ".json"), logsObject)
+
+	logs, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(objects[logsObject])
+	require.NoError(t, err)
+	assert.Equal(t, 2, logs.LogRecordCount())
+
+	var m struct {
+		Files []struct {
+			Key          string    `json:"key"`
+			Records      int       `json:"records"`
+			MinTimestamp time.Time `json:"min_timestamp"`
+			MaxTimestamp time.Time `json:"max_timestamp"`
+		} `json:"files"`
+	}
+	require.NoError(t, json.Unmarshal(objects[manifestObject], &m))
+	require.Len(t, m.Files, 1)
+	assert.Equal(t, strings.TrimPrefix(logsObject, "/my-bucket/"), m.Files[0].Key)
+	assert.Equal(t, 2, m.Files[0].Records)
+	assert.Equal(t, ts, m.Files[0].MinTimestamp)
+	assert.Equal(t, ts.Add(time.Second), m.Files[0].MaxTimestamp)
+}
+
+func TestAggregationStorageNotFound(t *testing.T) {
+	cfg, _, _ := newAggregationConfig(t)
+	storageID := component.MustNewID("file_storage")
+	cfg.Aggregation.Storage = &storageID
+
+	exp, err := createTracesExporter(context.Background(), exportertest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+	assert.EqualError(t, exp.Start(context.Background(), componenttest.NewNopHost()), "storage extension 'file_storage' not found")
+	require.NoError(t, exp.Shutdown(context.Background()))
+}
+
+func TestMetricsTimeRange(t *testing.T) {
+	ts := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	ms.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetTimestamp(pcommon.NewTimestampFromTime(ts.Add(time.Minute)))
+	ms.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty().SetTimestamp(pcommon.NewTimestampFromTime(ts))
+	ms.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty()
+	ms.AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty().SetTimestamp(pcommon.NewTimestampFromTime(ts.Add(time.Hour)))
+
+	var r timeRange
+	for i := 0; i < ms.Len(); i++ {
+		r.addMetric(ms.At(i))
+	}
+	chunk := r.chunk([]byte("data"), md.DataPointCount())
+	assert.Equal(t, 4, chunk.Records)
+	assert.Equal(t, ts, chunk.MinTimestamp)
+	assert.Equal(t, ts.Add(time.Hour), chunk.MaxTimestamp)
+}
@@ -5,6 +5,7 @@ package awss3exporter // import "github.com/open-telemetry/opentelemetry-collect
 
 import (
 	"errors"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configcompression"
@@ -57,6 +58,29 @@ type ResourceAttrsToS3 struct {
 	S3Prefix string `mapstructure:"s3_prefix"`
 }
 
+// AggregationConfig defines how the data of each partition is aggregated into larger objects.
+type AggregationConfig struct {
+	// Enabled buffers the data of each partition, instead of writing an object per export.
+	Enabled bool `mapstructure:"enabled"`
+	// MaxSize is the size, in bytes, of the buffered data of a partition that triggers the upload of an object.
+	// The size is measured on the OTLP protobuf encoding of the data.
+	MaxSize int64 `mapstructure:"max_size"`
+	// MaxRecords is the number of buffered log records, spans or metric data points of a partition that
+	// triggers the upload of an object. 0 means no limit.
+	MaxRecords int `mapstructure:"max_records"`
+	// MaxAge is the longest time the data of a partition is buffered before being uploaded.
+	MaxAge time.Duration `mapstructure:"max_age"`
+	// MaxBufferedSize is the size, in bytes, of the data buffered for all the partitions above which
+	// exports fail, so that they are retried or queued until the buffered data is uploaded.
+	MaxBufferedSize int64 `mapstructure:"max_buffered_size"`
+	// Storage is the storage extension holding the buffered data, so that it is uploaded after a restart.
+	// The data is buffered in memory when unset.
+	Storage *component.ID `mapstructure:"storage"`
+	// Manifest enables writing a manifest object to each partition, listing the objects written to it
+	// along with the range of their timestamps.
+	Manifest bool `mapstructure:"manifest"`
+}
+
 // Config contains the main configuration options for the s3 exporter
 type Config struct {
 	QueueSettings   exporterhelper.QueueBatchConfig `mapstructure:"sending_queue"`
@@ -68,6 +92,7 @@ type Config struct {
 	Encoding              *component.ID     `mapstructure:"encoding"`
 	EncodingFileExtension string            `mapstructure:"encoding_file_extension"`
 	ResourceAttrsToS3     ResourceAttrsToS3 `mapstructure:"resource_attrs_to_s3"`
+	Aggregation           AggregationConfig `mapstructure:"aggregation"`
 }
 
 func (c *Config) Validate() error {
@@ -116,5 +141,20 @@ func (c *Config) Validate() error {
 			errs = multierr.Append(errs, errors.New("marshaler does not support compression"))
 		}
 	}
+
+	if c.Aggregation.Enabled {
+		if c.Aggregation.MaxSize <= 0 {
+			errs = multierr.Append(errs, errors.New("aggregation max_size must be positive"))
+		}
+		if c.Aggregation.MaxRecords < 0 {
+			errs = multierr.Append(errs, errors.New("aggregation max_records must not be negative"))
+		}
+		if c.Aggregation.MaxAge <= 0 {
+			errs = multierr.Append(errs, errors.New("aggregation max_age must be positive"))
+		}
+		if c.Aggregation.MaxBufferedSize < c.Aggregation.MaxSize {
+			errs = multierr.Append(errs, errors.New("aggregation max_buffered_size must not be less than max_size"))
+		}
+	}
 	return errs
 }
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -48,6 +49,7 @@ func TestLoadConfig(t *testing.T) {
 			StorageClass:      "STANDARD",
 		},
 		MarshalerName: "otlp_json",
+		Aggregation:   createDefaultConfig().(*Config).Aggregation,
 	}, e,
 	)
 }
@@ -89,6 +91,7 @@ func TestConfig(t *testing.T) {
 			StorageClass:      "STANDARD",
 		},
 		MarshalerName: "otlp_json",
+		Aggregation:   createDefaultConfig().(*Config).Aggregation,
 	}, e,
 	)
 }
@@ -123,6 +126,7 @@ func TestConfigS3StorageClass(t *testing.T) {
 		QueueSettings:   queueCfg,
 		TimeoutSettings: timeoutCfg,
 		MarshalerName:   "otlp_json",
+		Aggregation:     createDefaultConfig().(*Config).Aggregation,
 	}, e,
 	)
 }
@@ -158,6 +162,7 @@ func TestConfigS3ACL(t *testing.T) {
 		QueueSettings:   queueCfg,
 		TimeoutSettings: timeoutCfg,
 		MarshalerName:   "otlp_json",
+		Aggregation:     createDefaultConfig().(*Config).Aggregation,
 	}, e,
 	)
 }
@@ -193,6 +198,7 @@ func TestConfigS3ACLDefined(t *testing.T) {
 		QueueSettings:   queueCfg,
 		TimeoutSettings: timeoutCfg,
 		MarshalerName:   "otlp_json",
+		Aggregation:     createDefaultConfig().(*Config).Aggregation,
 	}, e,
 	)
 }
@@ -229,6 +235,7 @@ func TestConfigForS3CompatibleSystems(t *testing.T) {
 			StorageClass:      "STANDARD",
 		},
 		MarshalerName: "otlp_json",
+		Aggregation:   createDefaultConfig().(*Config).Aggregation,
 	}, e,
 	)
 }
@@ -304,6 +311,42 @@ func TestConfig_Validate(t *testing.T) {
 			}(),
 			errExpected: errors.New("region is required"),
 		},
+		{
+			name: "aggregation limits",
+			config: func() *Config {
+				c := createDefaultConfig().(*Config)
+				c.S3Uploader.S3Bucket = "foo"
+				c.Aggregation.Enabled = true
+				c.Aggregation.MaxSize = 0
+				c.Aggregation.MaxRecords = -1
+				c.Aggregation.MaxAge = 0
+				return c
+			}(),
+			errExpected: multierr.Append(multierr.Append(errors.New("aggregation max_size must be positive"),
+				errors.New("aggregation max_records must not be negative")),
+				errors.New("aggregation max_age must be positive")),
+		},
+		{
+			name: "aggregation max buffered size",
+			config: func() *Config {
+				c := createDefaultConfig().(*Config)
+				c.S3Uploader.S3Bucket = "foo"
+				c.Aggregation.Enabled = true
+				c.Aggregation.MaxBufferedSize = c.Aggregation.MaxSize - 1
+				return c
+			}(),
+			errExpected: errors.New("aggregation max_buffered_size must not be less than max_size"),
+		},
+		{
+			name: "aggregation limits ignored when disabled",
+			config: func() *Config {
+				c := createDefaultConfig().(*Config)
+				c.S3Uploader.S3Bucket = "foo"
+				c.Aggregation.MaxSize = 0
+				return c
+			}(),
+			errExpected: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -342,6 +385,7 @@ func TestMarshallerName(t *testing.T) {
 			StorageClass:      "STANDARD",
 		},
 		MarshalerName: "sumo_ic",
+		Aggregation:   createDefaultConfig().(*Config).Aggregation,
 	}, e,
 	)
 
@@ -357,6 +401,7 @@ func TestMarshallerName(t *testing.T) {
 			StorageClass:      "STANDARD",
 		},
 		MarshalerName: "otlp_proto",
+		Aggregation:   createDefaultConfig().(*Config).Aggregation,
 	}, e,
 	)
 }
@@ -390,6 +435,7 @@ func TestCompressionName(t *testing.T) {
 			StorageClass:      "STANDARD",
 		},
 		MarshalerName: "otlp_json",
+		Aggregation:   createDefaultConfig().(*Config).Aggregation,
 	}, e,
 	)
 
@@ -406,6 +452,7 @@ func TestCompressionName(t *testing.T) {
 			StorageClass:      "STANDARD",
 		},
 		MarshalerName: "otlp_proto",
+		Aggregation:   createDefaultConfig().(*Config).Aggregation,
 	}, e,
 	)
 }
@@ -440,9 +487,52 @@ func TestResourceAttrsToS3(t *testing.T) {
 			StorageClass:      "STANDARD",
 		},
 		MarshalerName: "otlp_json",
+		Aggregation:   createDefaultConfig().(*Config).Aggregation,
 		ResourceAttrsToS3: ResourceAttrsToS3{
 			S3Prefix: "com.awss3.prefix",
 		},
 	}, e,
 	)
 }
+
+func TestAggregation(t *testing.T) {
+	factories, err := otelcoltest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Exporters[factory.Type()] = factory
+	cfg, err := otelcoltest.LoadConfigAndValidate(
+		filepath.Join("testdata", "config-s3_aggregation.yaml"), factories)
+
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	queueCfg := exporterhelper.NewDefaultQueueConfig()
+	queueCfg.Enabled = false
+	timeoutCfg := exporterhelper.NewDefaultTimeoutConfig()
+	storage := component.MustNewID("file_storage")
+
+	e := cfg.Exporters[component.MustNewID("awss3")].(*Config)
+
+	assert.Equal(t, &Config{
+		QueueSettings:   queueCfg,
+		TimeoutSettings: timeoutCfg,
+		S3Uploader: S3UploaderConfig{
+			Region:            "us-east-1",
+			S3Bucket:          "foo",
+			S3PartitionFormat: "year=%Y/month=%m/day=%d/hour=%H",
+			StorageClass:      "STANDARD",
+		},
+		MarshalerName: "otlp_json",
+		Aggregation: AggregationConfig{
+			Enabled:         true,
+			MaxSize:         1048576,
+			MaxRecords:      10000,
+			MaxAge:          time.Minute,
+			MaxBufferedSize: 8388608,
+			Storage:         &storage,
+			Manifest:        false,
+		},
+	}, e,
+	)
+}
@@ -10,21 +10,27 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter/internal/upload"
 )
 
 type s3Exporter struct {
-	config     *Config
-	signalType string
-	uploader   upload.Manager
-	logger     *zap.Logger
-	marshaler  marshaler
+	config        *Config
+	id            component.ID
+	signalType    string
+	uploader      upload.Manager
+	aggregator    *upload.Aggregator
+	storageClient storage.Client
+	logger        *zap.Logger
+	telemetry     component.TelemetrySettings
+	marshaler     marshaler
 }
 
 func newS3Exporter(
@@ -34,8 +40,10 @@ func newS3Exporter(
 ) *s3Exporter {
 	s3Exporter := &s3Exporter{
 		config:     config,
+		id:         params.ID,
 		signalType: signalType,
 		logger:     params.Logger,
+		telemetry:  params.TelemetrySettings,
 	}
 	return s3Exporter
 }
@@ -73,14 +81,33 @@ func (e *s3Exporter) start(ctx context.Context, host component.Host) error {
 		return err
 	}
 	e.uploader = up
+
+	if e.config.Aggregation.Enabled {
+		return e.startAggregation(ctx, host, up)
+	}
 	return nil
 }
 
+func (e *s3Exporter) shutdown(ctx context.Context) error {
+	var errs error
+	if e.aggregator != nil {
+		errs = multierr.Append(errs, e.aggregator.Shutdown(ctx))
+	}
+	if e.storageClient != nil {
+		errs = multierr.Append(errs, e.storageClient.Close(ctx))
+	}
+	return errs
+}
+
 func (e *s3Exporter) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
 func (e *s3Exporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	if e.aggregator != nil {
+		return e.aggregateMetrics(ctx, md)
+	}
+
 	buf, err := e.marshaler.MarshalMetrics(md)
 	if err != nil {
 		return err
@@ -91,6 +118,10 @@ func (e *s3Exporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) err
 }
 
 func (e *s3Exporter) ConsumeLogs(ctx context.Context, logs plog.Logs) error {
+	if e.aggregator != nil {
+		return e.aggregateLogs(ctx, logs)
+	}
+
 	buf, err := e.marshaler.MarshalLogs(logs)
 	if err != nil {
 		return err
@@ -102,6 +133,10 @@ func (e *s3Exporter) ConsumeLogs(ctx context.Context, logs plog.Logs) error {
 }
 
 func (e *s3Exporter) ConsumeTraces(ctx context.Context, traces ptrace.Traces) error {
+	if e.aggregator != nil {
+		return e.aggregateTraces(ctx, traces)
+	}
+
 	buf, err := e.marshaler.MarshalTraces(traces)
 	if err != nil {
 		return err
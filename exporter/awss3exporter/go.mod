@@ -20,8 +20,12 @@ require (
 	go.opentelemetry.io/collector/consumer v1.31.1-0.20250508034258-ac520a5c14cc
 	go.opentelemetry.io/collector/exporter v0.125.1-0.20250508034258-ac520a5c14cc
 	go.opentelemetry.io/collector/exporter/exportertest v0.125.1-0.20250508034258-ac520a5c14cc
+	go.opentelemetry.io/collector/extension/xextension v0.125.1-0.20250508034258-ac520a5c14cc
 	go.opentelemetry.io/collector/otelcol/otelcoltest v0.125.1-0.20250508034258-ac520a5c14cc
 	go.opentelemetry.io/collector/pdata v1.31.1-0.20250508034258-ac520a5c14cc
+	go.opentelemetry.io/otel/metric v1.35.0
+	go.opentelemetry.io/otel/sdk/metric v1.35.0
+	go.opentelemetry.io/otel/trace v1.35.0
 	go.uber.org/goleak v1.3.0
 	go.uber.org/multierr v1.11.0
 	go.uber.org/zap v1.27.0
@@ -99,7 +103,6 @@ require (
 	go.opentelemetry.io/collector/extension v1.31.1-0.20250508034258-ac520a5c14cc // indirect
 	go.opentelemetry.io/collector/extension/extensioncapabilities v0.125.1-0.20250508034258-ac520a5c14cc // indirect
 	go.opentelemetry.io/collector/extension/extensiontest v0.125.1-0.20250508034258-ac520a5c14cc // indirect
-	go.opentelemetry.io/collector/extension/xextension v0.125.1-0.20250508034258-ac520a5c14cc // indirect
 	go.opentelemetry.io/collector/featuregate v1.31.1-0.20250508034258-ac520a5c14cc // indirect
 	go.opentelemetry.io/collector/internal/fanoutconsumer v0.125.1-0.20250508034258-ac520a5c14cc // indirect
 	go.opentelemetry.io/collector/internal/telemetry v0.125.1-0.20250508034258-ac520a5c14cc // indirect
@@ -133,11 +136,8 @@ require (
 	go.opentelemetry.io/otel/exporters/stdout/stdoutmetric v1.35.0 // indirect
 	go.opentelemetry.io/otel/exporters/stdout/stdouttrace v1.35.0 // indirect
 	go.opentelemetry.io/otel/log v0.11.0 // indirect
-	go.opentelemetry.io/otel/metric v1.35.0 // indirect
 	go.opentelemetry.io/otel/sdk v1.35.0 // indirect
 	go.opentelemetry.io/otel/sdk/log v0.11.0 // indirect
-	go.opentelemetry.io/otel/sdk/metric v1.35.0 // indirect
-	go.opentelemetry.io/otel/trace v1.35.0 // indirect
 	go.opentelemetry.io/proto/otlp v1.5.0 // indirect
 	golang.org/x/exp v0.0.0-20240506185415-9bf2ced13842 // indirect
 	golang.org/x/net v0.40.0 // indirect
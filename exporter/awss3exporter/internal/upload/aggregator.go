@@ -0,0 +1,715 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package upload // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter/internal/upload"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tilinna/clock"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter/internal/metadata"
+)
+
+const (
+	indexKey       = "aggregation_index"
+	chunkKeyPrefix = "aggregation_chunk_"
+	entryKeyPrefix = "aggregation_entry_"
+
+	// maxCheckInterval is the longest interval between two checks for the buffers to upload.
+	maxCheckInterval = time.Second
+	// initialRetryInterval and maxRetryInterval bound the exponential backoff of the failed uploads of a buffer.
+	initialRetryInterval = time.Second
+	maxRetryInterval     = 5 * time.Minute
+)
+
+// Chunk is the data of an export buffered by the Aggregator.
+type Chunk struct {
+	// Data is the OTLP protobuf encoding of the data.
+	Data []byte
+	// Records is the number of log records, spans or metric data points of the chunk.
+	Records int
+	// MinTimestamp and MaxTimestamp are the range of the timestamps of the records,
+	// they are zero when the records have no timestamp.
+	MinTimestamp time.Time
+	MaxTimestamp time.Time
+}
+
+// errBufferFull is returned by Add when the buffered chunks reach the maximum buffered size, so that the
+// exports are retried or queued until the buffers are uploaded.
+var errBufferFull = errors.New("aggregation buffer is full")
+
+// EncodeFunc merges the buffered chunks and encodes them to the content of an object.
+type EncodeFunc func(chunks [][]byte) ([]byte, error)
+
+// AggregatorSettings defines when the buffered data of a partition is uploaded.
+type AggregatorSettings struct {
+	// MaxSize is the size, in bytes, of the buffered chunks that triggers an upload.
+	MaxSize int64
+	// MaxRecords is the number of buffered records that triggers an upload, 0 means no limit.
+	MaxRecords int
+	// MaxAge is the longest time the chunks are buffered before being uploaded.
+	MaxAge time.Duration
+	// MaxBufferedSize is the size, in bytes, of the chunks buffered for all the partitions, including the
+	// ones being uploaded, above which new chunks are rejected.
+	MaxBufferedSize int64
+	// Manifest enables writing a manifest listing the objects written to each partition.
+	Manifest bool
+	// Storage holds the buffered chunks when set, so that they are recovered after a restart.
+	// The chunks are held in memory otherwise.
+	Storage storage.Client
+}
+
+// chunkInfo describes a buffered chunk. Its data is only kept in memory when there is no storage.
+type chunkInfo struct {
+	ID           uint64    `json:"id"`
+	Size         int       `json:"size"`
+	Records      int       `json:"records"`
+	MinTimestamp time.Time `json:"min_timestamp"`
+	MaxTimestamp time.Time `json:"max_timestamp"`
+
+	data []byte
+}
+
+func chunkKey(id uint64) string {
+	return chunkKeyPrefix + strconv.FormatUint(id, 10)
+}
+
+func entryKey(id uint64) string {
+	return entryKeyPrefix + strconv.FormatUint(id, 10)
+}
+
+// chunkEntry is written to storage along with the data of each chunk, so that the buffers are rebuilt
+// after a restart without rewriting a description of all the buffered chunks on every export.
+type chunkEntry struct {
+	chunkInfo
+	Partition      string    `json:"partition"`
+	OverridePrefix string    `json:"override_prefix"`
+	Arrived        time.Time `json:"arrived"`
+}
+
+// chunkRange is written to storage under indexKey. It holds the range of the IDs of the chunks that may
+// still be buffered, which are read back by recover.
+type chunkRange struct {
+	First uint64 `json:"first"`
+	Next  uint64 `json:"next"`
+}
+
+// buffer holds the chunks of a partition until they are uploaded as a single object.
+type buffer struct {
+	Partition      string
+	OverridePrefix string
+	// Opened is the arrival time of the first chunk, which is used to build the key of the object.
+	Opened time.Time
+	Chunks []*chunkInfo
+
+	size    int64
+	records int
+	// failures is the number of consecutive failed uploads, the next upload being delayed until retryAt.
+	failures int
+	retryAt  time.Time
+}
+
+func (b *buffer) add(c *chunkInfo) {
+	b.Chunks = append(b.Chunks, c)
+	b.size += int64(c.Size)
+	b.records += c.Records
+}
+
+// merge appends the chunks of the other buffer of the same partition.
+func (b *buffer) merge(other *buffer) {
+	for _, c := range other.Chunks {
+		b.add(c)
+	}
+	if other.Opened.Before(b.Opened) {
+		b.Opened = other.Opened
+	}
+}
+
+// retryDelay returns the delay before uploading the buffer again after its last failure,
+// which doubles with each consecutive failure.
+func (b *buffer) retryDelay() time.Duration {
+	delay := initialRetryInterval
+	for i := 1; i < b.failures && delay < maxRetryInterval; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryInterval)
+}
+
+func (b *buffer) minID() uint64 {
+	id := b.Chunks[0].ID
+	for _, c := range b.Chunks[1:] {
+		id = min(id, c.ID)
+	}
+	return id
+}
+
+// timeRange returns the range of the timestamps of the chunks, ignoring the chunks without timestamps.
+func (b *buffer) timeRange() (minTimestamp, maxTimestamp time.Time) {
+	for _, c := range b.Chunks {
+		if !c.MinTimestamp.IsZero() && (minTimestamp.IsZero() || c.MinTimestamp.Before(minTimestamp)) {
+			minTimestamp = c.MinTimestamp
+		}
+		if c.MaxTimestamp.After(maxTimestamp) {
+			maxTimestamp = c.MaxTimestamp
+		}
+	}
+	return minTimestamp, maxTimestamp
+}
+
+// manifest lists the objects written to a partition along with the range of their timestamps,
+// so that they can be found without listing the bucket.
+type manifest struct {
+	Partition string         `json:"partition"`
+	Files     []manifestFile `json:"files"`
+
+	overridePrefix string
+	// dirty is set when the last version of the manifest failed to be uploaded.
+	dirty bool
+}
+
+type manifestFile struct {
+	Key          string     `json:"key"`
+	Size         int        `json:"size"`
+	Records      int        `json:"records"`
+	MinTimestamp *time.Time `json:"min_timestamp,omitempty"`
+	MaxTimestamp *time.Time `json:"max_timestamp,omitempty"`
+}
+
+// Aggregator buffers the data of each partition, and uploads it as a single object once the
+// buffered data reaches the size or record limits, is older than the maximum age, or once the
+// time window of the partition is over.
+type Aggregator struct {
+	manager   *s3manager
+	encode    EncodeFunc
+	settings  AggregatorSettings
+	logger    *zap.Logger
+	telemetry *metadata.TelemetryBuilder
+	// manifestName distinguishes the manifests of the collectors writing to the same partitions.
+	manifestName string
+
+	mu      sync.Mutex
+	buffers map[string]*buffer
+	// flushing holds the buffers being uploaded, which are kept in the index until they are written.
+	flushing map[*buffer]struct{}
+	// full holds the flushing buffers that reached the size or record limits, which are uploaded
+	// in the background once signaled through fullReady.
+	full      []*buffer
+	fullReady chan struct{}
+	nextID    uint64
+	// storing holds the IDs of the chunks being written to storage, which are not buffered yet.
+	storing map[uint64]struct{}
+	// bufferedSize is the size of the chunks being stored, of the buffers and of the flushing buffers.
+	bufferedSize int64
+	// indexRange is the range of chunk IDs to write to storage.
+	indexRange chunkRange
+
+	// indexMu serializes the writes of the index, so that storage is not accessed with the lock held.
+	indexMu sync.Mutex
+	// storedRange is the last range of chunk IDs written to storage.
+	storedRange chunkRange
+
+	manifestMu sync.Mutex
+	manifests  map[string]*manifest
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewAggregator returns an Aggregator uploading the objects with the given manager,
+// which must have been created by NewS3Manager.
+func NewAggregator(m Manager, encode EncodeFunc, settings AggregatorSettings, set component.TelemetrySettings) (*Aggregator, error) {
+	sm, ok := m.(*s3manager)
+	if !ok {
+		return nil, errors.New("aggregation requires an S3 upload manager")
+	}
+	telemetry, err := metadata.NewTelemetryBuilder(set)
+	if err != nil {
+		return nil, err
+	}
+	return &Aggregator{
+		manager:      sm,
+		encode:       encode,
+		settings:     settings,
+		logger:       set.Logger,
+		telemetry:    telemetry,
+		manifestName: sm.builder.uniqueKey(),
+		buffers:      map[string]*buffer{},
+		flushing:     map[*buffer]struct{}{},
+		fullReady:    make(chan struct{}, 1),
+		storing:      map[uint64]struct{}{},
+		manifests:    map[string]*manifest{},
+		done:         make(chan struct{}),
+	}, nil
+}
+
+// Start recovers the chunks buffered in storage by a previous run, and starts uploading the expired buffers.
+func (a *Aggregator) Start(ctx context.Context) error {
+	if err := a.recover(ctx); err != nil {
+		return err
+	}
+
+	a.wg.Add(1)
+	go a.run()
+	return nil
+}
+
+// Shutdown uploads all the buffered chunks. When an upload fails, its chunks are kept in storage
+// for the next run if there is one.
+func (a *Aggregator) Shutdown(ctx context.Context) error {
+	close(a.done)
+	a.wg.Wait()
+	defer a.telemetry.Shutdown()
+
+	a.mu.Lock()
+	buffers := a.full
+	a.full = nil
+	for partition, b := range a.buffers {
+		buffers = append(buffers, b)
+		a.flushing[b] = struct{}{}
+		delete(a.buffers, partition)
+	}
+	a.mu.Unlock()
+
+	var errs error
+	now := clock.Now(ctx)
+	for _, b := range buffers {
+		errs = multierr.Append(errs, a.flush(ctx, b, now))
+	}
+
+	a.manifestMu.Lock()
+	defer a.manifestMu.Unlock()
+	for partition, m := range a.manifests {
+		if m.dirty {
+			errs = multierr.Append(errs, a.putManifest(ctx, m))
+		}
+		delete(a.manifests, partition)
+	}
+	return errs
+}
+
+// Add buffers the chunk in the partition of its arrival time, and hands the buffer of the partition over
+// to the background uploads when it reaches the size or record limits. Failed uploads are logged and
+// retried with an exponential backoff, as the chunks stay buffered. Add fails when the buffered chunks
+// reach the maximum buffered size.
+func (a *Aggregator) Add(ctx context.Context, chunk Chunk, opts *UploadOptions) error {
+	if len(chunk.Data) == 0 {
+		return nil
+	}
+
+	overridePrefix := ""
+	if opts != nil {
+		overridePrefix = opts.OverridePrefix
+	}
+	now := clock.Now(ctx)
+	partition := a.manager.builder.bucketKeyPrefix(now, overridePrefix)
+
+	a.mu.Lock()
+	if a.settings.MaxBufferedSize > 0 && a.bufferedSize+int64(len(chunk.Data)) > a.settings.MaxBufferedSize {
+		a.mu.Unlock()
+		return errBufferFull
+	}
+	c := &chunkInfo{
+		ID:           a.nextID,
+		Size:         len(chunk.Data),
+		Records:      chunk.Records,
+		MinTimestamp: chunk.MinTimestamp,
+		MaxTimestamp: chunk.MaxTimestamp,
+	}
+	a.nextID++
+	// the size is reserved before the chunk is stored, so that concurrent exports can't exceed the limit
+	a.bufferedSize += int64(c.Size)
+	if a.settings.Storage != nil {
+		a.storing[c.ID] = struct{}{}
+		a.indexRange.Next = a.nextID
+	}
+	a.mu.Unlock()
+
+	if a.settings.Storage == nil {
+		c.data = chunk.Data
+	} else if err := a.storeChunk(ctx, c, chunk.Data, partition, overridePrefix, now); err != nil {
+		a.mu.Lock()
+		delete(a.storing, c.ID)
+		a.bufferedSize -= int64(c.Size)
+		a.mu.Unlock()
+		return fmt.Errorf("failed to store chunk: %w", err)
+	}
+
+	a.mu.Lock()
+	delete(a.storing, c.ID)
+	b, ok := a.buffers[partition]
+	if !ok {
+		b = &buffer{Partition: partition, OverridePrefix: overridePrefix, Opened: now}
+		a.buffers[partition] = b
+	}
+	b.add(c)
+	// the buffers whose upload failed are retried by the checks once their backoff is over
+	full := a.isFull(b) && !now.Before(b.retryAt)
+	if full {
+		delete(a.buffers, partition)
+		a.flushing[b] = struct{}{}
+		a.full = append(a.full, b)
+	}
+	a.mu.Unlock()
+
+	if full {
+		select {
+		case a.fullReady <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// isFull reports whether the buffer reached the size or record limits. It must be called with the lock held.
+func (a *Aggregator) isFull(b *buffer) bool {
+	return b.size >= a.settings.MaxSize || (a.settings.MaxRecords > 0 && b.records >= a.settings.MaxRecords)
+}
+
+func (a *Aggregator) run() {
+	defer a.wg.Done()
+
+	ticker := time.NewTicker(min(a.settings.MaxAge, maxCheckInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-a.done:
+			return
+		case <-a.fullReady:
+			a.flushFull(context.Background())
+		case now := <-ticker.C:
+			a.flushExpired(context.Background(), now)
+		}
+	}
+}
+
+// flushFull uploads the buffers that reached the size or record limits.
+func (a *Aggregator) flushFull(ctx context.Context) {
+	a.mu.Lock()
+	full := a.full
+	a.full = nil
+	a.mu.Unlock()
+
+	now := clock.Now(ctx)
+	for _, b := range full {
+		if err := a.flush(ctx, b, now); err != nil {
+			a.logger.Warn("Failed to upload aggregated object", zap.String("partition", b.Partition), zap.Error(err))
+		}
+	}
+}
+
+// flushExpired uploads the buffers older than the maximum age, the ones of the partitions whose time
+// window is over and the ones that reached the size or record limits during the backoff of their
+// failed upload, before closing the manifests of these partitions. The buffers whose upload failed
+// are only uploaded again once their backoff is over.
+func (a *Aggregator) flushExpired(ctx context.Context, now time.Time) {
+	a.mu.Lock()
+	var expired []*buffer
+	for partition, b := range a.buffers {
+		if now.Before(b.retryAt) {
+			continue
+		}
+		if !a.isFull(b) && now.Sub(b.Opened) < a.settings.MaxAge && a.manager.builder.bucketKeyPrefix(now, b.OverridePrefix) == partition {
+			continue
+		}
+		expired = append(expired, b)
+		a.flushing[b] = struct{}{}
+		delete(a.buffers, partition)
+	}
+	a.mu.Unlock()
+
+	for _, b := range expired {
+		if err := a.flush(ctx, b, now); err != nil {
+			a.logger.Warn("Failed to upload aggregated object", zap.String("partition", b.Partition), zap.Error(err))
+		}
+	}
+
+	a.closeManifests(ctx, now)
+}
+
+// flush uploads the buffer, which must have been moved to the flushing buffers. The buffer is restored
+// when the upload fails, so that it is retried once its backoff is over.
+func (a *Aggregator) flush(ctx context.Context, b *buffer, now time.Time) error {
+	chunks, err := a.loadChunks(ctx, b)
+	if err != nil {
+		a.restore(b, now)
+		return fmt.Errorf("failed to load chunks: %w", err)
+	}
+	if len(chunks) == 0 {
+		a.release(ctx, b)
+		return nil
+	}
+
+	data, err := a.encode(chunks)
+	if err != nil {
+		// encoding the same chunks again would fail the same way
+		a.release(ctx, b)
+		a.telemetry.Awss3exporterAggregationDroppedRecords.Add(ctx, int64(b.records))
+		return fmt.Errorf("failed to encode %d chunks, dropping them: %w", len(chunks), err)
+	}
+
+	key := a.manager.builder.Build(b.Opened, b.OverridePrefix)
+	if err := a.manager.put(ctx, key, data); err != nil {
+		a.restore(b, now)
+		return err
+	}
+	a.release(ctx, b)
+
+	if a.settings.Manifest {
+		a.addToManifest(ctx, b, key, len(data))
+	}
+	return nil
+}
+
+func (a *Aggregator) loadChunks(ctx context.Context, b *buffer) ([][]byte, error) {
+	chunks := make([][]byte, 0, len(b.Chunks))
+	if a.settings.Storage == nil {
+		for _, c := range b.Chunks {
+			chunks = append(chunks, c.data)
+		}
+		return chunks, nil
+	}
+
+	ops := make([]*storage.Operation, len(b.Chunks))
+	for i, c := range b.Chunks {
+		ops[i] = storage.GetOperation(chunkKey(c.ID))
+	}
+	if err := a.settings.Storage.Batch(ctx, ops...); err != nil {
+		return nil, err
+	}
+	for _, op := range ops {
+		if op.Value == nil {
+			a.logger.Warn("Buffered chunk missing from storage", zap.String("key", op.Key))
+			continue
+		}
+		chunks = append(chunks, op.Value)
+	}
+	return chunks, nil
+}
+
+// restore moves the buffer back to the buffers, ahead of the chunks received for its partition since,
+// and delays its next upload. The chunks stay in storage, along with their entries.
+func (a *Aggregator) restore(b *buffer, now time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	b.failures++
+	b.retryAt = now.Add(b.retryDelay())
+	delete(a.flushing, b)
+	if current, ok := a.buffers[b.Partition]; ok {
+		b.merge(current)
+	}
+	a.buffers[b.Partition] = b
+}
+
+// release forgets the buffer, and removes its chunks from storage.
+func (a *Aggregator) release(ctx context.Context, b *buffer) {
+	a.mu.Lock()
+	delete(a.flushing, b)
+	a.bufferedSize -= b.size
+	if a.settings.Storage != nil {
+		a.indexRange = a.liveRange()
+	}
+	a.mu.Unlock()
+
+	if a.settings.Storage == nil {
+		return
+	}
+	a.storeIndex(ctx)
+	ops := make([]*storage.Operation, 0, 2*len(b.Chunks))
+	for _, c := range b.Chunks {
+		ops = append(ops, storage.DeleteOperation(chunkKey(c.ID)), storage.DeleteOperation(entryKey(c.ID)))
+	}
+	if err := a.settings.Storage.Batch(ctx, ops...); err != nil {
+		a.logger.Warn("Failed to delete uploaded chunks from storage", zap.Error(err))
+	}
+}
+
+// storeChunk writes the data of the chunk to storage, along with the entry describing it, once the
+// index covers its ID.
+func (a *Aggregator) storeChunk(ctx context.Context, c *chunkInfo, data []byte, partition, overridePrefix string, arrived time.Time) error {
+	entry, err := json.Marshal(chunkEntry{chunkInfo: *c, Partition: partition, OverridePrefix: overridePrefix, Arrived: arrived})
+	if err != nil {
+		return err
+	}
+	a.storeIndex(ctx)
+	return a.settings.Storage.Batch(ctx, storage.SetOperation(chunkKey(c.ID), data), storage.SetOperation(entryKey(c.ID), entry))
+}
+
+// liveRange returns the range of the IDs of the chunks being stored or buffered. It only needs to be
+// computed when chunks are released, as new chunks only move the end of the range. It must be called
+// with the lock held.
+func (a *Aggregator) liveRange() chunkRange {
+	r := chunkRange{First: a.nextID, Next: a.nextID}
+	for id := range a.storing {
+		r.First = min(r.First, id)
+	}
+	for _, b := range a.buffers {
+		r.First = min(r.First, b.minID())
+	}
+	for b := range a.flushing {
+		r.First = min(r.First, b.minID())
+	}
+	return r
+}
+
+// storeIndex writes the range of the IDs of the chunks that may still be buffered to storage, when it changed,
+// so that the index has a constant size. The writes are serialized, and each one writes the latest range, so
+// that the concurrent exports share a single write and a stale range never overwrites a newer one.
+func (a *Aggregator) storeIndex(ctx context.Context) {
+	a.indexMu.Lock()
+	defer a.indexMu.Unlock()
+
+	a.mu.Lock()
+	r := a.indexRange
+	a.mu.Unlock()
+	if r == a.storedRange {
+		return
+	}
+	data, err := json.Marshal(r)
+	if err == nil {
+		err = a.settings.Storage.Set(ctx, indexKey, data)
+	}
+	if err != nil {
+		a.logger.Warn("Failed to store the aggregation index", zap.Error(err))
+		return
+	}
+	a.storedRange = r
+}
+
+// recover reads the entries of the chunks stored by a previous run. The recovered buffers of the partitions
+// whose time window is over are uploaded by the first check.
+func (a *Aggregator) recover(ctx context.Context) error {
+	if a.settings.Storage == nil {
+		return nil
+	}
+
+	data, err := a.settings.Storage.Get(ctx, indexKey)
+	if err != nil {
+		return fmt.Errorf("failed to read the aggregation index: %w", err)
+	}
+	if data == nil {
+		return nil
+	}
+	var r chunkRange
+	if err := json.Unmarshal(data, &r); err != nil {
+		return fmt.Errorf("failed to decode the aggregation index: %w", err)
+	}
+	ops := make([]*storage.Operation, 0, r.Next-r.First)
+	for id := r.First; id < r.Next; id++ {
+		ops = append(ops, storage.GetOperation(entryKey(id)))
+	}
+	if err := a.settings.Storage.Batch(ctx, ops...); err != nil {
+		return fmt.Errorf("failed to read the buffered chunks: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.nextID = r.Next
+	a.indexRange = r
+	a.storedRange = r
+	chunks := 0
+	for _, op := range ops {
+		// the chunks that were uploaded, or that failed to be stored, have no entry
+		if op.Value == nil {
+			continue
+		}
+		var entry chunkEntry
+		if err := json.Unmarshal(op.Value, &entry); err != nil {
+			return fmt.Errorf("failed to decode the buffered chunk %q: %w", op.Key, err)
+		}
+		c := entry.chunkInfo
+		b, ok := a.buffers[entry.Partition]
+		if !ok {
+			b = &buffer{Partition: entry.Partition, OverridePrefix: entry.OverridePrefix, Opened: entry.Arrived}
+			a.buffers[entry.Partition] = b
+		}
+		b.add(&c)
+		if entry.Arrived.Before(b.Opened) {
+			b.Opened = entry.Arrived
+		}
+		a.bufferedSize += int64(c.Size)
+		chunks++
+	}
+	if chunks > 0 {
+		a.logger.Info("Recovered buffered chunks from storage", zap.Int("partitions", len(a.buffers)), zap.Int("chunks", chunks))
+	}
+	return nil
+}
+
+// addToManifest adds the object uploaded for the buffer to the manifest of its partition.
+func (a *Aggregator) addToManifest(ctx context.Context, b *buffer, key string, size int) {
+	a.manifestMu.Lock()
+	defer a.manifestMu.Unlock()
+
+	m, ok := a.manifests[b.Partition]
+	if !ok {
+		m = &manifest{Partition: b.Partition, overridePrefix: b.OverridePrefix}
+		a.manifests[b.Partition] = m
+	}
+	file := manifestFile{Key: key, Size: size, Records: b.records}
+	if minTimestamp, maxTimestamp := b.timeRange(); !minTimestamp.IsZero() {
+		file.MinTimestamp = &minTimestamp
+		file.MaxTimestamp = &maxTimestamp
+	}
+	m.Files = append(m.Files, file)
+
+	if err := a.putManifest(ctx, m); err != nil {
+		a.logger.Warn("Failed to upload manifest", zap.String("partition", b.Partition), zap.Error(err))
+	}
+}
+
+// putManifest uploads the manifest. It must be called with the manifest lock held.
+func (a *Aggregator) putManifest(ctx context.Context, m *manifest) error {
+	data, err := json.Marshal(m)
+	if err == nil {
+		err = a.manager.putManifest(ctx, a.manager.builder.manifestKey(m.Partition, a.manifestName), data)
+	}
+	m.dirty = err != nil
+	return err
+}
+
+// closeManifests forgets the manifests of the partitions whose time window is over and that have
+// no buffered chunks left, after retrying the failed uploads of their last version.
+func (a *Aggregator) closeManifests(ctx context.Context, now time.Time) {
+	a.manifestMu.Lock()
+	defer a.manifestMu.Unlock()
+
+	for partition, m := range a.manifests {
+		if a.manager.builder.bucketKeyPrefix(now, m.overridePrefix) == partition || a.isBuffered(partition) {
+			continue
+		}
+		if m.dirty {
+			if err := a.putManifest(ctx, m); err != nil {
+				a.logger.Warn("Failed to upload manifest", zap.String("partition", partition), zap.Error(err))
+				continue
+			}
+		}
+		delete(a.manifests, partition)
+	}
+}
+
+func (a *Aggregator) isBuffered(partition string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.buffers[partition]; ok {
+		return true
+	}
+	for b := range a.flushing {
+		if b.Partition == partition {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,410 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package upload
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tilinna/clock"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter/internal/metadatatest"
+)
+
+var testStart = time.Date(2024, 1, 10, 10, 30, 10, 0, time.UTC)
+
+// objectServer records the objects uploaded to it.
+type objectServer struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	fail    bool
+}
+
+func (s *objectServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	_ = r.Body.Close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
+	s.objects[r.URL.Path] = body
+}
+
+func (s *objectServer) setFail(fail bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fail = fail
+}
+
+func (s *objectServer) uploaded() map[string][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	objects := make(map[string][]byte, len(s.objects))
+	for k, v := range s.objects {
+		objects[k] = v
+	}
+	return objects
+}
+
+type mapClient struct {
+	mu   sync.Mutex
+	data map[string][]byte
+}
+
+func (c *mapClient) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	err := c.Batch(ctx, op)
+	return op.Value, err
+}
+
+func (c *mapClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+func (c *mapClient) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+func (c *mapClient) Batch(_ context.Context, ops ...*storage.Operation) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = c.data[op.Key]
+		case storage.Set:
+			c.data[op.Key] = op.Value
+		case storage.Delete:
+			delete(c.data, op.Key)
+		}
+	}
+	return nil
+}
+
+func (c *mapClient) Close(context.Context) error {
+	return nil
+}
+
+func newTestAggregator(t *testing.T, settings AggregatorSettings) (*Aggregator, *objectServer) {
+	server := &objectServer{objects: map[string][]byte{}}
+	s := httptest.NewServer(server)
+	t.Cleanup(s.Close)
+
+	objects := 0
+	sm := NewS3Manager(
+		"my-bucket",
+		&PartitionKeyBuilder{
+			PartitionPrefix: "telemetry",
+			PartitionFormat: "year=%Y/month=%m/day=%d/hour=%H/minute=%M",
+			FilePrefix:      "signal-data-",
+			Metadata:        "logs",
+			FileFormat:      "json",
+			UniqueKeyFunc: func() string {
+				objects++
+				return "key" + strconv.Itoa(objects)
+			},
+		},
+		s3.New(s3.Options{
+			BaseEndpoint: aws.String(s.URL),
+			Region:       "local",
+		}),
+		"STANDARD",
+	)
+
+	// the chunks are separated by commas in the uploaded objects
+	encode := func(chunks [][]byte) ([]byte, error) {
+		return bytes.Join(chunks, []byte(",")), nil
+	}
+	a, err := NewAggregator(sm, encode, settings, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	return a, server
+}
+
+func addAt(t *testing.T, a *Aggregator, ts time.Time, data string, records int) {
+	ctx := clock.Context(context.Background(), clock.NewMock(ts))
+	chunk := Chunk{
+		Data:         []byte(data),
+		Records:      records,
+		MinTimestamp: ts.Add(-time.Minute),
+		MaxTimestamp: ts,
+	}
+	require.NoError(t, a.Add(ctx, chunk, nil))
+}
+
+func readManifest(t *testing.T, data []byte) manifest {
+	var m manifest
+	require.NoError(t, json.Unmarshal(data, &m))
+	return m
+}
+
+func TestNewAggregatorRequiresS3Manager(t *testing.T) {
+	_, err := NewAggregator(nil, nil, AggregatorSettings{}, componenttest.NewNopTelemetrySettings())
+	assert.EqualError(t, err, "aggregation requires an S3 upload manager")
+}
+
+func TestAggregatorMaxSize(t *testing.T) {
+	a, server := newTestAggregator(t, AggregatorSettings{MaxSize: 10, MaxAge: time.Hour, Manifest: true})
+
+	addAt(t, a, testStart, "first", 1)
+	addAt(t, a, testStart.Add(time.Second), "second", 2)
+	addAt(t, a, testStart.Add(2*time.Second), "third", 1)
+	// the full buffers are uploaded in the background
+	assert.Empty(t, server.uploaded())
+	a.flushFull(context.Background())
+
+	objects := server.uploaded()
+	require.Len(t, objects, 2)
+	assert.Equal(t, "first,second", string(objects["/my-bucket/telemetry/year=2024/month=01/day=10/hour=10/minute=30/signal-data-logs_key2.json"]))
+
+	m := readManifest(t, objects["/my-bucket/telemetry/year=2024/month=01/day=10/hour=10/minute=30/signal-data-logs_key1.manifest.json"])
+	minTimestamp, maxTimestamp := testStart.Add(-time.Minute), testStart.Add(time.Second)
+	assert.Equal(t, manifest{
+		Partition: "telemetry/year=2024/month=01/day=10/hour=10/minute=30",
+		Files: []manifestFile{{
+			Key:          "telemetry/year=2024/month=01/day=10/hour=10/minute=30/signal-data-logs_key2.json",
+			Size:         len("first,second"),
+			Records:      3,
+			MinTimestamp: &minTimestamp,
+			MaxTimestamp: &maxTimestamp,
+		}},
+	}, m)
+
+	require.NoError(t, a.Shutdown(context.Background()))
+	objects = server.uploaded()
+	require.Len(t, objects, 3)
+	assert.Equal(t, "third", string(objects["/my-bucket/telemetry/year=2024/month=01/day=10/hour=10/minute=30/signal-data-logs_key3.json"]))
+	m = readManifest(t, objects["/my-bucket/telemetry/year=2024/month=01/day=10/hour=10/minute=30/signal-data-logs_key1.manifest.json"])
+	require.Len(t, m.Files, 2)
+	assert.Equal(t, "telemetry/year=2024/month=01/day=10/hour=10/minute=30/signal-data-logs_key3.json", m.Files[1].Key)
+}
+
+func TestAggregatorMaxRecords(t *testing.T) {
+	a, server := newTestAggregator(t, AggregatorSettings{MaxSize: 1024, MaxRecords: 3, MaxAge: time.Hour})
+
+	addAt(t, a, testStart, "a", 2)
+	assert.Empty(t, server.uploaded())
+	addAt(t, a, testStart, "b", 1)
+	a.flushFull(context.Background())
+
+	assert.Equal(t, map[string][]byte{
+		"/my-bucket/telemetry/year=2024/month=01/day=10/hour=10/minute=30/signal-data-logs_key2.json": []byte("a,b"),
+	}, server.uploaded())
+	require.NoError(t, a.Shutdown(context.Background()))
+}
+
+func TestAggregatorFlushExpired(t *testing.T) {
+	a, server := newTestAggregator(t, AggregatorSettings{MaxSize: 1024, MaxAge: 30 * time.Second, Manifest: true})
+	ctx := context.Background()
+
+	addAt(t, a, testStart, "a", 1)
+	addAt(t, a, testStart.Add(10*time.Second), "b", 1)
+	a.flushExpired(ctx, testStart.Add(20*time.Second))
+	assert.Empty(t, server.uploaded())
+
+	// the buffer is older than the maximum age
+	a.flushExpired(ctx, testStart.Add(30*time.Second))
+	objects := server.uploaded()
+	assert.Equal(t, "a,b", string(objects["/my-bucket/telemetry/year=2024/month=01/day=10/hour=10/minute=30/signal-data-logs_key2.json"]))
+	assert.Len(t, a.manifests, 1)
+
+	// the time window of the partition is over
+	addAt(t, a, testStart.Add(40*time.Second), "c", 1)
+	a.flushExpired(ctx, testStart.Add(50*time.Second))
+	objects = server.uploaded()
+	assert.Equal(t, "c", string(objects["/my-bucket/telemetry/year=2024/month=01/day=10/hour=10/minute=30/signal-data-logs_key3.json"]))
+	assert.Len(t, a.manifests, 0)
+	m := readManifest(t, objects["/my-bucket/telemetry/year=2024/month=01/day=10/hour=10/minute=30/signal-data-logs_key1.manifest.json"])
+	assert.Len(t, m.Files, 2)
+
+	require.NoError(t, a.Shutdown(ctx))
+	assert.Len(t, server.uploaded(), 3)
+}
+
+func TestAggregatorOverridePrefix(t *testing.T) {
+	a, server := newTestAggregator(t, AggregatorSettings{MaxSize: 1024, MaxAge: time.Hour})
+	ctx := clock.Context(context.Background(), clock.NewMock(testStart))
+
+	require.NoError(t, a.Add(ctx, Chunk{Data: []byte("a")}, &UploadOptions{OverridePrefix: "host-a"}))
+	require.NoError(t, a.Add(ctx, Chunk{Data: []byte("b")}, &UploadOptions{OverridePrefix: "host-b"}))
+	require.NoError(t, a.Add(ctx, Chunk{Data: []byte("c")}, &UploadOptions{OverridePrefix: "host-a"}))
+	require.NoError(t, a.Shutdown(context.Background()))
+
+	objects := server.uploaded()
+	require.Len(t, objects, 2)
+	for path, data := range objects {
+		switch {
+		case strings.HasPrefix(path, "/my-bucket/host-a/year=2024/month=01/day=10/hour=10/minute=30/signal-data-logs_key"):
+			assert.Equal(t, "a,c", string(data))
+		case strings.HasPrefix(path, "/my-bucket/host-b/year=2024/month=01/day=10/hour=10/minute=30/signal-data-logs_key"):
+			assert.Equal(t, "b", string(data))
+		default:
+			assert.Fail(t, "unexpected object", path)
+		}
+	}
+}
+
+func TestAggregatorRetriesFailedUploads(t *testing.T) {
+	a, server := newTestAggregator(t, AggregatorSettings{MaxSize: 1024, MaxAge: 30 * time.Second})
+	ctx := context.Background()
+
+	server.setFail(true)
+	addAt(t, a, testStart, "a", 1)
+	a.flushExpired(ctx, testStart.Add(30*time.Second))
+	assert.Empty(t, server.uploaded())
+
+	addAt(t, a, testStart.Add(35*time.Second), "b", 1)
+	server.setFail(false)
+	a.flushExpired(ctx, testStart.Add(40*time.Second))
+	assert.Equal(t, map[string][]byte{
+		"/my-bucket/telemetry/year=2024/month=01/day=10/hour=10/minute=30/signal-data-logs_key3.json": []byte("a,b"),
+	}, server.uploaded())
+
+	require.NoError(t, a.Shutdown(ctx))
+}
+
+func TestAggregatorStorage(t *testing.T) {
+	client := &mapClient{data: map[string][]byte{}}
+	settings := AggregatorSettings{MaxSize: 1024, MaxAge: time.Hour, Storage: client}
+	ctx := context.Background()
+
+	a, server := newTestAggregator(t, settings)
+	require.NoError(t, a.Start(ctx))
+	addAt(t, a, testStart, "a", 1)
+	addAt(t, a, testStart, "b", 1)
+	assert.Nil(t, a.buffers["telemetry/year=2024/month=01/day=10/hour=10/minute=30"].Chunks[0].data)
+	// each chunk is stored along with its entry, and the index only holds the range of the chunk IDs
+	assert.Len(t, client.data, 5)
+	assert.JSONEq(t, `{"first":0,"next":2}`, string(client.data[indexKey]))
+
+	// the uploads fail on shutdown, so that the chunks are kept in storage
+	server.setFail(true)
+	require.Error(t, a.Shutdown(ctx))
+
+	recovered, server := newTestAggregator(t, settings)
+	require.NoError(t, recovered.Start(ctx))
+	addAt(t, recovered, testStart.Add(time.Second), "c", 1)
+	require.NoError(t, recovered.Shutdown(ctx))
+
+	assert.Equal(t, map[string][]byte{
+		"/my-bucket/telemetry/year=2024/month=01/day=10/hour=10/minute=30/signal-data-logs_key2.json": []byte("a,b,c"),
+	}, server.uploaded())
+	assert.Equal(t, map[string][]byte{indexKey: []byte(`{"first":3,"next":3}`)}, client.data)
+}
+
+func TestAggregatorMaxBufferedSize(t *testing.T) {
+	a, server := newTestAggregator(t, AggregatorSettings{MaxSize: 4, MaxAge: 30 * time.Second, MaxBufferedSize: 8})
+	ctx := clock.Context(context.Background(), clock.NewMock(testStart))
+
+	// the uploads fail, so that the chunks stay buffered
+	server.setFail(true)
+	require.NoError(t, a.Add(ctx, Chunk{Data: []byte("aaaa")}, nil))
+	a.flushFull(ctx)
+	require.NoError(t, a.Add(ctx, Chunk{Data: []byte("bbb")}, nil))
+	assert.ErrorIs(t, a.Add(ctx, Chunk{Data: []byte("cc")}, nil), errBufferFull)
+	require.NoError(t, a.Add(ctx, Chunk{Data: []byte("d")}, nil))
+	assert.ErrorIs(t, a.Add(ctx, Chunk{Data: []byte("e")}, nil), errBufferFull)
+
+	// the buffered size is released once the chunks are uploaded
+	server.setFail(false)
+	a.flushExpired(context.Background(), testStart.Add(30*time.Second))
+	assert.Len(t, server.uploaded(), 1)
+	require.NoError(t, a.Add(ctx, Chunk{Data: []byte("cc")}, nil))
+	require.NoError(t, a.Shutdown(context.Background()))
+}
+
+func TestAggregatorFlushesFullBuffersInBackground(t *testing.T) {
+	a, server := newTestAggregator(t, AggregatorSettings{MaxSize: 1024, MaxRecords: 1, MaxAge: time.Hour})
+	require.NoError(t, a.Start(context.Background()))
+
+	addAt(t, a, testStart, "a", 1)
+	assert.Eventually(t, func() bool {
+		return len(server.uploaded()) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, a.Shutdown(context.Background()))
+}
+
+func TestAggregatorRetryBackoff(t *testing.T) {
+	a, server := newTestAggregator(t, AggregatorSettings{MaxSize: 1024, MaxAge: 30 * time.Second})
+	ctx := context.Background()
+
+	server.setFail(true)
+	addAt(t, a, testStart, "a", 1)
+	a.flushExpired(ctx, testStart.Add(30*time.Second))
+	a.flushExpired(ctx, testStart.Add(31*time.Second))
+	partition := "telemetry/year=2024/month=01/day=10/hour=10/minute=30"
+	assert.Equal(t, 2, a.buffers[partition].failures)
+	assert.Equal(t, testStart.Add(33*time.Second), a.buffers[partition].retryAt)
+
+	// the upload is not retried before the end of the backoff
+	server.setFail(false)
+	a.flushExpired(ctx, testStart.Add(32*time.Second))
+	assert.Empty(t, server.uploaded())
+	a.flushExpired(ctx, testStart.Add(33*time.Second))
+	assert.Len(t, server.uploaded(), 1)
+
+	require.NoError(t, a.Shutdown(ctx))
+}
+
+func TestBufferRetryDelay(t *testing.T) {
+	b := &buffer{}
+	for failures, want := range map[int]time.Duration{
+		1:  time.Second,
+		2:  2 * time.Second,
+		5:  16 * time.Second,
+		9:  256 * time.Second,
+		10: maxRetryInterval,
+		64: maxRetryInterval,
+	} {
+		b.failures = failures
+		assert.Equal(t, want, b.retryDelay(), "failures: %d", failures)
+	}
+}
+
+func TestAggregatorEncodingFailure(t *testing.T) {
+	a, server := newTestAggregator(t, AggregatorSettings{MaxSize: 1024, MaxAge: 30 * time.Second})
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tt.Shutdown(context.Background())) })
+	telemetry, err := metadata.NewTelemetryBuilder(tt.NewTelemetrySettings())
+	require.NoError(t, err)
+	a.telemetry = telemetry
+	a.encode = func([][]byte) ([]byte, error) {
+		return nil, errors.New("encoding failed")
+	}
+	ctx := context.Background()
+
+	addAt(t, a, testStart, "a", 2)
+	addAt(t, a, testStart, "b", 1)
+	a.flushExpired(ctx, testStart.Add(30*time.Second))
+
+	// the chunks are dropped, as encoding them again would fail the same way
+	assert.Empty(t, server.uploaded())
+	assert.Empty(t, a.buffers)
+	assert.Zero(t, a.bufferedSize)
+	metadatatest.AssertEqualAwss3exporterAggregationDroppedRecords(t, tt,
+		[]metricdata.DataPoint[int64]{{Value: 3}},
+		metricdatatest.IgnoreTimestamp())
+	require.NoError(t, a.Shutdown(ctx))
+}
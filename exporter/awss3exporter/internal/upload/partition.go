@@ -89,3 +89,9 @@ func (pki *PartitionKeyBuilder) uniqueKey() string {
 
 	return strconv.Itoa(minOffset + rand.IntN(uniqueValues-minOffset))
 }
+
+// manifestKey returns the key of the manifest listing the objects written to the partition,
+// which is built from the key prefix returned by bucketKeyPrefix.
+func (pki *PartitionKeyBuilder) manifestKey(partition, name string) string {
+	return partition + "/" + pki.FilePrefix + pki.Metadata + "_" + name + ".manifest.json"
+}
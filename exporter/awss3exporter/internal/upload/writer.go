@@ -57,6 +57,16 @@ func (sw *s3manager) Upload(ctx context.Context, data []byte, opts *UploadOption
 		return nil
 	}
 
+	overridePrefix := ""
+	if opts != nil {
+		overridePrefix = opts.OverridePrefix
+	}
+
+	return sw.put(ctx, sw.builder.Build(clock.Now(ctx), overridePrefix), data)
+}
+
+// put compresses the data as configured and uploads it as the object with the given key.
+func (sw *s3manager) put(ctx context.Context, key string, data []byte) error {
 	content, err := sw.contentBuffer(data)
 	if err != nil {
 		return err
@@ -67,16 +77,9 @@ func (sw *s3manager) Upload(ctx context.Context, data []byte, opts *UploadOption
 		encoding = string(sw.builder.Compression)
 	}
 
-	now := clock.Now(ctx)
-
-	overridePrefix := ""
-	if opts != nil {
-		overridePrefix = opts.OverridePrefix
-	}
-
 	_, err = sw.uploader.Upload(ctx, &s3.PutObjectInput{
 		Bucket:          aws.String(sw.bucket),
-		Key:             aws.String(sw.builder.Build(now, overridePrefix)),
+		Key:             aws.String(key),
 		Body:            content,
 		ContentEncoding: aws.String(encoding),
 		StorageClass:    sw.storageClass,
@@ -86,6 +89,20 @@ func (sw *s3manager) Upload(ctx context.Context, data []byte, opts *UploadOption
 	return err
 }
 
+// putManifest uploads the uncompressed manifest with the given key, replacing its previous version.
+func (sw *s3manager) putManifest(ctx context.Context, key string, data []byte) error {
+	_, err := sw.uploader.Upload(ctx, &s3.PutObjectInput{
+		Bucket:       aws.String(sw.bucket),
+		Key:          aws.String(key),
+		Body:         bytes.NewReader(data),
+		ContentType:  aws.String("application/json"),
+		StorageClass: sw.storageClass,
+		ACL:          sw.acl,
+	})
+
+	return err
+}
+
 func (sw *s3manager) contentBuffer(raw []byte) (*bytes.Buffer, error) {
 	switch sw.builder.Compression {
 	case configcompression.TypeGzip:
@@ -4,12 +4,18 @@
 package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/servicediscovery/types"
 	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otelarrowexporter"
 )
 
 type routingKey int
@@ -23,6 +29,14 @@ const (
 	attrRouting
 )
 
+const (
+	otlpProtocolKey      = "protocol::otlp"
+	otlpHTTPProtocolKey  = "protocol::otlphttp"
+	otelArrowProtocolKey = "protocol::otelarrow"
+)
+
+var errMultipleProtocolsProvided = errors.New("only one protocol should be specified")
+
 const (
 	svcRoutingStr        = "service"
 	traceIDRoutingStr    = "traceID"
@@ -49,11 +63,111 @@ type Config struct {
 	// Supports all attributes available (both resource and span), as well as the pseudo attributes "span.kind" and
 	// "span.name".
 	RoutingAttributes []string `mapstructure:"routing_attributes"`
+
+	// HashRing controls how routing keys are distributed among the backends of the ring
+	HashRing HashRingSettings `mapstructure:"hash_ring"`
+}
+
+// HashRingSettings defines the configuration for the consistent hash ring
+type HashRingSettings struct {
+	// LoadFactor enables consistent hashing with bounded loads: no backend gets more than LoadFactor times the
+	// average load, and keys that would go over it are routed to the next backend in the ring. Zero disables it.
+	//
+	// The load is measured by each instance of the exporter on its own, so instances sharing the same backends may
+	// route the same key to different backends. It is therefore rejected for the routing keys that need to reach
+	// the same backend from every instance, such as the trace ID.
+	LoadFactor float64 `mapstructure:"load_factor"`
+
+	// TransitionPeriod is the time keys keep being routed to their previous backend after a change in the list of
+	// backends, as long as that backend is still part of the ring. When LoadFactor is set, keys are moved earlier if
+	// they go quiet. Zero disables it.
+	TransitionPeriod time.Duration `mapstructure:"transition_period"`
+
+	// KeyIdleTimeout is how long a routing key has to go without data to be considered quiet when LoadFactor is set.
+	// It is also the size of the window used to measure the load of the backends.
+	KeyIdleTimeout time.Duration `mapstructure:"key_idle_timeout"`
+
+	// MaxKeys is the maximum number of routing keys tracked when LoadFactor is set. New keys are still routed with
+	// bounded loads once it is reached, but aren't guaranteed to stick to their backend.
+	MaxKeys int `mapstructure:"max_keys"`
+}
+
+// Validate checks if the hash ring configuration is valid
+func (cfg *HashRingSettings) Validate() error {
+	if cfg.LoadFactor != 0 && cfg.LoadFactor <= 1 {
+		return errors.New("hash_ring: load_factor must be greater than 1")
+	}
+	if cfg.TransitionPeriod < 0 {
+		return errors.New("hash_ring: transition_period must not be negative")
+	}
+	if cfg.boundedLoads() && cfg.KeyIdleTimeout <= 0 {
+		return errors.New("hash_ring: key_idle_timeout must be positive when load_factor is set")
+	}
+	if cfg.boundedLoads() && cfg.MaxKeys <= 0 {
+		return errors.New("hash_ring: max_keys must be positive when load_factor is set")
+	}
+	return nil
+}
+
+// boundedLoads returns whether the routing keys have to be tracked to bound the load of the backends
+func (cfg *HashRingSettings) boundedLoads() bool {
+	return cfg.LoadFactor > 0
+}
+
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.HashRing.boundedLoads() && requiresAffinity(cfg.RoutingKey) {
+		return fmt.Errorf("hash_ring: load_factor can't be used with %s, as each instance of the exporter bounds the loads on its own and may route the same key to a different backend; set routing_key to %q or %q instead", routingKeyName(cfg.RoutingKey), svcRoutingStr, attrRoutingStr)
+	}
+	return nil
+}
+
+// requiresAffinity returns whether the data sharing a routing key has to reach the same backend, regardless of the
+// instance of the exporter it went through. The default routing key is the trace ID.
+func requiresAffinity(key string) bool {
+	switch key {
+	case "", traceIDRoutingStr, streamIDRoutingStr:
+		return true
+	}
+	return false
+}
+
+func routingKeyName(key string) string {
+	if key == "" {
+		return "the default routing key"
+	}
+	return fmt.Sprintf("the %q routing key", key)
+}
+
+// Unmarshal a confmap.Conf into the config struct.
+func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
+	count := 0
+	for _, key := range []string{otlpProtocolKey, otlpHTTPProtocolKey, otelArrowProtocolKey} {
+		if componentParser.IsSet(key) {
+			count++
+		}
+	}
+	if count > 1 {
+		return errMultipleProtocolsProvided
+	}
+
+	// the protocols other than OTLP are only present when configured, but they should start from their own defaults
+	if componentParser.IsSet(otlpHTTPProtocolKey) {
+		cfg.Protocol.OTLPHTTP = otlphttpexporter.NewFactory().CreateDefaultConfig().(*otlphttpexporter.Config)
+	}
+	if componentParser.IsSet(otelArrowProtocolKey) {
+		cfg.Protocol.OTelArrow = otelarrowexporter.NewFactory().CreateDefaultConfig().(*otelarrowexporter.Config)
+	}
+
+	return componentParser.Unmarshal(cfg)
 }
 
-// Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
+// Protocol holds the individual protocol-specific settings. Only one of them should be specified,
+// OTLP over gRPC is used when neither OTLPHTTP nor OTelArrow are set.
 type Protocol struct {
-	OTLP otlpexporter.Config `mapstructure:"otlp"`
+	OTLP      otlpexporter.Config       `mapstructure:"otlp"`
+	OTLPHTTP  *otlphttpexporter.Config  `mapstructure:"otlphttp"`
+	OTelArrow *otelarrowexporter.Config `mapstructure:"otelarrow"`
 }
 
 // ResolverSettings defines the configurations for the backend resolver
@@ -6,9 +6,12 @@ package loadbalancingexporter
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
@@ -25,3 +28,170 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, sub.Unmarshal(cfg))
 	require.NotNil(t, cfg)
 }
+
+func TestLoadHashRingConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "6").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+
+	assert.Equal(t, HashRingSettings{
+		LoadFactor:       1.25,
+		TransitionPeriod: 5 * time.Minute,
+		KeyIdleTimeout:   time.Minute,
+		MaxKeys:          defaultMaxKeys,
+	}, cfg.(*Config).HashRing)
+	assert.NoError(t, cfg.(*Config).Validate())
+}
+
+func TestLoadProtocolConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	t.Run("otlp by default", func(t *testing.T) {
+		cfg := NewFactory().CreateDefaultConfig()
+		sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "").String())
+		require.NoError(t, err)
+		require.NoError(t, sub.Unmarshal(cfg))
+
+		protocol := cfg.(*Config).Protocol
+		assert.Nil(t, protocol.OTLPHTTP)
+		assert.Nil(t, protocol.OTelArrow)
+		assert.Equal(t, time.Second, protocol.OTLP.TimeoutConfig.Timeout)
+	})
+
+	t.Run("otlphttp", func(t *testing.T) {
+		cfg := NewFactory().CreateDefaultConfig()
+		sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "7").String())
+		require.NoError(t, err)
+		require.NoError(t, sub.Unmarshal(cfg))
+
+		protocol := cfg.(*Config).Protocol
+		require.NotNil(t, protocol.OTLPHTTP)
+		assert.Nil(t, protocol.OTelArrow)
+		assert.Equal(t, "http://placeholder", protocol.OTLPHTTP.ClientConfig.Endpoint)
+		assert.True(t, protocol.OTLPHTTP.ClientConfig.TLSSetting.Insecure)
+		assert.Equal(t, configcompression.TypeZstd, protocol.OTLPHTTP.ClientConfig.Compression)
+		// the defaults of the otlphttp exporter are kept for everything else
+		assert.True(t, protocol.OTLPHTTP.RetryConfig.Enabled)
+	})
+
+	t.Run("otelarrow", func(t *testing.T) {
+		cfg := NewFactory().CreateDefaultConfig()
+		sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "8").String())
+		require.NoError(t, err)
+		require.NoError(t, sub.Unmarshal(cfg))
+
+		protocol := cfg.(*Config).Protocol
+		assert.Nil(t, protocol.OTLPHTTP)
+		require.NotNil(t, protocol.OTelArrow)
+		assert.Equal(t, 2, protocol.OTelArrow.Arrow.NumStreams)
+		assert.Equal(t, configcompression.TypeZstd, protocol.OTelArrow.ClientConfig.Compression)
+	})
+
+	t.Run("multiple protocols", func(t *testing.T) {
+		cfg := NewFactory().CreateDefaultConfig()
+		sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "multiple_protocols").String())
+		require.NoError(t, err)
+		assert.ErrorContains(t, sub.Unmarshal(cfg), errMultipleProtocolsProvided.Error())
+	})
+}
+
+func TestHashRingSettingsValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		cfg  HashRingSettings
+		err  string
+	}{
+		{
+			name: "defaults",
+			cfg:  HashRingSettings{KeyIdleTimeout: defaultKeyIdleTimeout, MaxKeys: defaultMaxKeys},
+		},
+		{
+			name: "bounded loads and transition",
+			cfg:  HashRingSettings{LoadFactor: 1.25, TransitionPeriod: time.Minute, KeyIdleTimeout: time.Second, MaxKeys: 10},
+		},
+		{
+			name: "transition without bounded loads",
+			cfg:  HashRingSettings{TransitionPeriod: time.Minute},
+		},
+		{
+			name: "load factor too low",
+			cfg:  HashRingSettings{LoadFactor: 1, KeyIdleTimeout: time.Second, MaxKeys: 10},
+			err:  "hash_ring: load_factor must be greater than 1",
+		},
+		{
+			name: "negative transition period",
+			cfg:  HashRingSettings{TransitionPeriod: -time.Minute, KeyIdleTimeout: time.Second},
+			err:  "hash_ring: transition_period must not be negative",
+		},
+		{
+			name: "missing key idle timeout",
+			cfg:  HashRingSettings{LoadFactor: 1.25, MaxKeys: 10},
+			err:  "hash_ring: key_idle_timeout must be positive when load_factor is set",
+		},
+		{
+			name: "missing max keys",
+			cfg:  HashRingSettings{LoadFactor: 1.25, KeyIdleTimeout: time.Second},
+			err:  "hash_ring: max_keys must be positive when load_factor is set",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestValidateBoundedLoadsRoutingKey(t *testing.T) {
+	for _, tt := range []struct {
+		routingKey string
+		err        string
+	}{
+		{
+			routingKey: "",
+			err:        "hash_ring: load_factor can't be used with the default routing key",
+		},
+		{
+			routingKey: traceIDRoutingStr,
+			err:        `hash_ring: load_factor can't be used with the "traceID" routing key`,
+		},
+		{
+			routingKey: streamIDRoutingStr,
+			err:        `hash_ring: load_factor can't be used with the "streamID" routing key`,
+		},
+		{
+			routingKey: svcRoutingStr,
+		},
+		{
+			routingKey: attrRoutingStr,
+		},
+	} {
+		t.Run(tt.routingKey, func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig().(*Config)
+			cfg.RoutingKey = tt.routingKey
+			cfg.HashRing.LoadFactor = 1.25
+
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+
+	t.Run("transition without bounded loads", func(t *testing.T) {
+		cfg := NewFactory().CreateDefaultConfig().(*Config)
+		cfg.HashRing.TransitionPeriod = time.Minute
+		assert.NoError(t, cfg.Validate())
+	})
+}
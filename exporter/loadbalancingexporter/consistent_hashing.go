@@ -5,6 +5,7 @@ package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"hash/crc32"
+	"slices"
 	"sort"
 )
 
@@ -27,13 +28,23 @@ type ringItem struct {
 type hashRing struct {
 	// ringItems holds all the positions, used for the lookup the position for the closest next ring item
 	items []ringItem
+
+	// endpoints holds the distinct endpoints that are part of the ring
+	endpoints []string
 }
 
 // newHashRing builds a new immutable consistent hash ring based on the given endpoints.
 func newHashRing(endpoints []string) *hashRing {
 	items := positionsForEndpoints(endpoints, defaultWeight)
+	distinct := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if !slices.Contains(distinct, endpoint) {
+			distinct = append(distinct, endpoint)
+		}
+	}
 	return &hashRing{
-		items: items,
+		items:     items,
+		endpoints: distinct,
 	}
 }
 
@@ -43,12 +54,44 @@ func (h *hashRing) endpointFor(identifier []byte) string {
 		// perhaps the ring itself couldn't get initialized yet?
 		return ""
 	}
+	return h.findEndpoint(positionForIdentifier(identifier))
+}
+
+// endpointsFor returns the distinct endpoints of the ring in the order they are found when walking the ring clockwise,
+// starting from the position of the given identifier. The first endpoint is the same as the one returned by endpointFor.
+func (h *hashRing) endpointsFor(identifier []byte) []string {
+	if h == nil || len(h.items) == 0 {
+		return nil
+	}
+	pos := positionForIdentifier(identifier)
+	start := sort.Search(len(h.items), func(i int) bool {
+		return h.items[i].pos >= pos
+	})
+
+	res := make([]string, 0, len(h.endpoints))
+	for i := 0; i < len(h.items) && len(res) < len(h.endpoints); i++ {
+		item := h.items[(start+i)%len(h.items)]
+		if !slices.Contains(res, item.endpoint) {
+			res = append(res, item.endpoint)
+		}
+	}
+	return res
+}
+
+// contains returns whether the given endpoint is part of the ring
+func (h *hashRing) contains(endpoint string) bool {
+	if h == nil {
+		return false
+	}
+	return slices.Contains(h.endpoints, endpoint)
+}
+
+// positionForIdentifier calculates the position in the ring for the given identifier
+func positionForIdentifier(identifier []byte) position {
 	hasher := crc32.NewIEEE()
 	hasher.Write(identifier)
 	hash := hasher.Sum32()
-	pos := hash % maxPositions
-
-	return h.findEndpoint(position(pos))
+	return position(hash % maxPositions)
 }
 
 // findEndpoint returns the "next" endpoint starting from the given position, or an empty string in case no endpoints are available
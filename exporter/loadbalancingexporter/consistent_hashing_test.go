@@ -46,6 +46,37 @@ func TestEndpointFor(t *testing.T) {
 	}
 }
 
+func TestEndpointsFor(t *testing.T) {
+	// prepare
+	endpoints := []string{"endpoint-1", "endpoint-2", "endpoint-3"}
+	ring := newHashRing(endpoints)
+
+	for _, id := range [][]byte{
+		{1, 2, 0, 0},
+		{128, 128, 0, 0},
+		[]byte("ad-service-7"),
+		[]byte("get-recommendations-1"),
+	} {
+		t.Run(fmt.Sprintf("Endpoints for id %s", string(id)), func(t *testing.T) {
+			// test
+			candidates := ring.endpointsFor(id)
+
+			// verify
+			assert.ElementsMatch(t, endpoints, candidates)
+			assert.Equal(t, ring.endpointFor(id), candidates[0])
+		})
+	}
+}
+
+func TestEndpointsForEmptyRing(t *testing.T) {
+	// prepare
+	var ring *hashRing
+
+	// test and verify
+	assert.Empty(t, ring.endpointsFor([]byte{1, 2, 0, 0}))
+	assert.Empty(t, newHashRing(nil).endpointsFor([]byte{1, 2, 0, 0}))
+}
+
 func TestPositionsFor(t *testing.T) {
 	// prepare
 	endpoint := "host1"
@@ -155,7 +186,7 @@ func TestPositionsForEndpoints(t *testing.T) {
 
 func TestEqual(t *testing.T) {
 	original := &hashRing{
-		[]ringItem{
+		items: []ringItem{
 			{pos: position(123), endpoint: "endpoint-1"},
 		},
 	}
@@ -167,7 +198,7 @@ func TestEqual(t *testing.T) {
 	}{
 		{
 			"empty",
-			&hashRing{[]ringItem{}},
+			&hashRing{items: []ringItem{}},
 			false,
 		},
 		{
@@ -178,7 +209,7 @@ func TestEqual(t *testing.T) {
 		{
 			"equal",
 			&hashRing{
-				[]ringItem{
+				items: []ringItem{
 					{pos: position(123), endpoint: "endpoint-1"},
 				},
 			},
@@ -187,7 +218,7 @@ func TestEqual(t *testing.T) {
 		{
 			"different length",
 			&hashRing{
-				[]ringItem{
+				items: []ringItem{
 					{pos: position(123), endpoint: "endpoint-1"},
 					{pos: position(124), endpoint: "endpoint-2"},
 				},
@@ -197,7 +228,7 @@ func TestEqual(t *testing.T) {
 		{
 			"different position",
 			&hashRing{
-				[]ringItem{
+				items: []ringItem{
 					{pos: position(124), endpoint: "endpoint-1"},
 				},
 			},
@@ -206,7 +237,7 @@ func TestEqual(t *testing.T) {
 		{
 			"different endpoint",
 			&hashRing{
-				[]ringItem{
+				items: []ringItem{
 					{pos: position(123), endpoint: "endpoint-2"},
 				},
 			},
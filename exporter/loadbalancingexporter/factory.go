@@ -8,14 +8,17 @@ package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otelarrowexporter"
 )
 
 const (
@@ -44,6 +47,37 @@ func createDefaultConfig() component.Config {
 		Protocol: Protocol{
 			OTLP: *otlpDefaultCfg,
 		},
+		HashRing: HashRingSettings{
+			KeyIdleTimeout: defaultKeyIdleTimeout,
+			MaxKeys:        defaultMaxKeys,
+		},
+	}
+}
+
+// buildExporterFactory returns the factory for the exporters of each backend, based on the configured protocol
+func buildExporterFactory(cfg *Config) exporter.Factory {
+	switch {
+	case cfg.Protocol.OTLPHTTP != nil:
+		return otlphttpexporter.NewFactory()
+	case cfg.Protocol.OTelArrow != nil:
+		return otelarrowexporter.NewFactory()
+	default:
+		return otlpexporter.NewFactory()
+	}
+}
+
+// buildProtocolExporterConfig returns the configuration for the exporter of the given backend, based on the configured protocol
+func buildProtocolExporterConfig(cfg *Config, endpoint string) component.Config {
+	switch {
+	case cfg.Protocol.OTLPHTTP != nil:
+		oCfg := buildOTLPHTTPExporterConfig(cfg, endpoint)
+		return &oCfg
+	case cfg.Protocol.OTelArrow != nil:
+		oCfg := buildOTelArrowExporterConfig(cfg, endpoint)
+		return &oCfg
+	default:
+		oCfg := buildExporterConfig(cfg, endpoint)
+		return &oCfg
 	}
 }
 
@@ -54,6 +88,31 @@ func buildExporterConfig(cfg *Config, endpoint string) otlpexporter.Config {
 	return oCfg
 }
 
+func buildOTLPHTTPExporterConfig(cfg *Config, endpoint string) otlphttpexporter.Config {
+	oCfg := *cfg.Protocol.OTLPHTTP
+
+	// the resolvers return host:port pairs, the scheme is taken from the configured endpoint, if any
+	scheme := "https"
+	if s, _, found := strings.Cut(oCfg.ClientConfig.Endpoint, "://"); found {
+		scheme = s
+	}
+	oCfg.ClientConfig.Endpoint = fmt.Sprintf("%s://%s", scheme, endpoint)
+
+	// signal-specific endpoints would send the data for all backends to the same place
+	oCfg.TracesEndpoint = ""
+	oCfg.MetricsEndpoint = ""
+	oCfg.LogsEndpoint = ""
+
+	return oCfg
+}
+
+func buildOTelArrowExporterConfig(cfg *Config, endpoint string) otelarrowexporter.Config {
+	oCfg := *cfg.Protocol.OTelArrow
+	oCfg.ClientConfig.Endpoint = endpoint
+
+	return oCfg
+}
+
 func buildExporterSettings(typ component.Type, params exporter.Settings, endpoint string) exporter.Settings {
 	// Override child exporter ID to segregate metrics from loadbalancing top level
 	childName := fmt.Sprintf("%s_%s", params.ID, endpoint)
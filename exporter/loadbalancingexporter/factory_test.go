@@ -16,11 +16,13 @@ import (
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
 	"go.opentelemetry.io/collector/otelcol/otelcoltest"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otelarrowexporter"
 )
 
 func TestTracesExporterGetsCreatedWithValidConfiguration(t *testing.T) {
@@ -98,6 +100,103 @@ func TestBuildExporterConfig(t *testing.T) {
 	assert.Equal(t, defaultCfg.RetryConfig, exporterCfg.RetryConfig)
 }
 
+func TestBuildExporterFactory(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		protocol Protocol
+		expected component.Type
+	}{
+		{
+			name:     "otlp",
+			protocol: Protocol{},
+			expected: otlpexporter.NewFactory().Type(),
+		},
+		{
+			name:     "otlphttp",
+			protocol: Protocol{OTLPHTTP: otlphttpexporter.NewFactory().CreateDefaultConfig().(*otlphttpexporter.Config)},
+			expected: otlphttpexporter.NewFactory().Type(),
+		},
+		{
+			name:     "otelarrow",
+			protocol: Protocol{OTelArrow: otelarrowexporter.NewFactory().CreateDefaultConfig().(*otelarrowexporter.Config)},
+			expected: otelarrowexporter.NewFactory().Type(),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Protocol: tt.protocol}
+			assert.Equal(t, tt.expected, buildExporterFactory(cfg).Type())
+		})
+	}
+}
+
+func TestBuildOTLPHTTPExporterConfig(t *testing.T) {
+	// prepare
+	defaultCfg := otlphttpexporter.NewFactory().CreateDefaultConfig().(*otlphttpexporter.Config)
+	otlpHTTPCfg := *defaultCfg
+	otlpHTTPCfg.TracesEndpoint = "https://should-be-ignored:4318/v1/traces"
+	cfg := &Config{Protocol: Protocol{OTLPHTTP: &otlpHTTPCfg}}
+
+	// test
+	exporterCfg := buildProtocolExporterConfig(cfg, "the-endpoint:4318").(*otlphttpexporter.Config)
+
+	// verify
+	assert.Equal(t, "https://the-endpoint:4318", exporterCfg.ClientConfig.Endpoint)
+	assert.Empty(t, exporterCfg.TracesEndpoint)
+	assert.Equal(t, defaultCfg.QueueConfig, exporterCfg.QueueConfig)
+	assert.Equal(t, defaultCfg.RetryConfig, exporterCfg.RetryConfig)
+	assert.Equal(t, "https://should-be-ignored:4318/v1/traces", cfg.Protocol.OTLPHTTP.TracesEndpoint, "the template must not be modified")
+
+	// test the scheme of the configured endpoint
+	otlpHTTPCfg.ClientConfig.Endpoint = "http://placeholder"
+	exporterCfg = buildProtocolExporterConfig(cfg, "the-endpoint:4318").(*otlphttpexporter.Config)
+
+	// verify
+	assert.Equal(t, "http://the-endpoint:4318", exporterCfg.ClientConfig.Endpoint)
+
+	// test insecure without scheme
+	otlpHTTPCfg.ClientConfig.Endpoint = ""
+	otlpHTTPCfg.ClientConfig.TLSSetting.Insecure = true
+	exporterCfg = buildProtocolExporterConfig(cfg, "the-endpoint:4318").(*otlphttpexporter.Config)
+
+	// verify
+	assert.Equal(t, "https://the-endpoint:4318", exporterCfg.ClientConfig.Endpoint)
+}
+
+func TestBuildOTelArrowExporterConfig(t *testing.T) {
+	// prepare
+	defaultCfg := otelarrowexporter.NewFactory().CreateDefaultConfig().(*otelarrowexporter.Config)
+	cfg := &Config{Protocol: Protocol{OTelArrow: defaultCfg}}
+
+	// test
+	exporterCfg := buildProtocolExporterConfig(cfg, "the-endpoint:4317").(*otelarrowexporter.Config)
+
+	// verify
+	assert.Equal(t, "the-endpoint:4317", exporterCfg.ClientConfig.Endpoint)
+	assert.Equal(t, defaultCfg.Arrow, exporterCfg.Arrow)
+	assert.Empty(t, defaultCfg.ClientConfig.Endpoint, "the template must not be modified")
+}
+
+func TestTracesExporterGetsCreatedWithOTLPHTTPProtocol(t *testing.T) {
+	// prepare
+	factory := NewFactory()
+	creationParams := exportertest.NewNopSettings(metadata.Type)
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			Static: &StaticResolver{Hostnames: []string{"endpoint-1"}},
+		},
+		Protocol: Protocol{
+			OTLPHTTP: otlphttpexporter.NewFactory().CreateDefaultConfig().(*otlphttpexporter.Config),
+		},
+	}
+
+	// test
+	exp, err := factory.CreateTraces(context.Background(), creationParams, cfg)
+
+	// verify
+	assert.NoError(t, err)
+	assert.NotNil(t, exp)
+}
+
 func TestBuildExporterSettings(t *testing.T) {
 	// prepare
 	creationParams := exportertest.NewNopSettings(metadata.Type)
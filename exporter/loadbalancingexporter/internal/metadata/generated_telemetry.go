@@ -27,7 +27,9 @@ type TelemetryBuilder struct {
 	mu                            sync.Mutex
 	registrations                 []metric.Registration
 	LoadbalancerBackendLatency    metric.Int64Histogram
+	LoadbalancerBackendLoadSkew   metric.Float64Gauge
 	LoadbalancerBackendOutcome    metric.Int64Counter
+	LoadbalancerMovedKeys         metric.Int64Counter
 	LoadbalancerNumBackendUpdates metric.Int64Counter
 	LoadbalancerNumBackends       metric.Int64Gauge
 	LoadbalancerNumResolutions    metric.Int64Counter
@@ -69,12 +71,24 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithExplicitBucketBoundaries([]float64{5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000}...),
 	)
 	errs = errors.Join(errs, err)
+	builder.LoadbalancerBackendLoadSkew, err = builder.meter.Float64Gauge(
+		"otelcol_loadbalancer_backend_load_skew",
+		metric.WithDescription("Ratio between the load routed to a backend and the average load across all backends, when bounded loads are enabled."),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	builder.LoadbalancerBackendOutcome, err = builder.meter.Int64Counter(
 		"otelcol_loadbalancer_backend_outcome",
 		metric.WithDescription("Number of successes and failures for each endpoint."),
 		metric.WithUnit("{outcomes}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.LoadbalancerMovedKeys, err = builder.meter.Int64Counter(
+		"otelcol_loadbalancer_moved_keys",
+		metric.WithDescription("Number of routing keys that were assigned to a different backend after the list of backends changed."),
+		metric.WithUnit("{keys}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.LoadbalancerNumBackendUpdates, err = builder.meter.Int64Counter(
 		"otelcol_loadbalancer_num_backend_updates",
 		metric.WithDescription("Number of times the list of backends was updated."),
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.uber.org/zap"
@@ -17,7 +18,10 @@ import (
 )
 
 const (
-	defaultPort = "4317"
+	defaultOTLPPort       = "4317"
+	defaultOTLPHTTPPort   = "4318"
+	defaultKeyIdleTimeout = 30 * time.Second
+	defaultMaxKeys        = 100_000
 )
 
 var (
@@ -34,9 +38,22 @@ type loadBalancer struct {
 	res  resolver
 	ring *hashRing
 
+	// table tracks the assignment of routing keys, only set when bounded loads are enabled
+	table *routingTable
+
+	// without bounded loads, keys are routed with the previous ring until the end of the transition period
+	// if their previous backend is still part of the current ring
+	transitionPeriod time.Duration
+	previousRing     *hashRing
+	transitionEnd    time.Time
+	now              func() time.Time
+
 	componentFactory componentFactory
 	exporters        map[string]*wrappedExporter
 
+	// defaultPort is the port of the backends of the configured protocol, used when the resolved endpoints have none
+	defaultPort string
+
 	stopped    bool
 	updateLock sync.RWMutex
 }
@@ -132,11 +149,20 @@ func newLoadBalancer(logger *zap.Logger, cfg component.Config, factory component
 		return nil, errNoResolver
 	}
 
+	var table *routingTable
+	if oCfg.HashRing.boundedLoads() {
+		table = newRoutingTable(oCfg.HashRing, telemetry)
+	}
+
 	return &loadBalancer{
 		logger:           logger,
 		res:              res,
+		table:            table,
+		transitionPeriod: oCfg.HashRing.TransitionPeriod,
+		now:              time.Now,
 		componentFactory: factory,
 		exporters:        map[string]*wrappedExporter{},
+		defaultPort:      protocolDefaultPort(oCfg),
 	}, nil
 }
 
@@ -153,6 +179,12 @@ func (lb *loadBalancer) onBackendChanges(resolved []string) {
 		lb.updateLock.Lock()
 		defer lb.updateLock.Unlock()
 
+		if lb.table != nil {
+			lb.table.onRingChange()
+		} else if lb.transitionPeriod > 0 && lb.ring != nil {
+			lb.previousRing = lb.ring
+			lb.transitionEnd = lb.now().Add(lb.transitionPeriod)
+		}
 		lb.ring = newRing
 
 		// TODO: set a timeout?
@@ -166,7 +198,7 @@ func (lb *loadBalancer) onBackendChanges(resolved []string) {
 
 func (lb *loadBalancer) addMissingExporters(ctx context.Context, endpoints []string) {
 	for _, endpoint := range endpoints {
-		endpoint = endpointWithPort(endpoint)
+		endpoint = endpointWithPort(endpoint, lb.defaultPort)
 
 		if _, exists := lb.exporters[endpoint]; !exists {
 			exp, err := lb.componentFactory(ctx, endpoint)
@@ -184,9 +216,17 @@ func (lb *loadBalancer) addMissingExporters(ctx context.Context, endpoints []str
 	}
 }
 
-func endpointWithPort(endpoint string) string {
+// protocolDefaultPort returns the default port of the receivers of the configured protocol
+func protocolDefaultPort(cfg *Config) string {
+	if cfg.Protocol.OTLPHTTP != nil {
+		return defaultOTLPHTTPPort
+	}
+	return defaultOTLPPort
+}
+
+func endpointWithPort(endpoint string, port string) string {
 	if !strings.Contains(endpoint, ":") {
-		endpoint = fmt.Sprintf("%s:%s", endpoint, defaultPort)
+		endpoint = fmt.Sprintf("%s:%s", endpoint, port)
 	}
 	return endpoint
 }
@@ -194,7 +234,7 @@ func endpointWithPort(endpoint string) string {
 func (lb *loadBalancer) removeExtraExporters(ctx context.Context, endpoints []string) {
 	endpointsWithPort := make([]string, len(endpoints))
 	for i, e := range endpoints {
-		endpointsWithPort[i] = endpointWithPort(e)
+		endpointsWithPort[i] = endpointWithPort(e, lb.defaultPort)
 	}
 	for existing := range lb.exporters {
 		if !endpointFound(existing, endpointsWithPort) {
@@ -235,8 +275,8 @@ func (lb *loadBalancer) exporterAndEndpoint(identifier []byte) (*wrappedExporter
 	// for details: https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/1690
 	lb.updateLock.RLock()
 	defer lb.updateLock.RUnlock()
-	endpoint := lb.ring.endpointFor(identifier)
-	exp, found := lb.exporters[endpointWithPort(endpoint)]
+	endpoint := lb.endpointFor(identifier)
+	exp, found := lb.exporters[endpointWithPort(endpoint, lb.defaultPort)]
 	if !found {
 		// something is really wrong... how come we couldn't find the exporter??
 		return nil, "", fmt.Errorf("couldn't find the exporter for the endpoint %q", endpoint)
@@ -244,3 +284,15 @@ func (lb *loadBalancer) exporterAndEndpoint(identifier []byte) (*wrappedExporter
 
 	return exp, endpoint, nil
 }
+
+func (lb *loadBalancer) endpointFor(identifier []byte) string {
+	if lb.table != nil {
+		return lb.table.endpointFor(lb.ring, identifier)
+	}
+	if lb.previousRing != nil && lb.now().Before(lb.transitionEnd) {
+		if endpoint := lb.previousRing.endpointFor(identifier); lb.ring.contains(endpoint) {
+			return endpoint
+		}
+	}
+	return lb.ring.endpointFor(identifier)
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,9 +16,11 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exportertest"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
 	"k8s.io/client-go/tools/clientcmd"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/otelarrowexporter"
 )
 
 func TestNewLoadBalancerNoResolver(t *testing.T) {
@@ -237,6 +240,77 @@ func TestOnBackendChanges(t *testing.T) {
 	assert.Len(t, p.ring.items, 2*defaultWeight)
 }
 
+func TestOnBackendChangesWithTransition(t *testing.T) {
+	// prepare
+	ts, tb := getTelemetryAssets(t)
+	cfg := simpleConfig()
+	cfg.HashRing = HashRingSettings{
+		TransitionPeriod: time.Minute,
+	}
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+
+	p, err := newLoadBalancer(ts.Logger, cfg, componentFactory, tb)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+	assert.Nil(t, p.table)
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	p.now = func() time.Time {
+		return now
+	}
+
+	p.onBackendChanges([]string{"endpoint-1", "endpoint-2"})
+	oldRing := p.ring
+	newRing := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+	key := movingKeys(oldRing, newRing, 1)[0]
+
+	_, before, err := p.exporterAndEndpoint(key)
+	require.NoError(t, err)
+
+	// test
+	p.onBackendChanges([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+
+	// verify
+	_, during, err := p.exporterAndEndpoint(key)
+	require.NoError(t, err)
+	assert.Equal(t, before, during)
+
+	now = now.Add(time.Minute)
+	_, after, err := p.exporterAndEndpoint(key)
+	require.NoError(t, err)
+	assert.Equal(t, "endpoint-3", after)
+}
+
+func TestOnBackendChangesWithTransitionRemovedBackend(t *testing.T) {
+	// prepare
+	ts, tb := getTelemetryAssets(t)
+	cfg := simpleConfig()
+	cfg.HashRing = HashRingSettings{
+		TransitionPeriod: time.Minute,
+	}
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+
+	p, err := newLoadBalancer(ts.Logger, cfg, componentFactory, tb)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	p.onBackendChanges([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+	newRing := newHashRing([]string{"endpoint-1", "endpoint-2"})
+	key := movingKeys(newRing, p.ring, 1)[0]
+
+	// test
+	p.onBackendChanges([]string{"endpoint-1", "endpoint-2"})
+
+	// verify
+	_, endpoint, err := p.exporterAndEndpoint(key)
+	require.NoError(t, err)
+	assert.Equal(t, newRing.endpointFor(key), endpoint)
+}
+
 func TestRemoveExtraExporters(t *testing.T) {
 	// prepare
 	ts, tb := getTelemetryAssets(t)
@@ -257,7 +331,7 @@ func TestRemoveExtraExporters(t *testing.T) {
 
 	// verify
 	assert.Len(t, p.exporters, 1)
-	assert.NotContains(t, p.exporters, endpointWithPort("endpoint-2"))
+	assert.NotContains(t, p.exporters, endpointWithPort("endpoint-2", defaultOTLPPort))
 }
 
 func TestAddMissingExporters(t *testing.T) {
@@ -352,18 +426,74 @@ func TestEndpointFound(t *testing.T) {
 
 func TestEndpointWithPort(t *testing.T) {
 	for _, tt := range []struct {
-		input, expected string
+		input, port, expected string
 	}{
 		{
 			"endpoint-1",
+			defaultOTLPPort,
 			"endpoint-1:4317",
 		},
+		{
+			"endpoint-1",
+			defaultOTLPHTTPPort,
+			"endpoint-1:4318",
+		},
 		{
 			"endpoint-1:55690",
+			defaultOTLPHTTPPort,
 			"endpoint-1:55690",
 		},
 	} {
-		assert.Equal(t, tt.expected, endpointWithPort(tt.input))
+		assert.Equal(t, tt.expected, endpointWithPort(tt.input, tt.port))
+	}
+}
+
+func TestDefaultPortOfProtocol(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		protocol Protocol
+		expected string
+	}{
+		{
+			name:     "otlp",
+			protocol: Protocol{OTLP: otlpexporter.Config{}},
+			expected: "4317",
+		},
+		{
+			name:     "otlphttp",
+			protocol: Protocol{OTLPHTTP: &otlphttpexporter.Config{}},
+			expected: "4318",
+		},
+		{
+			name:     "otelarrow",
+			protocol: Protocol{OTelArrow: &otelarrowexporter.Config{}},
+			expected: "4317",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, tb := getTelemetryAssets(t)
+			cfg := &Config{
+				Protocol: tt.protocol,
+				Resolver: ResolverSettings{
+					Static: &StaticResolver{Hostnames: []string{"endpoint-1"}},
+				},
+			}
+			var endpoints []string
+			componentFactory := func(_ context.Context, endpoint string) (component.Component, error) {
+				endpoints = append(endpoints, endpoint)
+				return newNopMockExporter(), nil
+			}
+			p, err := newLoadBalancer(ts.Logger, cfg, componentFactory, tb)
+			require.NoError(t, err)
+
+			require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, p.Shutdown(context.Background()))
+			}()
+
+			assert.Equal(t, []string{"endpoint-1:" + tt.expected}, endpoints)
+			assert.Contains(t, p.exporters, "endpoint-1:"+tt.expected)
+		})
 	}
 }
 
@@ -392,7 +522,7 @@ func TestFailedExporterInRing(t *testing.T) {
 	// this behavior. As the solution would require more locks/syncs/checks, we should probably wait to see
 	// if this is really a problem in the real world
 	resEndpoint := "endpoint-2"
-	delete(p.exporters, endpointWithPort(resEndpoint))
+	delete(p.exporters, endpointWithPort(resEndpoint, defaultOTLPPort))
 
 	// sanity check
 	require.Contains(t, p.res.(*staticResolver).endpoints, resEndpoint)
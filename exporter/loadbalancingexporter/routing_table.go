@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
+)
+
+// keyAssignment records the backend a routing key was assigned to.
+type keyAssignment struct {
+	endpoint string
+	lastSeen time.Time
+
+	// generation is the generation of the ring at the time the key was assigned
+	generation uint64
+}
+
+// routingTable keeps track of the routing keys that were recently seen and the backends they were assigned to.
+// It implements consistent hashing with bounded loads following Mirrokni et al., where a key that would overload
+// its backend is assigned to the next backend in the ring with spare capacity. It also allows keys to keep being
+// routed to their previous backend for a transition period after the ring changes, until they go quiet.
+//
+// The loads are the ones seen by this instance of the exporter only, which is why it isn't used for the routing
+// keys that have to reach the same backend from every instance.
+type routingTable struct {
+	loadFactor       float64
+	transitionPeriod time.Duration
+	idleTimeout      time.Duration
+	maxKeys          int
+
+	telemetry *metadata.TelemetryBuilder
+	now       func() time.Time
+
+	mu            sync.Mutex
+	keys          map[string]*keyAssignment
+	generation    uint64
+	transitionEnd time.Time
+
+	// the load of each backend is the number of lookups routed to it during the current and the previous window,
+	// each window lasting for the idle timeout
+	windowStart  time.Time
+	currentLoad  map[string]int64
+	previousLoad map[string]int64
+}
+
+func newRoutingTable(cfg HashRingSettings, telemetry *metadata.TelemetryBuilder) *routingTable {
+	return &routingTable{
+		loadFactor:       cfg.LoadFactor,
+		transitionPeriod: cfg.TransitionPeriod,
+		idleTimeout:      cfg.KeyIdleTimeout,
+		maxKeys:          cfg.MaxKeys,
+		telemetry:        telemetry,
+		now:              time.Now,
+		keys:             map[string]*keyAssignment{},
+		currentLoad:      map[string]int64{},
+		previousLoad:     map[string]int64{},
+	}
+}
+
+// onRingChange is called once the ring has been replaced. Keys assigned before the change are kept on their
+// backend until they go quiet or the transition period is over, whatever happens first.
+func (rt *routingTable) onRingChange() {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	rt.generation++
+	rt.transitionEnd = rt.now().Add(rt.transitionPeriod)
+}
+
+// endpointFor returns the backend responsible for the given identifier, recording the assignment.
+func (rt *routingTable) endpointFor(ring *hashRing, identifier []byte) string {
+	if ring == nil {
+		// perhaps the ring itself couldn't get initialized yet?
+		return ""
+	}
+
+	now := rt.now()
+	key := string(identifier)
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	rt.rotate(ring, now)
+
+	assignment, found := rt.keys[key]
+	if found && rt.isActive(assignment, ring, now) {
+		assignment.lastSeen = now
+		rt.currentLoad[assignment.endpoint]++
+		return assignment.endpoint
+	}
+
+	endpoint := rt.assign(ring, identifier)
+	if found && now.Sub(assignment.lastSeen) < rt.idleTimeout && assignment.endpoint != endpoint {
+		// the key was still active, but the ring change made it move to another backend
+		rt.telemetry.LoadbalancerMovedKeys.Add(context.Background(), 1)
+	}
+
+	if found || len(rt.keys) < rt.maxKeys {
+		// once the table is full, new keys are routed without being tracked until quiet keys are forgotten
+		rt.keys[key] = &keyAssignment{
+			endpoint:   endpoint,
+			lastSeen:   now,
+			generation: rt.generation,
+		}
+	}
+	rt.currentLoad[endpoint]++
+	return endpoint
+}
+
+// isActive returns whether the key should keep being routed to the backend it was assigned to.
+func (rt *routingTable) isActive(assignment *keyAssignment, ring *hashRing, now time.Time) bool {
+	if now.Sub(assignment.lastSeen) >= rt.idleTimeout {
+		return false
+	}
+	if !ring.contains(assignment.endpoint) {
+		// the backend is gone, there's nothing we can do to keep the key there
+		return false
+	}
+	return assignment.generation == rt.generation || now.Before(rt.transitionEnd)
+}
+
+// assign determines the backend for a key that has no active assignment. The ring is walked clockwise until
+// a backend is found whose load stays within the capacity after taking the key.
+func (rt *routingTable) assign(ring *hashRing, identifier []byte) string {
+	candidates := ring.endpointsFor(identifier)
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	capacity := rt.capacity(candidates)
+	for _, candidate := range candidates {
+		if float64(rt.load(candidate)+1) <= capacity {
+			return candidate
+		}
+	}
+
+	// can't happen with a load factor greater than 1, but we don't want to drop data in that case either
+	return candidates[0]
+}
+
+// capacity returns the maximum load a backend is allowed to have, after accounting for a new lookup.
+func (rt *routingTable) capacity(endpoints []string) float64 {
+	var total int64
+	for _, endpoint := range endpoints {
+		total += rt.load(endpoint)
+	}
+	return math.Ceil(rt.loadFactor * float64(total+1) / float64(len(endpoints)))
+}
+
+func (rt *routingTable) load(endpoint string) int64 {
+	return rt.currentLoad[endpoint] + rt.previousLoad[endpoint]
+}
+
+// rotate starts a new load window once the current one is over, reporting the load skew of the backends
+// and forgetting about the keys that went quiet.
+func (rt *routingTable) rotate(ring *hashRing, now time.Time) {
+	if rt.windowStart.IsZero() {
+		rt.windowStart = now
+		return
+	}
+
+	elapsed := now.Sub(rt.windowStart)
+	if elapsed < rt.idleTimeout {
+		return
+	}
+
+	rt.recordLoadSkew(ring)
+
+	if elapsed >= 2*rt.idleTimeout {
+		// nothing was seen during the whole previous window
+		rt.previousLoad = map[string]int64{}
+	} else {
+		rt.previousLoad = rt.currentLoad
+	}
+	rt.currentLoad = map[string]int64{}
+	rt.windowStart = now
+
+	for key, assignment := range rt.keys {
+		if now.Sub(assignment.lastSeen) >= rt.idleTimeout {
+			delete(rt.keys, key)
+		}
+	}
+}
+
+func (rt *routingTable) recordLoadSkew(ring *hashRing) {
+	if len(ring.endpoints) == 0 {
+		return
+	}
+
+	var total int64
+	for _, endpoint := range ring.endpoints {
+		total += rt.load(endpoint)
+	}
+	if total == 0 {
+		return
+	}
+
+	avg := float64(total) / float64(len(ring.endpoints))
+	for _, endpoint := range ring.endpoints {
+		skew := float64(rt.load(endpoint)) / avg
+		rt.telemetry.LoadbalancerBackendLoadSkew.Record(context.Background(), skew, metric.WithAttributeSet(attribute.NewSet(attribute.String("endpoint", endpoint))))
+	}
+}
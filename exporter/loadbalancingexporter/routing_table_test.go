@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadatatest"
+)
+
+func TestRoutingTableBoundedLoads(t *testing.T) {
+	// prepare
+	rt, _, _ := newTestRoutingTable(t, HashRingSettings{LoadFactor: 1.25, KeyIdleTimeout: time.Minute, MaxKeys: defaultMaxKeys})
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+
+	// test
+	assigned := map[string]string{}
+	for i := 0; i < 300; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		assigned[key] = rt.endpointFor(ring, []byte(key))
+	}
+
+	// verify
+	capacity := int64(math.Ceil(1.25 * 300 / 3))
+	for _, endpoint := range ring.endpoints {
+		assert.LessOrEqual(t, rt.load(endpoint), capacity)
+	}
+
+	// keys stick to their backend
+	for key, endpoint := range assigned {
+		assert.Equal(t, endpoint, rt.endpointFor(ring, []byte(key)))
+	}
+}
+
+func TestRoutingTableHotKey(t *testing.T) {
+	// prepare
+	rt, _, _ := newTestRoutingTable(t, HashRingSettings{LoadFactor: 1.25, KeyIdleTimeout: time.Minute, MaxKeys: defaultMaxKeys})
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+
+	hot := []byte("checkout")
+	hotEndpoint := ring.endpointFor(hot)
+	for i := 0; i < 100; i++ {
+		require.Equal(t, hotEndpoint, rt.endpointFor(ring, hot))
+	}
+
+	// test
+	diverted := 0
+	for i := 0; i < 50; i++ {
+		key := []byte(fmt.Sprintf("service-%d", i))
+		if ring.endpointFor(key) != hotEndpoint {
+			continue
+		}
+		diverted++
+		assert.NotEqual(t, hotEndpoint, rt.endpointFor(ring, key))
+	}
+
+	// verify
+	require.Positive(t, diverted)
+}
+
+func TestRoutingTableTransition(t *testing.T) {
+	// prepare
+	rt, clock, tt := newTestRoutingTable(t, HashRingSettings{LoadFactor: unboundedLoadFactor, TransitionPeriod: 5 * time.Minute, KeyIdleTimeout: time.Minute, MaxKeys: defaultMaxKeys})
+	oldRing := newHashRing([]string{"endpoint-1", "endpoint-2"})
+	newRing := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+	moving := movingKeys(oldRing, newRing, 3)
+
+	for _, key := range moving {
+		require.Equal(t, oldRing.endpointFor(key), rt.endpointFor(oldRing, key))
+	}
+
+	// test
+	rt.onRingChange()
+	clock.advance(30 * time.Second)
+
+	// verify
+	// keys seen before the change stay on their previous backend, new keys follow the new ring
+	for _, key := range moving {
+		assert.Equal(t, oldRing.endpointFor(key), rt.endpointFor(newRing, key))
+	}
+	fresh := movingKeys(oldRing, newRing, 4)[3]
+	assert.Equal(t, "endpoint-3", rt.endpointFor(newRing, fresh))
+
+	// the other keys are kept alive, and stay on their previous backend until the transition is over
+	for i := 0; i < 5; i++ {
+		clock.advance(50 * time.Second)
+		for _, key := range moving[1:] {
+			assert.Equal(t, oldRing.endpointFor(key), rt.endpointFor(newRing, key))
+		}
+	}
+	clock.advance(30 * time.Second)
+	for _, key := range moving[1:] {
+		assert.Equal(t, "endpoint-3", rt.endpointFor(newRing, key))
+	}
+
+	// the first key went quiet and is free to move, without being counted as moved
+	assert.Equal(t, "endpoint-3", rt.endpointFor(newRing, moving[0]))
+
+	metadatatest.AssertEqualLoadbalancerMovedKeys(t, tt,
+		[]metricdata.DataPoint[int64]{{Value: 2}},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestRoutingTableWithoutTransition(t *testing.T) {
+	// prepare
+	rt, _, tt := newTestRoutingTable(t, HashRingSettings{LoadFactor: 1.25, KeyIdleTimeout: time.Minute, MaxKeys: defaultMaxKeys})
+	oldRing := newHashRing([]string{"endpoint-1", "endpoint-2"})
+	newRing := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+	key := movingKeys(oldRing, newRing, 1)[0]
+	require.Equal(t, oldRing.endpointFor(key), rt.endpointFor(oldRing, key))
+
+	// test
+	rt.onRingChange()
+
+	// verify
+	assert.Equal(t, "endpoint-3", rt.endpointFor(newRing, key))
+	metadatatest.AssertEqualLoadbalancerMovedKeys(t, tt,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestRoutingTableRemovedBackend(t *testing.T) {
+	// prepare
+	rt, _, _ := newTestRoutingTable(t, HashRingSettings{LoadFactor: unboundedLoadFactor, TransitionPeriod: 5 * time.Minute, KeyIdleTimeout: time.Minute, MaxKeys: defaultMaxKeys})
+	oldRing := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+	newRing := newHashRing([]string{"endpoint-1", "endpoint-2"})
+	key := movingKeys(newRing, oldRing, 1)[0]
+	require.Equal(t, "endpoint-3", rt.endpointFor(oldRing, key))
+
+	// test
+	rt.onRingChange()
+
+	// verify
+	assert.Equal(t, newRing.endpointFor(key), rt.endpointFor(newRing, key))
+}
+
+func TestRoutingTableLoadSkew(t *testing.T) {
+	// prepare
+	rt, clock, tt := newTestRoutingTable(t, HashRingSettings{LoadFactor: 2, KeyIdleTimeout: time.Minute, MaxKeys: defaultMaxKeys})
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2"})
+
+	hot := []byte("checkout")
+	hotEndpoint := ring.endpointFor(hot)
+	coldEndpoint := "endpoint-1"
+	if hotEndpoint == coldEndpoint {
+		coldEndpoint = "endpoint-2"
+	}
+	for i := 0; i < 3; i++ {
+		rt.endpointFor(ring, hot)
+	}
+	rt.endpointFor(ring, keyFor(ring, coldEndpoint))
+
+	// test
+	clock.advance(time.Minute)
+	rt.endpointFor(ring, hot)
+
+	// verify
+	metadatatest.AssertEqualLoadbalancerBackendLoadSkew(t, tt,
+		[]metricdata.DataPoint[float64]{
+			{Attributes: attribute.NewSet(attribute.String("endpoint", hotEndpoint)), Value: 1.5},
+			{Attributes: attribute.NewSet(attribute.String("endpoint", coldEndpoint)), Value: 0.5},
+		},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestRoutingTableForgetsQuietKeys(t *testing.T) {
+	// prepare
+	rt, clock, _ := newTestRoutingTable(t, HashRingSettings{LoadFactor: unboundedLoadFactor, TransitionPeriod: time.Minute, KeyIdleTimeout: time.Minute, MaxKeys: defaultMaxKeys})
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2"})
+	rt.endpointFor(ring, []byte("key-1"))
+	rt.endpointFor(ring, []byte("key-2"))
+	require.Len(t, rt.keys, 2)
+
+	// test
+	clock.advance(30 * time.Second)
+	rt.endpointFor(ring, []byte("key-2"))
+	clock.advance(45 * time.Second)
+	rt.endpointFor(ring, []byte("key-3"))
+
+	// verify
+	assert.Len(t, rt.keys, 2)
+	assert.NotContains(t, rt.keys, "key-1")
+}
+
+func TestRoutingTableMaxKeys(t *testing.T) {
+	// prepare
+	rt, clock, _ := newTestRoutingTable(t, HashRingSettings{LoadFactor: unboundedLoadFactor, KeyIdleTimeout: time.Minute, MaxKeys: 2})
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2"})
+	rt.endpointFor(ring, []byte("key-1"))
+	rt.endpointFor(ring, []byte("key-2"))
+
+	// test
+	endpoint := rt.endpointFor(ring, []byte("key-3"))
+
+	// verify
+	assert.Equal(t, ring.endpointFor([]byte("key-3")), endpoint)
+	assert.Len(t, rt.keys, 2)
+	assert.NotContains(t, rt.keys, "key-3")
+
+	// there's room again once the quiet keys are forgotten
+	clock.advance(time.Minute)
+	rt.endpointFor(ring, []byte("key-3"))
+	assert.Contains(t, rt.keys, "key-3")
+}
+
+// unboundedLoadFactor is a load factor high enough for the keys to never be diverted in the tests
+const unboundedLoadFactor = 100
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestRoutingTable(t *testing.T, cfg HashRingSettings) (*routingTable, *fakeClock, *componenttest.Telemetry) {
+	tt := componenttest.NewTelemetry()
+	t.Cleanup(func() {
+		require.NoError(t, tt.Shutdown(context.Background()))
+	})
+	tb, err := metadata.NewTelemetryBuilder(tt.NewTelemetrySettings())
+	require.NoError(t, err)
+
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	rt := newRoutingTable(cfg, tb)
+	rt.now = func() time.Time {
+		return clock.now
+	}
+	return rt, clock, tt
+}
+
+// movingKeys returns the first n keys that are assigned to a different backend in the new ring
+func movingKeys(oldRing, newRing *hashRing, n int) [][]byte {
+	var keys [][]byte
+	for i := 0; len(keys) < n; i++ {
+		key := []byte(fmt.Sprintf("key-%d", i))
+		if oldRing.endpointFor(key) != newRing.endpointFor(key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// keyFor returns the first key that the ring assigns to the given endpoint
+func keyFor(ring *hashRing, endpoint string) []byte {
+	for i := 0; ; i++ {
+		key := []byte(fmt.Sprintf("key-%d", i))
+		if ring.endpointFor(key) == endpoint {
+			return key
+		}
+	}
+}
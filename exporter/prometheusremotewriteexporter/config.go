@@ -5,6 +5,7 @@ package prometheusremotewriteexporter // import "github.com/open-telemetry/opent
 
 import (
 	"errors"
+	"fmt"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -52,8 +53,19 @@ type Config struct {
 
 	// SendMetadata controls whether prometheus metadata will be generated and sent
 	SendMetadata bool `mapstructure:"send_metadata"`
+
+	// ProtocolVersion is the version of the remote write protocol used to send the metrics,
+	// either "1.0" (prometheus.WriteRequest) or "2.0" (io.prometheus.write.v2.Request).
+	ProtocolVersion string `mapstructure:"protocol_version"`
 }
 
+const (
+	// protocolVersionV1 is the Remote Write 1.0 protocol, sending prometheus.WriteRequest messages.
+	protocolVersionV1 = "1.0"
+	// protocolVersionV2 is the Remote Write 2.0 protocol, sending io.prometheus.write.v2.Request messages.
+	protocolVersionV2 = "2.0"
+)
+
 type CreatedMetric struct {
 	// Enabled if true the _created metrics could be exported
 	Enabled bool `mapstructure:"enabled"`
@@ -118,5 +130,11 @@ func (cfg *Config) Validate() error {
 		return errors.New("compression type must be snappy")
 	}
 
+	switch cfg.ProtocolVersion {
+	case "", protocolVersionV1, protocolVersionV2:
+	default:
+		return fmt.Errorf("protocol_version must be %q or %q, got %q", protocolVersionV1, protocolVersionV2, cfg.ProtocolVersion)
+	}
+
 	return nil
 }
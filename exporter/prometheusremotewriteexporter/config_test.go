@@ -74,6 +74,7 @@ func TestLoadConfig(t *testing.T) {
 					NumConsumers: 10,
 				},
 				AddMetricSuffixes:           false,
+				ProtocolVersion:             "1.0",
 				Namespace:                   "test-space",
 				ExternalLabels:              map[string]string{"key1": "value1", "key2": "value2"},
 				ClientConfig:                clientConfig,
@@ -99,6 +100,10 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "non_snappy_compression_type"),
 			errorMessage: "compression type must be snappy",
 		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_protocol_version"),
+			errorMessage: `protocol_version must be "1.0" or "2.0", got "3.0"`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -146,6 +151,20 @@ func TestDisabledTargetInfo(t *testing.T) {
 	assert.False(t, cfg.(*Config).TargetInfo.Enabled)
 }
 
+func TestProtocolVersion2(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "protocol_version_2").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+
+	assert.NoError(t, xconfmap.Validate(cfg))
+	assert.Equal(t, "2.0", cfg.(*Config).ProtocolVersion)
+}
+
 func toPtr[T any](val T) *T {
 	return &val
 }
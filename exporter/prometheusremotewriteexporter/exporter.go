@@ -19,6 +19,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
 	"github.com/prometheus/prometheus/prompb"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configretry"
@@ -40,6 +41,7 @@ type prwTelemetry interface {
 	recordTranslatedTimeSeries(ctx context.Context, numTS int)
 	recordRemoteWriteSentBatch(ctx context.Context)
 	setNumberConsumer(ctx context.Context, n int64)
+	recordWrittenStats(ctx context.Context, stats writeResponseStats)
 }
 
 type prwTelemetryOtel struct {
@@ -63,11 +65,37 @@ func (p *prwTelemetryOtel) recordTranslatedTimeSeries(ctx context.Context, numTS
 	p.telemetryBuilder.ExporterPrometheusremotewriteTranslatedTimeSeries.Add(ctx, int64(numTS), metric.WithAttributes(p.otelAttrs...))
 }
 
+func (p *prwTelemetryOtel) recordWrittenStats(ctx context.Context, stats writeResponseStats) {
+	p.telemetryBuilder.ExporterPrometheusremotewriteWrittenSamples.Add(ctx, int64(stats.samples), metric.WithAttributes(p.otelAttrs...))
+	p.telemetryBuilder.ExporterPrometheusremotewriteWrittenHistograms.Add(ctx, int64(stats.histograms), metric.WithAttributes(p.otelAttrs...))
+	p.telemetryBuilder.ExporterPrometheusremotewriteWrittenExemplars.Add(ctx, int64(stats.exemplars), metric.WithAttributes(p.otelAttrs...))
+}
+
 type buffer struct {
 	protobuf *proto.Buffer
 	snappy   []byte
 }
 
+// compress serializes the message, then compresses it with Snappy. The returned bytes are
+// only valid until the buffer is reused.
+func (buf *buffer) compress(msg proto.Message) ([]byte, error) {
+	// Uses proto.Marshal to convert the message into bytes array
+	if err := buf.protobuf.Marshal(msg); err != nil {
+		return nil, err
+	}
+	// If we don't pass a buffer large enough, Snappy Encode function will not use it and instead will allocate a new buffer.
+	// Manually grow the buffer to make sure Snappy uses it and we can re-use it afterwards.
+	maxCompressedLen := snappy.MaxEncodedLen(len(buf.protobuf.Bytes()))
+	if maxCompressedLen > len(buf.snappy) {
+		if cap(buf.snappy) < maxCompressedLen {
+			buf.snappy = make([]byte, maxCompressedLen)
+		} else {
+			buf.snappy = buf.snappy[:maxCompressedLen]
+		}
+	}
+	return snappy.Encode(buf.snappy, buf.protobuf.Bytes()), nil
+}
+
 // A reusable buffer pool for serializing protobufs and compressing them with Snappy.
 var bufferPool = sync.Pool{
 	New: func() any {
@@ -91,7 +119,9 @@ type prwExporter struct {
 	settings          component.TelemetrySettings
 	retrySettings     configretry.BackOffConfig
 	retryOnHTTP429    bool
-	wal               *prweWAL
+	protocolVersion   string
+	wal               *prweWAL[*prompb.WriteRequest]
+	walV2             *prweWAL[*writev2.Request]
 	exporterSettings  prometheusremotewrite.Settings
 	telemetry         prwTelemetry
 
@@ -157,6 +187,7 @@ func newPRWExporter(cfg *Config, set exporter.Settings) (*prwExporter, error) {
 		settings:          set.TelemetrySettings,
 		retrySettings:     cfg.BackOffConfig,
 		retryOnHTTP429:    retryOn429FeatureGate.IsEnabled(),
+		protocolVersion:   cfg.ProtocolVersion,
 		exporterSettings: prometheusremotewrite.Settings{
 			Namespace:         cfg.Namespace,
 			ExternalLabels:    sanitizedLabels,
@@ -168,7 +199,11 @@ func newPRWExporter(cfg *Config, set exporter.Settings) (*prwExporter, error) {
 		batchStatePool: sync.Pool{New: func() any { return newBatchTimeServicesState() }},
 	}
 
-	prwe.wal = newWAL(cfg.WAL, prwe.export)
+	if prwe.protocolVersion == protocolVersionV2 {
+		prwe.walV2 = newWALV2(cfg.WAL, prwe.exportV2)
+	} else {
+		prwe.wal = newWAL(cfg.WAL, prwe.export)
+	}
 	return prwe, nil
 }
 
@@ -185,6 +220,9 @@ func (prwe *prwExporter) shutdownWALIfEnabled() error {
 	if !prwe.walEnabled() {
 		return nil
 	}
+	if prwe.walV2 != nil {
+		return prwe.walV2.stop()
+	}
 	return prwe.wal.stop()
 }
 
@@ -212,6 +250,9 @@ func (prwe *prwExporter) PushMetrics(ctx context.Context, md pmetric.Metrics) er
 	case <-prwe.closeChan:
 		return errors.New("shutdown has been called")
 	default:
+		if prwe.protocolVersion == protocolVersionV2 {
+			return prwe.pushMetricsV2(ctx, md)
+		}
 
 		tsMap, err := prometheusremotewrite.FromMetrics(md, prwe.exporterSettings)
 		if err != nil {
@@ -271,7 +312,13 @@ func (prwe *prwExporter) handleExport(ctx context.Context, tsMap map[string]*pro
 
 // export sends a Snappy-compressed WriteRequest containing TimeSeries to a remote write endpoint in order
 func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteRequest) error {
-	input := make(chan *prompb.WriteRequest, len(requests))
+	return exportConcurrently(ctx, prwe.concurrency, requests, prwe.execute)
+}
+
+// exportConcurrently executes the requests with at most concurrency workers, collecting
+// their errors as permanent errors.
+func exportConcurrently[T any](ctx context.Context, concurrency int, requests []T, execute func(context.Context, T) error) error {
+	input := make(chan T, len(requests))
 	for _, request := range requests {
 		input <- request
 	}
@@ -279,7 +326,7 @@ func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteReq
 
 	var wg sync.WaitGroup
 
-	concurrencyLimit := int(math.Min(float64(prwe.concurrency), float64(len(requests))))
+	concurrencyLimit := int(math.Min(float64(concurrency), float64(len(requests))))
 	wg.Add(concurrencyLimit) // used to wait for workers to be finished
 
 	var mu sync.Mutex
@@ -298,7 +345,7 @@ func (prwe *prwExporter) export(ctx context.Context, requests []*prompb.WriteReq
 					if !ok {
 						return
 					}
-					if errExecute := prwe.execute(ctx, request); errExecute != nil {
+					if errExecute := execute(ctx, request); errExecute != nil {
 						mu.Lock()
 						errs = multierr.Append(errs, consumererror.NewPermanent(errExecute))
 						mu.Unlock()
@@ -317,23 +364,16 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 	buf.protobuf.Reset()
 	defer bufferPool.Put(buf)
 
-	// Uses proto.Marshal to convert the WriteRequest into bytes array
-	errMarshal := buf.protobuf.Marshal(writeReq)
-	if errMarshal != nil {
-		return consumererror.NewPermanent(errMarshal)
+	compressedData, errCompress := buf.compress(writeReq)
+	if errCompress != nil {
+		return consumererror.NewPermanent(errCompress)
 	}
-	// If we don't pass a buffer large enough, Snappy Encode function will not use it and instead will allocate a new buffer.
-	// Manually grow the buffer to make sure Snappy uses it and we can re-use it afterwards.
-	maxCompressedLen := snappy.MaxEncodedLen(len(buf.protobuf.Bytes()))
-	if maxCompressedLen > len(buf.snappy) {
-		if cap(buf.snappy) < maxCompressedLen {
-			buf.snappy = make([]byte, maxCompressedLen)
-		} else {
-			buf.snappy = buf.snappy[:maxCompressedLen]
-		}
-	}
-	compressedData := snappy.Encode(buf.snappy, buf.protobuf.Bytes())
+	return prwe.send(ctx, compressedData, remoteWriteProtocolV1, nil)
+}
 
+// send posts the Snappy-compressed request to the remote write endpoint, retrying on
+// recoverable errors. onSuccess, when set, is called with the response of the successful post.
+func (prwe *prwExporter) send(ctx context.Context, compressedData []byte, protocol remoteWriteProtocol, onSuccess func(*http.Response)) error {
 	// executeFunc can be used for backoff and non backoff scenarios.
 	executeFunc := func() error {
 		// check there was no timeout in the component level to avoid retries
@@ -354,8 +394,8 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 		// Add necessary headers specified by:
 		// https://cortexmetrics.io/docs/apis/#remote-api
 		req.Header.Add("Content-Encoding", "snappy")
-		req.Header.Set("Content-Type", "application/x-protobuf")
-		req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+		req.Header.Set("Content-Type", protocol.contentType)
+		req.Header.Set("X-Prometheus-Remote-Write-Version", protocol.version)
 		req.Header.Set("User-Agent", prwe.userAgentHeader)
 
 		resp, err := prwe.client.Do(req)
@@ -373,6 +413,9 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 		// Reference for different behavior according to status code:
 		// https://github.com/prometheus/prometheus/pull/2552/files#diff-ae8db9d16d8057358e49d694522e7186
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			if onSuccess != nil {
+				onSuccess(resp)
+			}
 			return nil
 		}
 
@@ -414,7 +457,7 @@ func (prwe *prwExporter) execute(ctx context.Context, writeReq *prompb.WriteRequ
 	return err
 }
 
-func (prwe *prwExporter) walEnabled() bool { return prwe.wal != nil }
+func (prwe *prwExporter) walEnabled() bool { return prwe.wal != nil || prwe.walV2 != nil }
 
 func (prwe *prwExporter) turnOnWALIfEnabled(ctx context.Context) error {
 	if !prwe.walEnabled() {
@@ -425,5 +468,8 @@ func (prwe *prwExporter) turnOnWALIfEnabled(ctx context.Context) error {
 		<-prwe.closeChan
 		cancel()
 	}()
+	if prwe.walV2 != nil {
+		return prwe.walV2.run(cancelCtx)
+	}
 	return prwe.wal.run(cancelCtx)
 }
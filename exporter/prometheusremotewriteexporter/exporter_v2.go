@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewriteexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter"
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+)
+
+// remoteWriteProtocol holds the headers identifying the version of the remote write protocol
+// of the requests.
+type remoteWriteProtocol struct {
+	contentType string
+	version     string
+}
+
+var (
+	// remoteWriteProtocolV1 is the Remote Write 1.0 protocol, see
+	// https://prometheus.io/docs/specs/prw/remote_write_spec/
+	remoteWriteProtocolV1 = remoteWriteProtocol{
+		contentType: "application/x-protobuf",
+		version:     "0.1.0",
+	}
+	// remoteWriteProtocolV2 is the Remote Write 2.0 protocol, see
+	// https://prometheus.io/docs/specs/prw/remote_write_spec_2_0/
+	remoteWriteProtocolV2 = remoteWriteProtocol{
+		contentType: "application/x-protobuf;proto=io.prometheus.write.v2.Request",
+		version:     "2.0.0",
+	}
+)
+
+// Headers of the responses of the Remote Write 2.0 endpoints reporting the number of
+// samples, histograms and exemplars written.
+const (
+	writtenSamplesHeader    = "X-Prometheus-Remote-Write-Samples-Written"
+	writtenHistogramsHeader = "X-Prometheus-Remote-Write-Histograms-Written"
+	writtenExemplarsHeader  = "X-Prometheus-Remote-Write-Exemplars-Written"
+)
+
+// writeResponseStats holds the number of samples, histograms and exemplars of a request,
+// either sent or reported as written by the endpoint.
+type writeResponseStats struct {
+	samples    int
+	histograms int
+	exemplars  int
+}
+
+// pushMetricsV2 converts metrics to Prometheus Remote Write 2.0 TimeSeries and sends them
+// to the remote endpoint.
+func (prwe *prwExporter) pushMetricsV2(ctx context.Context, md pmetric.Metrics) error {
+	tsMap, symbolsTable, err := prometheusremotewrite.FromMetricsV2(md, prwe.exporterSettings)
+	if err != nil {
+		prwe.telemetry.recordTranslationFailure(ctx)
+		prwe.settings.Logger.Debug("failed to translate metrics, exporting remaining metrics", zap.Error(err), zap.Int("translated", len(tsMap)))
+	}
+
+	prwe.telemetry.recordTranslatedTimeSeries(ctx, len(tsMap))
+
+	// Call export even if a conversion error, since there may be points that were successfully converted.
+	return prwe.handleExportV2(ctx, symbolsTable.Symbols(), tsMap)
+}
+
+func (prwe *prwExporter) handleExportV2(ctx context.Context, symbols []string, tsMap map[string]*writev2.TimeSeries) error {
+	// There are no metrics to export, so return.
+	if len(tsMap) == 0 {
+		return nil
+	}
+
+	state := prwe.batchStatePool.Get().(*batchTimeSeriesState)
+	defer prwe.batchStatePool.Put(state)
+	// Calls the helper function to convert and batch the TsMap to the desired format
+	requests, err := batchTimeSeriesV2(tsMap, symbols, prwe.maxBatchSizeBytes, state)
+	if err != nil {
+		return err
+	}
+	if prwe.walV2 == nil {
+		// Perform a direct export otherwise.
+		return prwe.exportV2(ctx, requests)
+	}
+
+	// Otherwise the WAL is enabled, and just persist the requests to the WAL
+	// and they'll be exported in another goroutine to the RemoteWrite endpoint.
+	if err = prwe.walV2.persistToWAL(requests); err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return nil
+}
+
+// exportV2 sends Snappy-compressed Remote Write 2.0 requests to a remote write endpoint.
+func (prwe *prwExporter) exportV2(ctx context.Context, requests []*writev2.Request) error {
+	return exportConcurrently(ctx, prwe.concurrency, requests, prwe.executeV2)
+}
+
+func (prwe *prwExporter) executeV2(ctx context.Context, writeReq *writev2.Request) error {
+	buf := bufferPool.Get().(*buffer)
+	buf.protobuf.Reset()
+	defer bufferPool.Put(buf)
+
+	compressedData, errCompress := buf.compress(writeReq)
+	if errCompress != nil {
+		return consumererror.NewPermanent(errCompress)
+	}
+	return prwe.send(ctx, compressedData, remoteWriteProtocolV2, func(resp *http.Response) {
+		prwe.handleWriteResponseStats(ctx, resp.Header, writeReq)
+	})
+}
+
+// handleWriteResponseStats records the number of samples, histograms and exemplars the
+// endpoint reported as written, and warns when some of the sent ones were not written.
+func (prwe *prwExporter) handleWriteResponseStats(ctx context.Context, header http.Header, writeReq *writev2.Request) {
+	written, confirmed, err := parseWriteResponseStats(header)
+	if err != nil {
+		prwe.settings.Logger.Warn("failed to parse the written stats of the remote write response", zap.Error(err))
+		return
+	}
+	if !confirmed {
+		// The endpoint does not report what it wrote, e.g. it only implements Remote Write 1.0.
+		return
+	}
+	prwe.telemetry.recordWrittenStats(ctx, written)
+
+	sent := requestStats(writeReq)
+	if written.samples < sent.samples || written.histograms < sent.histograms || written.exemplars < sent.exemplars {
+		prwe.settings.Logger.Warn("remote write endpoint did not write all the sent data",
+			zap.Int("sent_samples", sent.samples), zap.Int("written_samples", written.samples),
+			zap.Int("sent_histograms", sent.histograms), zap.Int("written_histograms", written.histograms),
+			zap.Int("sent_exemplars", sent.exemplars), zap.Int("written_exemplars", written.exemplars),
+		)
+	}
+}
+
+// parseWriteResponseStats parses the written stats headers of a Remote Write 2.0 response.
+// The stats are confirmed when at least one of the headers is set, the missing ones are
+// then zero.
+func parseWriteResponseStats(header http.Header) (stats writeResponseStats, confirmed bool, err error) {
+	for _, h := range []struct {
+		name  string
+		value *int
+	}{
+		{name: writtenSamplesHeader, value: &stats.samples},
+		{name: writtenHistogramsHeader, value: &stats.histograms},
+		{name: writtenExemplarsHeader, value: &stats.exemplars},
+	} {
+		v := header.Get(h.name)
+		if v == "" {
+			continue
+		}
+		if *h.value, err = strconv.Atoi(v); err != nil {
+			return writeResponseStats{}, false, fmt.Errorf("invalid %s header %q: %w", h.name, v, err)
+		}
+		confirmed = true
+	}
+	return stats, confirmed, nil
+}
+
+// requestStats returns the number of samples, histograms and exemplars of the request.
+func requestStats(writeReq *writev2.Request) writeResponseStats {
+	var stats writeResponseStats
+	for i := range writeReq.Timeseries {
+		ts := &writeReq.Timeseries[i]
+		stats.samples += len(ts.Samples)
+		stats.histograms += len(ts.Histograms)
+		stats.exemplars += len(ts.Exemplars)
+	}
+	return stats
+}
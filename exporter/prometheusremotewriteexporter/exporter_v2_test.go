@@ -0,0 +1,308 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewriteexporter
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter/internal/metadatatest"
+)
+
+func TestPushMetricsV2(t *testing.T) {
+	start := pcommon.NewTimestampFromTime(time.Now().Add(-time.Minute))
+	ts := pcommon.NewTimestampFromTime(time.Now())
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("test_requests")
+	sum.SetDescription("The number of requests.")
+	sum.SetEmptySum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sumDP := sum.Sum().DataPoints().AppendEmpty()
+	sumDP.SetStartTimestamp(start)
+	sumDP.SetTimestamp(ts)
+	sumDP.SetIntValue(42)
+	exemplar := sumDP.Exemplars().AppendEmpty()
+	exemplar.SetTimestamp(ts)
+	exemplar.SetIntValue(1)
+	exemplar.SetTraceID(pcommon.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10})
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("test_latency")
+	histogram.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	histogramDP := histogram.ExponentialHistogram().DataPoints().AppendEmpty()
+	histogramDP.SetStartTimestamp(start)
+	histogramDP.SetTimestamp(ts)
+	histogramDP.SetCount(2)
+	histogramDP.SetSum(3)
+	histogramDP.Positive().BucketCounts().FromRaw([]uint64{2})
+
+	requests := make(chan *writev2.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+		assert.Equal(t, "application/x-protobuf;proto=io.prometheus.write.v2.Request", r.Header.Get("Content-Type"))
+		assert.Equal(t, "2.0.0", r.Header.Get("X-Prometheus-Remote-Write-Version"))
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		dest, err := snappy.Decode(nil, body)
+		assert.NoError(t, err)
+		req := &writev2.Request{}
+		assert.NoError(t, proto.Unmarshal(dest, req))
+		requests <- req
+
+		// The endpoint reports it did not write the exemplar.
+		w.Header().Set(writtenSamplesHeader, "1")
+		w.Header().Set(writtenHistogramsHeader, "1")
+		w.Header().Set(writtenExemplarsHeader, "0")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = server.URL
+	cfg.ProtocolVersion = protocolVersionV2
+	cfg.TargetInfo.Enabled = false
+	cfg.AddMetricSuffixes = false
+	cfg.SendMetadata = true
+
+	testTel := componenttest.NewTelemetry()
+	t.Cleanup(func() {
+		require.NoError(t, testTel.Shutdown(context.Background()))
+	})
+	prwe, err := newPRWExporter(cfg, metadatatest.NewSettings(testTel))
+	require.NoError(t, err)
+	require.NoError(t, prwe.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, prwe.Shutdown(context.Background()))
+	})
+
+	require.NoError(t, prwe.PushMetrics(context.Background(), md))
+	received := <-requests
+	require.Len(t, received.Timeseries, 2)
+
+	for _, series := range received.Timeseries {
+		assert.Equal(t, convertTimeStampForTest(start), series.CreatedTimestamp)
+		require.Len(t, series.LabelsRefs, 2)
+		assert.Equal(t, "__name__", received.Symbols[series.LabelsRefs[0]])
+
+		switch name := received.Symbols[series.LabelsRefs[1]]; name {
+		case "test_requests":
+			assert.Equal(t, []writev2.Sample{{Value: 42, Timestamp: convertTimeStampForTest(ts)}}, series.Samples)
+			assert.Equal(t, writev2.Metadata_METRIC_TYPE_COUNTER, series.Metadata.Type)
+			assert.Equal(t, "The number of requests.", received.Symbols[series.Metadata.HelpRef])
+			require.Len(t, series.Exemplars, 1)
+			require.Len(t, series.Exemplars[0].LabelsRefs, 2)
+			assert.Equal(t, "trace_id", received.Symbols[series.Exemplars[0].LabelsRefs[0]])
+			assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", received.Symbols[series.Exemplars[0].LabelsRefs[1]])
+		case "test_latency":
+			assert.Empty(t, series.Samples)
+			require.Len(t, series.Histograms, 1)
+			assert.Equal(t, uint64(2), series.Histograms[0].GetCountInt())
+			assert.Equal(t, writev2.Metadata_METRIC_TYPE_HISTOGRAM, series.Metadata.Type)
+		default:
+			t.Errorf("unexpected series %q", name)
+		}
+	}
+
+	attrs := attribute.NewSet(attribute.String("exporter", "prometheusremotewrite"), attribute.String("endpoint", server.URL))
+	metadatatest.AssertEqualExporterPrometheusremotewriteWrittenSamples(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1, Attributes: attrs}},
+		metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualExporterPrometheusremotewriteWrittenHistograms(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1, Attributes: attrs}},
+		metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualExporterPrometheusremotewriteWrittenExemplars(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 0, Attributes: attrs}},
+		metricdatatest.IgnoreTimestamp())
+}
+
+func TestPushMetricsV2WithoutMetadata(t *testing.T) {
+	md := pmetric.NewMetrics()
+	gauge := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	gauge.SetName("test_gauge")
+	gauge.SetDescription("A gauge.")
+	dp := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(time.Now()))
+	dp.SetIntValue(1)
+
+	requests := make(chan *writev2.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		dest, err := snappy.Decode(nil, body)
+		assert.NoError(t, err)
+		req := &writev2.Request{}
+		assert.NoError(t, proto.Unmarshal(dest, req))
+		requests <- req
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.ClientConfig.Endpoint = server.URL
+	cfg.ProtocolVersion = protocolVersionV2
+	cfg.TargetInfo.Enabled = false
+
+	testTel := componenttest.NewTelemetry()
+	t.Cleanup(func() {
+		require.NoError(t, testTel.Shutdown(context.Background()))
+	})
+	prwe, err := newPRWExporter(cfg, metadatatest.NewSettings(testTel))
+	require.NoError(t, err)
+	require.NoError(t, prwe.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, prwe.Shutdown(context.Background()))
+	})
+
+	// send_metadata is false by default, the time series are sent without metadata
+	require.NoError(t, prwe.PushMetrics(context.Background(), md))
+	received := <-requests
+	require.Len(t, received.Timeseries, 1)
+	assert.Equal(t, writev2.Metadata{}, received.Timeseries[0].Metadata)
+	assert.NotContains(t, received.Symbols, "A gauge.")
+}
+
+func convertTimeStampForTest(ts pcommon.Timestamp) int64 {
+	return ts.AsTime().UnixMilli()
+}
+
+func Test_parseWriteResponseStats(t *testing.T) {
+	tests := []struct {
+		name          string
+		header        http.Header
+		wantStats     writeResponseStats
+		wantConfirmed bool
+		wantErr       bool
+	}{
+		{
+			name:   "no_headers",
+			header: http.Header{},
+		},
+		{
+			name: "all_headers",
+			header: http.Header{
+				writtenSamplesHeader:    []string{"10"},
+				writtenHistogramsHeader: []string{"2"},
+				writtenExemplarsHeader:  []string{"1"},
+			},
+			wantStats:     writeResponseStats{samples: 10, histograms: 2, exemplars: 1},
+			wantConfirmed: true,
+		},
+		{
+			name: "samples_header_only",
+			header: http.Header{
+				writtenSamplesHeader: []string{"3"},
+			},
+			wantStats:     writeResponseStats{samples: 3},
+			wantConfirmed: true,
+		},
+		{
+			name: "invalid_header",
+			header: http.Header{
+				writtenSamplesHeader: []string{"many"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, confirmed, err := parseWriteResponseStats(tt.header)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantStats, stats)
+			assert.Equal(t, tt.wantConfirmed, confirmed)
+		})
+	}
+}
+
+func Test_requestStats(t *testing.T) {
+	req := &writev2.Request{
+		Timeseries: []writev2.TimeSeries{
+			{
+				Samples:   []writev2.Sample{{Value: 1}, {Value: 2}},
+				Exemplars: []writev2.Exemplar{{Value: 1}},
+			},
+			{
+				Histograms: []writev2.Histogram{{Sum: 1}},
+			},
+		},
+	}
+	assert.Equal(t, writeResponseStats{samples: 2, histograms: 1, exemplars: 1}, requestStats(req))
+}
+
+func TestExportV2WithWALEnabled(t *testing.T) {
+	received := make(chan *writev2.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		dest, err := snappy.Decode(nil, body)
+		assert.NoError(t, err)
+		req := &writev2.Request{}
+		assert.NoError(t, proto.Unmarshal(dest, req))
+		received <- req
+	}))
+	defer server.Close()
+
+	clientConfig := confighttp.NewDefaultClientConfig()
+	clientConfig.Endpoint = server.URL
+	cfg := &Config{
+		ClientConfig:     clientConfig,
+		ProtocolVersion:  protocolVersionV2,
+		RemoteWriteQueue: RemoteWriteQueue{NumConsumers: 1},
+		WAL: &WALConfig{
+			Directory:         t.TempDir(),
+			BufferSize:        1,
+			TruncateFrequency: 10 * time.Millisecond,
+		},
+		TargetInfo: &TargetInfo{}, // Declared just to avoid nil pointer dereference.
+	}
+	testTel := componenttest.NewTelemetry()
+	prwe, err := newPRWExporter(cfg, metadatatest.NewSettings(testTel))
+	require.NoError(t, err)
+	require.Nil(t, prwe.wal)
+	require.NotNil(t, prwe.walV2)
+	require.NoError(t, prwe.Start(context.Background(), componenttest.NewNopHost()))
+
+	tsMap := map[string]*writev2.TimeSeries{
+		"0": {
+			LabelsRefs: []uint32{1, 2},
+			Samples:    []writev2.Sample{{Value: 1, Timestamp: 100}},
+		},
+	}
+	require.NoError(t, prwe.handleExportV2(context.Background(), []string{"", "__name__", "test"}, tsMap))
+
+	select {
+	case req := <-received:
+		assert.Equal(t, []string{"", "__name__", "test"}, req.Symbols)
+		require.Len(t, req.Timeseries, 1)
+		assert.Equal(t, []writev2.Sample{{Value: 1, Timestamp: 100}}, req.Timeseries[0].Samples)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the request exported from the WAL")
+	}
+	require.NoError(t, prwe.Shutdown(context.Background()))
+	require.NoError(t, testTel.Shutdown(context.Background()))
+}
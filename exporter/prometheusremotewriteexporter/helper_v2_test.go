@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewriteexporter
+
+import (
+	"testing"
+
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_batchTimeSeriesV2(t *testing.T) {
+	symbols := []string{"", "__name__", "test_1", "test_2", "trace_id", "abc", "help", "unit"}
+	tsMap := map[string]*writev2.TimeSeries{
+		"1": {
+			LabelsRefs: []uint32{1, 2},
+			Samples:    []writev2.Sample{{Value: 1, Timestamp: 200}, {Value: 2, Timestamp: 100}},
+			Exemplars:  []writev2.Exemplar{{LabelsRefs: []uint32{4, 5}, Value: 1}},
+			Metadata:   writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_COUNTER, HelpRef: 6, UnitRef: 7},
+		},
+		"2": {
+			LabelsRefs: []uint32{1, 3},
+			Samples:    []writev2.Sample{{Value: 3, Timestamp: 100}},
+		},
+	}
+
+	t.Run("single_batch", func(t *testing.T) {
+		state := newBatchTimeServicesState()
+		requests, err := batchTimeSeriesV2(tsMap, symbols, 100000, state)
+		require.NoError(t, err)
+		require.Len(t, requests, 1)
+		assert.Len(t, requests[0].Timeseries, 2)
+		for _, ts := range requests[0].Timeseries {
+			assertSeriesSymbols(t, requests[0].Symbols, ts)
+		}
+		assert.Equal(t, 2, state.nextRequestBufferSize)
+	})
+
+	t.Run("batch_per_series", func(t *testing.T) {
+		state := newBatchTimeServicesState()
+		requests, err := batchTimeSeriesV2(tsMap, symbols, 10, state)
+		require.NoError(t, err)
+		require.Len(t, requests, 2)
+		for _, req := range requests {
+			require.Len(t, req.Timeseries, 1)
+			assertSeriesSymbols(t, req.Symbols, req.Timeseries[0])
+			// Each request only holds the symbols of its series.
+			assert.Equal(t, "", req.Symbols[0])
+			if req.Symbols[req.Timeseries[0].LabelsRefs[1]] == "test_2" {
+				assert.Equal(t, []string{"", "__name__", "test_2"}, req.Symbols)
+			}
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		_, err := batchTimeSeriesV2(map[string]*writev2.TimeSeries{}, symbols, 100, newBatchTimeServicesState())
+		assert.Error(t, err)
+	})
+}
+
+// assertSeriesSymbols asserts that the series of the batch reference the same symbols as in tsMap.
+func assertSeriesSymbols(t *testing.T, symbols []string, ts writev2.TimeSeries) {
+	require.Len(t, ts.LabelsRefs, 2)
+	assert.Equal(t, "__name__", symbols[ts.LabelsRefs[0]])
+	switch symbols[ts.LabelsRefs[1]] {
+	case "test_1":
+		// The samples are ordered by timestamp.
+		assert.Equal(t, []writev2.Sample{{Value: 2, Timestamp: 100}, {Value: 1, Timestamp: 200}}, ts.Samples)
+		require.Len(t, ts.Exemplars, 1)
+		assert.Equal(t, "trace_id", symbols[ts.Exemplars[0].LabelsRefs[0]])
+		assert.Equal(t, "abc", symbols[ts.Exemplars[0].LabelsRefs[1]])
+		assert.Equal(t, "help", symbols[ts.Metadata.HelpRef])
+		assert.Equal(t, "unit", symbols[ts.Metadata.UnitRef])
+	case "test_2":
+		assert.Equal(t, []writev2.Sample{{Value: 3, Timestamp: 100}}, ts.Samples)
+		assert.Zero(t, ts.Metadata.HelpRef)
+	default:
+		t.Errorf("unexpected series %q", symbols[ts.LabelsRefs[1]])
+	}
+}
@@ -30,6 +30,9 @@ type TelemetryBuilder struct {
 	ExporterPrometheusremotewriteFailedTranslations   metric.Int64Counter
 	ExporterPrometheusremotewriteSentBatches          metric.Int64Counter
 	ExporterPrometheusremotewriteTranslatedTimeSeries metric.Int64Counter
+	ExporterPrometheusremotewriteWrittenExemplars     metric.Int64Counter
+	ExporterPrometheusremotewriteWrittenHistograms    metric.Int64Counter
+	ExporterPrometheusremotewriteWrittenSamples       metric.Int64Counter
 }
 
 // TelemetryBuilderOption applies changes to default builder.
@@ -85,5 +88,23 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("1"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ExporterPrometheusremotewriteWrittenExemplars, err = builder.meter.Int64Counter(
+		"otelcol_exporter_prometheusremotewrite_written_exemplars",
+		metric.WithDescription("Number of exemplars that the remote write 2.0 endpoint reported as written"),
+		metric.WithUnit("{exemplar}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ExporterPrometheusremotewriteWrittenHistograms, err = builder.meter.Int64Counter(
+		"otelcol_exporter_prometheusremotewrite_written_histograms",
+		metric.WithDescription("Number of native histogram samples that the remote write 2.0 endpoint reported as written"),
+		metric.WithUnit("{histogram}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ExporterPrometheusremotewriteWrittenSamples, err = builder.meter.Int64Counter(
+		"otelcol_exporter_prometheusremotewrite_written_samples",
+		metric.WithDescription("Number of samples that the remote write 2.0 endpoint reported as written"),
+		metric.WithUnit("{sample}"),
+	)
+	errs = errors.Join(errs, err)
 	return &builder, errs
 }
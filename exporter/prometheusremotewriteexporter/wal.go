@@ -14,19 +14,31 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/prometheus/prometheus/prompb"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
 	"github.com/tidwall/wal"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 )
 
-type prweWAL struct {
+// walRequest is a remote write request persisted in the WAL, either a prompb.WriteRequest
+// of the Remote Write 1.0 protocol or a writev2.Request of the Remote Write 2.0 protocol.
+type walRequest interface {
+	*prompb.WriteRequest | *writev2.Request
+	proto.Message
+}
+
+type prweWAL[T walRequest] struct {
 	wg        sync.WaitGroup // wg waits for the go routines to finish.
 	mu        sync.Mutex     // mu protects the fields below.
 	wal       *wal.Log
 	walConfig *WALConfig
 	walPath   string
+	// subdirectory is the subdirectory of walConfig.Directory holding the WAL, each
+	// protocol version has its own as their requests are encoded differently.
+	subdirectory string
 
-	exportSink func(ctx context.Context, reqL []*prompb.WriteRequest) error
+	newRequest func() T
+	exportSink func(ctx context.Context, reqL []T) error
 
 	stopOnce  sync.Once
 	stopChan  chan struct{}
@@ -38,6 +50,9 @@ type prweWAL struct {
 const (
 	defaultWALBufferSize        = 300
 	defaultWALTruncateFrequency = 1 * time.Minute
+
+	walSubdirectory   = "prom_remotewrite"
+	walSubdirectoryV2 = "prom_remotewrite_v2"
 )
 
 type WALConfig struct {
@@ -60,25 +75,39 @@ func (wc *WALConfig) truncateFrequency() time.Duration {
 	return defaultWALTruncateFrequency
 }
 
-func newWAL(walConfig *WALConfig, exportSink func(context.Context, []*prompb.WriteRequest) error) *prweWAL {
+func newWAL(walConfig *WALConfig, exportSink func(context.Context, []*prompb.WriteRequest) error) *prweWAL[*prompb.WriteRequest] {
+	return newWALWithRequest(walConfig, walSubdirectory, func() *prompb.WriteRequest { return new(prompb.WriteRequest) }, exportSink)
+}
+
+func newWALV2(walConfig *WALConfig, exportSink func(context.Context, []*writev2.Request) error) *prweWAL[*writev2.Request] {
+	return newWALWithRequest(walConfig, walSubdirectoryV2, func() *writev2.Request { return new(writev2.Request) }, exportSink)
+}
+
+func newWALWithRequest[T walRequest](walConfig *WALConfig, subdirectory string, newRequest func() T, exportSink func(context.Context, []T) error) *prweWAL[T] {
 	if walConfig == nil {
 		// There are cases for which the WAL can be disabled.
 		// TODO: Perhaps log that the WAL wasn't enabled.
 		return nil
 	}
 
-	return &prweWAL{
-		exportSink: exportSink,
-		walConfig:  walConfig,
-		stopChan:   make(chan struct{}),
-		rNotify:    make(chan struct{}),
-		rWALIndex:  &atomic.Uint64{},
-		wWALIndex:  &atomic.Uint64{},
+	return &prweWAL[T]{
+		subdirectory: subdirectory,
+		newRequest:   newRequest,
+		exportSink:   exportSink,
+		walConfig:    walConfig,
+		stopChan:     make(chan struct{}),
+		rNotify:      make(chan struct{}),
+		rWALIndex:    &atomic.Uint64{},
+		wWALIndex:    &atomic.Uint64{},
 	}
 }
 
 func (wc *WALConfig) createWAL() (*wal.Log, string, error) {
-	walPath := filepath.Join(wc.Directory, "prom_remotewrite")
+	return wc.createWALIn(walSubdirectory)
+}
+
+func (wc *WALConfig) createWALIn(subdirectory string) (*wal.Log, string, error) {
+	walPath := filepath.Join(wc.Directory, subdirectory)
 	log, err := wal.Open(walPath, &wal.Options{
 		SegmentCacheSize: wc.bufferSize(),
 		NoCopy:           true,
@@ -95,7 +124,7 @@ var (
 )
 
 // retrieveWALIndices queries the WriteAheadLog for its current first and last indices.
-func (prweWAL *prweWAL) retrieveWALIndices() (err error) {
+func (prweWAL *prweWAL[T]) retrieveWALIndices() (err error) {
 	prweWAL.mu.Lock()
 	defer prweWAL.mu.Unlock()
 
@@ -104,7 +133,7 @@ func (prweWAL *prweWAL) retrieveWALIndices() (err error) {
 		return err
 	}
 
-	log, walPath, err := prweWAL.walConfig.createWAL()
+	log, walPath, err := prweWAL.walConfig.createWALIn(prweWAL.subdirectory)
 	if err != nil {
 		return err
 	}
@@ -126,7 +155,7 @@ func (prweWAL *prweWAL) retrieveWALIndices() (err error) {
 	return nil
 }
 
-func (prweWAL *prweWAL) stop() error {
+func (prweWAL *prweWAL[T]) stop() error {
 	err := errAlreadyClosed
 	prweWAL.stopOnce.Do(func() {
 		close(prweWAL.stopChan)
@@ -137,7 +166,7 @@ func (prweWAL *prweWAL) stop() error {
 }
 
 // run begins reading from the WAL until prwe.stopChan is closed.
-func (prweWAL *prweWAL) run(ctx context.Context) (err error) {
+func (prweWAL *prweWAL[T]) run(ctx context.Context) (err error) {
 	var logger *zap.Logger
 	logger, err = loggerFromContext(ctx)
 	if err != nil {
@@ -184,13 +213,13 @@ func (prweWAL *prweWAL) run(ctx context.Context) (err error) {
 	return nil
 }
 
-// continuallyPopWALThenExport reads a prompb.WriteRequest proto encoded blob from the WAL, and moves
+// continuallyPopWALThenExport reads a request proto encoded blob from the WAL, and moves
 // the WAL's front index forward until either the read buffer period expires or the maximum
 // buffer size is exceeded. When either of the two conditions are matched, it then exports
 // the requests to the Remote-Write endpoint, and then truncates the head of the WAL to where
 // it last read from.
-func (prweWAL *prweWAL) continuallyPopWALThenExport(ctx context.Context, signalStart func()) (err error) {
-	var reqL []*prompb.WriteRequest
+func (prweWAL *prweWAL[T]) continuallyPopWALThenExport(ctx context.Context, signalStart func()) (err error) {
+	var reqL []T
 	defer func() {
 		// Keeping it within a closure to ensure that the later
 		// updated value of reqL is always flushed to disk.
@@ -222,7 +251,7 @@ func (prweWAL *prweWAL) continuallyPopWALThenExport(ctx context.Context, signalS
 		default:
 		}
 
-		var req *prompb.WriteRequest
+		var req T
 		req, err = prweWAL.readPrompbFromWAL(ctx, prweWAL.rWALIndex.Load())
 		if err != nil {
 			return err
@@ -253,7 +282,7 @@ func (prweWAL *prweWAL) continuallyPopWALThenExport(ctx context.Context, signalS
 	}
 }
 
-func (prweWAL *prweWAL) closeWAL() error {
+func (prweWAL *prweWAL[T]) closeWAL() error {
 	if prweWAL.wal != nil {
 		err := prweWAL.wal.Close()
 		prweWAL.wal = nil
@@ -262,7 +291,7 @@ func (prweWAL *prweWAL) closeWAL() error {
 	return nil
 }
 
-func (prweWAL *prweWAL) syncAndTruncateFront() error {
+func (prweWAL *prweWAL[T]) syncAndTruncateFront() error {
 	prweWAL.mu.Lock()
 	defer prweWAL.mu.Unlock()
 
@@ -282,7 +311,7 @@ func (prweWAL *prweWAL) syncAndTruncateFront() error {
 	return nil
 }
 
-func (prweWAL *prweWAL) exportThenFrontTruncateWAL(ctx context.Context, reqL []*prompb.WriteRequest) error {
+func (prweWAL *prweWAL[T]) exportThenFrontTruncateWAL(ctx context.Context, reqL []T) error {
 	if len(reqL) == 0 {
 		return nil
 	}
@@ -303,7 +332,7 @@ func (prweWAL *prweWAL) exportThenFrontTruncateWAL(ctx context.Context, reqL []*
 // persistToWAL is the routine that'll be hooked into the exporter's receiving side and it'll
 // write them to the Write-Ahead-Log so that shutdowns won't lose data, and that the routine that
 // reads from the WAL can then process the previously serialized requests.
-func (prweWAL *prweWAL) persistToWAL(requests []*prompb.WriteRequest) error {
+func (prweWAL *prweWAL[T]) persistToWAL(requests []T) error {
 	prweWAL.mu.Lock()
 	defer prweWAL.mu.Unlock()
 
@@ -326,7 +355,7 @@ func (prweWAL *prweWAL) persistToWAL(requests []*prompb.WriteRequest) error {
 	return prweWAL.wal.WriteBatch(batch)
 }
 
-func (prweWAL *prweWAL) readPrompbFromWAL(ctx context.Context, index uint64) (wreq *prompb.WriteRequest, err error) {
+func (prweWAL *prweWAL[T]) readPrompbFromWAL(ctx context.Context, index uint64) (wreq T, err error) {
 	var protoBlob []byte
 	for i := 0; i < 12; i++ {
 		// Firstly check if we've been terminated, then exit if so.
@@ -348,7 +377,7 @@ func (prweWAL *prweWAL) readPrompbFromWAL(ctx context.Context, index uint64) (wr
 		}
 		protoBlob, err = prweWAL.wal.Read(index)
 		if err == nil { // The read succeeded.
-			req := new(prompb.WriteRequest)
+			req := prweWAL.newRequest()
 			if err = proto.Unmarshal(protoBlob, req); err != nil {
 				return nil, err
 			}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"sort"
 	"testing"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
 	"github.com/prometheus/prometheus/prompb"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
@@ -161,6 +163,59 @@ func TestWAL_persist(t *testing.T) {
 	require.Equal(t, reqLFromWAL[1], reqL[1])
 }
 
+func TestWALV2_persist(t *testing.T) {
+	// Unit tests that Remote Write 2.0 requests written to the WAL persist.
+	config := &WALConfig{Directory: t.TempDir()}
+
+	pwal := newWALV2(config, func(context.Context, []*writev2.Request) error { return nil })
+	require.NotNil(t, pwal)
+
+	reqL := []*writev2.Request{
+		{
+			Symbols: []string{"", "ts1l1", "ts1k1"},
+			Timeseries: []writev2.TimeSeries{
+				{
+					LabelsRefs: []uint32{1, 2},
+					Samples:    []writev2.Sample{{Value: 1, Timestamp: 100}},
+				},
+			},
+		},
+		{
+			Symbols: []string{"", "ts2l1", "ts2k1"},
+			Timeseries: []writev2.TimeSeries{
+				{
+					LabelsRefs:       []uint32{1, 2},
+					Samples:          []writev2.Sample{{Value: 2, Timestamp: 200}},
+					CreatedTimestamp: 50,
+				},
+			},
+		},
+	}
+
+	ctx := context.Background()
+	require.NoError(t, pwal.retrieveWALIndices())
+	t.Cleanup(func() {
+		assert.NoError(t, pwal.stop())
+	})
+	// Each protocol version has its own WAL.
+	assert.Equal(t, filepath.Join(config.Directory, walSubdirectoryV2), pwal.walPath)
+
+	require.NoError(t, pwal.persistToWAL(reqL))
+
+	start, err := pwal.wal.FirstIndex()
+	require.NoError(t, err)
+	end, err := pwal.wal.LastIndex()
+	require.NoError(t, err)
+
+	var reqLFromWAL []*writev2.Request
+	for i := start; i <= end; i++ {
+		req, err := pwal.readPrompbFromWAL(ctx, i)
+		require.NoError(t, err)
+		reqLFromWAL = append(reqLFromWAL, req)
+	}
+	require.Equal(t, reqL, reqLFromWAL)
+}
+
 func TestExportWithWALEnabled(t *testing.T) {
 	cfg := &Config{
 		WAL: &WALConfig{
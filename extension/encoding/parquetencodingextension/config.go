@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+)
+
+const (
+	compressionNone   = "none"
+	compressionSnappy = "snappy"
+	compressionGzip   = "gzip"
+	compressionZstd   = "zstd"
+	compressionLz4    = "lz4"
+	compressionBrotli = "brotli"
+
+	defaultRowGroupSize = 100_000
+)
+
+var compressionCodecs = map[string]compress.Compression{
+	compressionNone:   compress.Codecs.Uncompressed,
+	compressionSnappy: compress.Codecs.Snappy,
+	compressionGzip:   compress.Codecs.Gzip,
+	compressionZstd:   compress.Codecs.Zstd,
+	compressionLz4:    compress.Codecs.Lz4Raw,
+	compressionBrotli: compress.Codecs.Brotli,
+}
+
+var _ xconfmap.Validator = (*Config)(nil)
+
+type Config struct {
+	// PromotedAttributes lists the attributes that are copied to dedicated columns,
+	// so that they can be filtered on without scanning the attribute maps.
+	PromotedAttributes PromotedAttributes `mapstructure:"promoted_attributes"`
+	// RowGroupSize is the maximum number of rows in a row group.
+	RowGroupSize int64 `mapstructure:"row_group_size"`
+	// Compression is the codec used to compress the column chunks.
+	Compression string `mapstructure:"compression"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+type PromotedAttributes struct {
+	// Resource lists the resource attributes promoted to a `resource_<name>` column.
+	Resource []string `mapstructure:"resource"`
+	// Record lists the log record, span or data point attributes promoted to an `attribute_<name>` column.
+	Record []string `mapstructure:"record"`
+}
+
+func (c *Config) Validate() error {
+	if c.RowGroupSize <= 0 {
+		return errors.New("row_group_size must be greater than 0")
+	}
+	if _, ok := compressionCodecs[c.Compression]; !ok {
+		return fmt.Errorf("unsupported compression %q", c.Compression)
+	}
+
+	columns := map[string]string{}
+	for _, name := range fixedColumnNames() {
+		columns[name] = ""
+	}
+	for _, attr := range c.PromotedAttributes.promoted() {
+		if attr.key == "" {
+			return errors.New("promoted attribute names must not be empty")
+		}
+		if other, found := columns[attr.column]; found {
+			if other == "" {
+				return fmt.Errorf("promoted attribute %q conflicts with the %q column", attr.key, attr.column)
+			}
+			return fmt.Errorf("promoted attributes %q and %q both map to the %q column", other, attr.key, attr.column)
+		}
+		columns[attr.column] = attr.key
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr string
+	}{
+		{
+			id: component.NewIDWithName(metadata.Type, ""),
+			expected: &Config{
+				RowGroupSize: defaultRowGroupSize,
+				Compression:  compressionZstd,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				PromotedAttributes: PromotedAttributes{
+					Resource: []string{"service.name", "host.name"},
+					Record:   []string{"http.route"},
+				},
+				RowGroupSize: 5000,
+				Compression:  compressionSnappy,
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_compression"),
+			expectedErr: `unsupported compression "lzo"`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_row_group_size"),
+			expectedErr: "row_group_size must be greater than 0",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "conflicting_columns"),
+			expectedErr: `promoted attributes "http.route" and "http_route" both map to the "attribute_http_route" column`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "reserved_column"),
+			expectedErr: `promoted attribute "schema.url" conflicts with the "resource_schema_url" column`,
+		},
+	}
+
+	for _, tt := range tests {
+		name := strings.ReplaceAll(tt.id.String(), "/", "_")
+		t.Run(name, func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			err = xconfmap.Validate(cfg)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, cfg)
+			}
+		})
+	}
+}
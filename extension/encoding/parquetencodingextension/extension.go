@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding"
+)
+
+var (
+	_ encoding.LogsMarshalerExtension      = (*parquetExtension)(nil)
+	_ encoding.LogsUnmarshalerExtension    = (*parquetExtension)(nil)
+	_ encoding.MetricsMarshalerExtension   = (*parquetExtension)(nil)
+	_ encoding.MetricsUnmarshalerExtension = (*parquetExtension)(nil)
+	_ encoding.TracesMarshalerExtension    = (*parquetExtension)(nil)
+	_ encoding.TracesUnmarshalerExtension  = (*parquetExtension)(nil)
+)
+
+type parquetExtension struct {
+	promoted         []promotedAttribute
+	logsSchema       *arrow.Schema
+	metricsSchema    *arrow.Schema
+	tracesSchema     *arrow.Schema
+	writerProperties *parquet.WriterProperties
+}
+
+func newExtension(config *Config) *parquetExtension {
+	promoted := config.PromotedAttributes.promoted()
+	return &parquetExtension{
+		promoted:      promoted,
+		logsSchema:    newLogsSchema(promoted),
+		metricsSchema: newMetricsSchema(promoted),
+		tracesSchema:  newTracesSchema(promoted),
+		writerProperties: parquet.NewWriterProperties(
+			parquet.WithAllocator(memory.DefaultAllocator),
+			parquet.WithCompression(compressionCodecs[config.Compression]),
+			parquet.WithMaxRowGroupLength(config.RowGroupSize),
+		),
+	}
+}
+
+func (e *parquetExtension) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	rec := logsRecord(e.logsSchema, e.promoted, ld)
+	defer rec.Release()
+	return e.write(rec)
+}
+
+func (e *parquetExtension) UnmarshalLogs(buf []byte) (plog.Logs, error) {
+	r := &logsReader{logs: plog.NewLogs()}
+	if err := read(buf, r.read); err != nil {
+		return plog.NewLogs(), err
+	}
+	return r.logs, nil
+}
+
+func (e *parquetExtension) MarshalMetrics(md pmetric.Metrics) ([]byte, error) {
+	rec := metricsRecord(e.metricsSchema, e.promoted, md)
+	defer rec.Release()
+	return e.write(rec)
+}
+
+func (e *parquetExtension) UnmarshalMetrics(buf []byte) (pmetric.Metrics, error) {
+	r := &metricsReader{metrics: pmetric.NewMetrics()}
+	if err := read(buf, r.read); err != nil {
+		return pmetric.NewMetrics(), err
+	}
+	return r.metrics, nil
+}
+
+func (e *parquetExtension) MarshalTraces(td ptrace.Traces) ([]byte, error) {
+	rec := tracesRecord(e.tracesSchema, e.promoted, td)
+	defer rec.Release()
+	return e.write(rec)
+}
+
+func (e *parquetExtension) UnmarshalTraces(buf []byte) (ptrace.Traces, error) {
+	r := &tracesReader{traces: ptrace.NewTraces()}
+	if err := read(buf, r.read); err != nil {
+		return ptrace.NewTraces(), err
+	}
+	return r.traces, nil
+}
+
+func (e *parquetExtension) Start(_ context.Context, _ component.Host) error {
+	return nil
+}
+
+func (e *parquetExtension) Shutdown(_ context.Context) error {
+	return nil
+}
+
+// write encodes the record as a complete Parquet file, split into row groups of the configured size.
+func (e *parquetExtension) write(rec arrow.Record) ([]byte, error) {
+	var buf bytes.Buffer
+	fw, err := pqarrow.NewFileWriter(rec.Schema(), &buf, e.writerProperties, pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create parquet writer: %w", err)
+	}
+	if err = fw.Write(rec); err != nil {
+		_ = fw.Close()
+		return nil, fmt.Errorf("failed to write parquet file: %w", err)
+	}
+	if err = fw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write parquet file: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// read decodes a Parquet file, calling fn for each of its records.
+func read(buf []byte, fn func(arrow.Record) error) error {
+	tbl, err := pqarrow.ReadTable(context.Background(), bytes.NewReader(buf), parquet.NewReaderProperties(memory.DefaultAllocator), pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	if err != nil {
+		return fmt.Errorf("failed to read parquet file: %w", err)
+	}
+	defer tbl.Release()
+
+	tr := array.NewTableReader(tbl, -1)
+	defer tr.Release()
+	for tr.Next() {
+		if err = fn(tr.Record()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
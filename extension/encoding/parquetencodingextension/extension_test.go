@@ -0,0 +1,413 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestLogsRoundTrip(t *testing.T) {
+	ext := newTestExtension(t, createDefaultConfig().(*Config))
+	logs := generateLogs()
+
+	buf, err := ext.MarshalLogs(logs)
+	require.NoError(t, err)
+
+	got, err := ext.UnmarshalLogs(buf)
+	require.NoError(t, err)
+	assert.Equal(t, logs, got)
+}
+
+func TestTracesRoundTrip(t *testing.T) {
+	ext := newTestExtension(t, createDefaultConfig().(*Config))
+	traces := generateTraces()
+
+	buf, err := ext.MarshalTraces(traces)
+	require.NoError(t, err)
+
+	got, err := ext.UnmarshalTraces(buf)
+	require.NoError(t, err)
+	assert.Equal(t, traces, got)
+}
+
+func TestEmptyRoundTrip(t *testing.T) {
+	ext := newTestExtension(t, createDefaultConfig().(*Config))
+
+	buf, err := ext.MarshalLogs(plog.NewLogs())
+	require.NoError(t, err)
+	logs, err := ext.UnmarshalLogs(buf)
+	require.NoError(t, err)
+	assert.Equal(t, 0, logs.LogRecordCount())
+
+	buf, err = ext.MarshalMetrics(pmetric.NewMetrics())
+	require.NoError(t, err)
+	metrics, err := ext.UnmarshalMetrics(buf)
+	require.NoError(t, err)
+	assert.Equal(t, 0, metrics.DataPointCount())
+
+	buf, err = ext.MarshalTraces(ptrace.NewTraces())
+	require.NoError(t, err)
+	traces, err := ext.UnmarshalTraces(buf)
+	require.NoError(t, err)
+	assert.Equal(t, 0, traces.SpanCount())
+}
+
+func TestMetricsRoundTrip(t *testing.T) {
+	ext := newTestExtension(t, createDefaultConfig().(*Config))
+	metrics := generateMetrics()
+
+	buf, err := ext.MarshalMetrics(metrics)
+	require.NoError(t, err)
+
+	got, err := ext.UnmarshalMetrics(buf)
+	require.NoError(t, err)
+	assert.Equal(t, metrics, got)
+}
+
+func TestTypedValuesRoundTrip(t *testing.T) {
+	ext := newTestExtension(t, createDefaultConfig().(*Config))
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutInt("host.cpus", 8)
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().Attributes().PutBool("scope.enabled", true)
+
+	lr := sl.LogRecords().AppendEmpty()
+	body := lr.Body().SetEmptyMap()
+	body.PutInt("status", 200)
+	body.PutDouble("ratio", 0.5)
+	body.PutEmptyBytes("raw").FromRaw([]byte{0, 1, 2})
+	nested := body.PutEmptySlice("nested")
+	nested.AppendEmpty().SetDouble(math.Inf(1))
+	nested.AppendEmpty().SetEmptyMap().PutStr("key", "value")
+	nested.AppendEmpty()
+	setTypedAttributes(lr.Attributes())
+
+	lr = sl.LogRecords().AppendEmpty()
+	lr.Body().SetInt(42)
+	lr = sl.LogRecords().AppendEmpty()
+	lr.Body().SetEmptySlice().AppendEmpty().SetBool(false)
+	lr = sl.LogRecords().AppendEmpty()
+	lr.Body().SetEmptyBytes()
+
+	buf, err := ext.MarshalLogs(logs)
+	require.NoError(t, err)
+	got, err := ext.UnmarshalLogs(buf)
+	require.NoError(t, err)
+	assert.Equal(t, logs, got)
+
+	traces := ptrace.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	setTypedAttributes(span.Attributes())
+	setTypedAttributes(span.Events().AppendEmpty().Attributes())
+	setTypedAttributes(span.Links().AppendEmpty().Attributes())
+
+	buf, err = ext.MarshalTraces(traces)
+	require.NoError(t, err)
+	gotTraces, err := ext.UnmarshalTraces(buf)
+	require.NoError(t, err)
+	assert.Equal(t, traces, gotTraces)
+}
+
+func setTypedAttributes(attrs pcommon.Map) {
+	attrs.PutStr("str", "value")
+	attrs.PutInt("int", -42)
+	attrs.PutDouble("double", -1.5)
+	attrs.PutBool("bool", true)
+	attrs.PutEmptyBytes("bytes").FromRaw([]byte("bytes"))
+	attrs.PutEmpty("empty")
+	attrs.PutEmptyMap("map").PutInt("count", 1)
+	slice := attrs.PutEmptySlice("slice")
+	slice.AppendEmpty().SetStr("a")
+	slice.AppendEmpty().SetInt(1)
+}
+
+func TestPromotedAttributes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.PromotedAttributes = PromotedAttributes{
+		Resource: []string{"service.name"},
+		Record:   []string{"http.route", "missing"},
+	}
+	ext := newTestExtension(t, cfg)
+	traces := generateTraces()
+
+	buf, err := ext.MarshalTraces(traces)
+	require.NoError(t, err)
+
+	tbl, err := pqarrow.ReadTable(context.Background(), bytes.NewReader(buf), parquet.NewReaderProperties(memory.DefaultAllocator), pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	require.NoError(t, err)
+	defer tbl.Release()
+	tr := array.NewTableReader(tbl, -1)
+	defer tr.Release()
+	require.True(t, tr.Next())
+	rec := tr.Record()
+
+	service, err := valueColumn(rec, "resource_service_name")
+	require.NoError(t, err)
+	route, err := valueColumn(rec, "attribute_http_route")
+	require.NoError(t, err)
+	missing, err := valueColumn(rec, "attribute_missing")
+	require.NoError(t, err)
+	for i := 0; i < int(rec.NumRows()); i++ {
+		assert.Equal(t, "checkout", service.str.Value(i))
+		assert.True(t, missing.isNull(i))
+	}
+	assert.Equal(t, "/cart", route.str.Value(0))
+	assert.True(t, route.isNull(1))
+
+	// the promoted attributes are kept in the attribute maps, and the promoted columns are ignored when reading
+	got, err := ext.UnmarshalTraces(buf)
+	require.NoError(t, err)
+	assert.Equal(t, traces, got)
+}
+
+func TestRowGroupsAndCompression(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.RowGroupSize = 2
+	cfg.Compression = compressionSnappy
+	ext := newTestExtension(t, cfg)
+	logs := generateLogs()
+	require.Equal(t, 5, logs.LogRecordCount())
+
+	buf, err := ext.MarshalLogs(logs)
+	require.NoError(t, err)
+
+	rdr, err := file.NewParquetReader(bytes.NewReader(buf))
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, rdr.Close())
+	}()
+	assert.Equal(t, 3, rdr.NumRowGroups())
+	column, err := rdr.MetaData().RowGroup(0).ColumnChunk(0)
+	require.NoError(t, err)
+	assert.Equal(t, compress.Codecs.Snappy, column.Compression())
+
+	// rows are grouped by resource and scope across row groups
+	got, err := ext.UnmarshalLogs(buf)
+	require.NoError(t, err)
+	assert.Equal(t, logs, got)
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	ext := newTestExtension(t, createDefaultConfig().(*Config))
+
+	_, err := ext.UnmarshalLogs([]byte("not parquet"))
+	assert.ErrorContains(t, err, "failed to read parquet file")
+	_, err = ext.UnmarshalMetrics([]byte("not parquet"))
+	assert.ErrorContains(t, err, "failed to read parquet file")
+	_, err = ext.UnmarshalTraces([]byte("not parquet"))
+	assert.ErrorContains(t, err, "failed to read parquet file")
+
+	buf, err := ext.MarshalLogs(generateLogs())
+	require.NoError(t, err)
+	_, err = ext.UnmarshalTraces(buf)
+	assert.EqualError(t, err, `missing column "parent_span_id"`)
+	_, err = ext.UnmarshalMetrics(buf)
+	assert.EqualError(t, err, `missing column "metric_name"`)
+}
+
+func newTestExtension(t *testing.T, cfg *Config) *parquetExtension {
+	require.NoError(t, cfg.Validate())
+	return newExtension(cfg)
+}
+
+func generateLogs() plog.Logs {
+	ts := pcommon.NewTimestampFromTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	logs := plog.NewLogs()
+
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl("https://opentelemetry.io/schemas/1.26.0")
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("logger")
+	sl.Scope().SetVersion("1.0.0")
+	sl.Scope().Attributes().PutStr("scope.key", "value")
+	for i := 0; i < 3; i++ {
+		lr := sl.LogRecords().AppendEmpty()
+		lr.SetTimestamp(ts + pcommon.Timestamp(i))
+		lr.SetObservedTimestamp(ts + pcommon.Timestamp(i+1))
+		lr.SetSeverityNumber(plog.SeverityNumberInfo)
+		lr.SetSeverityText("INFO")
+		lr.Body().SetStr("message")
+		lr.Attributes().PutStr("http.route", "/cart")
+		lr.SetDroppedAttributesCount(1)
+		lr.SetFlags(plog.DefaultLogRecordFlags.WithIsSampled(true))
+		lr.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, byte(i)})
+		lr.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, byte(i)})
+	}
+	sl = rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("other")
+	sl.LogRecords().AppendEmpty().Body().SetStr("without timestamp")
+
+	rl = logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "payment")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().SetSeverityNumber(plog.SeverityNumberError)
+	return logs
+}
+
+func generateTraces() ptrace.Traces {
+	ts := pcommon.NewTimestampFromTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	traces := ptrace.NewTraces()
+
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.SetSchemaUrl("https://opentelemetry.io/schemas/1.26.0")
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("tracer")
+	ss.SetSchemaUrl("https://opentelemetry.io/schemas/1.26.0")
+
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	span.SetParentSpanID([8]byte{8, 7, 6, 5, 4, 3, 2, 1})
+	span.TraceState().FromRaw("vendor=value")
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetStartTimestamp(ts)
+	span.SetEndTimestamp(ts + pcommon.Timestamp(time.Second))
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.Status().SetMessage("failed")
+	span.Attributes().PutStr("http.route", "/cart")
+	span.SetDroppedAttributesCount(1)
+	span.SetFlags(1)
+	event := span.Events().AppendEmpty()
+	event.SetTimestamp(ts + 10)
+	event.SetName("exception")
+	event.Attributes().PutStr("exception.type", "Error")
+	event.SetDroppedAttributesCount(2)
+	span.Events().AppendEmpty().SetName("without timestamp")
+	span.SetDroppedEventsCount(3)
+	link := span.Links().AppendEmpty()
+	link.SetTraceID([16]byte{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1})
+	link.SetSpanID([8]byte{1, 1, 1, 1, 1, 1, 1, 1})
+	link.TraceState().FromRaw("other=value")
+	link.Attributes().PutStr("link.key", "value")
+	link.SetDroppedAttributesCount(4)
+	link.SetFlags(1)
+	span.SetDroppedLinksCount(5)
+
+	span = ss.Spans().AppendEmpty()
+	span.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID([8]byte{2, 2, 2, 2, 2, 2, 2, 2})
+	span.SetName("internal")
+	span.SetKind(ptrace.SpanKindInternal)
+	return traces
+}
+
+func generateMetrics() pmetric.Metrics {
+	ts := pcommon.NewTimestampFromTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	metrics := pmetric.NewMetrics()
+
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.SetSchemaUrl("https://opentelemetry.io/schemas/1.26.0")
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("meter")
+	sm.Scope().SetVersion("1.0.0")
+
+	m := sm.Metrics().AppendEmpty()
+	m.SetName("cpu.usage")
+	m.SetDescription("CPU usage")
+	m.SetUnit("1")
+	m.Metadata().PutStr("metadata.key", "value")
+	gauge := m.SetEmptyGauge()
+	dp := gauge.DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(0.5)
+	dp.Attributes().PutStr("cpu", "0")
+	exemplar := dp.Exemplars().AppendEmpty()
+	exemplar.SetTimestamp(ts)
+	exemplar.SetDoubleValue(0.75)
+	exemplar.FilteredAttributes().PutInt("thread", 3)
+	exemplar.SetTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	exemplar.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+	dp = gauge.DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(1)
+	dp.Attributes().PutInt("cpu", 1)
+	dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	dp.Exemplars().AppendEmpty().SetIntValue(2)
+
+	m = sm.Metrics().AppendEmpty()
+	m.SetName("http.requests")
+	sum := m.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.SetIsMonotonic(true)
+	dp = sum.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(ts)
+	dp.SetTimestamp(ts + 10)
+	dp.SetIntValue(100)
+	dp.Attributes().PutStr("http.route", "/cart")
+
+	m = sm.Metrics().AppendEmpty()
+	m.SetName("http.duration")
+	m.SetUnit("ms")
+	histogram := m.SetEmptyHistogram()
+	histogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	hdp := histogram.DataPoints().AppendEmpty()
+	hdp.SetStartTimestamp(ts)
+	hdp.SetTimestamp(ts + 10)
+	hdp.SetCount(6)
+	hdp.SetSum(120)
+	hdp.SetMin(1)
+	hdp.SetMax(50)
+	hdp.BucketCounts().FromRaw([]uint64{1, 2, 3})
+	hdp.ExplicitBounds().FromRaw([]float64{10, 20})
+	hdp.Exemplars().AppendEmpty().SetDoubleValue(15)
+	histogram.DataPoints().AppendEmpty().SetCount(0)
+
+	m = sm.Metrics().AppendEmpty()
+	m.SetName("latency")
+	expHistogram := m.SetEmptyExponentialHistogram()
+	expHistogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	edp := expHistogram.DataPoints().AppendEmpty()
+	edp.SetTimestamp(ts)
+	edp.SetCount(10)
+	edp.SetSum(55.5)
+	edp.SetScale(-2)
+	edp.SetZeroCount(1)
+	edp.SetZeroThreshold(0.001)
+	edp.Positive().SetOffset(3)
+	edp.Positive().BucketCounts().FromRaw([]uint64{4, 5})
+	edp.Negative().SetOffset(-1)
+
+	sm = rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("other")
+	m = sm.Metrics().AppendEmpty()
+	m.SetName("rpc.duration")
+	sdp := m.SetEmptySummary().DataPoints().AppendEmpty()
+	sdp.SetTimestamp(ts)
+	sdp.SetCount(3)
+	sdp.SetSum(12)
+	q := sdp.QuantileValues().AppendEmpty()
+	q.SetQuantile(0.5)
+	q.SetValue(4)
+	q = sdp.QuantileValues().AppendEmpty()
+	q.SetQuantile(0.99)
+	q.SetValue(7)
+
+	rm = metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "payment")
+	m = rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("queue.size")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(3)
+	return metrics
+}
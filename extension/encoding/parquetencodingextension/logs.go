@@ -0,0 +1,207 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const (
+	columnObservedTimestamp = "observed_timestamp"
+	columnSeverityNumber    = "severity_number"
+	columnSeverityText      = "severity_text"
+	columnBody              = "body"
+)
+
+// logsFields are the columns of a log record, which are followed by the resource, scope and promoted columns.
+func logsFields() []arrow.Field {
+	return []arrow.Field{
+		{Name: columnTimestamp, Type: timestampType, Nullable: true},
+		{Name: columnObservedTimestamp, Type: timestampType, Nullable: true},
+		{Name: columnSeverityNumber, Type: arrow.PrimitiveTypes.Int32},
+		{Name: columnSeverityText, Type: arrow.BinaryTypes.String},
+		{Name: columnBody, Type: valueType, Nullable: true},
+		{Name: columnAttributes, Type: attributesType},
+		{Name: columnDroppedAttributesCount, Type: arrow.PrimitiveTypes.Uint32},
+		{Name: columnFlags, Type: arrow.PrimitiveTypes.Uint32},
+		{Name: columnTraceID, Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: columnSpanID, Type: arrow.BinaryTypes.String, Nullable: true},
+	}
+}
+
+func newLogsSchema(promoted []promotedAttribute) *arrow.Schema {
+	return newSchema(promoted, logsFields(), resourceScopeFields())
+}
+
+// logsRecord converts the logs to a record, with a row per log record.
+func logsRecord(schema *arrow.Schema, promoted []promotedAttribute, ld plog.Logs) arrow.Record {
+	rb := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer rb.Release()
+
+	timestamp := fieldBuilder[*array.TimestampBuilder](rb, columnTimestamp)
+	observedTimestamp := fieldBuilder[*array.TimestampBuilder](rb, columnObservedTimestamp)
+	severityNumber := fieldBuilder[*array.Int32Builder](rb, columnSeverityNumber)
+	severityText := fieldBuilder[*array.StringBuilder](rb, columnSeverityText)
+	body := newValueBuilder(fieldBuilder[*array.StructBuilder](rb, columnBody))
+	attributes := newAttributesBuilder(fieldBuilder[*array.MapBuilder](rb, columnAttributes))
+	droppedAttributesCount := fieldBuilder[*array.Uint32Builder](rb, columnDroppedAttributesCount)
+	flags := fieldBuilder[*array.Uint32Builder](rb, columnFlags)
+	traceID := fieldBuilder[*array.StringBuilder](rb, columnTraceID)
+	spanID := fieldBuilder[*array.StringBuilder](rb, columnSpanID)
+	resourceScope := newResourceScopeBuilders(rb)
+	promotedColumns := newPromotedBuilders(rb, promoted)
+
+	rb.Reserve(ld.LogRecordCount())
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				appendTimestamp(timestamp, lr.Timestamp())
+				appendTimestamp(observedTimestamp, lr.ObservedTimestamp())
+				severityNumber.Append(int32(lr.SeverityNumber()))
+				severityText.Append(lr.SeverityText())
+				if lr.Body().Type() == pcommon.ValueTypeEmpty {
+					body.appendNull()
+				} else {
+					body.append(lr.Body())
+				}
+				attributes.append(lr.Attributes())
+				droppedAttributesCount.Append(lr.DroppedAttributesCount())
+				flags.Append(uint32(lr.Flags()))
+				appendID(traceID, lr.TraceID())
+				appendID(spanID, lr.SpanID())
+				resourceScope.append(rl.Resource(), rl.SchemaUrl(), sl.Scope(), sl.SchemaUrl())
+				promotedColumns.append(rl.Resource().Attributes(), lr.Attributes())
+			}
+		}
+	}
+	return rb.NewRecord()
+}
+
+// logsColumns reads the columns of a log record. The promoted columns are ignored, as the attributes
+// are restored from the attribute maps.
+type logsColumns struct {
+	timestamp              *array.Timestamp
+	observedTimestamp      *array.Timestamp
+	severityNumber         *array.Int32
+	severityText           *array.String
+	body                   valueColumns
+	attributes             attributesColumns
+	droppedAttributesCount *array.Uint32
+	flags                  *array.Uint32
+	traceID                *array.String
+	spanID                 *array.String
+	resourceScope          resourceScopeColumns
+}
+
+func newLogsColumns(rec arrow.Record) (logsColumns, error) {
+	var c logsColumns
+	var err error
+	if c.timestamp, err = columnAs[*array.Timestamp](rec, columnTimestamp); err != nil {
+		return c, err
+	}
+	if c.observedTimestamp, err = columnAs[*array.Timestamp](rec, columnObservedTimestamp); err != nil {
+		return c, err
+	}
+	if c.severityNumber, err = columnAs[*array.Int32](rec, columnSeverityNumber); err != nil {
+		return c, err
+	}
+	if c.severityText, err = columnAs[*array.String](rec, columnSeverityText); err != nil {
+		return c, err
+	}
+	if c.body, err = valueColumn(rec, columnBody); err != nil {
+		return c, err
+	}
+	if c.attributes, err = attributesColumn(rec, columnAttributes); err != nil {
+		return c, err
+	}
+	if c.droppedAttributesCount, err = columnAs[*array.Uint32](rec, columnDroppedAttributesCount); err != nil {
+		return c, err
+	}
+	if c.flags, err = columnAs[*array.Uint32](rec, columnFlags); err != nil {
+		return c, err
+	}
+	if c.traceID, err = columnAs[*array.String](rec, columnTraceID); err != nil {
+		return c, err
+	}
+	if c.spanID, err = columnAs[*array.String](rec, columnSpanID); err != nil {
+		return c, err
+	}
+	c.resourceScope, err = newResourceScopeColumns(rec)
+	return c, err
+}
+
+// logsReader appends the rows of the records to the logs, grouping consecutive rows that share the same
+// resource and scope.
+type logsReader struct {
+	logs     plog.Logs
+	grouping grouping
+	rl       plog.ResourceLogs
+	sl       plog.ScopeLogs
+}
+
+func (r *logsReader) read(rec arrow.Record) error {
+	c, err := newLogsColumns(rec)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < int(rec.NumRows()); i++ {
+		newResource, newScope := r.grouping.next(c.resourceScope.resourceKey(i), c.resourceScope.scopeKey(i))
+		if newResource {
+			r.rl = r.logs.ResourceLogs().AppendEmpty()
+			schemaURL, err := c.resourceScope.resource(i, r.rl.Resource())
+			if err != nil {
+				return err
+			}
+			r.rl.SetSchemaUrl(schemaURL)
+		}
+		if newScope {
+			r.sl = r.rl.ScopeLogs().AppendEmpty()
+			schemaURL, err := c.resourceScope.scope(i, r.sl.Scope())
+			if err != nil {
+				return err
+			}
+			r.sl.SetSchemaUrl(schemaURL)
+		}
+
+		lr := r.sl.LogRecords().AppendEmpty()
+		lr.SetTimestamp(readTimestamp(c.timestamp, i))
+		lr.SetObservedTimestamp(readTimestamp(c.observedTimestamp, i))
+		lr.SetSeverityNumber(plog.SeverityNumber(c.severityNumber.Value(i)))
+		lr.SetSeverityText(c.severityText.Value(i))
+		if !c.body.isNull(i) {
+			if err := c.body.read(i, lr.Body()); err != nil {
+				return fmt.Errorf("column %q: %w", columnBody, err)
+			}
+		}
+		if err := c.attributes.read(i, lr.Attributes()); err != nil {
+			return fmt.Errorf("column %q: %w", columnAttributes, err)
+		}
+		lr.SetDroppedAttributesCount(c.droppedAttributesCount.Value(i))
+		lr.SetFlags(plog.LogRecordFlags(c.flags.Value(i)))
+		traceID, err := readTraceID(c.traceID, i)
+		if err != nil {
+			return err
+		}
+		lr.SetTraceID(traceID)
+		spanID, err := readSpanID(c.spanID, i)
+		if err != nil {
+			return err
+		}
+		lr.SetSpanID(spanID)
+	}
+	return nil
+}
@@ -0,0 +1,903 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	columnMetricName             = "metric_name"
+	columnMetricDescription      = "metric_description"
+	columnMetricUnit             = "metric_unit"
+	columnMetricType             = "metric_type"
+	columnMetricMetadata         = "metric_metadata"
+	columnAggregationTemporality = "aggregation_temporality"
+	columnIsMonotonic            = "is_monotonic"
+	columnValueDouble            = "value_double"
+	columnValueInt               = "value_int"
+	columnCount                  = "count"
+	columnSum                    = "sum"
+	columnMin                    = "min"
+	columnMax                    = "max"
+	columnBucketCounts           = "bucket_counts"
+	columnExplicitBounds         = "explicit_bounds"
+	columnScale                  = "scale"
+	columnZeroCount              = "zero_count"
+	columnZeroThreshold          = "zero_threshold"
+	columnPositiveOffset         = "positive_offset"
+	columnPositiveBucketCounts   = "positive_bucket_counts"
+	columnNegativeOffset         = "negative_offset"
+	columnNegativeBucketCounts   = "negative_bucket_counts"
+	columnQuantileValues         = "quantile_values"
+	columnQuantile               = "quantile"
+	columnValue                  = "value"
+	columnExemplars              = "exemplars"
+	columnFilteredAttributes     = "filtered_attributes"
+)
+
+// the metric types and aggregation temporalities are written as strings that don't depend on the pdata representation
+var (
+	metricTypes = map[pmetric.MetricType]string{
+		pmetric.MetricTypeGauge:                "Gauge",
+		pmetric.MetricTypeSum:                  "Sum",
+		pmetric.MetricTypeHistogram:            "Histogram",
+		pmetric.MetricTypeExponentialHistogram: "ExponentialHistogram",
+		pmetric.MetricTypeSummary:              "Summary",
+	}
+	temporalities = map[pmetric.AggregationTemporality]string{
+		pmetric.AggregationTemporalityUnspecified: "Unspecified",
+		pmetric.AggregationTemporalityDelta:       "Delta",
+		pmetric.AggregationTemporalityCumulative:  "Cumulative",
+	}
+)
+
+var (
+	quantileType = arrow.StructOf(
+		arrow.Field{Name: columnQuantile, Type: arrow.PrimitiveTypes.Float64},
+		arrow.Field{Name: columnValue, Type: arrow.PrimitiveTypes.Float64},
+	)
+	exemplarType = arrow.StructOf(
+		arrow.Field{Name: columnTimestamp, Type: timestampType, Nullable: true},
+		arrow.Field{Name: columnValueDouble, Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		arrow.Field{Name: columnValueInt, Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		arrow.Field{Name: columnFilteredAttributes, Type: attributesType},
+		arrow.Field{Name: columnTraceID, Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: columnSpanID, Type: arrow.BinaryTypes.String, Nullable: true},
+	)
+)
+
+// metricsFields are the columns of a data point, which are followed by the resource, scope and promoted columns.
+// The columns that don't apply to the type of the metric are null or empty.
+func metricsFields() []arrow.Field {
+	return []arrow.Field{
+		{Name: columnMetricName, Type: arrow.BinaryTypes.String},
+		{Name: columnMetricDescription, Type: arrow.BinaryTypes.String},
+		{Name: columnMetricUnit, Type: arrow.BinaryTypes.String},
+		{Name: columnMetricType, Type: arrow.BinaryTypes.String},
+		{Name: columnMetricMetadata, Type: attributesType},
+		{Name: columnAggregationTemporality, Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: columnIsMonotonic, Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+		{Name: columnStartTimestamp, Type: timestampType, Nullable: true},
+		{Name: columnTimestamp, Type: timestampType, Nullable: true},
+		{Name: columnAttributes, Type: attributesType},
+		{Name: columnFlags, Type: arrow.PrimitiveTypes.Uint32},
+		{Name: columnValueDouble, Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: columnValueInt, Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: columnCount, Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+		{Name: columnSum, Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: columnMin, Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: columnMax, Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: columnBucketCounts, Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64)},
+		{Name: columnExplicitBounds, Type: arrow.ListOf(arrow.PrimitiveTypes.Float64)},
+		{Name: columnScale, Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: columnZeroCount, Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+		{Name: columnZeroThreshold, Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: columnPositiveOffset, Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: columnPositiveBucketCounts, Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64)},
+		{Name: columnNegativeOffset, Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: columnNegativeBucketCounts, Type: arrow.ListOf(arrow.PrimitiveTypes.Uint64)},
+		{Name: columnQuantileValues, Type: arrow.ListOf(quantileType)},
+		{Name: columnExemplars, Type: arrow.ListOf(exemplarType)},
+	}
+}
+
+func newMetricsSchema(promoted []promotedAttribute) *arrow.Schema {
+	return newSchema(promoted, metricsFields(), resourceScopeFields())
+}
+
+// dataPoint holds the columns of a data point of any type, the has* fields telling which ones are set.
+type dataPoint struct {
+	startTimestamp pcommon.Timestamp
+	timestamp      pcommon.Timestamp
+	attributes     pcommon.Map
+	flags          pmetric.DataPointFlags
+	exemplars      pmetric.ExemplarSlice
+
+	valueType   pmetric.NumberDataPointValueType
+	doubleValue float64
+	intValue    int64
+
+	hasCount bool
+	count    uint64
+	hasSum   bool
+	sum      float64
+	hasMin   bool
+	minimum  float64
+	hasMax   bool
+	maximum  float64
+
+	hasBuckets     bool
+	bucketCounts   pcommon.UInt64Slice
+	explicitBounds pcommon.Float64Slice
+
+	exponential   bool
+	scale         int32
+	zeroCount     uint64
+	zeroThreshold float64
+	positive      pmetric.ExponentialHistogramDataPointBuckets
+	negative      pmetric.ExponentialHistogramDataPointBuckets
+
+	hasQuantiles bool
+	quantiles    pmetric.SummaryDataPointValueAtQuantileSlice
+}
+
+// metricsBuilders appends the columns of a data point.
+type metricsBuilders struct {
+	metricName             *array.StringBuilder
+	metricDescription      *array.StringBuilder
+	metricUnit             *array.StringBuilder
+	metricType             *array.StringBuilder
+	metricMetadata         attributesBuilder
+	aggregationTemporality *array.StringBuilder
+	isMonotonic            *array.BooleanBuilder
+	startTimestamp         *array.TimestampBuilder
+	timestamp              *array.TimestampBuilder
+	attributes             attributesBuilder
+	flags                  *array.Uint32Builder
+	valueDouble            *array.Float64Builder
+	valueInt               *array.Int64Builder
+	count                  *array.Uint64Builder
+	sum                    *array.Float64Builder
+	minimum                *array.Float64Builder
+	maximum                *array.Float64Builder
+	bucketCounts           *array.ListBuilder
+	explicitBounds         *array.ListBuilder
+	scale                  *array.Int32Builder
+	zeroCount              *array.Uint64Builder
+	zeroThreshold          *array.Float64Builder
+	positiveOffset         *array.Int32Builder
+	positiveBucketCounts   *array.ListBuilder
+	negativeOffset         *array.Int32Builder
+	negativeBucketCounts   *array.ListBuilder
+
+	quantileValues *array.ListBuilder
+	quantile       *array.StructBuilder
+	quantileQ      *array.Float64Builder
+	quantileValue  *array.Float64Builder
+
+	exemplars                  *array.ListBuilder
+	exemplar                   *array.StructBuilder
+	exemplarTimestamp          *array.TimestampBuilder
+	exemplarValueDouble        *array.Float64Builder
+	exemplarValueInt           *array.Int64Builder
+	exemplarFilteredAttributes attributesBuilder
+	exemplarTraceID            *array.StringBuilder
+	exemplarSpanID             *array.StringBuilder
+}
+
+func newMetricsBuilders(rb *array.RecordBuilder) *metricsBuilders {
+	b := &metricsBuilders{
+		metricName:             fieldBuilder[*array.StringBuilder](rb, columnMetricName),
+		metricDescription:      fieldBuilder[*array.StringBuilder](rb, columnMetricDescription),
+		metricUnit:             fieldBuilder[*array.StringBuilder](rb, columnMetricUnit),
+		metricType:             fieldBuilder[*array.StringBuilder](rb, columnMetricType),
+		metricMetadata:         newAttributesBuilder(fieldBuilder[*array.MapBuilder](rb, columnMetricMetadata)),
+		aggregationTemporality: fieldBuilder[*array.StringBuilder](rb, columnAggregationTemporality),
+		isMonotonic:            fieldBuilder[*array.BooleanBuilder](rb, columnIsMonotonic),
+		startTimestamp:         fieldBuilder[*array.TimestampBuilder](rb, columnStartTimestamp),
+		timestamp:              fieldBuilder[*array.TimestampBuilder](rb, columnTimestamp),
+		attributes:             newAttributesBuilder(fieldBuilder[*array.MapBuilder](rb, columnAttributes)),
+		flags:                  fieldBuilder[*array.Uint32Builder](rb, columnFlags),
+		valueDouble:            fieldBuilder[*array.Float64Builder](rb, columnValueDouble),
+		valueInt:               fieldBuilder[*array.Int64Builder](rb, columnValueInt),
+		count:                  fieldBuilder[*array.Uint64Builder](rb, columnCount),
+		sum:                    fieldBuilder[*array.Float64Builder](rb, columnSum),
+		minimum:                fieldBuilder[*array.Float64Builder](rb, columnMin),
+		maximum:                fieldBuilder[*array.Float64Builder](rb, columnMax),
+		bucketCounts:           fieldBuilder[*array.ListBuilder](rb, columnBucketCounts),
+		explicitBounds:         fieldBuilder[*array.ListBuilder](rb, columnExplicitBounds),
+		scale:                  fieldBuilder[*array.Int32Builder](rb, columnScale),
+		zeroCount:              fieldBuilder[*array.Uint64Builder](rb, columnZeroCount),
+		zeroThreshold:          fieldBuilder[*array.Float64Builder](rb, columnZeroThreshold),
+		positiveOffset:         fieldBuilder[*array.Int32Builder](rb, columnPositiveOffset),
+		positiveBucketCounts:   fieldBuilder[*array.ListBuilder](rb, columnPositiveBucketCounts),
+		negativeOffset:         fieldBuilder[*array.Int32Builder](rb, columnNegativeOffset),
+		negativeBucketCounts:   fieldBuilder[*array.ListBuilder](rb, columnNegativeBucketCounts),
+		quantileValues:         fieldBuilder[*array.ListBuilder](rb, columnQuantileValues),
+		exemplars:              fieldBuilder[*array.ListBuilder](rb, columnExemplars),
+	}
+	b.quantile = b.quantileValues.ValueBuilder().(*array.StructBuilder)
+	b.quantileQ = structFieldBuilder[*array.Float64Builder](b.quantile, quantileType, columnQuantile)
+	b.quantileValue = structFieldBuilder[*array.Float64Builder](b.quantile, quantileType, columnValue)
+	b.exemplar = b.exemplars.ValueBuilder().(*array.StructBuilder)
+	b.exemplarTimestamp = structFieldBuilder[*array.TimestampBuilder](b.exemplar, exemplarType, columnTimestamp)
+	b.exemplarValueDouble = structFieldBuilder[*array.Float64Builder](b.exemplar, exemplarType, columnValueDouble)
+	b.exemplarValueInt = structFieldBuilder[*array.Int64Builder](b.exemplar, exemplarType, columnValueInt)
+	b.exemplarFilteredAttributes = newAttributesBuilder(structFieldBuilder[*array.MapBuilder](b.exemplar, exemplarType, columnFilteredAttributes))
+	b.exemplarTraceID = structFieldBuilder[*array.StringBuilder](b.exemplar, exemplarType, columnTraceID)
+	b.exemplarSpanID = structFieldBuilder[*array.StringBuilder](b.exemplar, exemplarType, columnSpanID)
+	return b
+}
+
+func (b *metricsBuilders) appendMetric(m pmetric.Metric) {
+	b.metricName.Append(m.Name())
+	b.metricDescription.Append(m.Description())
+	b.metricUnit.Append(m.Unit())
+	b.metricType.Append(metricTypes[m.Type()])
+	b.metricMetadata.append(m.Metadata())
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		b.aggregationTemporality.Append(temporalities[m.Sum().AggregationTemporality()])
+		b.isMonotonic.Append(m.Sum().IsMonotonic())
+	case pmetric.MetricTypeHistogram:
+		b.aggregationTemporality.Append(temporalities[m.Histogram().AggregationTemporality()])
+		b.isMonotonic.AppendNull()
+	case pmetric.MetricTypeExponentialHistogram:
+		b.aggregationTemporality.Append(temporalities[m.ExponentialHistogram().AggregationTemporality()])
+		b.isMonotonic.AppendNull()
+	default:
+		b.aggregationTemporality.AppendNull()
+		b.isMonotonic.AppendNull()
+	}
+}
+
+func (b *metricsBuilders) appendDataPoint(dp dataPoint) {
+	appendTimestamp(b.startTimestamp, dp.startTimestamp)
+	appendTimestamp(b.timestamp, dp.timestamp)
+	b.attributes.append(dp.attributes)
+	b.flags.Append(uint32(dp.flags))
+
+	switch dp.valueType {
+	case pmetric.NumberDataPointValueTypeDouble:
+		b.valueDouble.Append(dp.doubleValue)
+		b.valueInt.AppendNull()
+	case pmetric.NumberDataPointValueTypeInt:
+		b.valueDouble.AppendNull()
+		b.valueInt.Append(dp.intValue)
+	default:
+		b.valueDouble.AppendNull()
+		b.valueInt.AppendNull()
+	}
+
+	appendOptional(b.count, dp.count, dp.hasCount)
+	appendOptional(b.sum, dp.sum, dp.hasSum)
+	appendOptional(b.minimum, dp.minimum, dp.hasMin)
+	appendOptional(b.maximum, dp.maximum, dp.hasMax)
+
+	b.bucketCounts.Append(true)
+	b.explicitBounds.Append(true)
+	if dp.hasBuckets {
+		b.bucketCounts.ValueBuilder().(*array.Uint64Builder).AppendValues(dp.bucketCounts.AsRaw(), nil)
+		b.explicitBounds.ValueBuilder().(*array.Float64Builder).AppendValues(dp.explicitBounds.AsRaw(), nil)
+	}
+
+	appendOptional(b.scale, dp.scale, dp.exponential)
+	appendOptional(b.zeroCount, dp.zeroCount, dp.exponential)
+	appendOptional(b.zeroThreshold, dp.zeroThreshold, dp.exponential)
+	b.positiveBucketCounts.Append(true)
+	b.negativeBucketCounts.Append(true)
+	if dp.exponential {
+		b.positiveOffset.Append(dp.positive.Offset())
+		b.positiveBucketCounts.ValueBuilder().(*array.Uint64Builder).AppendValues(dp.positive.BucketCounts().AsRaw(), nil)
+		b.negativeOffset.Append(dp.negative.Offset())
+		b.negativeBucketCounts.ValueBuilder().(*array.Uint64Builder).AppendValues(dp.negative.BucketCounts().AsRaw(), nil)
+	} else {
+		b.positiveOffset.AppendNull()
+		b.negativeOffset.AppendNull()
+	}
+
+	b.quantileValues.Append(true)
+	if dp.hasQuantiles {
+		for i := 0; i < dp.quantiles.Len(); i++ {
+			q := dp.quantiles.At(i)
+			b.quantile.Append(true)
+			b.quantileQ.Append(q.Quantile())
+			b.quantileValue.Append(q.Value())
+		}
+	}
+
+	b.exemplars.Append(true)
+	for i := 0; i < dp.exemplars.Len(); i++ {
+		e := dp.exemplars.At(i)
+		b.exemplar.Append(true)
+		appendTimestamp(b.exemplarTimestamp, e.Timestamp())
+		switch e.ValueType() {
+		case pmetric.ExemplarValueTypeDouble:
+			b.exemplarValueDouble.Append(e.DoubleValue())
+			b.exemplarValueInt.AppendNull()
+		case pmetric.ExemplarValueTypeInt:
+			b.exemplarValueDouble.AppendNull()
+			b.exemplarValueInt.Append(e.IntValue())
+		default:
+			b.exemplarValueDouble.AppendNull()
+			b.exemplarValueInt.AppendNull()
+		}
+		b.exemplarFilteredAttributes.append(e.FilteredAttributes())
+		appendID(b.exemplarTraceID, e.TraceID())
+		appendID(b.exemplarSpanID, e.SpanID())
+	}
+}
+
+type optionalBuilder[T any] interface {
+	Append(T)
+	AppendNull()
+}
+
+func appendOptional[T any](b optionalBuilder[T], v T, ok bool) {
+	if ok {
+		b.Append(v)
+		return
+	}
+	b.AppendNull()
+}
+
+// metricsRecord converts the metrics to a record, with a row per data point. Metrics without data points
+// are not written.
+func metricsRecord(schema *arrow.Schema, promoted []promotedAttribute, md pmetric.Metrics) arrow.Record {
+	rb := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer rb.Release()
+
+	b := newMetricsBuilders(rb)
+	resourceScope := newResourceScopeBuilders(rb)
+	promotedColumns := newPromotedBuilders(rb, promoted)
+
+	rb.Reserve(md.DataPointCount())
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			ms := sm.Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				forEachDataPoint(m, func(dp dataPoint) {
+					b.appendMetric(m)
+					b.appendDataPoint(dp)
+					resourceScope.append(rm.Resource(), rm.SchemaUrl(), sm.Scope(), sm.SchemaUrl())
+					promotedColumns.append(rm.Resource().Attributes(), dp.attributes)
+				})
+			}
+		}
+	}
+	return rb.NewRecord()
+}
+
+func forEachDataPoint(m pmetric.Metric, fn func(dataPoint)) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		forEachNumberDataPoint(m.Gauge().DataPoints(), fn)
+	case pmetric.MetricTypeSum:
+		forEachNumberDataPoint(m.Sum().DataPoints(), fn)
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			fn(dataPoint{
+				startTimestamp: dp.StartTimestamp(),
+				timestamp:      dp.Timestamp(),
+				attributes:     dp.Attributes(),
+				flags:          dp.Flags(),
+				exemplars:      dp.Exemplars(),
+				hasCount:       true,
+				count:          dp.Count(),
+				hasSum:         dp.HasSum(),
+				sum:            dp.Sum(),
+				hasMin:         dp.HasMin(),
+				minimum:        dp.Min(),
+				hasMax:         dp.HasMax(),
+				maximum:        dp.Max(),
+				hasBuckets:     true,
+				bucketCounts:   dp.BucketCounts(),
+				explicitBounds: dp.ExplicitBounds(),
+			})
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			fn(dataPoint{
+				startTimestamp: dp.StartTimestamp(),
+				timestamp:      dp.Timestamp(),
+				attributes:     dp.Attributes(),
+				flags:          dp.Flags(),
+				exemplars:      dp.Exemplars(),
+				hasCount:       true,
+				count:          dp.Count(),
+				hasSum:         dp.HasSum(),
+				sum:            dp.Sum(),
+				hasMin:         dp.HasMin(),
+				minimum:        dp.Min(),
+				hasMax:         dp.HasMax(),
+				maximum:        dp.Max(),
+				exponential:    true,
+				scale:          dp.Scale(),
+				zeroCount:      dp.ZeroCount(),
+				zeroThreshold:  dp.ZeroThreshold(),
+				positive:       dp.Positive(),
+				negative:       dp.Negative(),
+			})
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			fn(dataPoint{
+				startTimestamp: dp.StartTimestamp(),
+				timestamp:      dp.Timestamp(),
+				attributes:     dp.Attributes(),
+				flags:          dp.Flags(),
+				exemplars:      pmetric.NewExemplarSlice(),
+				hasCount:       true,
+				count:          dp.Count(),
+				hasSum:         true,
+				sum:            dp.Sum(),
+				hasQuantiles:   true,
+				quantiles:      dp.QuantileValues(),
+			})
+		}
+	}
+}
+
+func forEachNumberDataPoint(dps pmetric.NumberDataPointSlice, fn func(dataPoint)) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		fn(dataPoint{
+			startTimestamp: dp.StartTimestamp(),
+			timestamp:      dp.Timestamp(),
+			attributes:     dp.Attributes(),
+			flags:          dp.Flags(),
+			exemplars:      dp.Exemplars(),
+			valueType:      dp.ValueType(),
+			doubleValue:    dp.DoubleValue(),
+			intValue:       dp.IntValue(),
+		})
+	}
+}
+
+// metricsColumns reads the columns of a data point. The promoted columns are ignored, as the attributes
+// are restored from the attribute maps.
+type metricsColumns struct {
+	metricName             *array.String
+	metricDescription      *array.String
+	metricUnit             *array.String
+	metricType             *array.String
+	metricMetadata         attributesColumns
+	aggregationTemporality *array.String
+	isMonotonic            *array.Boolean
+	startTimestamp         *array.Timestamp
+	timestamp              *array.Timestamp
+	attributes             attributesColumns
+	flags                  *array.Uint32
+	valueDouble            *array.Float64
+	valueInt               *array.Int64
+	count                  *array.Uint64
+	sum                    *array.Float64
+	minimum                *array.Float64
+	maximum                *array.Float64
+	bucketCounts           *array.List
+	bucketCountValues      *array.Uint64
+	explicitBounds         *array.List
+	explicitBoundValues    *array.Float64
+	scale                  *array.Int32
+	zeroCount              *array.Uint64
+	zeroThreshold          *array.Float64
+	positiveOffset         *array.Int32
+	positiveBucketCounts   *array.List
+	positiveBucketValues   *array.Uint64
+	negativeOffset         *array.Int32
+	negativeBucketCounts   *array.List
+	negativeBucketValues   *array.Uint64
+	quantileValues         *array.List
+	quantile               *array.Float64
+	quantileValue          *array.Float64
+	exemplars              *array.List
+	resourceScope          resourceScopeColumns
+
+	exemplarTimestamp          *array.Timestamp
+	exemplarValueDouble        *array.Float64
+	exemplarValueInt           *array.Int64
+	exemplarFilteredAttributes attributesColumns
+	exemplarTraceID            *array.String
+	exemplarSpanID             *array.String
+}
+
+func newMetricsColumns(rec arrow.Record) (metricsColumns, error) {
+	var c metricsColumns
+	var err error
+	if c.metricName, err = columnAs[*array.String](rec, columnMetricName); err != nil {
+		return c, err
+	}
+	if c.metricDescription, err = columnAs[*array.String](rec, columnMetricDescription); err != nil {
+		return c, err
+	}
+	if c.metricUnit, err = columnAs[*array.String](rec, columnMetricUnit); err != nil {
+		return c, err
+	}
+	if c.metricType, err = columnAs[*array.String](rec, columnMetricType); err != nil {
+		return c, err
+	}
+	if c.metricMetadata, err = attributesColumn(rec, columnMetricMetadata); err != nil {
+		return c, err
+	}
+	if c.aggregationTemporality, err = columnAs[*array.String](rec, columnAggregationTemporality); err != nil {
+		return c, err
+	}
+	if c.isMonotonic, err = columnAs[*array.Boolean](rec, columnIsMonotonic); err != nil {
+		return c, err
+	}
+	if c.startTimestamp, err = columnAs[*array.Timestamp](rec, columnStartTimestamp); err != nil {
+		return c, err
+	}
+	if c.timestamp, err = columnAs[*array.Timestamp](rec, columnTimestamp); err != nil {
+		return c, err
+	}
+	if c.attributes, err = attributesColumn(rec, columnAttributes); err != nil {
+		return c, err
+	}
+	if c.flags, err = columnAs[*array.Uint32](rec, columnFlags); err != nil {
+		return c, err
+	}
+	if c.valueDouble, err = columnAs[*array.Float64](rec, columnValueDouble); err != nil {
+		return c, err
+	}
+	if c.valueInt, err = columnAs[*array.Int64](rec, columnValueInt); err != nil {
+		return c, err
+	}
+	if c.count, err = columnAs[*array.Uint64](rec, columnCount); err != nil {
+		return c, err
+	}
+	if c.sum, err = columnAs[*array.Float64](rec, columnSum); err != nil {
+		return c, err
+	}
+	if c.minimum, err = columnAs[*array.Float64](rec, columnMin); err != nil {
+		return c, err
+	}
+	if c.maximum, err = columnAs[*array.Float64](rec, columnMax); err != nil {
+		return c, err
+	}
+	if c.bucketCounts, c.bucketCountValues, err = listColumn[*array.Uint64](rec, columnBucketCounts); err != nil {
+		return c, err
+	}
+	if c.explicitBounds, c.explicitBoundValues, err = listColumn[*array.Float64](rec, columnExplicitBounds); err != nil {
+		return c, err
+	}
+	if c.scale, err = columnAs[*array.Int32](rec, columnScale); err != nil {
+		return c, err
+	}
+	if c.zeroCount, err = columnAs[*array.Uint64](rec, columnZeroCount); err != nil {
+		return c, err
+	}
+	if c.zeroThreshold, err = columnAs[*array.Float64](rec, columnZeroThreshold); err != nil {
+		return c, err
+	}
+	if c.positiveOffset, err = columnAs[*array.Int32](rec, columnPositiveOffset); err != nil {
+		return c, err
+	}
+	if c.positiveBucketCounts, c.positiveBucketValues, err = listColumn[*array.Uint64](rec, columnPositiveBucketCounts); err != nil {
+		return c, err
+	}
+	if c.negativeOffset, err = columnAs[*array.Int32](rec, columnNegativeOffset); err != nil {
+		return c, err
+	}
+	if c.negativeBucketCounts, c.negativeBucketValues, err = listColumn[*array.Uint64](rec, columnNegativeBucketCounts); err != nil {
+		return c, err
+	}
+	if c.resourceScope, err = newResourceScopeColumns(rec); err != nil {
+		return c, err
+	}
+	if err = c.quantileColumns(rec); err != nil {
+		return c, fmt.Errorf("column %q: %w", columnQuantileValues, err)
+	}
+	if err = c.exemplarColumns(rec); err != nil {
+		return c, fmt.Errorf("column %q: %w", columnExemplars, err)
+	}
+	return c, nil
+}
+
+// listColumn returns the list column with the given name and its values, making sure they have the expected type.
+func listColumn[T arrow.Array](rec arrow.Record, name string) (*array.List, T, error) {
+	var zero T
+	list, err := columnAs[*array.List](rec, name)
+	if err != nil {
+		return nil, zero, err
+	}
+	values, ok := list.ListValues().(T)
+	if !ok {
+		return nil, zero, fmt.Errorf("unexpected type %s for the values of column %q", list.ListValues().DataType(), name)
+	}
+	return list, values, nil
+}
+
+func (c *metricsColumns) quantileColumns(rec arrow.Record) error {
+	var quantiles *array.Struct
+	var err error
+	if c.quantileValues, quantiles, err = listColumn[*array.Struct](rec, columnQuantileValues); err != nil {
+		return err
+	}
+	if c.quantile, err = structFieldAs[*array.Float64](quantiles, columnQuantile); err != nil {
+		return err
+	}
+	c.quantileValue, err = structFieldAs[*array.Float64](quantiles, columnValue)
+	return err
+}
+
+func (c *metricsColumns) exemplarColumns(rec arrow.Record) error {
+	var exemplars *array.Struct
+	var err error
+	if c.exemplars, exemplars, err = listColumn[*array.Struct](rec, columnExemplars); err != nil {
+		return err
+	}
+	if c.exemplarTimestamp, err = structFieldAs[*array.Timestamp](exemplars, columnTimestamp); err != nil {
+		return err
+	}
+	if c.exemplarValueDouble, err = structFieldAs[*array.Float64](exemplars, columnValueDouble); err != nil {
+		return err
+	}
+	if c.exemplarValueInt, err = structFieldAs[*array.Int64](exemplars, columnValueInt); err != nil {
+		return err
+	}
+	if c.exemplarFilteredAttributes, err = attributesField(exemplars, columnFilteredAttributes); err != nil {
+		return err
+	}
+	if c.exemplarTraceID, err = structFieldAs[*array.String](exemplars, columnTraceID); err != nil {
+		return err
+	}
+	c.exemplarSpanID, err = structFieldAs[*array.String](exemplars, columnSpanID)
+	return err
+}
+
+// metricKey identifies the metric of a row, so that consecutive rows sharing the same metric can be grouped.
+func (c metricsColumns) metricKey(i int) string {
+	return c.metricName.Value(i) + "\x00" + c.metricDescription.Value(i) + "\x00" + c.metricUnit.Value(i) + "\x00" +
+		c.metricType.Value(i) + "\x00" + c.metricMetadata.key(i) + "\x00" + c.aggregationTemporality.ValueStr(i) + "\x00" +
+		c.isMonotonic.ValueStr(i)
+}
+
+// metricsReader appends the rows of the records to the metrics, grouping consecutive rows that share the same
+// resource, scope and metric.
+type metricsReader struct {
+	metrics   pmetric.Metrics
+	grouping  grouping
+	metricKey string
+	rm        pmetric.ResourceMetrics
+	sm        pmetric.ScopeMetrics
+	metric    pmetric.Metric
+}
+
+func (r *metricsReader) read(rec arrow.Record) error {
+	c, err := newMetricsColumns(rec)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < int(rec.NumRows()); i++ {
+		newResource, newScope := r.grouping.next(c.resourceScope.resourceKey(i), c.resourceScope.scopeKey(i))
+		if newResource {
+			r.rm = r.metrics.ResourceMetrics().AppendEmpty()
+			schemaURL, err := c.resourceScope.resource(i, r.rm.Resource())
+			if err != nil {
+				return err
+			}
+			r.rm.SetSchemaUrl(schemaURL)
+		}
+		if newScope {
+			r.sm = r.rm.ScopeMetrics().AppendEmpty()
+			schemaURL, err := c.resourceScope.scope(i, r.sm.Scope())
+			if err != nil {
+				return err
+			}
+			r.sm.SetSchemaUrl(schemaURL)
+		}
+		metricKey := c.metricKey(i)
+		if newScope || metricKey != r.metricKey {
+			r.metric = r.sm.Metrics().AppendEmpty()
+			if err := c.metric(i, r.metric); err != nil {
+				return err
+			}
+		}
+		r.metricKey = metricKey
+
+		if err := c.dataPoint(i, r.metric); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// metric restores the metric of a row, without its data points.
+func (c metricsColumns) metric(i int, m pmetric.Metric) error {
+	m.SetName(c.metricName.Value(i))
+	m.SetDescription(c.metricDescription.Value(i))
+	m.SetUnit(c.metricUnit.Value(i))
+	if err := c.metricMetadata.read(i, m.Metadata()); err != nil {
+		return fmt.Errorf("column %q: %w", columnMetricMetadata, err)
+	}
+	metricType, err := parseMetricType(c.metricType.Value(i))
+	if err != nil {
+		return err
+	}
+	var temporality pmetric.AggregationTemporality
+	if !c.aggregationTemporality.IsNull(i) {
+		if temporality, err = parseTemporality(c.aggregationTemporality.Value(i)); err != nil {
+			return err
+		}
+	}
+	switch metricType {
+	case pmetric.MetricTypeGauge:
+		m.SetEmptyGauge()
+	case pmetric.MetricTypeSum:
+		sum := m.SetEmptySum()
+		sum.SetAggregationTemporality(temporality)
+		sum.SetIsMonotonic(!c.isMonotonic.IsNull(i) && c.isMonotonic.Value(i))
+	case pmetric.MetricTypeHistogram:
+		m.SetEmptyHistogram().SetAggregationTemporality(temporality)
+	case pmetric.MetricTypeExponentialHistogram:
+		m.SetEmptyExponentialHistogram().SetAggregationTemporality(temporality)
+	case pmetric.MetricTypeSummary:
+		m.SetEmptySummary()
+	}
+	return nil
+}
+
+// dataPoint appends the data point of a row to the metric.
+func (c metricsColumns) dataPoint(i int, m pmetric.Metric) error {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return c.numberDataPoint(i, m.Gauge().DataPoints().AppendEmpty())
+	case pmetric.MetricTypeSum:
+		return c.numberDataPoint(i, m.Sum().DataPoints().AppendEmpty())
+	case pmetric.MetricTypeHistogram:
+		dp := m.Histogram().DataPoints().AppendEmpty()
+		if err := c.common(i, dp.Attributes(), dp.Exemplars()); err != nil {
+			return err
+		}
+		dp.SetStartTimestamp(readTimestamp(c.startTimestamp, i))
+		dp.SetTimestamp(readTimestamp(c.timestamp, i))
+		dp.SetFlags(pmetric.DataPointFlags(c.flags.Value(i)))
+		dp.SetCount(c.count.Value(i))
+		if !c.sum.IsNull(i) {
+			dp.SetSum(c.sum.Value(i))
+		}
+		if !c.minimum.IsNull(i) {
+			dp.SetMin(c.minimum.Value(i))
+		}
+		if !c.maximum.IsNull(i) {
+			dp.SetMax(c.maximum.Value(i))
+		}
+		start, end := c.bucketCounts.ValueOffsets(i)
+		dp.BucketCounts().FromRaw(c.bucketCountValues.Uint64Values()[start:end])
+		start, end = c.explicitBounds.ValueOffsets(i)
+		dp.ExplicitBounds().FromRaw(c.explicitBoundValues.Float64Values()[start:end])
+	case pmetric.MetricTypeExponentialHistogram:
+		dp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+		if err := c.common(i, dp.Attributes(), dp.Exemplars()); err != nil {
+			return err
+		}
+		dp.SetStartTimestamp(readTimestamp(c.startTimestamp, i))
+		dp.SetTimestamp(readTimestamp(c.timestamp, i))
+		dp.SetFlags(pmetric.DataPointFlags(c.flags.Value(i)))
+		dp.SetCount(c.count.Value(i))
+		if !c.sum.IsNull(i) {
+			dp.SetSum(c.sum.Value(i))
+		}
+		if !c.minimum.IsNull(i) {
+			dp.SetMin(c.minimum.Value(i))
+		}
+		if !c.maximum.IsNull(i) {
+			dp.SetMax(c.maximum.Value(i))
+		}
+		dp.SetScale(c.scale.Value(i))
+		dp.SetZeroCount(c.zeroCount.Value(i))
+		dp.SetZeroThreshold(c.zeroThreshold.Value(i))
+		dp.Positive().SetOffset(c.positiveOffset.Value(i))
+		start, end := c.positiveBucketCounts.ValueOffsets(i)
+		dp.Positive().BucketCounts().FromRaw(c.positiveBucketValues.Uint64Values()[start:end])
+		dp.Negative().SetOffset(c.negativeOffset.Value(i))
+		start, end = c.negativeBucketCounts.ValueOffsets(i)
+		dp.Negative().BucketCounts().FromRaw(c.negativeBucketValues.Uint64Values()[start:end])
+	case pmetric.MetricTypeSummary:
+		dp := m.Summary().DataPoints().AppendEmpty()
+		if err := c.attributes.read(i, dp.Attributes()); err != nil {
+			return fmt.Errorf("column %q: %w", columnAttributes, err)
+		}
+		dp.SetStartTimestamp(readTimestamp(c.startTimestamp, i))
+		dp.SetTimestamp(readTimestamp(c.timestamp, i))
+		dp.SetFlags(pmetric.DataPointFlags(c.flags.Value(i)))
+		dp.SetCount(c.count.Value(i))
+		dp.SetSum(c.sum.Value(i))
+		start, end := c.quantileValues.ValueOffsets(i)
+		dp.QuantileValues().EnsureCapacity(int(end - start))
+		for j := int(start); j < int(end); j++ {
+			q := dp.QuantileValues().AppendEmpty()
+			q.SetQuantile(c.quantile.Value(j))
+			q.SetValue(c.quantileValue.Value(j))
+		}
+	}
+	return nil
+}
+
+func (c metricsColumns) numberDataPoint(i int, dp pmetric.NumberDataPoint) error {
+	if err := c.common(i, dp.Attributes(), dp.Exemplars()); err != nil {
+		return err
+	}
+	dp.SetStartTimestamp(readTimestamp(c.startTimestamp, i))
+	dp.SetTimestamp(readTimestamp(c.timestamp, i))
+	dp.SetFlags(pmetric.DataPointFlags(c.flags.Value(i)))
+	switch {
+	case !c.valueDouble.IsNull(i):
+		dp.SetDoubleValue(c.valueDouble.Value(i))
+	case !c.valueInt.IsNull(i):
+		dp.SetIntValue(c.valueInt.Value(i))
+	}
+	return nil
+}
+
+// common restores the attributes and the exemplars of a data point.
+func (c metricsColumns) common(i int, attributes pcommon.Map, exemplars pmetric.ExemplarSlice) error {
+	if err := c.attributes.read(i, attributes); err != nil {
+		return fmt.Errorf("column %q: %w", columnAttributes, err)
+	}
+	start, end := c.exemplars.ValueOffsets(i)
+	exemplars.EnsureCapacity(int(end - start))
+	for j := int(start); j < int(end); j++ {
+		e := exemplars.AppendEmpty()
+		e.SetTimestamp(readTimestamp(c.exemplarTimestamp, j))
+		switch {
+		case !c.exemplarValueDouble.IsNull(j):
+			e.SetDoubleValue(c.exemplarValueDouble.Value(j))
+		case !c.exemplarValueInt.IsNull(j):
+			e.SetIntValue(c.exemplarValueInt.Value(j))
+		}
+		if err := c.exemplarFilteredAttributes.read(j, e.FilteredAttributes()); err != nil {
+			return fmt.Errorf("column %q: %w", columnExemplars, err)
+		}
+		traceID, err := readTraceID(c.exemplarTraceID, j)
+		if err != nil {
+			return err
+		}
+		e.SetTraceID(traceID)
+		spanID, err := readSpanID(c.exemplarSpanID, j)
+		if err != nil {
+			return err
+		}
+		e.SetSpanID(spanID)
+	}
+	return nil
+}
+
+func parseMetricType(s string) (pmetric.MetricType, error) {
+	for metricType, name := range metricTypes {
+		if name == s {
+			return metricType, nil
+		}
+	}
+	return pmetric.MetricTypeEmpty, fmt.Errorf("unknown metric type %q", s)
+}
+
+func parseTemporality(s string) (pmetric.AggregationTemporality, error) {
+	for temporality, name := range temporalities {
+		if name == s {
+			return temporality, nil
+		}
+	}
+	return pmetric.AggregationTemporalityUnspecified, fmt.Errorf("unknown aggregation temporality %q", s)
+}
@@ -0,0 +1,334 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	columnResourceAttributes = "resource_attributes"
+	columnResourceSchemaURL  = "resource_schema_url"
+	columnScopeName          = "scope_name"
+	columnScopeVersion       = "scope_version"
+	columnScopeAttributes    = "scope_attributes"
+	columnScopeSchemaURL     = "scope_schema_url"
+
+	columnTimestamp              = "timestamp"
+	columnAttributes             = "attributes"
+	columnDroppedAttributesCount = "dropped_attributes_count"
+	columnFlags                  = "flags"
+	columnTraceID                = "trace_id"
+	columnSpanID                 = "span_id"
+	columnTraceState             = "trace_state"
+	columnName                   = "name"
+
+	promotedResourcePrefix = "resource_"
+	promotedRecordPrefix   = "attribute_"
+)
+
+var timestampType = &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"}
+
+// resourceScopeFields are the columns describing the resource and the instrumentation scope of a row.
+func resourceScopeFields() []arrow.Field {
+	return []arrow.Field{
+		{Name: columnResourceAttributes, Type: attributesType},
+		{Name: columnResourceSchemaURL, Type: arrow.BinaryTypes.String},
+		{Name: columnScopeName, Type: arrow.BinaryTypes.String},
+		{Name: columnScopeVersion, Type: arrow.BinaryTypes.String},
+		{Name: columnScopeAttributes, Type: attributesType},
+		{Name: columnScopeSchemaURL, Type: arrow.BinaryTypes.String},
+	}
+}
+
+// fixedColumnNames returns the names of all the columns that aren't promoted attributes, for all signals.
+func fixedColumnNames() []string {
+	var names []string
+	for _, fields := range [][]arrow.Field{logsFields(), tracesFields(), metricsFields(), resourceScopeFields()} {
+		for _, field := range fields {
+			names = append(names, field.Name)
+		}
+	}
+	return names
+}
+
+type promotedAttribute struct {
+	key    string
+	column string
+	// resource is set when the attribute is read from the resource rather than the record
+	resource bool
+}
+
+func (p PromotedAttributes) promoted() []promotedAttribute {
+	promoted := make([]promotedAttribute, 0, len(p.Resource)+len(p.Record))
+	for _, key := range p.Resource {
+		promoted = append(promoted, promotedAttribute{key: key, column: promotedResourcePrefix + sanitize(key), resource: true})
+	}
+	for _, key := range p.Record {
+		promoted = append(promoted, promotedAttribute{key: key, column: promotedRecordPrefix + sanitize(key)})
+	}
+	return promoted
+}
+
+// sanitize replaces the characters that are not letters, digits or underscores, so that attribute names
+// such as `service.name` are turned into column names that query engines don't need to quote.
+func sanitize(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+}
+
+func promotedFields(promoted []promotedAttribute) []arrow.Field {
+	fields := make([]arrow.Field, 0, len(promoted))
+	for _, attr := range promoted {
+		fields = append(fields, arrow.Field{Name: attr.column, Type: valueType, Nullable: true})
+	}
+	return fields
+}
+
+func newSchema(promoted []promotedAttribute, fields ...[]arrow.Field) *arrow.Schema {
+	var all []arrow.Field
+	for _, f := range fields {
+		all = append(all, f...)
+	}
+	all = append(all, promotedFields(promoted)...)
+	return arrow.NewSchema(all, nil)
+}
+
+func fieldBuilder[T array.Builder](rb *array.RecordBuilder, name string) T {
+	return rb.Field(rb.Schema().FieldIndices(name)[0]).(T)
+}
+
+// resourceScopeBuilders appends the resource and scope columns of a row.
+type resourceScopeBuilders struct {
+	resourceAttributes attributesBuilder
+	resourceSchemaURL  *array.StringBuilder
+	scopeName          *array.StringBuilder
+	scopeVersion       *array.StringBuilder
+	scopeAttributes    attributesBuilder
+	scopeSchemaURL     *array.StringBuilder
+}
+
+func newResourceScopeBuilders(rb *array.RecordBuilder) resourceScopeBuilders {
+	return resourceScopeBuilders{
+		resourceAttributes: newAttributesBuilder(fieldBuilder[*array.MapBuilder](rb, columnResourceAttributes)),
+		resourceSchemaURL:  fieldBuilder[*array.StringBuilder](rb, columnResourceSchemaURL),
+		scopeName:          fieldBuilder[*array.StringBuilder](rb, columnScopeName),
+		scopeVersion:       fieldBuilder[*array.StringBuilder](rb, columnScopeVersion),
+		scopeAttributes:    newAttributesBuilder(fieldBuilder[*array.MapBuilder](rb, columnScopeAttributes)),
+		scopeSchemaURL:     fieldBuilder[*array.StringBuilder](rb, columnScopeSchemaURL),
+	}
+}
+
+func (b resourceScopeBuilders) append(resource pcommon.Resource, resourceSchemaURL string, scope pcommon.InstrumentationScope, scopeSchemaURL string) {
+	b.resourceAttributes.append(resource.Attributes())
+	b.resourceSchemaURL.Append(resourceSchemaURL)
+	b.scopeName.Append(scope.Name())
+	b.scopeVersion.Append(scope.Version())
+	b.scopeAttributes.append(scope.Attributes())
+	b.scopeSchemaURL.Append(scopeSchemaURL)
+}
+
+// promotedBuilders appends the promoted attribute columns of a row, which are null when the attribute is missing.
+type promotedBuilders struct {
+	attributes []promotedAttribute
+	builders   []valueBuilder
+}
+
+func newPromotedBuilders(rb *array.RecordBuilder, promoted []promotedAttribute) promotedBuilders {
+	b := promotedBuilders{attributes: promoted}
+	for _, attr := range promoted {
+		b.builders = append(b.builders, newValueBuilder(fieldBuilder[*array.StructBuilder](rb, attr.column)))
+	}
+	return b
+}
+
+func (b promotedBuilders) append(resourceAttributes, recordAttributes pcommon.Map) {
+	for i, attr := range b.attributes {
+		attrs := recordAttributes
+		if attr.resource {
+			attrs = resourceAttributes
+		}
+		if v, ok := attrs.Get(attr.key); ok {
+			b.builders[i].append(v)
+		} else {
+			b.builders[i].appendNull()
+		}
+	}
+}
+
+func appendTimestamp(b *array.TimestampBuilder, ts pcommon.Timestamp) {
+	if ts == 0 {
+		b.AppendNull()
+		return
+	}
+	b.Append(arrow.Timestamp(ts))
+}
+
+type id interface {
+	IsEmpty() bool
+	String() string
+}
+
+// appendID appends the hex representation of a trace or span ID, or null when the ID is empty.
+func appendID(b *array.StringBuilder, id id) {
+	if id.IsEmpty() {
+		b.AppendNull()
+		return
+	}
+	b.Append(id.String())
+}
+
+// columnAs returns the column with the given name, making sure it has the expected type.
+func columnAs[T arrow.Array](rec arrow.Record, name string) (T, error) {
+	var zero T
+	indices := rec.Schema().FieldIndices(name)
+	if len(indices) == 0 {
+		return zero, fmt.Errorf("missing column %q", name)
+	}
+	column, ok := rec.Column(indices[0]).(T)
+	if !ok {
+		return zero, fmt.Errorf("unexpected type %s for column %q", rec.Column(indices[0]).DataType(), name)
+	}
+	return column, nil
+}
+
+// structFieldAs returns the field with the given name of a struct array, making sure it has the expected type.
+func structFieldAs[T arrow.Array](s *array.Struct, name string) (T, error) {
+	var zero T
+	idx, found := s.DataType().(*arrow.StructType).FieldIdx(name)
+	if !found {
+		return zero, fmt.Errorf("missing field %q", name)
+	}
+	field, ok := s.Field(idx).(T)
+	if !ok {
+		return zero, fmt.Errorf("unexpected type %s for field %q", s.Field(idx).DataType(), name)
+	}
+	return field, nil
+}
+
+// resourceScopeColumns reads the resource and scope columns of a row.
+type resourceScopeColumns struct {
+	resourceAttributes attributesColumns
+	resourceSchemaURL  *array.String
+	scopeName          *array.String
+	scopeVersion       *array.String
+	scopeAttributes    attributesColumns
+	scopeSchemaURL     *array.String
+}
+
+func newResourceScopeColumns(rec arrow.Record) (resourceScopeColumns, error) {
+	var c resourceScopeColumns
+	var err error
+	if c.resourceAttributes, err = attributesColumn(rec, columnResourceAttributes); err != nil {
+		return c, err
+	}
+	if c.resourceSchemaURL, err = columnAs[*array.String](rec, columnResourceSchemaURL); err != nil {
+		return c, err
+	}
+	if c.scopeName, err = columnAs[*array.String](rec, columnScopeName); err != nil {
+		return c, err
+	}
+	if c.scopeVersion, err = columnAs[*array.String](rec, columnScopeVersion); err != nil {
+		return c, err
+	}
+	if c.scopeAttributes, err = attributesColumn(rec, columnScopeAttributes); err != nil {
+		return c, err
+	}
+	c.scopeSchemaURL, err = columnAs[*array.String](rec, columnScopeSchemaURL)
+	return c, err
+}
+
+// resourceKey identifies the resource of a row, so that consecutive rows sharing the same resource can be grouped.
+func (c resourceScopeColumns) resourceKey(i int) string {
+	return c.resourceAttributes.key(i) + "\x00" + c.resourceSchemaURL.Value(i)
+}
+
+// scopeKey identifies the scope of a row, so that consecutive rows sharing the same scope can be grouped.
+func (c resourceScopeColumns) scopeKey(i int) string {
+	return c.scopeName.Value(i) + "\x00" + c.scopeVersion.Value(i) + "\x00" + c.scopeAttributes.key(i) + "\x00" + c.scopeSchemaURL.Value(i)
+}
+
+// resource restores the resource of a row, returning its schema URL.
+func (c resourceScopeColumns) resource(i int, resource pcommon.Resource) (string, error) {
+	if err := c.resourceAttributes.read(i, resource.Attributes()); err != nil {
+		return "", fmt.Errorf("column %q: %w", columnResourceAttributes, err)
+	}
+	return c.resourceSchemaURL.Value(i), nil
+}
+
+// scope restores the scope of a row, returning its schema URL.
+func (c resourceScopeColumns) scope(i int, scope pcommon.InstrumentationScope) (string, error) {
+	scope.SetName(c.scopeName.Value(i))
+	scope.SetVersion(c.scopeVersion.Value(i))
+	if err := c.scopeAttributes.read(i, scope.Attributes()); err != nil {
+		return "", fmt.Errorf("column %q: %w", columnScopeAttributes, err)
+	}
+	return c.scopeSchemaURL.Value(i), nil
+}
+
+// grouping keeps track of the resource and scope of the last row, across records.
+type grouping struct {
+	started     bool
+	resourceKey string
+	scopeKey    string
+}
+
+// next returns whether the row needs a new resource and a new scope to be appended.
+func (g *grouping) next(resourceKey, scopeKey string) (newResource, newScope bool) {
+	newResource = !g.started || resourceKey != g.resourceKey
+	newScope = newResource || scopeKey != g.scopeKey
+	g.started = true
+	g.resourceKey = resourceKey
+	g.scopeKey = scopeKey
+	return newResource, newScope
+}
+
+func readTimestamp(c *array.Timestamp, i int) pcommon.Timestamp {
+	if c.IsNull(i) {
+		return 0
+	}
+	return pcommon.Timestamp(c.Value(i))
+}
+
+func readTraceID(c *array.String, i int) (pcommon.TraceID, error) {
+	var id pcommon.TraceID
+	if c.IsNull(i) {
+		return id, nil
+	}
+	err := decodeID(c.Value(i), id[:])
+	return id, err
+}
+
+func readSpanID(c *array.String, i int) (pcommon.SpanID, error) {
+	var id pcommon.SpanID
+	if c.IsNull(i) {
+		return id, nil
+	}
+	err := decodeID(c.Value(i), id[:])
+	return id, err
+}
+
+func decodeID(s string, dest []byte) error {
+	decoded, err := hex.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("invalid id %q: %w", s, err)
+	}
+	if len(decoded) != len(dest) {
+		return fmt.Errorf("invalid id %q: expected %d bytes, got %d", s, len(dest), len(decoded))
+	}
+	copy(dest, decoded)
+	return nil
+}
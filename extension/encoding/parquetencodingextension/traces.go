@@ -0,0 +1,470 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	columnParentSpanID       = "parent_span_id"
+	columnKind               = "kind"
+	columnStartTimestamp     = "start_timestamp"
+	columnEndTimestamp       = "end_timestamp"
+	columnDurationNs         = "duration_ns"
+	columnStatusCode         = "status_code"
+	columnStatusMessage      = "status_message"
+	columnEvents             = "events"
+	columnDroppedEventsCount = "dropped_events_count"
+	columnLinks              = "links"
+	columnDroppedLinksCount  = "dropped_links_count"
+)
+
+// the span kinds and status codes are written as strings that don't depend on the pdata representation
+var (
+	spanKinds = map[ptrace.SpanKind]string{
+		ptrace.SpanKindUnspecified: "Unspecified",
+		ptrace.SpanKindInternal:    "Internal",
+		ptrace.SpanKindServer:      "Server",
+		ptrace.SpanKindClient:      "Client",
+		ptrace.SpanKindProducer:    "Producer",
+		ptrace.SpanKindConsumer:    "Consumer",
+	}
+	statusCodes = map[ptrace.StatusCode]string{
+		ptrace.StatusCodeUnset: "Unset",
+		ptrace.StatusCodeOk:    "Ok",
+		ptrace.StatusCodeError: "Error",
+	}
+)
+
+var (
+	eventType = arrow.StructOf(
+		arrow.Field{Name: columnTimestamp, Type: timestampType, Nullable: true},
+		arrow.Field{Name: columnName, Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: columnAttributes, Type: attributesType},
+		arrow.Field{Name: columnDroppedAttributesCount, Type: arrow.PrimitiveTypes.Uint32},
+	)
+	linkType = arrow.StructOf(
+		arrow.Field{Name: columnTraceID, Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: columnSpanID, Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: columnTraceState, Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: columnAttributes, Type: attributesType},
+		arrow.Field{Name: columnDroppedAttributesCount, Type: arrow.PrimitiveTypes.Uint32},
+		arrow.Field{Name: columnFlags, Type: arrow.PrimitiveTypes.Uint32},
+	)
+)
+
+// tracesFields are the columns of a span, which are followed by the resource, scope and promoted columns.
+func tracesFields() []arrow.Field {
+	return []arrow.Field{
+		{Name: columnTraceID, Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: columnSpanID, Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: columnParentSpanID, Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: columnTraceState, Type: arrow.BinaryTypes.String},
+		{Name: columnName, Type: arrow.BinaryTypes.String},
+		{Name: columnKind, Type: arrow.BinaryTypes.String},
+		{Name: columnStartTimestamp, Type: timestampType, Nullable: true},
+		{Name: columnEndTimestamp, Type: timestampType, Nullable: true},
+		{Name: columnDurationNs, Type: arrow.PrimitiveTypes.Int64},
+		{Name: columnStatusCode, Type: arrow.BinaryTypes.String},
+		{Name: columnStatusMessage, Type: arrow.BinaryTypes.String},
+		{Name: columnAttributes, Type: attributesType},
+		{Name: columnDroppedAttributesCount, Type: arrow.PrimitiveTypes.Uint32},
+		{Name: columnEvents, Type: arrow.ListOf(eventType)},
+		{Name: columnDroppedEventsCount, Type: arrow.PrimitiveTypes.Uint32},
+		{Name: columnLinks, Type: arrow.ListOf(linkType)},
+		{Name: columnDroppedLinksCount, Type: arrow.PrimitiveTypes.Uint32},
+		{Name: columnFlags, Type: arrow.PrimitiveTypes.Uint32},
+	}
+}
+
+func newTracesSchema(promoted []promotedAttribute) *arrow.Schema {
+	return newSchema(promoted, tracesFields(), resourceScopeFields())
+}
+
+func structFieldBuilder[T array.Builder](sb *array.StructBuilder, st *arrow.StructType, name string) T {
+	idx, _ := st.FieldIdx(name)
+	return sb.FieldBuilder(idx).(T)
+}
+
+// tracesRecord converts the traces to a record, with a row per span.
+func tracesRecord(schema *arrow.Schema, promoted []promotedAttribute, td ptrace.Traces) arrow.Record {
+	rb := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer rb.Release()
+
+	traceID := fieldBuilder[*array.StringBuilder](rb, columnTraceID)
+	spanID := fieldBuilder[*array.StringBuilder](rb, columnSpanID)
+	parentSpanID := fieldBuilder[*array.StringBuilder](rb, columnParentSpanID)
+	traceState := fieldBuilder[*array.StringBuilder](rb, columnTraceState)
+	name := fieldBuilder[*array.StringBuilder](rb, columnName)
+	kind := fieldBuilder[*array.StringBuilder](rb, columnKind)
+	startTimestamp := fieldBuilder[*array.TimestampBuilder](rb, columnStartTimestamp)
+	endTimestamp := fieldBuilder[*array.TimestampBuilder](rb, columnEndTimestamp)
+	durationNs := fieldBuilder[*array.Int64Builder](rb, columnDurationNs)
+	statusCode := fieldBuilder[*array.StringBuilder](rb, columnStatusCode)
+	statusMessage := fieldBuilder[*array.StringBuilder](rb, columnStatusMessage)
+	attributes := newAttributesBuilder(fieldBuilder[*array.MapBuilder](rb, columnAttributes))
+	droppedAttributesCount := fieldBuilder[*array.Uint32Builder](rb, columnDroppedAttributesCount)
+	droppedEventsCount := fieldBuilder[*array.Uint32Builder](rb, columnDroppedEventsCount)
+	droppedLinksCount := fieldBuilder[*array.Uint32Builder](rb, columnDroppedLinksCount)
+	flags := fieldBuilder[*array.Uint32Builder](rb, columnFlags)
+	resourceScope := newResourceScopeBuilders(rb)
+	promotedColumns := newPromotedBuilders(rb, promoted)
+
+	events := fieldBuilder[*array.ListBuilder](rb, columnEvents)
+	event := events.ValueBuilder().(*array.StructBuilder)
+	eventTimestamp := structFieldBuilder[*array.TimestampBuilder](event, eventType, columnTimestamp)
+	eventName := structFieldBuilder[*array.StringBuilder](event, eventType, columnName)
+	eventAttributes := newAttributesBuilder(structFieldBuilder[*array.MapBuilder](event, eventType, columnAttributes))
+	eventDroppedAttributesCount := structFieldBuilder[*array.Uint32Builder](event, eventType, columnDroppedAttributesCount)
+
+	links := fieldBuilder[*array.ListBuilder](rb, columnLinks)
+	link := links.ValueBuilder().(*array.StructBuilder)
+	linkTraceID := structFieldBuilder[*array.StringBuilder](link, linkType, columnTraceID)
+	linkSpanID := structFieldBuilder[*array.StringBuilder](link, linkType, columnSpanID)
+	linkTraceState := structFieldBuilder[*array.StringBuilder](link, linkType, columnTraceState)
+	linkAttributes := newAttributesBuilder(structFieldBuilder[*array.MapBuilder](link, linkType, columnAttributes))
+	linkDroppedAttributesCount := structFieldBuilder[*array.Uint32Builder](link, linkType, columnDroppedAttributesCount)
+	linkFlags := structFieldBuilder[*array.Uint32Builder](link, linkType, columnFlags)
+
+	rb.Reserve(td.SpanCount())
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				appendID(traceID, span.TraceID())
+				appendID(spanID, span.SpanID())
+				appendID(parentSpanID, span.ParentSpanID())
+				traceState.Append(span.TraceState().AsRaw())
+				name.Append(span.Name())
+				kind.Append(spanKinds[span.Kind()])
+				appendTimestamp(startTimestamp, span.StartTimestamp())
+				appendTimestamp(endTimestamp, span.EndTimestamp())
+				durationNs.Append(int64(span.EndTimestamp()) - int64(span.StartTimestamp()))
+				statusCode.Append(statusCodes[span.Status().Code()])
+				statusMessage.Append(span.Status().Message())
+				attributes.append(span.Attributes())
+				droppedAttributesCount.Append(span.DroppedAttributesCount())
+
+				events.Append(true)
+				for l := 0; l < span.Events().Len(); l++ {
+					e := span.Events().At(l)
+					event.Append(true)
+					appendTimestamp(eventTimestamp, e.Timestamp())
+					eventName.Append(e.Name())
+					eventAttributes.append(e.Attributes())
+					eventDroppedAttributesCount.Append(e.DroppedAttributesCount())
+				}
+				droppedEventsCount.Append(span.DroppedEventsCount())
+
+				links.Append(true)
+				for l := 0; l < span.Links().Len(); l++ {
+					sl := span.Links().At(l)
+					link.Append(true)
+					appendID(linkTraceID, sl.TraceID())
+					appendID(linkSpanID, sl.SpanID())
+					linkTraceState.Append(sl.TraceState().AsRaw())
+					linkAttributes.append(sl.Attributes())
+					linkDroppedAttributesCount.Append(sl.DroppedAttributesCount())
+					linkFlags.Append(sl.Flags())
+				}
+				droppedLinksCount.Append(span.DroppedLinksCount())
+
+				flags.Append(span.Flags())
+				resourceScope.append(rs.Resource(), rs.SchemaUrl(), ss.Scope(), ss.SchemaUrl())
+				promotedColumns.append(rs.Resource().Attributes(), span.Attributes())
+			}
+		}
+	}
+	return rb.NewRecord()
+}
+
+// tracesColumns reads the columns of a span. The duration and the promoted columns are ignored,
+// as they are derived from the other columns.
+type tracesColumns struct {
+	traceID                *array.String
+	spanID                 *array.String
+	parentSpanID           *array.String
+	traceState             *array.String
+	name                   *array.String
+	kind                   *array.String
+	startTimestamp         *array.Timestamp
+	endTimestamp           *array.Timestamp
+	statusCode             *array.String
+	statusMessage          *array.String
+	attributes             attributesColumns
+	droppedAttributesCount *array.Uint32
+	events                 *array.List
+	droppedEventsCount     *array.Uint32
+	links                  *array.List
+	droppedLinksCount      *array.Uint32
+	flags                  *array.Uint32
+	resourceScope          resourceScopeColumns
+
+	eventTimestamp              *array.Timestamp
+	eventName                   *array.String
+	eventAttributes             attributesColumns
+	eventDroppedAttributesCount *array.Uint32
+
+	linkTraceID                *array.String
+	linkSpanID                 *array.String
+	linkTraceState             *array.String
+	linkAttributes             attributesColumns
+	linkDroppedAttributesCount *array.Uint32
+	linkFlags                  *array.Uint32
+}
+
+func newTracesColumns(rec arrow.Record) (tracesColumns, error) {
+	var c tracesColumns
+	var err error
+	if c.traceID, err = columnAs[*array.String](rec, columnTraceID); err != nil {
+		return c, err
+	}
+	if c.spanID, err = columnAs[*array.String](rec, columnSpanID); err != nil {
+		return c, err
+	}
+	if c.parentSpanID, err = columnAs[*array.String](rec, columnParentSpanID); err != nil {
+		return c, err
+	}
+	if c.traceState, err = columnAs[*array.String](rec, columnTraceState); err != nil {
+		return c, err
+	}
+	if c.name, err = columnAs[*array.String](rec, columnName); err != nil {
+		return c, err
+	}
+	if c.kind, err = columnAs[*array.String](rec, columnKind); err != nil {
+		return c, err
+	}
+	if c.startTimestamp, err = columnAs[*array.Timestamp](rec, columnStartTimestamp); err != nil {
+		return c, err
+	}
+	if c.endTimestamp, err = columnAs[*array.Timestamp](rec, columnEndTimestamp); err != nil {
+		return c, err
+	}
+	if c.statusCode, err = columnAs[*array.String](rec, columnStatusCode); err != nil {
+		return c, err
+	}
+	if c.statusMessage, err = columnAs[*array.String](rec, columnStatusMessage); err != nil {
+		return c, err
+	}
+	if c.attributes, err = attributesColumn(rec, columnAttributes); err != nil {
+		return c, err
+	}
+	if c.droppedAttributesCount, err = columnAs[*array.Uint32](rec, columnDroppedAttributesCount); err != nil {
+		return c, err
+	}
+	if c.events, err = columnAs[*array.List](rec, columnEvents); err != nil {
+		return c, err
+	}
+	if c.droppedEventsCount, err = columnAs[*array.Uint32](rec, columnDroppedEventsCount); err != nil {
+		return c, err
+	}
+	if c.links, err = columnAs[*array.List](rec, columnLinks); err != nil {
+		return c, err
+	}
+	if c.droppedLinksCount, err = columnAs[*array.Uint32](rec, columnDroppedLinksCount); err != nil {
+		return c, err
+	}
+	if c.flags, err = columnAs[*array.Uint32](rec, columnFlags); err != nil {
+		return c, err
+	}
+	if c.resourceScope, err = newResourceScopeColumns(rec); err != nil {
+		return c, err
+	}
+	if err = c.eventColumns(); err != nil {
+		return c, fmt.Errorf("column %q: %w", columnEvents, err)
+	}
+	if err = c.linkColumns(); err != nil {
+		return c, fmt.Errorf("column %q: %w", columnLinks, err)
+	}
+	return c, nil
+}
+
+func (c *tracesColumns) eventColumns() error {
+	events, ok := c.events.ListValues().(*array.Struct)
+	if !ok {
+		return fmt.Errorf("unexpected type %s", c.events.ListValues().DataType())
+	}
+	var err error
+	if c.eventTimestamp, err = structFieldAs[*array.Timestamp](events, columnTimestamp); err != nil {
+		return err
+	}
+	if c.eventName, err = structFieldAs[*array.String](events, columnName); err != nil {
+		return err
+	}
+	if c.eventAttributes, err = attributesField(events, columnAttributes); err != nil {
+		return err
+	}
+	c.eventDroppedAttributesCount, err = structFieldAs[*array.Uint32](events, columnDroppedAttributesCount)
+	return err
+}
+
+func (c *tracesColumns) linkColumns() error {
+	links, ok := c.links.ListValues().(*array.Struct)
+	if !ok {
+		return fmt.Errorf("unexpected type %s", c.links.ListValues().DataType())
+	}
+	var err error
+	if c.linkTraceID, err = structFieldAs[*array.String](links, columnTraceID); err != nil {
+		return err
+	}
+	if c.linkSpanID, err = structFieldAs[*array.String](links, columnSpanID); err != nil {
+		return err
+	}
+	if c.linkTraceState, err = structFieldAs[*array.String](links, columnTraceState); err != nil {
+		return err
+	}
+	if c.linkAttributes, err = attributesField(links, columnAttributes); err != nil {
+		return err
+	}
+	if c.linkDroppedAttributesCount, err = structFieldAs[*array.Uint32](links, columnDroppedAttributesCount); err != nil {
+		return err
+	}
+	c.linkFlags, err = structFieldAs[*array.Uint32](links, columnFlags)
+	return err
+}
+
+// tracesReader appends the rows of the records to the traces, grouping consecutive rows that share the same
+// resource and scope.
+type tracesReader struct {
+	traces   ptrace.Traces
+	grouping grouping
+	rs       ptrace.ResourceSpans
+	ss       ptrace.ScopeSpans
+}
+
+func (r *tracesReader) read(rec arrow.Record) error {
+	c, err := newTracesColumns(rec)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < int(rec.NumRows()); i++ {
+		newResource, newScope := r.grouping.next(c.resourceScope.resourceKey(i), c.resourceScope.scopeKey(i))
+		if newResource {
+			r.rs = r.traces.ResourceSpans().AppendEmpty()
+			schemaURL, err := c.resourceScope.resource(i, r.rs.Resource())
+			if err != nil {
+				return err
+			}
+			r.rs.SetSchemaUrl(schemaURL)
+		}
+		if newScope {
+			r.ss = r.rs.ScopeSpans().AppendEmpty()
+			schemaURL, err := c.resourceScope.scope(i, r.ss.Scope())
+			if err != nil {
+				return err
+			}
+			r.ss.SetSchemaUrl(schemaURL)
+		}
+
+		if err := c.span(i, r.ss.Spans().AppendEmpty()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c tracesColumns) span(i int, span ptrace.Span) error {
+	traceID, err := readTraceID(c.traceID, i)
+	if err != nil {
+		return err
+	}
+	span.SetTraceID(traceID)
+	spanID, err := readSpanID(c.spanID, i)
+	if err != nil {
+		return err
+	}
+	span.SetSpanID(spanID)
+	parentSpanID, err := readSpanID(c.parentSpanID, i)
+	if err != nil {
+		return err
+	}
+	span.SetParentSpanID(parentSpanID)
+	span.TraceState().FromRaw(c.traceState.Value(i))
+	span.SetName(c.name.Value(i))
+	kind, err := parseSpanKind(c.kind.Value(i))
+	if err != nil {
+		return err
+	}
+	span.SetKind(kind)
+	span.SetStartTimestamp(readTimestamp(c.startTimestamp, i))
+	span.SetEndTimestamp(readTimestamp(c.endTimestamp, i))
+	code, err := parseStatusCode(c.statusCode.Value(i))
+	if err != nil {
+		return err
+	}
+	span.Status().SetCode(code)
+	span.Status().SetMessage(c.statusMessage.Value(i))
+	if err := c.attributes.read(i, span.Attributes()); err != nil {
+		return fmt.Errorf("column %q: %w", columnAttributes, err)
+	}
+	span.SetDroppedAttributesCount(c.droppedAttributesCount.Value(i))
+	span.SetDroppedEventsCount(c.droppedEventsCount.Value(i))
+	span.SetDroppedLinksCount(c.droppedLinksCount.Value(i))
+	span.SetFlags(c.flags.Value(i))
+
+	start, end := c.events.ValueOffsets(i)
+	span.Events().EnsureCapacity(int(end - start))
+	for j := int(start); j < int(end); j++ {
+		event := span.Events().AppendEmpty()
+		event.SetTimestamp(readTimestamp(c.eventTimestamp, j))
+		event.SetName(c.eventName.Value(j))
+		if err := c.eventAttributes.read(j, event.Attributes()); err != nil {
+			return fmt.Errorf("column %q: %w", columnEvents, err)
+		}
+		event.SetDroppedAttributesCount(c.eventDroppedAttributesCount.Value(j))
+	}
+
+	start, end = c.links.ValueOffsets(i)
+	span.Links().EnsureCapacity(int(end - start))
+	for j := int(start); j < int(end); j++ {
+		link := span.Links().AppendEmpty()
+		traceID, err := readTraceID(c.linkTraceID, j)
+		if err != nil {
+			return err
+		}
+		link.SetTraceID(traceID)
+		spanID, err := readSpanID(c.linkSpanID, j)
+		if err != nil {
+			return err
+		}
+		link.SetSpanID(spanID)
+		link.TraceState().FromRaw(c.linkTraceState.Value(j))
+		if err := c.linkAttributes.read(j, link.Attributes()); err != nil {
+			return fmt.Errorf("column %q: %w", columnLinks, err)
+		}
+		link.SetDroppedAttributesCount(c.linkDroppedAttributesCount.Value(j))
+		link.SetFlags(c.linkFlags.Value(j))
+	}
+	return nil
+}
+
+func parseSpanKind(s string) (ptrace.SpanKind, error) {
+	for kind, name := range spanKinds {
+		if name == s {
+			return kind, nil
+		}
+	}
+	return ptrace.SpanKindUnspecified, fmt.Errorf("unknown span kind %q", s)
+}
+
+func parseStatusCode(s string) (ptrace.StatusCode, error) {
+	for code, name := range statusCodes {
+		if name == s {
+			return code, nil
+		}
+	}
+	return ptrace.StatusCodeUnset, fmt.Errorf("unknown status code %q", s)
+}
@@ -0,0 +1,465 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parquetencodingextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/encoding/parquetencodingextension"
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	fieldValueType   = "type"
+	fieldValueStr    = "str"
+	fieldValueInt    = "int"
+	fieldValueDouble = "double"
+	fieldValueBool   = "bool"
+	fieldValueBytes  = "bytes"
+	fieldValueJSON   = "json"
+)
+
+// valueType stores a pcommon.Value in the field matching its type, the other fields being null. Maps and
+// slices are stored in the json field, using the OTLP JSON encoding of AnyValue, so that the types of their
+// nested values are kept as well.
+var valueType = arrow.StructOf(
+	arrow.Field{Name: fieldValueType, Type: arrow.BinaryTypes.String},
+	arrow.Field{Name: fieldValueStr, Type: arrow.BinaryTypes.String, Nullable: true},
+	arrow.Field{Name: fieldValueInt, Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	arrow.Field{Name: fieldValueDouble, Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	arrow.Field{Name: fieldValueBool, Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+	arrow.Field{Name: fieldValueBytes, Type: arrow.BinaryTypes.Binary, Nullable: true},
+	arrow.Field{Name: fieldValueJSON, Type: arrow.BinaryTypes.String, Nullable: true},
+)
+
+var attributesType = arrow.MapOf(arrow.BinaryTypes.String, valueType)
+
+// valueTypes maps the names written to the type field to the value types.
+var valueTypes = func() map[string]pcommon.ValueType {
+	types := map[string]pcommon.ValueType{}
+	for _, t := range []pcommon.ValueType{
+		pcommon.ValueTypeEmpty, pcommon.ValueTypeStr, pcommon.ValueTypeInt, pcommon.ValueTypeDouble,
+		pcommon.ValueTypeBool, pcommon.ValueTypeMap, pcommon.ValueTypeSlice, pcommon.ValueTypeBytes,
+	} {
+		types[t.String()] = t
+	}
+	return types
+}()
+
+// valueBuilder appends values to a column of valueType.
+type valueBuilder struct {
+	sb      *array.StructBuilder
+	typ     *array.StringBuilder
+	str     *array.StringBuilder
+	integer *array.Int64Builder
+	double  *array.Float64Builder
+	boolean *array.BooleanBuilder
+	bytes   *array.BinaryBuilder
+	json    *array.StringBuilder
+}
+
+func newValueBuilder(sb *array.StructBuilder) valueBuilder {
+	return valueBuilder{
+		sb:      sb,
+		typ:     structFieldBuilder[*array.StringBuilder](sb, valueType, fieldValueType),
+		str:     structFieldBuilder[*array.StringBuilder](sb, valueType, fieldValueStr),
+		integer: structFieldBuilder[*array.Int64Builder](sb, valueType, fieldValueInt),
+		double:  structFieldBuilder[*array.Float64Builder](sb, valueType, fieldValueDouble),
+		boolean: structFieldBuilder[*array.BooleanBuilder](sb, valueType, fieldValueBool),
+		bytes:   structFieldBuilder[*array.BinaryBuilder](sb, valueType, fieldValueBytes),
+		json:    structFieldBuilder[*array.StringBuilder](sb, valueType, fieldValueJSON),
+	}
+}
+
+func (b valueBuilder) appendNull() {
+	b.sb.AppendNull()
+}
+
+func (b valueBuilder) append(v pcommon.Value) {
+	b.sb.Append(true)
+	b.typ.Append(v.Type().String())
+
+	str, integer, double, boolean, bytes, json := b.str.AppendNull, b.integer.AppendNull, b.double.AppendNull, b.boolean.AppendNull, b.bytes.AppendNull, b.json.AppendNull
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		str = func() { b.str.Append(v.Str()) }
+	case pcommon.ValueTypeInt:
+		integer = func() { b.integer.Append(v.Int()) }
+	case pcommon.ValueTypeDouble:
+		double = func() { b.double.Append(v.Double()) }
+	case pcommon.ValueTypeBool:
+		boolean = func() { b.boolean.Append(v.Bool()) }
+	case pcommon.ValueTypeBytes:
+		bytes = func() { b.bytes.Append(v.Bytes().AsRaw()) }
+	case pcommon.ValueTypeMap, pcommon.ValueTypeSlice:
+		json = func() { b.json.Append(marshalJSONValue(v)) }
+	}
+	str()
+	integer()
+	double()
+	boolean()
+	bytes()
+	json()
+}
+
+// valueColumns reads the values of a column of valueType.
+type valueColumns struct {
+	s       *array.Struct
+	typ     *array.String
+	str     *array.String
+	integer *array.Int64
+	double  *array.Float64
+	boolean *array.Boolean
+	bytes   *array.Binary
+	json    *array.String
+}
+
+func newValueColumns(s *array.Struct) (valueColumns, error) {
+	c := valueColumns{s: s}
+	var err error
+	if c.typ, err = structFieldAs[*array.String](s, fieldValueType); err != nil {
+		return c, err
+	}
+	if c.str, err = structFieldAs[*array.String](s, fieldValueStr); err != nil {
+		return c, err
+	}
+	if c.integer, err = structFieldAs[*array.Int64](s, fieldValueInt); err != nil {
+		return c, err
+	}
+	if c.double, err = structFieldAs[*array.Float64](s, fieldValueDouble); err != nil {
+		return c, err
+	}
+	if c.boolean, err = structFieldAs[*array.Boolean](s, fieldValueBool); err != nil {
+		return c, err
+	}
+	if c.bytes, err = structFieldAs[*array.Binary](s, fieldValueBytes); err != nil {
+		return c, err
+	}
+	c.json, err = structFieldAs[*array.String](s, fieldValueJSON)
+	return c, err
+}
+
+// valueColumn returns the value column with the given name.
+func valueColumn(rec arrow.Record, name string) (valueColumns, error) {
+	column, err := columnAs[*array.Struct](rec, name)
+	if err != nil {
+		return valueColumns{}, err
+	}
+	c, err := newValueColumns(column)
+	if err != nil {
+		return c, fmt.Errorf("column %q: %w", name, err)
+	}
+	return c, nil
+}
+
+// isNull returns whether the value at the given index is missing, as opposed to being an empty value.
+func (c valueColumns) isNull(i int) bool {
+	return c.s.IsNull(i)
+}
+
+func (c valueColumns) read(i int, dest pcommon.Value) error {
+	t, ok := valueTypes[c.typ.Value(i)]
+	if !ok {
+		return fmt.Errorf("unknown value type %q", c.typ.Value(i))
+	}
+	switch t {
+	case pcommon.ValueTypeStr:
+		dest.SetStr(c.str.Value(i))
+	case pcommon.ValueTypeInt:
+		dest.SetInt(c.integer.Value(i))
+	case pcommon.ValueTypeDouble:
+		dest.SetDouble(c.double.Value(i))
+	case pcommon.ValueTypeBool:
+		dest.SetBool(c.boolean.Value(i))
+	case pcommon.ValueTypeBytes:
+		dest.SetEmptyBytes().FromRaw(c.bytes.Value(i))
+	case pcommon.ValueTypeMap, pcommon.ValueTypeSlice:
+		return unmarshalJSONValue(c.json.Value(i), dest)
+	}
+	return nil
+}
+
+// key identifies the value at the given index, including its type.
+func (c valueColumns) key(i int) string {
+	var repr string
+	switch c.typ.Value(i) {
+	case pcommon.ValueTypeStr.String():
+		repr = c.str.Value(i)
+	case pcommon.ValueTypeInt.String():
+		repr = strconv.FormatInt(c.integer.Value(i), 10)
+	case pcommon.ValueTypeDouble.String():
+		repr = strconv.FormatUint(math.Float64bits(c.double.Value(i)), 16)
+	case pcommon.ValueTypeBool.String():
+		repr = strconv.FormatBool(c.boolean.Value(i))
+	case pcommon.ValueTypeBytes.String():
+		repr = string(c.bytes.Value(i))
+	case pcommon.ValueTypeMap.String(), pcommon.ValueTypeSlice.String():
+		repr = c.json.Value(i)
+	}
+	return c.typ.Value(i) + "\x01" + repr
+}
+
+// attributesBuilder appends attribute maps to a column of attributesType.
+type attributesBuilder struct {
+	mb     *array.MapBuilder
+	keys   *array.StringBuilder
+	values valueBuilder
+}
+
+func newAttributesBuilder(mb *array.MapBuilder) attributesBuilder {
+	return attributesBuilder{
+		mb:     mb,
+		keys:   mb.KeyBuilder().(*array.StringBuilder),
+		values: newValueBuilder(mb.ItemBuilder().(*array.StructBuilder)),
+	}
+}
+
+func (b attributesBuilder) append(attrs pcommon.Map) {
+	b.mb.Append(true)
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		b.keys.Append(k)
+		b.values.append(v)
+		return true
+	})
+}
+
+// attributesColumns reads the attribute maps of a column of attributesType.
+type attributesColumns struct {
+	m      *array.Map
+	keys   *array.String
+	values valueColumns
+}
+
+func newAttributesColumns(m *array.Map) (attributesColumns, error) {
+	keys, ok := m.Keys().(*array.String)
+	if !ok {
+		return attributesColumns{}, fmt.Errorf("unexpected key type %s", m.Keys().DataType())
+	}
+	items, ok := m.Items().(*array.Struct)
+	if !ok {
+		return attributesColumns{}, fmt.Errorf("unexpected item type %s", m.Items().DataType())
+	}
+	values, err := newValueColumns(items)
+	if err != nil {
+		return attributesColumns{}, err
+	}
+	return attributesColumns{m: m, keys: keys, values: values}, nil
+}
+
+// attributesColumn returns the attribute map column with the given name.
+func attributesColumn(rec arrow.Record, name string) (attributesColumns, error) {
+	column, err := columnAs[*array.Map](rec, name)
+	if err != nil {
+		return attributesColumns{}, err
+	}
+	c, err := newAttributesColumns(column)
+	if err != nil {
+		return c, fmt.Errorf("column %q: %w", name, err)
+	}
+	return c, nil
+}
+
+// attributesField returns the attribute map field with the given name of a struct array.
+func attributesField(s *array.Struct, name string) (attributesColumns, error) {
+	field, err := structFieldAs[*array.Map](s, name)
+	if err != nil {
+		return attributesColumns{}, err
+	}
+	c, err := newAttributesColumns(field)
+	if err != nil {
+		return c, fmt.Errorf("field %q: %w", name, err)
+	}
+	return c, nil
+}
+
+// read restores the attributes of a row.
+func (c attributesColumns) read(i int, dest pcommon.Map) error {
+	if c.m.IsNull(i) {
+		return nil
+	}
+	start, end := c.m.ValueOffsets(i)
+	dest.EnsureCapacity(int(end - start))
+	for j := int(start); j < int(end); j++ {
+		if err := c.values.read(j, dest.PutEmpty(c.keys.Value(j))); err != nil {
+			return fmt.Errorf("attribute %q: %w", c.keys.Value(j), err)
+		}
+	}
+	return nil
+}
+
+// key identifies the attributes of a row.
+func (c attributesColumns) key(i int) string {
+	if c.m.IsNull(i) {
+		return ""
+	}
+	start, end := c.m.ValueOffsets(i)
+	var sb strings.Builder
+	for j := int(start); j < int(end); j++ {
+		sb.WriteString(c.keys.Value(j))
+		sb.WriteByte('=')
+		sb.WriteString(c.values.key(j))
+		sb.WriteByte(0)
+	}
+	return sb.String()
+}
+
+// jsonValue is the OTLP JSON encoding of an AnyValue. Empty values have none of the fields set.
+type jsonValue struct {
+	StringValue *string     `json:"stringValue,omitempty"`
+	IntValue    *string     `json:"intValue,omitempty"`
+	DoubleValue *jsonDouble `json:"doubleValue,omitempty"`
+	BoolValue   *bool       `json:"boolValue,omitempty"`
+	BytesValue  *string     `json:"bytesValue,omitempty"`
+	ArrayValue  *jsonArray  `json:"arrayValue,omitempty"`
+	KvlistValue *jsonKvlist `json:"kvlistValue,omitempty"`
+}
+
+type jsonArray struct {
+	Values []jsonValue `json:"values"`
+}
+
+type jsonKvlist struct {
+	Values []jsonKeyValue `json:"values"`
+}
+
+type jsonKeyValue struct {
+	Key   string    `json:"key"`
+	Value jsonValue `json:"value"`
+}
+
+// jsonDouble encodes the doubles that JSON numbers can't represent as strings, as the OTLP JSON encoding does.
+type jsonDouble float64
+
+func (d jsonDouble) MarshalJSON() ([]byte, error) {
+	f := float64(d)
+	switch {
+	case math.IsNaN(f):
+		return []byte(`"NaN"`), nil
+	case math.IsInf(f, 1):
+		return []byte(`"Infinity"`), nil
+	case math.IsInf(f, -1):
+		return []byte(`"-Infinity"`), nil
+	}
+	return json.Marshal(f)
+}
+
+func (d *jsonDouble) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		switch s {
+		case "NaN":
+			*d = jsonDouble(math.NaN())
+		case "Infinity":
+			*d = jsonDouble(math.Inf(1))
+		case "-Infinity":
+			*d = jsonDouble(math.Inf(-1))
+		default:
+			return fmt.Errorf("invalid double %q", s)
+		}
+		return nil
+	}
+	var f float64
+	if err := json.Unmarshal(data, &f); err != nil {
+		return err
+	}
+	*d = jsonDouble(f)
+	return nil
+}
+
+func marshalJSONValue(v pcommon.Value) string {
+	// marshaling can't fail, as the doubles that JSON can't represent are encoded as strings
+	data, _ := json.Marshal(toJSONValue(v))
+	return string(data)
+}
+
+func toJSONValue(v pcommon.Value) jsonValue {
+	var jv jsonValue
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		s := v.Str()
+		jv.StringValue = &s
+	case pcommon.ValueTypeInt:
+		s := strconv.FormatInt(v.Int(), 10)
+		jv.IntValue = &s
+	case pcommon.ValueTypeDouble:
+		d := jsonDouble(v.Double())
+		jv.DoubleValue = &d
+	case pcommon.ValueTypeBool:
+		b := v.Bool()
+		jv.BoolValue = &b
+	case pcommon.ValueTypeBytes:
+		s := base64.StdEncoding.EncodeToString(v.Bytes().AsRaw())
+		jv.BytesValue = &s
+	case pcommon.ValueTypeSlice:
+		jv.ArrayValue = &jsonArray{Values: make([]jsonValue, 0, v.Slice().Len())}
+		for i := 0; i < v.Slice().Len(); i++ {
+			jv.ArrayValue.Values = append(jv.ArrayValue.Values, toJSONValue(v.Slice().At(i)))
+		}
+	case pcommon.ValueTypeMap:
+		jv.KvlistValue = &jsonKvlist{Values: make([]jsonKeyValue, 0, v.Map().Len())}
+		v.Map().Range(func(k string, mv pcommon.Value) bool {
+			jv.KvlistValue.Values = append(jv.KvlistValue.Values, jsonKeyValue{Key: k, Value: toJSONValue(mv)})
+			return true
+		})
+	}
+	return jv
+}
+
+func unmarshalJSONValue(s string, dest pcommon.Value) error {
+	var jv jsonValue
+	if err := json.Unmarshal([]byte(s), &jv); err != nil {
+		return fmt.Errorf("invalid json value: %w", err)
+	}
+	return fromJSONValue(jv, dest)
+}
+
+func fromJSONValue(jv jsonValue, dest pcommon.Value) error {
+	switch {
+	case jv.StringValue != nil:
+		dest.SetStr(*jv.StringValue)
+	case jv.IntValue != nil:
+		i, err := strconv.ParseInt(*jv.IntValue, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid int value: %w", err)
+		}
+		dest.SetInt(i)
+	case jv.DoubleValue != nil:
+		dest.SetDouble(float64(*jv.DoubleValue))
+	case jv.BoolValue != nil:
+		dest.SetBool(*jv.BoolValue)
+	case jv.BytesValue != nil:
+		b, err := base64.StdEncoding.DecodeString(*jv.BytesValue)
+		if err != nil {
+			return fmt.Errorf("invalid bytes value: %w", err)
+		}
+		dest.SetEmptyBytes().FromRaw(b)
+	case jv.ArrayValue != nil:
+		slice := dest.SetEmptySlice()
+		slice.EnsureCapacity(len(jv.ArrayValue.Values))
+		for _, v := range jv.ArrayValue.Values {
+			if err := fromJSONValue(v, slice.AppendEmpty()); err != nil {
+				return err
+			}
+		}
+	case jv.KvlistValue != nil:
+		m := dest.SetEmptyMap()
+		m.EnsureCapacity(len(jv.KvlistValue.Values))
+		for _, kv := range jv.KvlistValue.Values {
+			if kv.Key == "" {
+				return errors.New("invalid kvlist value: empty key")
+			}
+			if err := fromJSONValue(kv.Value, m.PutEmpty(kv.Key)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	cefPrefix      = "CEF:"
+	cefHeaderCount = 7
+
+	// Keys of the values returned by ParseCEF and ParseLEEF.
+	SecurityEventVersion            = "version"
+	SecurityEventDeviceVendor       = "device_vendor"
+	SecurityEventDeviceProduct      = "device_product"
+	SecurityEventDeviceVersion      = "device_version"
+	SecurityEventDeviceEventClassID = "device_event_class_id"
+	SecurityEventName               = "name"
+	SecurityEventSeverity           = "severity"
+	SecurityEventID                 = "event_id"
+	SecurityEventExtensions         = "extensions"
+
+	// Severity levels returned by SecuritySeverityLevel.
+	SecuritySeverityUnknown  = "Unknown"
+	SecuritySeverityLow      = "Low"
+	SecuritySeverityMedium   = "Medium"
+	SecuritySeverityHigh     = "High"
+	SecuritySeverityVeryHigh = "Very-High"
+)
+
+// ParseCEF parses an ArcSight Common Event Format (CEF) message of the form
+// `CEF:Version|Device Vendor|Device Product|Device Version|Device Event Class ID|Name|Severity|Extension`.
+// Any text preceding the `CEF:` prefix, such as a syslog header, is ignored.
+// Header fields are unescaped (`\|` and `\\`), and the space separated extension key/value
+// pairs are returned in a nested map, with their `\=`, `\\`, `\n` and `\r` escape sequences resolved.
+func ParseCEF(input string) (map[string]any, error) {
+	start := strings.Index(input, cefPrefix)
+	if start < 0 {
+		return nil, errors.New("missing CEF prefix")
+	}
+
+	fields, rest, err := splitHeader(input[start+len(cefPrefix):], cefHeaderCount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CEF header: %w", err)
+	}
+
+	extensions, err := parseCEFExtensions(rest)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CEF extension: %w", err)
+	}
+
+	return map[string]any{
+		SecurityEventVersion:            fields[0],
+		SecurityEventDeviceVendor:       fields[1],
+		SecurityEventDeviceProduct:      fields[2],
+		SecurityEventDeviceVersion:      fields[3],
+		SecurityEventDeviceEventClassID: fields[4],
+		SecurityEventName:               fields[5],
+		SecurityEventSeverity:           fields[6],
+		SecurityEventExtensions:         extensions,
+	}, nil
+}
+
+// SecuritySeverityLevel returns the level of a CEF or LEEF severity.
+// Numeric severities are mapped as: 0-3 Low, 4-6 Medium, 7-8 High and 9-10 Very-High.
+// The textual levels defined by CEF are accepted case-insensitively.
+func SecuritySeverityLevel(severity string) (string, error) {
+	severity = strings.TrimSpace(severity)
+	if n, err := strconv.Atoi(severity); err == nil {
+		switch {
+		case n < 0 || n > 10:
+			return "", fmt.Errorf("severity %d is out of range [0, 10]", n)
+		case n <= 3:
+			return SecuritySeverityLow, nil
+		case n <= 6:
+			return SecuritySeverityMedium, nil
+		case n <= 8:
+			return SecuritySeverityHigh, nil
+		default:
+			return SecuritySeverityVeryHigh, nil
+		}
+	}
+	for _, level := range []string{SecuritySeverityUnknown, SecuritySeverityLow, SecuritySeverityMedium, SecuritySeverityHigh, SecuritySeverityVeryHigh} {
+		if strings.EqualFold(severity, level) {
+			return level, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported severity %q", severity)
+}
+
+// splitHeader splits the first count pipe separated header fields, unescaping `\|` and `\\`.
+// The remaining text, after the last header pipe, is returned unchanged.
+func splitHeader(input string, count int) ([]string, string, error) {
+	fields := make([]string, 0, count)
+	var current strings.Builder
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		if c == '\\' && i+1 < len(input) && (input[i+1] == '|' || input[i+1] == '\\') {
+			current.WriteByte(input[i+1])
+			i++
+			continue
+		}
+		if c != '|' {
+			current.WriteByte(c)
+			continue
+		}
+		fields = append(fields, current.String())
+		current.Reset()
+		if len(fields) == count {
+			return fields, input[i+1:], nil
+		}
+	}
+	return nil, "", fmt.Errorf("expected %d fields, got %d", count, len(fields)+1)
+}
+
+// parseCEFExtensions parses space separated key=value pairs, where values can contain spaces.
+// A key starts after a space and ends at the first unescaped equal sign.
+func parseCEFExtensions(input string) (map[string]any, error) {
+	type mark struct{ keyStart, equal int }
+	var marks []mark
+	for i := 0; i < len(input); i++ {
+		switch input[i] {
+		case '\\':
+			i++
+		case '=':
+			keyStart := strings.LastIndexByte(input[:i], ' ') + 1
+			if len(marks) > 0 && keyStart <= marks[len(marks)-1].equal {
+				// An unescaped equal sign within the value of the previous key.
+				continue
+			}
+			if !isExtensionKey(input[keyStart:i]) {
+				continue
+			}
+			marks = append(marks, mark{keyStart, i})
+		}
+	}
+
+	extensions := make(map[string]any, len(marks))
+	if len(marks) == 0 {
+		if strings.TrimSpace(input) != "" {
+			return nil, fmt.Errorf("no key/value pairs found in %q", input)
+		}
+		return extensions, nil
+	}
+	if leading := strings.TrimSpace(input[:marks[0].keyStart]); leading != "" {
+		return nil, fmt.Errorf("unexpected text %q before the first key", leading)
+	}
+	for i, m := range marks {
+		end := len(input)
+		if i+1 < len(marks) {
+			end = marks[i+1].keyStart
+		}
+		extensions[input[m.keyStart:m.equal]] = unescapeCEFExtensionValue(strings.TrimRight(input[m.equal+1:end], " "))
+	}
+	return extensions, nil
+}
+
+func isExtensionKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, c := range key {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') && !strings.ContainsRune("_.-[]", c) {
+			return false
+		}
+	}
+	return true
+}
+
+func unescapeCEFExtensionValue(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCEF(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]any
+	}{
+		{
+			name:  "header only",
+			input: "CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|",
+			expected: map[string]any{
+				"version":               "0",
+				"device_vendor":         "Security",
+				"device_product":        "threatmanager",
+				"device_version":        "1.0",
+				"device_event_class_id": "100",
+				"name":                  "worm successfully stopped",
+				"severity":              "10",
+				"extensions":            map[string]any{},
+			},
+		},
+		{
+			name:  "extensions",
+			input: "CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 spt=1232 msg=Detected a threat. No action needed.",
+			expected: map[string]any{
+				"version":               "0",
+				"device_vendor":         "Security",
+				"device_product":        "threatmanager",
+				"device_version":        "1.0",
+				"device_event_class_id": "100",
+				"name":                  "worm successfully stopped",
+				"severity":              "10",
+				"extensions": map[string]any{
+					"src": "10.0.0.1",
+					"dst": "2.1.2.2",
+					"spt": "1232",
+					"msg": "Detected a threat. No action needed.",
+				},
+			},
+		},
+		{
+			name:  "syslog prefix and escaped header",
+			input: `<134>Sep 19 08:26:10 host CEF:1|Vendor\|Inc|Product\\Suite|2.0|signature|Name with \| pipe|High|act=blocked`,
+			expected: map[string]any{
+				"version":               "1",
+				"device_vendor":         "Vendor|Inc",
+				"device_product":        `Product\Suite`,
+				"device_version":        "2.0",
+				"device_event_class_id": "signature",
+				"name":                  "Name with | pipe",
+				"severity":              "High",
+				"extensions": map[string]any{
+					"act": "blocked",
+				},
+			},
+		},
+		{
+			name:  "escaped extension values",
+			input: `CEF:0|V|P|1|id|n|5|request=http://a.com/?x\=1&y=2 filePath=C:\\Windows\\temp msg=line1\nline2\r cs1Label=rule cs1=a b|c`,
+			expected: map[string]any{
+				"version":               "0",
+				"device_vendor":         "V",
+				"device_product":        "P",
+				"device_version":        "1",
+				"device_event_class_id": "id",
+				"name":                  "n",
+				"severity":              "5",
+				"extensions": map[string]any{
+					"request":  "http://a.com/?x=1&y=2",
+					"filePath": `C:\Windows\temp`,
+					"msg":      "line1\nline2\r",
+					"cs1Label": "rule",
+					"cs1":      "a b|c",
+				},
+			},
+		},
+		{
+			name:  "empty values",
+			input: "CEF:0|V|P|1|id|n|5|suser= duser=bob",
+			expected: map[string]any{
+				"version":               "0",
+				"device_vendor":         "V",
+				"device_product":        "P",
+				"device_version":        "1",
+				"device_event_class_id": "id",
+				"name":                  "n",
+				"severity":              "5",
+				"extensions": map[string]any{
+					"suser": "",
+					"duser": "bob",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := ParseCEF(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestParseCEF_errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "missing prefix",
+			input:    "LEEF:1.0|V|P|1|id|",
+			expected: "missing CEF prefix",
+		},
+		{
+			name:     "incomplete header",
+			input:    "CEF:0|V|P|1|id|n",
+			expected: "invalid CEF header: expected 7 fields, got 6",
+		},
+		{
+			name:     "no pairs",
+			input:    "CEF:0|V|P|1|id|n|5|just some text",
+			expected: `invalid CEF extension: no key/value pairs found in "just some text"`,
+		},
+		{
+			name:     "leading text",
+			input:    "CEF:0|V|P|1|id|n|5|garbage src=10.0.0.1",
+			expected: `invalid CEF extension: unexpected text "garbage" before the first key`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCEF(tt.input)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestSecuritySeverityLevel(t *testing.T) {
+	tests := []struct {
+		severity string
+		expected string
+	}{
+		{"0", SecuritySeverityLow},
+		{"3", SecuritySeverityLow},
+		{"4", SecuritySeverityMedium},
+		{"6", SecuritySeverityMedium},
+		{"7", SecuritySeverityHigh},
+		{"8", SecuritySeverityHigh},
+		{"9", SecuritySeverityVeryHigh},
+		{"10", SecuritySeverityVeryHigh},
+		{"very-high", SecuritySeverityVeryHigh},
+		{" medium ", SecuritySeverityMedium},
+		{"Unknown", SecuritySeverityUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.severity, func(t *testing.T) {
+			level, err := SecuritySeverityLevel(tt.severity)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, level)
+		})
+	}
+
+	_, err := SecuritySeverityLevel("11")
+	assert.EqualError(t, err, "severity 11 is out of range [0, 10]")
+	_, err = SecuritySeverityLevel("critical")
+	assert.EqualError(t, err, `unsupported severity "critical"`)
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/multierr"
+)
+
+const (
+	leefPrefix      = "LEEF:"
+	leefHeaderCount = 5
+
+	// LEEFDefaultDelimiter is the LEEF 1.0 attributes delimiter.
+	LEEFDefaultDelimiter = "\t"
+	// LEEFSeverityKey is the LEEF predefined attribute holding the event severity.
+	LEEFSeverityKey = "sev"
+)
+
+// ParseLEEF parses an IBM Log Event Extended Format (LEEF) 1.0 or 2.0 message of the form
+// `LEEF:Version|Vendor|Product|Version|EventID|[Delimiter|]Attributes`.
+// Any text preceding the `LEEF:` prefix, such as a syslog header, is ignored.
+// The attributes are split using the delimiter declared by LEEF 2.0 headers, either as a
+// character or as its hexadecimal code (e.g. `^`, `x5E` or `0x5E`). When the header does not
+// declare one, the given delimiter is used, or a tab if it is empty.
+func ParseLEEF(input string, delimiter string) (map[string]any, error) {
+	start := strings.Index(input, leefPrefix)
+	if start < 0 {
+		return nil, errors.New("missing LEEF prefix")
+	}
+
+	fields, rest, err := splitHeader(input[start+len(leefPrefix):], leefHeaderCount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LEEF header: %w", err)
+	}
+
+	if delimiter == "" {
+		delimiter = LEEFDefaultDelimiter
+	}
+	if strings.HasPrefix(fields[0], "2") {
+		if end := strings.IndexByte(rest, '|'); end >= 0 {
+			if declared, ok := parseLEEFDelimiter(rest[:end]); ok {
+				delimiter = declared
+				rest = rest[end+1:]
+			}
+		}
+	}
+
+	attributes, err := parseLEEFAttributes(rest, delimiter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid LEEF attributes: %w", err)
+	}
+
+	return map[string]any{
+		SecurityEventVersion:       fields[0],
+		SecurityEventDeviceVendor:  fields[1],
+		SecurityEventDeviceProduct: fields[2],
+		SecurityEventDeviceVersion: fields[3],
+		SecurityEventID:            fields[4],
+		SecurityEventExtensions:    attributes,
+	}, nil
+}
+
+// parseLEEFDelimiter parses the delimiter header field of LEEF 2.0 messages.
+func parseLEEFDelimiter(value string) (string, bool) {
+	switch {
+	case value == "":
+		return "", false
+	case len(value) == 1:
+		return value, true
+	}
+	lower := strings.ToLower(value)
+	hex, ok := strings.CutPrefix(lower, "0x")
+	if !ok {
+		hex, ok = strings.CutPrefix(lower, "x")
+	}
+	if !ok || hex == "" || len(hex) > 4 {
+		return "", false
+	}
+	code, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return "", false
+	}
+	return string(rune(code)), true
+}
+
+func parseLEEFAttributes(input string, delimiter string) (map[string]any, error) {
+	attributes := make(map[string]any)
+	var errs error
+	for _, pair := range strings.Split(input, delimiter) {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			errs = multierr.Append(errs, fmt.Errorf("cannot split %q into a key and a value", pair))
+			continue
+		}
+		attributes[key] = value
+	}
+	return attributes, errs
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLEEF(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		delimiter string
+		expected  map[string]any
+	}{
+		{
+			name:  "leef 1.0",
+			input: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tdst=172.50.123.1\tsev=5\tcat=anomaly\tmsg=there are spaces",
+			expected: map[string]any{
+				"version":        "1.0",
+				"device_vendor":  "Microsoft",
+				"device_product": "MSExchange",
+				"device_version": "4.0 SP1",
+				"event_id":       "15345",
+				"extensions": map[string]any{
+					"src": "192.0.2.0",
+					"dst": "172.50.123.1",
+					"sev": "5",
+					"cat": "anomaly",
+					"msg": "there are spaces",
+				},
+			},
+		},
+		{
+			name:  "leef 2.0 with character delimiter",
+			input: "<13>Jan 18 11:07:53 host LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5^sev=5^url=http://a.com/?x=1",
+			expected: map[string]any{
+				"version":        "2.0",
+				"device_vendor":  "Lancope",
+				"device_product": "StealthWatch",
+				"device_version": "1.0",
+				"event_id":       "41",
+				"extensions": map[string]any{
+					"src": "10.0.1.8",
+					"dst": "10.0.0.5",
+					"sev": "5",
+					"url": "http://a.com/?x=1",
+				},
+			},
+		},
+		{
+			name:  "leef 2.0 with hex delimiter",
+			input: "LEEF:2.0|Vendor|Product|1.0|id|0x7C|a=1|b=2",
+			expected: map[string]any{
+				"version":        "2.0",
+				"device_vendor":  "Vendor",
+				"device_product": "Product",
+				"device_version": "1.0",
+				"event_id":       "id",
+				"extensions": map[string]any{
+					"a": "1",
+					"b": "2",
+				},
+			},
+		},
+		{
+			name:      "custom delimiter",
+			input:     "LEEF:1.0|Vendor|Product|1.0|id|a=1;b=2;",
+			delimiter: ";",
+			expected: map[string]any{
+				"version":        "1.0",
+				"device_vendor":  "Vendor",
+				"device_product": "Product",
+				"device_version": "1.0",
+				"event_id":       "id",
+				"extensions": map[string]any{
+					"a": "1",
+					"b": "2",
+				},
+			},
+		},
+		{
+			name:  "leef 2.0 without declared delimiter",
+			input: "LEEF:2.0|Vendor|Product|1.0|id|a=1\tb=x|y",
+			expected: map[string]any{
+				"version":        "2.0",
+				"device_vendor":  "Vendor",
+				"device_product": "Product",
+				"device_version": "1.0",
+				"event_id":       "id",
+				"extensions": map[string]any{
+					"a": "1",
+					"b": "x|y",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := ParseLEEF(tt.input, tt.delimiter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestParseLEEF_errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "missing prefix",
+			input:    "CEF:0|V|P|1|id|n|5|",
+			expected: "missing LEEF prefix",
+		},
+		{
+			name:     "incomplete header",
+			input:    "LEEF:1.0|V|P|1",
+			expected: "invalid LEEF header: expected 5 fields, got 4",
+		},
+		{
+			name:     "invalid pair",
+			input:    "LEEF:1.0|V|P|1|id|a=1\tinvalid",
+			expected: `invalid LEEF attributes: cannot split "invalid" into a key and a value`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseLEEF(tt.input, "")
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"golang.org/x/net/html/charset"
+)
+
+// Keys of the values returned by ParseXML.
+const (
+	XMLTagKey        = "tag"
+	XMLContentKey    = "content"
+	XMLAttributesKey = "attributes"
+	XMLChildrenKey   = "children"
+)
+
+// ParseXML parses a single XML document, preserving its attributes and element order.
+// Each element is returned as a map holding its `tag`, its trimmed text `content`, its
+// `attributes` and its `children` elements, the last three being omitted when empty.
+// Comments, processing instructions and directives are ignored, as is the whitespace
+// between elements, so documents spanning multiple lines are supported. Any bytes
+// following the root element are rejected.
+func ParseXML(input string) (pcommon.Map, error) {
+	parsedXML := xmlElement{}
+
+	decoder := xml.NewDecoder(strings.NewReader(input))
+	decoder.CharsetReader = charset.NewReaderLabel
+	if err := decoder.Decode(&parsedXML); err != nil {
+		return pcommon.Map{}, fmt.Errorf("unmarshal xml: %w", err)
+	}
+
+	// The offsets of the decoder are the ones of the UTF-8 input when another
+	// encoding is declared, so the end of the input is checked with the next token.
+	if _, err := decoder.RawToken(); !errors.Is(err, io.EOF) {
+		return pcommon.Map{}, errors.New("trailing bytes after parsing xml")
+	}
+
+	parsedMap := pcommon.NewMap()
+	parsedXML.intoMap(parsedMap)
+	return parsedMap, nil
+}
+
+type xmlElement struct {
+	tag        string
+	attributes []xml.Attr
+	text       string
+	children   []xmlElement
+}
+
+// UnmarshalXML implements xml.Unmarshaler for xmlElement
+func (a *xmlElement) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	a.tag = start.Name.Local
+	a.attributes = start.Attr
+
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return fmt.Errorf("decode next token: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			child := xmlElement{}
+			err := d.DecodeElement(&child, &t)
+			if err != nil {
+				return err
+			}
+
+			a.children = append(a.children, child)
+		case xml.EndElement:
+			// End element means we've reached the end of parsing
+			return nil
+		case xml.CharData:
+			// Strip leading/trailing spaces to ignore newlines and
+			// indentation in formatted XML
+			a.text += string(bytes.TrimSpace([]byte(t)))
+		case xml.Comment: // ignore comments
+		case xml.ProcInst: // ignore processing instructions
+		case xml.Directive: // ignore directives
+		default:
+			return fmt.Errorf("unexpected token type %T", t)
+		}
+	}
+}
+
+// intoMap converts and adds the xmlElement into the provided pcommon.Map.
+func (a xmlElement) intoMap(m pcommon.Map) {
+	m.EnsureCapacity(4)
+
+	m.PutStr(XMLTagKey, a.tag)
+
+	if a.text != "" {
+		m.PutStr(XMLContentKey, a.text)
+	}
+
+	if len(a.attributes) > 0 {
+		attrs := m.PutEmptyMap(XMLAttributesKey)
+		attrs.EnsureCapacity(len(a.attributes))
+
+		for _, attr := range a.attributes {
+			attrs.PutStr(attr.Name.Local, attr.Value)
+		}
+	}
+
+	if len(a.children) > 0 {
+		children := m.PutEmptySlice(XMLChildrenKey)
+		children.EnsureCapacity(len(a.children))
+
+		for _, child := range a.children {
+			child.intoMap(children.AppendEmpty().SetEmptyMap())
+		}
+	}
+}
+
+// ParseSimplifiedXML parses XML elements into a map keyed by element names, discarding
+// attributes and extraneous text content. Elements containing only text are returned as
+// the first text or CDATA content, elements with children as maps, and repeated sibling
+// elements are grouped into a slice. Empty elements are omitted.
+func ParseSimplifiedXML(input string) (pcommon.Map, error) {
+	doc, err := decodeXMLNodes(input)
+	if err != nil {
+		return pcommon.Map{}, err
+	}
+
+	docMap := pcommon.NewMap()
+	parseElement(doc, docMap)
+	return docMap, nil
+}
+
+// xmlNode is an element of the tree decoded by decodeXMLNodes.
+type xmlNode struct {
+	tag string
+	// texts are the text and CDATA contents held directly by the element.
+	texts    []string
+	children []*xmlNode
+}
+
+// decodeXMLNodes decodes the XML elements into the children of a document node.
+func decodeXMLNodes(input string) (*xmlNode, error) {
+	decoder := xml.NewDecoder(strings.NewReader(input))
+	decoder.CharsetReader = charset.NewReaderLabel
+	doc := &xmlNode{}
+	stack := []*xmlNode{doc}
+	for {
+		tok, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return doc, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal xml: %w", err)
+		}
+
+		parent := stack[len(stack)-1]
+		switch t := tok.(type) {
+		case xml.StartElement:
+			node := &xmlNode{tag: t.Name.Local}
+			parent.children = append(parent.children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			parent.texts = append(parent.texts, string(t))
+		}
+	}
+}
+
+func parseElement(parent *xmlNode, parentMap pcommon.Map) {
+	// Count the number of each element tag so we know whether it will be a member of a slice or not
+	childTags := make(map[string]int)
+	for _, child := range parent.children {
+		childTags[child.tag]++
+	}
+	if len(childTags) == 0 {
+		return
+	}
+
+	// Convert the children, now knowing whether they will be a member of a slice or not
+	for _, child := range parent.children {
+		leafValue := leafValueFromElement(child)
+
+		// Slice of the same element
+		if childTags[child.tag] > 1 {
+			// Get or create the slice of children
+			var childrenSlice pcommon.Slice
+			childrenValue, ok := parentMap.Get(child.tag)
+			if ok {
+				childrenSlice = childrenValue.Slice()
+			} else {
+				childrenSlice = parentMap.PutEmptySlice(child.tag)
+			}
+
+			// Add the child's text content to the slice
+			if leafValue != "" {
+				childrenSlice.AppendEmpty().SetStr(leafValue)
+				continue
+			}
+
+			// Parse the child to make sure there's something to add
+			childMap := pcommon.NewMap()
+			parseElement(child, childMap)
+			if childMap.Len() == 0 {
+				continue
+			}
+
+			childMap.MoveTo(childrenSlice.AppendEmpty().SetEmptyMap())
+			continue
+		}
+
+		if leafValue != "" {
+			parentMap.PutStr(child.tag, leafValue)
+			continue
+		}
+
+		// Child will be a map
+		childMap := pcommon.NewMap()
+		parseElement(child, childMap)
+		if childMap.Len() == 0 {
+			continue
+		}
+
+		childMap.MoveTo(parentMap.PutEmptyMap(child.tag))
+	}
+}
+
+func leafValueFromElement(node *xmlNode) string {
+	// If there are any child elements, ignore any extraneous text.
+	if len(node.children) > 0 || len(node.texts) == 0 {
+		return ""
+	}
+	// No child elements, so return the first text or CDATA content
+	return node.texts[0]
+}
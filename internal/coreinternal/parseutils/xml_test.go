@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const windowsEventXML = `<?xml version="1.0" encoding="UTF-8"?>
+<Event xmlns="http://schemas.microsoft.com/win/2004/08/events/event">
+  <System>
+    <Provider Name="Microsoft-Windows-Security-Auditing"/>
+    <EventID>4624</EventID>
+    <Level>0</Level>
+  </System>
+  <!-- event data -->
+  <EventData>
+    <Data Name="SubjectUserName">admin</Data>
+    <Data Name="LogonType"><![CDATA[2]]></Data>
+  </EventData>
+</Event>`
+
+func TestParseXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]any
+	}{
+		{
+			name:  "single element",
+			input: `<log level="info">started</log>`,
+			expected: map[string]any{
+				"tag":        "log",
+				"content":    "started",
+				"attributes": map[string]any{"level": "info"},
+			},
+		},
+		{
+			name:  "multi-line document",
+			input: windowsEventXML,
+			expected: map[string]any{
+				"tag":        "Event",
+				"attributes": map[string]any{"xmlns": "http://schemas.microsoft.com/win/2004/08/events/event"},
+				"children": []any{
+					map[string]any{
+						"tag": "System",
+						"children": []any{
+							map[string]any{
+								"tag":        "Provider",
+								"attributes": map[string]any{"Name": "Microsoft-Windows-Security-Auditing"},
+							},
+							map[string]any{"tag": "EventID", "content": "4624"},
+							map[string]any{"tag": "Level", "content": "0"},
+						},
+					},
+					map[string]any{
+						"tag": "EventData",
+						"children": []any{
+							map[string]any{
+								"tag":        "Data",
+								"content":    "admin",
+								"attributes": map[string]any{"Name": "SubjectUserName"},
+							},
+							map[string]any{
+								"tag":        "Data",
+								"content":    "2",
+								"attributes": map[string]any{"Name": "LogonType"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := ParseXML(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual.AsRaw())
+		})
+	}
+}
+
+func TestParseSimplifiedXML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]any
+	}{
+		{
+			name:     "single element",
+			input:    `<log level="info">started</log>`,
+			expected: map[string]any{"log": "started"},
+		},
+		{
+			name:  "multi-line document",
+			input: windowsEventXML,
+			expected: map[string]any{
+				"Event": map[string]any{
+					"System": map[string]any{
+						"EventID": "4624",
+						"Level":   "0",
+					},
+					"EventData": map[string]any{
+						"Data": []any{"admin", "2"},
+					},
+				},
+			},
+		},
+		{
+			name:     "empty document",
+			input:    `<log/>`,
+			expected: map[string]any{},
+		},
+		{
+			name:     "multiple elements",
+			input:    `<log>started</log><log>stopped</log>`,
+			expected: map[string]any{"log": []any{"started", "stopped"}},
+		},
+		{
+			name:     "first text content",
+			input:    `<log>started<!-- ignored -->again</log>`,
+			expected: map[string]any{"log": "started"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := ParseSimplifiedXML(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, actual.AsRaw())
+		})
+	}
+}
+
+func TestParseXMLCharset(t *testing.T) {
+	// "café" encoded in ISO-8859-1
+	input := "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><log level=\"info\">caf\xe9</log>"
+
+	full, err := ParseXML(input)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"tag":        "log",
+		"content":    "café",
+		"attributes": map[string]any{"level": "info"},
+	}, full.AsRaw())
+
+	simplified, err := ParseSimplifiedXML(input)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"log": "café"}, simplified.AsRaw())
+}
+
+func TestParseXML_errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "no element",
+			input:    "just some text",
+			expected: "unmarshal xml: EOF",
+		},
+		{
+			name:     "unclosed element",
+			input:    "<a><b>text</b>",
+			expected: "unmarshal xml: decode next token: XML syntax error on line 1: unexpected EOF",
+		},
+		{
+			name:     "multiple roots",
+			input:    "<a/><b/>",
+			expected: "trailing bytes after parsing xml",
+		},
+		{
+			name:     "trailing text",
+			input:    "<a/>\n",
+			expected: "trailing bytes after parsing xml",
+		},
+		{
+			name:     "mismatched tags",
+			input:    "<a></b>",
+			expected: "unmarshal xml: decode next token: XML syntax error on line 1: element <a> closed by </b>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseXML(tt.input)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestParseSimplifiedXML_errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "unclosed element",
+			input:    "<a><b>text</b>",
+			expected: "unmarshal xml: XML syntax error on line 1: unexpected EOF",
+		},
+		{
+			name:     "mismatched tags",
+			input:    "<a></b>",
+			expected: "unmarshal xml: XML syntax error on line 1: element <a> closed by </b>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSimplifiedXML(tt.input)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,496 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+)
+
+// Rules reported by the static analysis of statements and conditions.
+const (
+	// DiagnosticRuleConstantCondition reports where clauses and conditions that always
+	// evaluate to the same result, regardless of the telemetry being processed.
+	DiagnosticRuleConstantCondition = "constant-condition"
+	// DiagnosticRuleTypeMismatch reports comparisons between values of types that can
+	// never be equal or ordered, such as an int path and a string literal.
+	DiagnosticRuleTypeMismatch = "type-mismatch"
+	// DiagnosticRuleNilPath reports paths that are always nil when they are read, such as
+	// cache keys that are not set by any previous statement.
+	DiagnosticRuleNilPath = "nil-path"
+)
+
+// Diagnostic is a potential problem found by the static analysis of a statement or condition.
+// Diagnostics do not prevent statements or conditions from being parsed or executed.
+type Diagnostic struct {
+	// Rule is the name of the check that reported the problem, for example "type-mismatch".
+	Rule string
+	// Text is the original text of the statement or condition.
+	Text string
+	// Message describes the problem.
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s: %s", d.Rule, d.Message, d.Text)
+}
+
+// WithStaticAnalysisContext sets the function used to create an empty context during static analysis.
+// Paths are evaluated against that context to find their types, which enables detecting comparisons
+// between mismatched types. Without this option, the type of paths is considered unknown.
+func WithStaticAnalysisContext[K any](newContext func() K) Option[K] {
+	return func(p *Parser[K]) {
+		p.newAnalysisContext = newContext
+	}
+}
+
+// EnableStaticAnalysisLogging makes the Parser log, at warning level, the problems found by the
+// static analysis of the statements and conditions parsed with ParseStatements and ParseConditions.
+// See AnalyzeStatements for the list of checks.
+func EnableStaticAnalysisLogging[K any]() Option[K] {
+	return func(p *Parser[K]) {
+		p.staticAnalysisLogging = true
+	}
+}
+
+// AnalyzeStatements statically analyzes the given statements and returns the potential problems found,
+// such as where clauses that can never match, comparisons between mismatched types, and cache keys read
+// before any previous statement sets them. Statements are analyzed as a sequence executed in order.
+// Only the syntax of the statements is verified, functions are not resolved. If any statement is not
+// valid, an error containing each syntax error is returned along with the diagnostics of the valid ones.
+func (p *Parser[K]) AnalyzeStatements(statements []string) ([]Diagnostic, error) {
+	a := p.newAnalyzer()
+	var parseErrs []error
+	for _, statement := range statements {
+		parsed, err := parseStatement(statement)
+		if err != nil {
+			parseErrs = append(parseErrs, fmt.Errorf("unable to parse OTTL statement %q: %w", statement, err))
+			continue
+		}
+		a.analyzeStatement(statement, parsed)
+	}
+	return a.diagnostics, errors.Join(parseErrs...)
+}
+
+// AnalyzeConditions statically analyzes the given conditions and returns the potential problems found,
+// such as conditions that are always true or false, comparisons between mismatched types, and cache
+// keys that are never set. See AnalyzeStatements for details.
+func (p *Parser[K]) AnalyzeConditions(conditions []string) ([]Diagnostic, error) {
+	a := p.newAnalyzer()
+	var parseErrs []error
+	for _, condition := range conditions {
+		parsed, err := parseCondition(condition)
+		if err != nil {
+			parseErrs = append(parseErrs, fmt.Errorf("unable to parse OTTL condition %q: %w", condition, err))
+			continue
+		}
+		a.analyzeCondition(condition, parsed)
+	}
+	return a.diagnostics, errors.Join(parseErrs...)
+}
+
+func (p *Parser[K]) logDiagnostics(kind string, diagnostics []Diagnostic) {
+	for _, d := range diagnostics {
+		p.telemetrySettings.Logger.Warn(
+			"potential problem found in OTTL "+kind,
+			zap.String("rule", d.Rule),
+			zap.String("message", d.Message),
+			zap.String(kind, d.Text),
+		)
+	}
+}
+
+// staticType is the type of a value as far as it can be known without evaluating the statement.
+type staticType int
+
+const (
+	unknownType staticType = iota
+	nilType
+	boolType
+	intType
+	floatType
+	stringType
+	bytesType
+	mapType
+	timeType
+	durationType
+)
+
+func (t staticType) String() string {
+	switch t {
+	case nilType:
+		return "nil"
+	case boolType:
+		return "bool"
+	case intType:
+		return "int"
+	case floatType:
+		return "float"
+	case stringType:
+		return "string"
+	case bytesType:
+		return "bytes"
+	case mapType:
+		return "map"
+	case timeType:
+		return "time"
+	case durationType:
+		return "duration"
+	default:
+		return "unknown"
+	}
+}
+
+func staticTypeOf(val any) staticType {
+	switch val.(type) {
+	case bool:
+		return boolType
+	case int64:
+		return intType
+	case float64:
+		return floatType
+	case string:
+		return stringType
+	case []byte:
+		return bytesType
+	case pcommon.Map, map[string]any:
+		return mapType
+	case time.Time:
+		return timeType
+	case time.Duration:
+		return durationType
+	default:
+		return unknownType
+	}
+}
+
+// comparable reports whether values of the given known types can be compared. It mirrors
+// Parser.compare, where mismatched types are never equal and never ordered.
+func (t staticType) comparable(other staticType) bool {
+	if t == other {
+		return true
+	}
+	numeric := func(t staticType) bool { return t == intType || t == floatType }
+	return numeric(t) && numeric(other)
+}
+
+// tristate is the result of a condition evaluated statically.
+type tristate int
+
+const (
+	unknownResult tristate = iota
+	alwaysFalseResult
+	alwaysTrueResult
+)
+
+func tristateOf(b bool) tristate {
+	if b {
+		return alwaysTrueResult
+	}
+	return alwaysFalseResult
+}
+
+func (t tristate) not() tristate {
+	switch t {
+	case alwaysTrueResult:
+		return alwaysFalseResult
+	case alwaysFalseResult:
+		return alwaysTrueResult
+	default:
+		return unknownResult
+	}
+}
+
+type analyzer[K any] struct {
+	parser      *Parser[K]
+	diagnostics []Diagnostic
+	// cacheKeys holds, per path context, the cache keys set by the analyzed statements.
+	cacheKeys map[string]map[string]bool
+	// cacheSet holds the path contexts where the whole cache, or keys unknown
+	// until execution, are set by the analyzed statements.
+	cacheSet map[string]bool
+}
+
+func (p *Parser[K]) newAnalyzer() *analyzer[K] {
+	return &analyzer[K]{
+		parser:    p,
+		cacheKeys: map[string]map[string]bool{},
+		cacheSet:  map[string]bool{},
+	}
+}
+
+func (a *analyzer[K]) report(rule, text, format string, args ...any) {
+	a.diagnostics = append(a.diagnostics, Diagnostic{Rule: rule, Text: text, Message: fmt.Sprintf(format, args...)})
+}
+
+func (a *analyzer[K]) analyzeStatement(text string, parsed *parsedStatement) {
+	if parsed.WhereClause != nil {
+		switch a.evalCondition(text, parsed.WhereClause) {
+		case alwaysFalseResult:
+			a.report(DiagnosticRuleConstantCondition, text, "the where clause is always false, so the statement is never executed")
+		case alwaysTrueResult:
+			a.report(DiagnosticRuleConstantCondition, text, "the where clause is always true and can be removed")
+		}
+	}
+
+	// The where clause and the editor arguments are evaluated before the editor
+	// sets its target, so all of them must be checked against the previous statements.
+	targets := a.editorTargets(&parsed.Editor)
+	var reads []path
+	if parsed.WhereClause != nil {
+		reads = append(reads, getBooleanExpressionPaths(parsed.WhereClause)...)
+	}
+	for i := range parsed.Editor.Arguments {
+		if _, isTarget := targets[i]; isTarget {
+			continue
+		}
+		visitor := &grammarPathVisitor{}
+		parsed.Editor.Arguments[i].accept(visitor)
+		reads = append(reads, visitor.paths...)
+	}
+	a.checkCacheReads(text, reads, "no previous statement sets it")
+
+	for _, target := range targets {
+		pathContext, fields := a.cachePath(target)
+		if fields == nil {
+			continue
+		}
+		if len(fields[0].Keys) == 0 || fields[0].Keys[0].String == nil {
+			a.cacheSet[pathContext] = true
+			continue
+		}
+		if a.cacheKeys[pathContext] == nil {
+			a.cacheKeys[pathContext] = map[string]bool{}
+		}
+		a.cacheKeys[pathContext][*fields[0].Keys[0].String] = true
+	}
+}
+
+func (a *analyzer[K]) analyzeCondition(text string, parsed *booleanExpression) {
+	switch a.evalCondition(text, parsed) {
+	case alwaysFalseResult:
+		a.report(DiagnosticRuleConstantCondition, text, "the condition is always false")
+	case alwaysTrueResult:
+		a.report(DiagnosticRuleConstantCondition, text, "the condition is always true")
+	}
+	a.checkCacheReads(text, getBooleanExpressionPaths(parsed), "conditions cannot set it")
+}
+
+// editorTargets returns the path arguments an editor may set, indexed by argument position.
+// Standard editors receive their target as the first argument, user-defined editors
+// may set any of the paths they receive.
+func (a *analyzer[K]) editorTargets(ed *editor) map[int]*path {
+	targets := map[int]*path{}
+	for i := range ed.Arguments {
+		if _, ok := a.parser.userFunctions[ed.Function]; !ok && i > 0 {
+			break
+		}
+		arg := ed.Arguments[i]
+		if arg.Value.Literal != nil && arg.Value.Literal.Path != nil {
+			targets[i] = arg.Value.Literal.Path
+		}
+	}
+	return targets
+}
+
+// cachePath returns the context and fields of the given path if it refers to the cache,
+// or nil fields otherwise.
+func (a *analyzer[K]) cachePath(p *path) (string, []field) {
+	pathContext, fields := p.Context, p.Fields
+	if len(a.parser.pathContextNames) == 0 && pathContext != "" {
+		pathContext, fields = "", append([]field{{Name: p.Context}}, p.Fields...)
+	}
+	if len(fields) == 0 || fields[0].Name != "cache" {
+		return "", nil
+	}
+	return pathContext, fields
+}
+
+func (a *analyzer[K]) checkCacheReads(text string, reads []path, reason string) {
+	reported := map[string]bool{}
+	for i := range reads {
+		pathContext, fields := a.cachePath(&reads[i])
+		if fields == nil || len(fields[0].Keys) == 0 || fields[0].Keys[0].String == nil || a.cacheSet[pathContext] {
+			continue
+		}
+		key := *fields[0].Keys[0].String
+		if a.cacheKeys[pathContext][key] {
+			continue
+		}
+		originalText := buildOriginalText(&reads[i])
+		if reported[originalText] {
+			continue
+		}
+		reported[originalText] = true
+		a.report(DiagnosticRuleNilPath, text, "path %q is always nil because %s", originalText, reason)
+	}
+}
+
+// evalCondition statically evaluates the given boolean expression. Constant conditions
+// caused by mismatched types are reported only as such.
+func (a *analyzer[K]) evalCondition(text string, expr *booleanExpression) tristate {
+	reported := len(a.diagnostics)
+	result := a.evalBooleanExpression(text, expr)
+	if len(a.diagnostics) > reported {
+		return unknownResult
+	}
+	return result
+}
+
+func (a *analyzer[K]) evalBooleanExpression(text string, expr *booleanExpression) tristate {
+	terms := []*term{expr.Left}
+	for _, rhs := range expr.Right {
+		terms = append(terms, rhs.Term)
+	}
+	result := alwaysFalseResult
+	for _, t := range terms {
+		switch a.evalTerm(text, t) {
+		case alwaysTrueResult:
+			result = alwaysTrueResult
+		case unknownResult:
+			if result != alwaysTrueResult {
+				result = unknownResult
+			}
+		}
+	}
+	return result
+}
+
+func (a *analyzer[K]) evalTerm(text string, t *term) tristate {
+	values := []*booleanValue{t.Left}
+	for _, rhs := range t.Right {
+		values = append(values, rhs.Value)
+	}
+	result := alwaysTrueResult
+	for _, v := range values {
+		switch a.evalBooleanValue(text, v) {
+		case alwaysFalseResult:
+			result = alwaysFalseResult
+		case unknownResult:
+			if result != alwaysFalseResult {
+				result = unknownResult
+			}
+		}
+	}
+	return result
+}
+
+func (a *analyzer[K]) evalBooleanValue(text string, v *booleanValue) tristate {
+	var result tristate
+	switch {
+	case v.Comparison != nil:
+		result = a.evalComparison(text, v.Comparison)
+	case v.ConstExpr != nil && v.ConstExpr.Boolean != nil:
+		result = tristateOf(bool(*v.ConstExpr.Boolean))
+	case v.SubExpr != nil:
+		result = a.evalBooleanExpression(text, v.SubExpr)
+	}
+	if v.Negation != nil {
+		return result.not()
+	}
+	return result
+}
+
+func (a *analyzer[K]) evalComparison(text string, c *comparison) tristate {
+	left, leftLiteral := a.valueType(&c.Left)
+	right, rightLiteral := a.valueType(&c.Right)
+
+	// Comparisons between literals only are evaluated as they would be at execution time.
+	if leftLiteral && rightLiteral {
+		expr, err := a.parser.newComparisonEvaluator(c)
+		if err != nil {
+			return unknownResult
+		}
+		var tCtx K
+		result, err := expr.Eval(context.Background(), tCtx)
+		if err != nil {
+			return unknownResult
+		}
+		return tristateOf(result)
+	}
+
+	if left == unknownType || right == unknownType {
+		return unknownResult
+	}
+	if (left == nilType && right == bytesType) || (left == bytesType && right == nilType) {
+		// Byte slices can be nil.
+		return unknownResult
+	}
+	if left == nilType || right == nilType || !left.comparable(right) {
+		// A value of a known type is never nil, and mismatched types are never equal nor ordered.
+		result := c.Op == ne
+		a.report(DiagnosticRuleTypeMismatch, text, "comparing %s to %s with %q is always %t", left, right, compareOpText(c.Op), result)
+		return tristateOf(result)
+	}
+	return unknownResult
+}
+
+func compareOpText(op compareOp) string {
+	for text, o := range compareOpTable {
+		if o == op {
+			return text
+		}
+	}
+	return op.String()
+}
+
+// valueType returns the static type of the given value, and whether it is a literal whose
+// value is known without evaluating the statement.
+func (a *analyzer[K]) valueType(v *value) (staticType, bool) {
+	switch {
+	case v.IsNil != nil:
+		return nilType, true
+	case v.String != nil:
+		return stringType, true
+	case v.Bool != nil:
+		return boolType, true
+	case v.Bytes != nil:
+		return bytesType, true
+	case v.Enum != nil:
+		return intType, true
+	case v.Map != nil:
+		return mapType, false
+	case v.Literal != nil:
+		switch {
+		case v.Literal.Int != nil:
+			return intType, true
+		case v.Literal.Float != nil:
+			return floatType, true
+		case v.Literal.Path != nil:
+			return a.pathType(v.Literal.Path), false
+		}
+	}
+	return unknownType, false
+}
+
+// pathType returns the type of the value the given path evaluates to on an empty context.
+// Types that can vary depending on the telemetry, such as attribute values, are unknown.
+func (a *analyzer[K]) pathType(p *path) (t staticType) {
+	if a.parser.newAnalysisContext == nil {
+		return unknownType
+	}
+	bp, err := a.parser.newPath(p)
+	if err != nil {
+		return unknownType
+	}
+	getter, err := a.parser.parsePath(bp)
+	if err != nil {
+		return unknownType
+	}
+	defer func() {
+		if recover() != nil {
+			t = unknownType
+		}
+	}()
+	val, err := getter.Get(context.Background(), a.parser.newAnalysisContext())
+	if err != nil {
+		return unknownType
+	}
+	return staticTypeOf(val)
+}
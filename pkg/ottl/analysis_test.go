@@ -0,0 +1,264 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newAnalysisTestParser(t *testing.T, options ...Option[any]) Parser[any] {
+	p, err := NewParser[any](
+		CreateFactoryMap[any](),
+		testParsePath[any],
+		componenttest.NewNopTelemetrySettings(),
+		append([]Option[any]{
+			WithEnumParser[any](testParseEnum),
+			WithStaticAnalysisContext[any](func() any { return "" }),
+		}, options...)...,
+	)
+	require.NoError(t, err)
+	return p
+}
+
+func Test_AnalyzeStatements(t *testing.T) {
+	tests := []struct {
+		name       string
+		statements []string
+		want       []Diagnostic
+	}{
+		{
+			name:       "no problems",
+			statements: []string{`set(name, "foo") where name == "bar"`, `set(cache["a"], name)`, `set(name, cache["a"])`},
+		},
+		{
+			name:       "where clause always false",
+			statements: []string{`set(name, "foo") where 1 > 2 and name == "bar"`},
+			want: []Diagnostic{{
+				Rule:    DiagnosticRuleConstantCondition,
+				Text:    `set(name, "foo") where 1 > 2 and name == "bar"`,
+				Message: "the where clause is always false, so the statement is never executed",
+			}},
+		},
+		{
+			name:       "where clause always true",
+			statements: []string{`set(name, "foo") where not false or name == "bar"`},
+			want: []Diagnostic{{
+				Rule:    DiagnosticRuleConstantCondition,
+				Text:    `set(name, "foo") where not false or name == "bar"`,
+				Message: "the where clause is always true and can be removed",
+			}},
+		},
+		{
+			name:       "literal comparison",
+			statements: []string{`set(name, "foo") where TEST_ENUM == 0`},
+			want: []Diagnostic{{
+				Rule:    DiagnosticRuleConstantCondition,
+				Text:    `set(name, "foo") where TEST_ENUM == 0`,
+				Message: "the where clause is always true and can be removed",
+			}},
+		},
+		{
+			name:       "type mismatch",
+			statements: []string{`set(name, "foo") where name == 1`},
+			want: []Diagnostic{{
+				Rule:    DiagnosticRuleTypeMismatch,
+				Text:    `set(name, "foo") where name == 1`,
+				Message: `comparing string to int with "==" is always false`,
+			}},
+		},
+		{
+			name:       "type mismatch with nil",
+			statements: []string{`set(name, "foo") where (name != nil or name == "bar")`},
+			want: []Diagnostic{{
+				Rule:    DiagnosticRuleTypeMismatch,
+				Text:    `set(name, "foo") where (name != nil or name == "bar")`,
+				Message: `comparing string to nil with "!=" is always true`,
+			}},
+		},
+		{
+			name:       "unknown types",
+			statements: []string{`set(name, "foo") where dur1 == 1 and Foo() > 2 and unknown == "a"`},
+		},
+		{
+			name:       "cache key never set",
+			statements: []string{`set(cache["a"], "foo")`, `set(name, cache["b"]) where cache["a"] != nil`},
+			want: []Diagnostic{{
+				Rule:    DiagnosticRuleNilPath,
+				Text:    `set(name, cache["b"]) where cache["a"] != nil`,
+				Message: `path "cache[b]" is always nil because no previous statement sets it`,
+			}},
+		},
+		{
+			name:       "cache key read before set",
+			statements: []string{`set(cache["a"], Concat([cache["a"], "foo"], ""))`},
+			want: []Diagnostic{{
+				Rule:    DiagnosticRuleNilPath,
+				Text:    `set(cache["a"], Concat([cache["a"], "foo"], ""))`,
+				Message: `path "cache[a]" is always nil because no previous statement sets it`,
+			}},
+		},
+		{
+			name:       "whole cache set",
+			statements: []string{`merge_maps(cache, attributes, "upsert")`, `set(name, cache["a"])`},
+		},
+		{
+			name:       "cache key set dynamically",
+			statements: []string{`set(cache[name], "foo")`, `set(name, cache["a"])`},
+		},
+		{
+			name:       "cache read in lambda",
+			statements: []string{`set(name, Call([1], x => cache["a"]))`},
+			want: []Diagnostic{{
+				Rule:    DiagnosticRuleNilPath,
+				Text:    `set(name, Call([1], x => cache["a"]))`,
+				Message: `path "cache[a]" is always nil because no previous statement sets it`,
+			}},
+		},
+	}
+
+	p := newAnalysisTestParser(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics, err := p.AnalyzeStatements(tt.statements)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, diagnostics)
+		})
+	}
+}
+
+func Test_AnalyzeStatements_path_context(t *testing.T) {
+	p := newAnalysisTestParser(t, WithPathContextNames[any]([]string{"log", "resource"}))
+	diagnostics, err := p.AnalyzeStatements([]string{
+		`set(log.cache["a"], "foo")`,
+		`set(log.name, resource.cache["a"]) where log.name == 1`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Diagnostic{
+		{
+			Rule:    DiagnosticRuleTypeMismatch,
+			Text:    `set(log.name, resource.cache["a"]) where log.name == 1`,
+			Message: `comparing string to int with "==" is always false`,
+		},
+		{
+			Rule:    DiagnosticRuleNilPath,
+			Text:    `set(log.name, resource.cache["a"]) where log.name == 1`,
+			Message: `path "resource.cache[a]" is always nil because no previous statement sets it`,
+		},
+	}, diagnostics)
+}
+
+func Test_AnalyzeStatements_without_analysis_context(t *testing.T) {
+	p, err := NewParser[any](CreateFactoryMap[any](), testParsePath[any], componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	diagnostics, err := p.AnalyzeStatements([]string{`set(name, "foo") where name == 1`})
+	require.NoError(t, err)
+	assert.Empty(t, diagnostics)
+}
+
+func Test_AnalyzeStatements_syntax_error(t *testing.T) {
+	p := newAnalysisTestParser(t)
+	diagnostics, err := p.AnalyzeStatements([]string{`set(name, "foo") where`, `set(name, "foo") where name == 1`})
+	assert.ErrorContains(t, err, `unable to parse OTTL statement "set(name, \"foo\") where"`)
+	assert.Len(t, diagnostics, 1)
+}
+
+func Test_AnalyzeConditions(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []string
+		want       []Diagnostic
+	}{
+		{
+			name:       "no problems",
+			conditions: []string{`name == "foo"`, `IsMatch(name, "a.*")`},
+		},
+		{
+			name:       "always true",
+			conditions: []string{`true or name == "foo"`},
+			want: []Diagnostic{{
+				Rule:    DiagnosticRuleConstantCondition,
+				Text:    `true or name == "foo"`,
+				Message: "the condition is always true",
+			}},
+		},
+		{
+			name:       "always false",
+			conditions: []string{`not (1 < 2) and name == "foo"`},
+			want: []Diagnostic{{
+				Rule:    DiagnosticRuleConstantCondition,
+				Text:    `not (1 < 2) and name == "foo"`,
+				Message: "the condition is always false",
+			}},
+		},
+		{
+			name:       "ordering mismatch",
+			conditions: []string{`name > 1.5`},
+			want: []Diagnostic{{
+				Rule:    DiagnosticRuleTypeMismatch,
+				Text:    `name > 1.5`,
+				Message: `comparing string to float with ">" is always false`,
+			}},
+		},
+		{
+			name:       "cache is never set",
+			conditions: []string{`cache["a"] == "foo"`},
+			want: []Diagnostic{{
+				Rule:    DiagnosticRuleNilPath,
+				Text:    `cache["a"] == "foo"`,
+				Message: `path "cache[a]" is always nil because conditions cannot set it`,
+			}},
+		},
+	}
+
+	p := newAnalysisTestParser(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diagnostics, err := p.AnalyzeConditions(tt.conditions)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, diagnostics)
+		})
+	}
+}
+
+func Test_EnableStaticAnalysisLogging(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	settings := componenttest.NewNopTelemetrySettings()
+	settings.Logger = zap.New(core)
+
+	p, err := NewParser[any](
+		CreateFactoryMap[any](NewFactory("set", &mockSetArguments[any]{}, func(_ FunctionContext, _ Arguments) (ExprFunc[any], error) {
+			return func(context.Context, any) (any, error) {
+				return nil, nil
+			}, nil
+		})),
+		testParsePath[any],
+		settings,
+		WithStaticAnalysisContext[any](func() any { return "" }),
+		EnableStaticAnalysisLogging[any](),
+	)
+	require.NoError(t, err)
+
+	_, err = p.ParseStatements([]string{`set(name, "foo") where name == 1`})
+	require.NoError(t, err)
+	_, err = p.ParseConditions([]string{`name == "foo"`, `1 == 2`})
+	require.NoError(t, err)
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "potential problem found in OTTL statement", entries[0].Message)
+	assert.Equal(t, map[string]any{
+		"rule":      DiagnosticRuleTypeMismatch,
+		"message":   `comparing string to int with "==" is always false`,
+		"statement": `set(name, "foo") where name == 1`,
+	}, entries[0].ContextMap())
+	assert.Equal(t, "potential problem found in OTTL condition", entries[1].Message)
+	assert.Equal(t, "1 == 2", entries[1].ContextMap()["condition"])
+}
@@ -0,0 +1,419 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+type severity string
+
+const (
+	// severityError is used for statements and conditions that are not valid OTTL.
+	severityError severity = "error"
+	// severityWarning is used for the problems found by the static analysis.
+	severityWarning severity = "warning"
+	// severitySkipped is used for statements and conditions that could not be analyzed,
+	// for example because their context could not be inferred.
+	severitySkipped severity = "skipped"
+)
+
+// finding is a problem found in a statement or condition of a collector configuration.
+type finding struct {
+	// location is the processor and field of the statement or condition, for
+	// example "processors::transform/redact::log_statements[0]".
+	location string
+	severity severity
+	message  string
+	text     string
+}
+
+func (f finding) String() string {
+	if f.text == "" {
+		return fmt.Sprintf("%s: %s: %s", f.location, f.severity, f.message)
+	}
+	return fmt.Sprintf("%s: %s: %s: %s", f.location, f.severity, f.message, f.text)
+}
+
+type signal string
+
+const (
+	signalTraces  signal = "traces"
+	signalMetrics signal = "metrics"
+	signalLogs    signal = "logs"
+)
+
+// transformStatementsFields maps the transform processor statements fields to their signals.
+var transformStatementsFields = []struct {
+	name   string
+	signal signal
+}{
+	{"trace_statements", signalTraces},
+	{"metric_statements", signalMetrics},
+	{"log_statements", signalLogs},
+}
+
+// filterConditionsFields maps the filter processor conditions fields to their signals and contexts.
+var filterConditionsFields = []struct {
+	signal  signal
+	name    string
+	context string
+}{
+	{signalTraces, "span", ottlspan.ContextName},
+	{signalTraces, "spanevent", ottlspanevent.ContextName},
+	{signalMetrics, "metric", ottlmetric.ContextName},
+	{signalMetrics, "datapoint", ottldatapoint.ContextName},
+	{signalLogs, "log_record", ottllog.ContextName},
+}
+
+type transformConfig struct {
+	Functions        []ottl.FunctionDefinition `yaml:"functions"`
+	TraceStatements  []any                     `yaml:"trace_statements"`
+	MetricStatements []any                     `yaml:"metric_statements"`
+	LogStatements    []any                     `yaml:"log_statements"`
+}
+
+type contextStatements struct {
+	Context    string   `yaml:"context"`
+	Conditions []string `yaml:"conditions"`
+	Statements []string `yaml:"statements"`
+}
+
+// checkConfig analyzes the OTTL statements and conditions of the transform and
+// filter processors declared in the given collector configuration.
+func checkConfig(content []byte) ([]finding, error) {
+	var cfg struct {
+		Processors map[string]yaml.Node `yaml:"processors"`
+	}
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(cfg.Processors))
+	for id := range cfg.Processors {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	var findings []finding
+	for _, id := range ids {
+		node := cfg.Processors[id]
+		location := "processors::" + id
+		var err error
+		switch componentType, _, _ := strings.Cut(id, "/"); componentType {
+		case "transform":
+			findings, err = checkTransform(findings, location, &node)
+		case "filter":
+			findings, err = checkFilter(findings, location, &node)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", location, err)
+		}
+	}
+	return findings, nil
+}
+
+func checkTransform(findings []finding, location string, node *yaml.Node) ([]finding, error) {
+	var cfg transformConfig
+	if err := node.Decode(&cfg); err != nil {
+		return nil, err
+	}
+	values := map[string][]any{
+		"trace_statements":  cfg.TraceStatements,
+		"metric_statements": cfg.MetricStatements,
+		"log_statements":    cfg.LogStatements,
+	}
+
+	for _, field := range transformStatementsFields {
+		groups, err := toContextStatements(values[field.name])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.name, err)
+		}
+		if len(groups) == 0 {
+			continue
+		}
+		pc, err := newParserCollection(field.signal, transformFunctions, cfg.Functions)
+		if err != nil {
+			findings = append(findings, finding{
+				location: location + "::functions",
+				severity: severitySkipped,
+				message:  fmt.Sprintf("unable to load the function definitions: %v", err),
+			})
+			if pc, err = newParserCollection(field.signal, transformFunctions, nil); err != nil {
+				return nil, err
+			}
+		}
+		for i, group := range groups {
+			if len(group.Statements) == 0 {
+				continue
+			}
+			groupLocation := fmt.Sprintf("%s::%s[%d]", location, field.name, i)
+			findings = analyzeContextStatements(findings, groupLocation, pc, group)
+		}
+	}
+	return findings, nil
+}
+
+// toContextStatements converts the transform processor statements, configured using
+// the basic or the advanced configuration style, into groups of statements.
+func toContextStatements(values []any) ([]contextStatements, error) {
+	var groups []contextStatements
+	var basic contextStatements
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			basic.Statements = append(basic.Statements, v)
+		case map[string]any:
+			var group contextStatements
+			content, err := yaml.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			if err = yaml.Unmarshal(content, &group); err != nil {
+				return nil, err
+			}
+			groups = append(groups, group)
+		default:
+			return nil, fmt.Errorf("unexpected statements type %T", value)
+		}
+	}
+	if len(basic.Statements) > 0 {
+		if len(groups) > 0 {
+			return nil, errors.New("configuring multiple configuration styles is not supported")
+		}
+		groups = append(groups, basic)
+	}
+	return groups, nil
+}
+
+func analyzeContextStatements(findings []finding, location string, pc *ottl.ParserCollection[any], group contextStatements) []finding {
+	if group.Context != "" {
+		context := strings.ToLower(group.Context)
+		diagnostics, err := pc.AnalyzeStatementsWithContext(context, ottl.NewStatementsGetter(group.Statements), true)
+		findings = appendFindings(findings, location+"::statements", diagnostics, err)
+		if len(group.Conditions) > 0 {
+			diagnostics, err = pc.AnalyzeConditionsWithContext(context, ottl.NewConditionsGetter(group.Conditions), true)
+			findings = appendFindings(findings, location+"::conditions", diagnostics, err)
+		}
+		return findings
+	}
+
+	diagnostics, err := pc.AnalyzeStatements(ottl.NewStatementsGetter(group.Statements), ottl.WithContextInferenceConditions(group.Conditions))
+	if err != nil {
+		return appendInferenceError(findings, location, pc, group, err)
+	}
+	findings = appendFindings(findings, location+"::statements", diagnostics, nil)
+	if len(group.Conditions) > 0 {
+		diagnostics, err = pc.AnalyzeConditions(ottl.NewConditionsGetter(group.Conditions))
+		findings = appendFindings(findings, location+"::conditions", diagnostics, err)
+	}
+	return findings
+}
+
+// appendInferenceError reports the syntax errors of the given statements and conditions if
+// any, as they prevent the context inference. Otherwise, it reports the group as skipped.
+func appendInferenceError(findings []finding, location string, pc *ottl.ParserCollection[any], group contextStatements, inferenceErr error) []finding {
+	reported := len(findings)
+	_, err := pc.AnalyzeStatementsWithContext(ottlresource.ContextName, ottl.NewStatementsGetter(group.Statements), false)
+	findings = appendSyntaxErrors(findings, location+"::statements", err)
+	_, err = pc.AnalyzeConditionsWithContext(ottlresource.ContextName, ottl.NewConditionsGetter(group.Conditions), false)
+	findings = appendSyntaxErrors(findings, location+"::conditions", err)
+	if len(findings) > reported {
+		return findings
+	}
+	return append(findings, finding{location: location, severity: severitySkipped, message: inferenceErr.Error()})
+}
+
+func checkFilter(findings []finding, location string, node *yaml.Node) ([]finding, error) {
+	var cfg map[string]any
+	if err := node.Decode(&cfg); err != nil {
+		return nil, err
+	}
+	collections := map[signal]*ottl.ParserCollection[any]{}
+	for _, field := range filterConditionsFields {
+		signalConfig, _ := cfg[string(field.signal)].(map[string]any)
+		conditions, err := toStrings(signalConfig[field.name])
+		if err != nil {
+			return nil, fmt.Errorf("%s::%s: %w", field.signal, field.name, err)
+		}
+		if len(conditions) == 0 {
+			continue
+		}
+		pc, ok := collections[field.signal]
+		if !ok {
+			if pc, err = newParserCollection(field.signal, filterFunctions, nil); err != nil {
+				return nil, err
+			}
+			collections[field.signal] = pc
+		}
+		diagnostics, err := pc.AnalyzeConditionsWithContext(field.context, ottl.NewConditionsGetter(conditions), true)
+		findings = appendFindings(findings, fmt.Sprintf("%s::%s::%s", location, field.signal, field.name), diagnostics, err)
+	}
+	return findings, nil
+}
+
+func toStrings(value any) ([]string, error) {
+	values, _ := value.([]any)
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected condition type %T", v)
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+func appendFindings(findings []finding, location string, diagnostics []ottl.Diagnostic, err error) []finding {
+	for _, d := range diagnostics {
+		findings = append(findings, finding{
+			location: location,
+			severity: severityWarning,
+			message:  d.Rule + ": " + d.Message,
+			text:     d.Text,
+		})
+	}
+	if err == nil {
+		return findings
+	}
+	reported := len(findings)
+	findings = appendSyntaxErrors(findings, location, err)
+	if len(findings) > reported {
+		return findings
+	}
+	return append(findings, finding{location: location, severity: severitySkipped, message: err.Error()})
+}
+
+// appendSyntaxErrors reports the errors returned by the static analysis, which are joined
+// errors with one syntax error per statement or condition.
+func appendSyntaxErrors(findings []finding, location string, err error) []finding {
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) {
+		return findings
+	}
+	for _, e := range joined.Unwrap() {
+		findings = append(findings, finding{location: location, severity: severityError, message: e.Error()})
+	}
+	return findings
+}
+
+// functionSet identifies the functions available to the statements or conditions of a component.
+type functionSet int
+
+const (
+	// transformFunctions are the standard OTTL functions. The functions specific to the transform
+	// processor are unknown, which only affects the inference of contexts, as the static analysis
+	// does not resolve functions.
+	transformFunctions functionSet = iota
+	// filterFunctions are the standard OTTL converters and the functions of the filter processor.
+	filterFunctions
+)
+
+// newParserCollection creates a ParserCollection with all the contexts of the given signal,
+// using the given set of functions.
+func newParserCollection(s signal, set functionSet, definitions []ottl.FunctionDefinition) (*ottl.ParserCollection[any], error) {
+	settings := component.TelemetrySettings{Logger: zap.NewNop()}
+	options := []ottl.ParserCollectionOption[any]{
+		withContext(ottlresource.ContextName, ottlresource.NewParser, set, settings, ottlresource.EnablePathContextNames()),
+		withContext(ottlscope.ContextName, ottlscope.NewParser, set, settings, ottlscope.EnablePathContextNames()),
+	}
+	switch s {
+	case signalTraces:
+		options = append(options,
+			withContext(ottlspan.ContextName, ottlspan.NewParser, set, settings, ottlspan.EnablePathContextNames()),
+			withContext(ottlspanevent.ContextName, ottlspanevent.NewParser, set, settings, ottlspanevent.EnablePathContextNames()),
+		)
+	case signalMetrics:
+		options = append(options,
+			withContext(ottlmetric.ContextName, ottlmetric.NewParser, set, settings, ottlmetric.EnablePathContextNames()),
+			withContext(ottldatapoint.ContextName, ottldatapoint.NewParser, set, settings, ottldatapoint.EnablePathContextNames()),
+		)
+	case signalLogs:
+		options = append(options, withContext(ottllog.ContextName, ottllog.NewParser, set, settings, ottllog.EnablePathContextNames()))
+	}
+	if len(definitions) > 0 {
+		options = append(options, ottl.WithParserCollectionFunctionDefinitions[any](definitions))
+	}
+	return ottl.NewParserCollection(settings, options...)
+}
+
+func withContext[K any](
+	context string,
+	newParser func(map[string]ottl.Factory[K], component.TelemetrySettings, ...ottl.Option[K]) (ottl.Parser[K], error),
+	set functionSet,
+	settings component.TelemetrySettings,
+	options ...ottl.Option[K],
+) ottl.ParserCollectionOption[any] {
+	return func(pc *ottl.ParserCollection[any]) error {
+		functions := ottlfuncs.StandardFuncs[K]()
+		if set == filterFunctions {
+			functions = filterContextFunctions[K](context)
+		}
+		parser, err := newParser(functions, settings, options...)
+		if err != nil {
+			return err
+		}
+		return ottl.WithParserCollectionContext[K, any](context, &parser)(pc)
+	}
+}
+
+// filterContextFunctions returns the functions available to the conditions of the given
+// context of the filter processor.
+func filterContextFunctions[K any](context string) map[string]ottl.Factory[K] {
+	functions := ottlfuncs.StandardConverters[K]()
+	var factories []any
+	switch context {
+	case ottlspan.ContextName:
+		factories = append(factories, ottlfuncs.NewIsRootSpanFactory())
+	case ottlmetric.ContextName:
+		factories = append(factories,
+			ottl.NewFactory("HasAttrOnDatapoint", &hasAttrOnDatapointArguments{}, createFilterFunction[K]),
+			ottl.NewFactory("HasAttrKeyOnDatapoint", &hasAttrKeyOnDatapointArguments{}, createFilterFunction[K]),
+		)
+	}
+	for _, factory := range factories {
+		if f, ok := factory.(ottl.Factory[K]); ok {
+			functions[f.Name()] = f
+		}
+	}
+	return functions
+}
+
+// hasAttrOnDatapointArguments are the arguments of the HasAttrOnDatapoint function of the filter processor.
+type hasAttrOnDatapointArguments struct {
+	Key         string
+	ExpectedVal string
+}
+
+// hasAttrKeyOnDatapointArguments are the arguments of the HasAttrKeyOnDatapoint function of the filter processor.
+type hasAttrKeyOnDatapointArguments struct {
+	Key string
+}
+
+// createFilterFunction creates the functions of the filter processor, which are only declared
+// so that the conditions using them are known, as the conditions are never executed.
+func createFilterFunction[K any](_ ottl.FunctionContext, _ ottl.Arguments) (ottl.ExprFunc[K], error) {
+	return func(context.Context, K) (any, error) {
+		return nil, errors.New("the functions of the filter processor are not executed by ottlcheck")
+	}, nil
+}
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+)
+
+func Test_checkConfig(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	findings, err := checkConfig(content)
+	require.NoError(t, err)
+
+	want := []finding{
+		{
+			location: "processors::filter/conditions::traces::span",
+			severity: severityWarning,
+			message:  `type-mismatch: comparing int to string with "==" is always false`,
+			text:     `span.status.code == "error"`,
+		},
+		{
+			location: "processors::filter/conditions::logs::log_record",
+			severity: severityWarning,
+			message:  `nil-path: path "log.cache[drop]" is always nil because conditions cannot set it`,
+			text:     `log.cache["drop"] == true`,
+		},
+		{
+			location: "processors::transform/advanced::log_statements[0]::statements",
+			severity: severityWarning,
+			message:  "constant-condition: the where clause is always true and can be removed",
+			text:     `set(log.body, "redacted") where 1 == 1`,
+		},
+		{
+			location: "processors::transform/advanced::log_statements[0]::conditions",
+			severity: severityWarning,
+			message:  `type-mismatch: comparing int to string with "==" is always false`,
+			text:     `log.severity_number == "INFO"`,
+		},
+		{
+			location: "processors::transform/advanced::log_statements[1]::statements",
+			severity: severityError,
+			message:  `unable to parse OTTL statement "set(attributes[\"env\"], \"prod\"": statement has invalid syntax: 1:30: unexpected token "<EOF>" (expected ")" Key*)`,
+		},
+		{
+			location: "processors::transform/basic::trace_statements[0]::statements",
+			severity: severityWarning,
+			message:  `type-mismatch: comparing int to string with "==" is always false`,
+			text:     `set(span.attributes["kind"], "server") where span.kind == "Server"`,
+		},
+		{
+			location: "processors::transform/basic::trace_statements[0]::statements",
+			severity: severityWarning,
+			message:  `nil-path: path "span.cache[name]" is always nil because no previous statement sets it`,
+			text:     `set(span.name, span.cache["name"])`,
+		},
+	}
+	assert.Equal(t, want, findings)
+}
+
+func Test_checkConfig_inference(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []finding
+	}{
+		{
+			name: "syntax error",
+			content: `
+processors:
+  transform:
+    log_statements:
+      - set(log.body, "a"
+`,
+			want: []finding{{
+				location: "processors::transform::log_statements[0]::statements",
+				severity: severityError,
+				message:  `unable to parse OTTL statement "set(log.body, \"a\"": statement has invalid syntax: 1:18: unexpected token "<EOF>" (expected ")" Key*)`,
+			}},
+		},
+		{
+			name: "unknown function",
+			content: `
+processors:
+  transform:
+    metric_statements:
+      - convert_sum_to_gauge() where metric.name == "a"
+`,
+			want: []finding{{
+				location: "processors::transform::metric_statements[0]",
+				severity: severitySkipped,
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings, err := checkConfig([]byte(tt.content))
+			require.NoError(t, err)
+			require.Len(t, findings, len(tt.want))
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i].location, findings[i].location)
+				assert.Equal(t, tt.want[i].severity, findings[i].severity)
+				if tt.want[i].message != "" {
+					assert.Equal(t, tt.want[i].message, findings[i].message)
+				}
+			}
+		})
+	}
+}
+
+func Test_filterContextFunctions(t *testing.T) {
+	spanFunctions := filterContextFunctions[ottlspan.TransformContext](ottlspan.ContextName)
+	assert.Contains(t, spanFunctions, "IsRootSpan")
+	assert.NotContains(t, spanFunctions, "set")
+
+	metricFunctions := filterContextFunctions[ottlmetric.TransformContext](ottlmetric.ContextName)
+	assert.Contains(t, metricFunctions, "HasAttrOnDatapoint")
+	assert.Contains(t, metricFunctions, "HasAttrKeyOnDatapoint")
+	assert.Contains(t, metricFunctions, "IsMatch")
+	assert.NotContains(t, metricFunctions, "IsRootSpan")
+}
+
+func Test_checkConfig_invalid(t *testing.T) {
+	_, err := checkConfig([]byte("processors: ["))
+	assert.Error(t, err)
+
+	_, err = checkConfig([]byte("processors:\n  transform:\n    log_statements:\n      - set(log.body, \"a\")\n      - context: log\n"))
+	assert.ErrorContains(t, err, "configuring multiple configuration styles is not supported")
+
+	_, err = checkConfig([]byte("processors:\n  filter:\n    logs:\n      log_record:\n        - 1\n"))
+	assert.ErrorContains(t, err, "unexpected condition type int")
+}
+
+func Test_run(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.yaml")
+	require.NoError(t, os.WriteFile(valid, []byte("processors:\n  transform:\n    log_statements:\n      - set(log.body, \"a\") where log.body == nil\n"), 0o600))
+	warning := filepath.Join(dir, "warning.yaml")
+	require.NoError(t, os.WriteFile(warning, []byte("processors:\n  filter:\n    logs:\n      log_record:\n        - 1 == 1\n"), 0o600))
+
+	tests := []struct {
+		name     string
+		args     []string
+		want     int
+		wantOut  string
+		wantErrs string
+	}{
+		{
+			name: "no problems",
+			args: []string{valid},
+			want: 0,
+		},
+		{
+			name:    "warnings",
+			args:    []string{valid, warning},
+			want:    0,
+			wantOut: warning + ": processors::filter::logs::log_record: warning: constant-condition: the condition is always true: 1 == 1\n",
+		},
+		{
+			name:    "strict warnings",
+			args:    []string{"-strict", warning},
+			want:    1,
+			wantOut: warning + ": processors::filter::logs::log_record: warning: constant-condition: the condition is always true: 1 == 1\n",
+		},
+		{
+			name: "errors",
+			args: []string{filepath.Join("testdata", "config.yaml")},
+			want: 1,
+		},
+		{
+			name:     "missing file",
+			args:     []string{filepath.Join(dir, "missing.yaml")},
+			want:     1,
+			wantErrs: "missing.yaml",
+		},
+		{
+			name:     "no arguments",
+			want:     2,
+			wantErrs: "usage: ottlcheck",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			assert.Equal(t, tt.want, run(tt.args, &stdout, &stderr))
+			if tt.wantOut != "" {
+				assert.Equal(t, tt.wantOut, stdout.String())
+			}
+			assert.Contains(t, stderr.String(), tt.wantErrs)
+		})
+	}
+}
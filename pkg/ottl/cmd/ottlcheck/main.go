@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// ottlcheck statically analyzes the OTTL statements and conditions of the transform and filter
+// processors declared in collector configuration files. It reports invalid statements as errors,
+// and potential problems, such as conditions that can never match, as warnings.
+//
+// Usage:
+//
+//	ottlcheck [-strict] <config.yaml>...
+//
+// The exit status is 1 if any error is found, or if any warning is found and -strict is set.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("ottlcheck", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	strict := flags.Bool("strict", false, "exit with a non-zero status when warnings are found")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: ottlcheck [-strict] <config.yaml>...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	failed := false
+	for _, path := range flags.Args() {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", path, err)
+			failed = true
+			continue
+		}
+		findings, err := checkConfig(content)
+		if err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", path, err)
+			failed = true
+			continue
+		}
+		for _, f := range findings {
+			fmt.Fprintf(stdout, "%s: %s\n", path, f)
+			if f.severity == severityError || (*strict && f.severity == severityWarning) {
+				failed = true
+			}
+		}
+	}
+	if failed {
+		return 1
+	}
+	return 0
+}
@@ -16,21 +16,18 @@ func NewParser[K any](
 	telemetrySettings component.TelemetrySettings,
 	pathExpressionParser ottl.PathExpressionParser[K],
 	enumParser ottl.EnumParser,
+	newAnalysisContext func() K,
 	options ...ottl.Option[K],
 ) (ottl.Parser[K], error) {
-	p, err := ottl.NewParser(
+	return ottl.NewParser(
 		functions,
 		pathExpressionParser,
 		telemetrySettings,
-		ottl.WithEnumParser[K](enumParser),
+		append([]ottl.Option[K]{
+			ottl.WithEnumParser[K](enumParser),
+			ottl.WithStaticAnalysisContext[K](newAnalysisContext),
+		}, options...)...,
 	)
-	if err != nil {
-		return ottl.Parser[K]{}, err
-	}
-	for _, opt := range options {
-		opt(&p)
-	}
-	return p, nil
 }
 
 func PathExpressionParser[K any](
@@ -30,6 +30,7 @@ func TestNewParser(t *testing.T) {
 		componenttest.NewNopTelemetrySettings(),
 		pathExpressionParser,
 		enumParser,
+		func() testContext { return testContext{} },
 	)
 
 	assert.NoError(t, err)
@@ -52,6 +53,7 @@ func TestNewParserWithOptions(t *testing.T) {
 		componenttest.NewNopTelemetrySettings(),
 		pathExpressionParser,
 		enumParser,
+		func() testContext { return testContext{} },
 		customOption,
 	)
 
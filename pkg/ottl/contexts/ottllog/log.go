@@ -140,6 +140,13 @@ func WithStatementSequenceErrorMode(errorMode ottl.ErrorMode) StatementSequenceO
 	}
 }
 
+// WithStatementSequenceTelemetry enables the statement-level telemetry of a statement sequence.
+func WithStatementSequenceTelemetry() StatementSequenceOption {
+	return func(s *ottl.StatementSequence[TransformContext]) {
+		ottl.WithStatementSequenceTelemetry[TransformContext]()(s)
+	}
+}
+
 // NewStatementSequence creates a new statement sequence with the provided statements and options.
 func NewStatementSequence(statements []*ottl.Statement[TransformContext], telemetrySettings component.TelemetrySettings, options ...StatementSequenceOption) ottl.StatementSequence[TransformContext] {
 	s := ottl.NewStatementSequence(statements, telemetrySettings)
@@ -179,10 +186,17 @@ func NewParser(
 		telemetrySettings,
 		pathExpressionParser(getCache),
 		parseEnum,
+		newAnalysisContext,
 		options...,
 	)
 }
 
+// newAnalysisContext returns an empty TransformContext used to find the types of paths
+// during the static analysis of statements and conditions.
+func newAnalysisContext() TransformContext {
+	return NewTransformContext(plog.NewLogRecord(), pcommon.NewInstrumentationScope(), pcommon.NewResource(), plog.NewScopeLogs(), plog.NewResourceLogs())
+}
+
 func parseEnum(val *ottl.EnumSymbol) (*ottl.Enum, error) {
 	if val != nil {
 		if enum, ok := ctxlog.SymbolTable[*val]; ok {
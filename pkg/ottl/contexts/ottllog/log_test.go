@@ -12,6 +12,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 
@@ -926,3 +927,20 @@ func Test_ParseEnum_False(t *testing.T) {
 		})
 	}
 }
+
+func Test_AnalyzeStatements(t *testing.T) {
+	parser, err := NewParser(ottl.CreateFactoryMap[TransformContext](), componenttest.NewNopTelemetrySettings(), ottl.WithPathContextNames[TransformContext]([]string{ctxlog.Name}))
+	require.NoError(t, err)
+
+	diagnostics, err := parser.AnalyzeStatements([]string{
+		`set(log.body, "foo") where log.severity_number == "INFO"`,
+		`set(log.body, "foo") where log.severity_number >= SEVERITY_NUMBER_WARN and log.attributes["a"] == 1`,
+		`set(log.body, "foo") where log.body == nil or log.time_unix_nano > 1.5`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []ottl.Diagnostic{{
+		Rule:    ottl.DiagnosticRuleTypeMismatch,
+		Text:    `set(log.body, "foo") where log.severity_number == "INFO"`,
+		Message: `comparing int to string with "==" is always false`,
+	}}, diagnostics)
+}
@@ -113,10 +113,17 @@ func NewParser(functions map[string]ottl.Factory[TransformContext], telemetrySet
 		telemetrySettings,
 		pathExpressionParser(getCache),
 		parseEnum,
+		newAnalysisContext,
 		options...,
 	)
 }
 
+// newAnalysisContext returns an empty TransformContext used to find the types of paths
+// during the static analysis of statements and conditions.
+func newAnalysisContext() TransformContext {
+	return NewTransformContext(pprofile.NewProfile(), pcommon.NewInstrumentationScope(), pcommon.NewResource(), pprofile.NewScopeProfiles(), pprofile.NewResourceProfiles())
+}
+
 // EnablePathContextNames enables the support for path's context names on statements.
 // When this option is configured, all statement's paths must have a valid context prefix,
 // otherwise an error is reported.
@@ -142,6 +149,13 @@ func WithStatementSequenceErrorMode(errorMode ottl.ErrorMode) StatementSequenceO
 	}
 }
 
+// WithStatementSequenceTelemetry enables the statement-level telemetry of a statement sequence.
+func WithStatementSequenceTelemetry() StatementSequenceOption {
+	return func(s *ottl.StatementSequence[TransformContext]) {
+		ottl.WithStatementSequenceTelemetry[TransformContext]()(s)
+	}
+}
+
 // NewStatementSequence creates a new statement sequence with the provided statements and options.
 func NewStatementSequence(statements []*ottl.Statement[TransformContext], telemetrySettings component.TelemetrySettings, options ...StatementSequenceOption) ottl.StatementSequence[TransformContext] {
 	s := ottl.NewStatementSequence(statements, telemetrySettings)
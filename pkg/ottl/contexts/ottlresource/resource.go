@@ -8,6 +8,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/zap/zapcore"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
@@ -87,6 +88,13 @@ func WithStatementSequenceErrorMode(errorMode ottl.ErrorMode) StatementSequenceO
 	}
 }
 
+// WithStatementSequenceTelemetry enables the statement-level telemetry of a statement sequence.
+func WithStatementSequenceTelemetry() StatementSequenceOption {
+	return func(s *ottl.StatementSequence[TransformContext]) {
+		ottl.WithStatementSequenceTelemetry[TransformContext]()(s)
+	}
+}
+
 // NewStatementSequence creates a new statement sequence with the provided statements and options.
 func NewStatementSequence(statements []*ottl.Statement[TransformContext], telemetrySettings component.TelemetrySettings, options ...StatementSequenceOption) ottl.StatementSequence[TransformContext] {
 	s := ottl.NewStatementSequence(statements, telemetrySettings)
@@ -126,10 +134,17 @@ func NewParser(
 		telemetrySettings,
 		pathExpressionParser(getCache),
 		parseEnum,
+		newAnalysisContext,
 		options...,
 	)
 }
 
+// newAnalysisContext returns an empty TransformContext used to find the types of paths
+// during the static analysis of statements and conditions.
+func newAnalysisContext() TransformContext {
+	return NewTransformContext(pcommon.NewResource(), plog.NewResourceLogs())
+}
+
 func parseEnum(_ *ottl.EnumSymbol) (*ottl.Enum, error) {
 	return nil, errors.New("resource context does not provide Enum support")
 }
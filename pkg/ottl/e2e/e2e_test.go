@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -721,6 +722,84 @@ func Test_e2e_converters(t *testing.T) {
 				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
 			},
 		},
+		{
+			statement: `set(attributes["test"], Keys(attributes["foo"]))`,
+			want: func(tCtx ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetStr("bar")
+				s.AppendEmpty().SetStr("flags")
+				s.AppendEmpty().SetStr("slice")
+				s.AppendEmpty().SetStr("nested")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Values(attributes["foo"]["nested"]))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutEmptySlice("test").AppendEmpty().SetStr("pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], IndexOf(["a", "b", "c"], "c"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutInt("test", 2)
+			},
+		},
+		{
+			statement: `set(attributes["test"], IndexOf(attributes["things"], {"name": "bar", "value": 5}))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutInt("test", 1)
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where ContainsValue(attributes["foo"]["slice"], "val")`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where ContainsValue(attributes["roles"], "admin")`,
+			want:      func(_ ottllog.TransformContext) {},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where IsInCIDR("10.1.2.3", ["192.168.0.0/16", "10.0.0.0/8"])`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where IsInCIDR("2001:db8::1", ["10.0.0.0/8"])`,
+			want:      func(_ ottllog.TransformContext) {},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where IsPrivateIP("192.168.1.1") and not IsLoopbackIP("192.168.1.1")`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where IsLoopbackIP("::1") and IsLinkLocalIP("fe80::1")`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], ParseIP("2001:DB8:0:0::1"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "2001:db8::1")
+			},
+		},
+		{
+			statement: `set(attributes["test"], IPNetwork("192.168.10.42", 24))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "192.168.10.0/24")
+			},
+		},
+		{
+			statement: `set(attributes["test"], IPNetwork("2001:db8:1234:5678::1", 24, 48))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "2001:db8:1234::/48")
+			},
+		},
 		{
 			statement: `set(attributes["test"], "pass") where IsString("")`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -832,6 +911,36 @@ func Test_e2e_converters(t *testing.T) {
 				m.AppendEmpty().SetStr("value2")
 			},
 		},
+		{
+			statement: `set(attributes["test"], ParseCEF("CEF:0|Vendor|Product|1.0|100|name|5|src=10.0.0.1 msg=a b"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutStr("version", "0")
+				m.PutStr("device_vendor", "Vendor")
+				m.PutStr("device_product", "Product")
+				m.PutStr("device_version", "1.0")
+				m.PutStr("device_event_class_id", "100")
+				m.PutStr("name", "name")
+				m.PutStr("severity", "5")
+				e := m.PutEmptyMap("extensions")
+				e.PutStr("src", "10.0.0.1")
+				e.PutStr("msg", "a b")
+			},
+		},
+		{
+			statement: `set(attributes["test"], ParseLEEF("LEEF:1.0|Vendor|Product|1.0|41|src=10.0.0.1;sev=5", ";"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutStr("version", "1.0")
+				m.PutStr("device_vendor", "Vendor")
+				m.PutStr("device_product", "Product")
+				m.PutStr("device_version", "1.0")
+				m.PutStr("event_id", "41")
+				e := m.PutEmptyMap("extensions")
+				e.PutStr("src", "10.0.0.1")
+				e.PutStr("sev", "5")
+			},
+		},
 		{
 			statement: `set(attributes["test"], ParseKeyValue("k1=v1 k2=v2"))`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -1206,6 +1315,68 @@ func Test_e2e_converters(t *testing.T) {
 				tCtx.GetLogRecord().Attributes().PutInt("test", 2)
 			},
 		},
+		{
+			statement: `set(attributes["test"], Filter(attributes["things"], x => x["value"] > 3))`,
+			want: func(tCtx ottllog.TransformContext) {
+				thing := tCtx.GetLogRecord().Attributes().PutEmptySlice("test").AppendEmpty().SetEmptyMap()
+				thing.PutStr("name", "bar")
+				thing.PutInt("value", 5)
+			},
+		},
+		{
+			statement: `set(attributes["test"], Filter(attributes["foo"], (k, v) => IsString(v) and k != "bar"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutEmptyMap("test").PutStr("flags", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Filter(attributes["foo"], (k, v) => attributes["foo"][k] == "pass"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutStr("bar", "pass")
+				m.PutStr("flags", "pass")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Map(attributes["things"], x => x["name"]))`,
+			want: func(tCtx ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetStr("foo")
+				s.AppendEmpty().SetStr("bar")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Map(attributes["things"], (i, x) => Concat([body, x["name"], String(i)], "-")))`,
+			want: func(tCtx ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("test")
+				s.AppendEmpty().SetStr("operationA-foo-0")
+				s.AppendEmpty().SetStr("operationA-bar-1")
+			},
+		},
+		{
+			statement: `set(attributes["test"], Any(attributes["things"], x => x["name"] == "bar"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutBool("test", true)
+			},
+		},
+		{
+			statement: `set(attributes["test"], All(attributes["things"], x => x["value"] > 3))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutBool("test", false)
+			},
+		},
+		{
+			statement: `set(attributes["test"], Any(attributes["things"], x => Any(attributes["array"], y => y == x["name"])))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutBool("test", false)
+			},
+		},
+		{
+			statement: `set(attributes["test"], "pass") where All([1, 2, 3], x => x < attributes["int_value"] + 4)`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "pass")
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1437,6 +1608,100 @@ func Test_e2e_ottl_statement_sequence(t *testing.T) {
 	}
 }
 
+func Test_e2e_function_definitions(t *testing.T) {
+	definitions := []ottl.FunctionDefinition{
+		{
+			Name:   "normalize_http",
+			Params: []ottl.ParameterDefinition{{Name: "target", Type: ottl.PathParameter}},
+			Statements: []string{
+				`set(target["http.method"], ToUpperCase(target["http.method"]))`,
+				`set(target["http.route"], target["http.path"]) where target["http.path"] != nil`,
+				`delete_key(target, "http.path")`,
+			},
+		},
+		{
+			Name:       "URLPath",
+			Params:     []ottl.ParameterDefinition{{Name: "url", Type: ottl.StringParameter}},
+			Expression: `Split(url, "/")[3]`,
+		},
+		{
+			Name:   "tag",
+			Params: []ottl.ParameterDefinition{{Name: "target", Type: ottl.PathParameter}, {Name: "value"}},
+			Statements: []string{
+				`set(target["tag"], value)`,
+			},
+		},
+	}
+
+	tests := []struct {
+		statement string
+		want      func(tCtx ottllog.TransformContext)
+	}{
+		{
+			statement: `normalize_http(attributes)`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("http.method", "GET")
+				tCtx.GetLogRecord().Attributes().PutStr("http.route", "/health")
+				tCtx.GetLogRecord().Attributes().Remove("http.path")
+			},
+		},
+		{
+			statement: `set(attributes["test"], URLPath(attributes["http.url"]))`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("test", "health")
+			},
+		},
+		{
+			statement: `tag(attributes["foo"], Concat([body, "tag"], "-")) where URLPath(attributes["http.url"]) == "health"`,
+			want: func(tCtx ottllog.TransformContext) {
+				m, _ := tCtx.GetLogRecord().Attributes().Get("foo")
+				m.Map().PutStr("tag", "operationA-tag")
+			},
+		},
+		{
+			statement: `tag(target = resource.attributes, value = 1)`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetResource().Attributes().PutInt("tag", 1)
+			},
+		},
+	}
+
+	settings := componenttest.NewNopTelemetrySettings()
+	parserWithoutPathCtx, err := ottllog.NewParser(ottlfuncs.StandardFuncs[ottllog.TransformContext](), settings, ottl.WithFunctionDefinitions[ottllog.TransformContext](definitions))
+	require.NoError(t, err)
+	parserWithPathCtx, err := ottllog.NewParser(ottlfuncs.StandardFuncs[ottllog.TransformContext](), settings, ottllog.EnablePathContextNames())
+	require.NoError(t, err)
+	pc, err := ottl.NewParserCollection(settings,
+		ottl.WithParserCollectionFunctionDefinitions[*ottl.Statement[ottllog.TransformContext]](definitions),
+		ottl.WithParserCollectionContext[ottllog.TransformContext, *ottl.Statement[ottllog.TransformContext]](
+			ottllog.ContextName,
+			&parserWithPathCtx,
+			ottl.WithStatementConverter(func(_ *ottl.ParserCollection[*ottl.Statement[ottllog.TransformContext]], _ ottl.StatementsGetter, parsedStatements []*ottl.Statement[ottllog.TransformContext]) (*ottl.Statement[ottllog.TransformContext], error) {
+				return parsedStatements[0], nil
+			})))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			withoutPathCtx, err := parserWithoutPathCtx.ParseStatement(tt.statement)
+			require.NoError(t, err)
+			withPathCtx, err := pc.ParseStatementsWithContext(ottllog.ContextName, ottl.NewStatementsGetter([]string{tt.statement}), true)
+			require.NoError(t, err)
+
+			for _, statement := range []*ottl.Statement[ottllog.TransformContext]{withoutPathCtx, withPathCtx} {
+				tCtx := constructLogTransformContextEditors()
+				_, _, err = statement.Execute(context.Background(), tCtx)
+				require.NoError(t, err)
+
+				exTCtx := constructLogTransformContextEditors()
+				tt.want(exTCtx)
+
+				assert.NoError(t, plogtest.CompareResourceLogs(newResourceLogs(exTCtx), newResourceLogs(tCtx)))
+			}
+		})
+	}
+}
+
 func Test_e2e_ottl_value_expressions(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -820,6 +820,12 @@ func (p *Parser[K]) newGetter(val value) (Getter[K], error) {
 			return &literal[K]{value: *i}, nil
 		}
 		if eL.Path != nil {
+			if g, ok, err := p.newLambdaParamGetSetter(eL.Path); ok {
+				return g, err
+			}
+			if g, ok, err := p.newUserFunctionArgGetSetter(eL.Path); ok {
+				return g, err
+			}
 			np, err := p.newPath(eL.Path)
 			if err != nil {
 				return nil, err
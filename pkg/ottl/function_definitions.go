@@ -0,0 +1,552 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// FunctionDefinition declares a reusable OTTL function in terms of other OTTL statements or
+// expressions, so it can be configured once and invoked by name from any statement or condition.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+type FunctionDefinition struct {
+	// Name is the name used to invoke the function. Names starting with a lowercase letter
+	// declare Editors, which are defined by Statements. Names starting with an uppercase letter
+	// declare Converters, which are defined by an Expression.
+	Name string `mapstructure:"name"`
+	// Params are the parameters of the function, referenced by name within its body.
+	Params []ParameterDefinition `mapstructure:"params"`
+	// Statements are executed in order every time the Editor is invoked.
+	Statements []string `mapstructure:"statements"`
+	// Expression is the value expression, or condition, evaluated every time the Converter is invoked.
+	Expression string `mapstructure:"expression"`
+}
+
+// ParameterDefinition declares a parameter of a FunctionDefinition.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+type ParameterDefinition struct {
+	// Name is the name used to reference the parameter within the function's body.
+	Name string `mapstructure:"name"`
+	// Type is the type of the values accepted by the parameter. Defaults to AnyParameter.
+	Type ParameterType `mapstructure:"type"`
+}
+
+// ParameterType is the type of the values accepted by a function parameter.
+type ParameterType string
+
+const (
+	// AnyParameter accepts any value.
+	AnyParameter ParameterType = "any"
+	// PathParameter accepts a telemetry path, which can be read, indexed, and set within the function's body.
+	PathParameter ParameterType = "path"
+	// StringParameter accepts string values.
+	StringParameter ParameterType = "string"
+	// IntParameter accepts int64 values.
+	IntParameter ParameterType = "int"
+	// FloatParameter accepts float64 values.
+	FloatParameter ParameterType = "float"
+	// BoolParameter accepts bool values.
+	BoolParameter ParameterType = "bool"
+	// MapParameter accepts map values.
+	MapParameter ParameterType = "map"
+	// ListParameter accepts list values.
+	ListParameter ParameterType = "list"
+)
+
+// UnmarshalText unmarshals a string into a ParameterType. It errors if the type is unknown.
+func (t *ParameterType) UnmarshalText(text []byte) error {
+	str := ParameterType(strings.ToLower(string(text)))
+	switch str {
+	case AnyParameter, PathParameter, StringParameter, IntParameter, FloatParameter, BoolParameter, MapParameter, ListParameter:
+		*t = str
+		return nil
+	default:
+		return fmt.Errorf("unknown parameter type %v", str)
+	}
+}
+
+// WithFunctionDefinitions adds the given user-defined functions to the Parser, so they can be invoked
+// by statements and conditions like any other function. The definitions are validated by NewParser.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func WithFunctionDefinitions[K any](definitions []FunctionDefinition) Option[K] {
+	return func(p *Parser[K]) {
+		p.functionDefinitions = append(p.functionDefinitions, definitions...)
+	}
+}
+
+var (
+	editorNameRegexp    = regexp.MustCompile(`^[a-z][a-zA-Z0-9_]*$`)
+	converterNameRegexp = regexp.MustCompile(`^[A-Z][a-zA-Z0-9_]*$`)
+	paramNameRegexp     = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	reservedNames       = map[string]struct{}{"and": {}, "or": {}, "not": {}, "true": {}, "false": {}, "nil": {}, "where": {}}
+)
+
+// userFunction is a parsed FunctionDefinition. Its body is compiled for every invocation,
+// binding the parameters to the invocation arguments.
+type userFunction struct {
+	definition FunctionDefinition
+	statements []*parsedStatement
+	expression *value
+	condition  *booleanExpression
+	calls      map[string]struct{}
+}
+
+func (p *Parser[K]) addFunctionDefinitions(definitions []FunctionDefinition) error {
+	if len(definitions) == 0 {
+		return nil
+	}
+	userFunctions := make(map[string]*userFunction, len(p.userFunctions)+len(definitions))
+	for name, uf := range p.userFunctions {
+		userFunctions[name] = uf
+	}
+
+	var errs []error
+	for _, def := range definitions {
+		if _, ok := userFunctions[def.Name]; ok {
+			errs = append(errs, fmt.Errorf("function %q is defined more than once", def.Name))
+			continue
+		}
+		if _, ok := p.functions[def.Name]; ok {
+			errs = append(errs, fmt.Errorf("function %q is already defined and cannot be redefined", def.Name))
+			continue
+		}
+		uf, err := p.newUserFunction(def)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid definition of function %q: %w", def.Name, err))
+			continue
+		}
+		userFunctions[def.Name] = uf
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for name := range userFunctions {
+		if err := checkRecursiveCalls(userFunctions, name, []string{name}); err != nil {
+			return err
+		}
+	}
+	p.userFunctions = userFunctions
+	return nil
+}
+
+func (p *Parser[K]) newUserFunction(def FunctionDefinition) (*userFunction, error) {
+	isEditor := editorNameRegexp.MatchString(def.Name)
+	if !isEditor && !converterNameRegexp.MatchString(def.Name) {
+		return nil, errors.New("names must start with a lowercase letter for editors or with an uppercase letter for converters, and only contain letters, digits and underscores")
+	}
+	if _, ok := reservedNames[def.Name]; ok {
+		return nil, fmt.Errorf("%q is a reserved word", def.Name)
+	}
+
+	params := make(map[string]struct{}, len(def.Params))
+	for _, param := range def.Params {
+		if !paramNameRegexp.MatchString(param.Name) {
+			return nil, fmt.Errorf("parameter %q must start with a lowercase letter and only contain lowercase letters, digits and underscores", param.Name)
+		}
+		if _, ok := reservedNames[param.Name]; ok {
+			return nil, fmt.Errorf("parameter %q is a reserved word", param.Name)
+		}
+		if _, ok := p.pathContextNames[param.Name]; ok {
+			return nil, fmt.Errorf("parameter %q conflicts with the context of the same name", param.Name)
+		}
+		if _, ok := params[param.Name]; ok {
+			return nil, fmt.Errorf("duplicate parameter %q", param.Name)
+		}
+		switch param.Type {
+		case "", AnyParameter, PathParameter, StringParameter, IntParameter, FloatParameter, BoolParameter, MapParameter, ListParameter:
+		default:
+			return nil, fmt.Errorf("parameter %q has unknown type %q", param.Name, param.Type)
+		}
+		params[param.Name] = struct{}{}
+	}
+
+	uf := &userFunction{definition: def}
+	hints := newGrammarContextInferrerVisitor()
+	if isEditor {
+		if def.Expression != "" {
+			return nil, errors.New("editors must be defined by statements, not by an expression")
+		}
+		if len(def.Statements) == 0 {
+			return nil, errors.New("editors must define at least one statement")
+		}
+		uf.statements = make([]*parsedStatement, len(def.Statements))
+		for i, statement := range def.Statements {
+			parsed, err := parseStatement(statement)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse statement %q: %w", statement, err)
+			}
+			parsed.Editor.accept(&hints)
+			if parsed.WhereClause != nil {
+				parsed.WhereClause.accept(&hints)
+			}
+			uf.statements[i] = parsed
+		}
+	} else {
+		if len(def.Statements) != 0 {
+			return nil, errors.New("converters must be defined by an expression, not by statements")
+		}
+		if def.Expression == "" {
+			return nil, errors.New("converters must define an expression")
+		}
+		parsed, err := parseValueExpression(def.Expression)
+		if err == nil {
+			parsed.accept(&hints)
+			uf.expression = parsed
+		} else {
+			// Converters may also be defined by a condition, in which case they return a bool.
+			condition, condErr := parseCondition(def.Expression)
+			if condErr != nil {
+				return nil, fmt.Errorf("unable to parse expression %q: %w", def.Expression, err)
+			}
+			condition.accept(&hints)
+			uf.condition = condition
+		}
+	}
+	uf.calls = hints.functions
+	return uf, nil
+}
+
+// checkRecursiveCalls returns an error if the user-defined function at the end of the
+// given call chain ends up calling itself or any other function of the chain.
+func checkRecursiveCalls(userFunctions map[string]*userFunction, name string, chain []string) error {
+	for call := range userFunctions[name].calls {
+		if _, ok := userFunctions[call]; !ok {
+			continue
+		}
+		for _, caller := range chain {
+			if call == caller {
+				return fmt.Errorf("function %q cannot be called recursively: %s", call, strings.Join(append(chain, call), " -> "))
+			}
+		}
+		if err := checkRecursiveCalls(userFunctions, call, append(chain, call)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// userFunctionArg is the argument bound to a user-defined function parameter for a single invocation.
+type userFunctionArg[K any] struct {
+	param ParameterDefinition
+	// getter evaluates the argument of non-path parameters.
+	getter Getter[K]
+	// path, parser and keys are set for path parameters. The path must be resolved by the
+	// parser of the invocation scope, and the keys are appended to the resolved path.
+	path   *path
+	parser *Parser[K]
+	keys   []Key[K]
+}
+
+func (p *Parser[K]) newUserFunctionCall(uf *userFunction, ed editor) (Expr[K], error) {
+	args, err := p.bindUserFunctionArgs(uf, ed.Arguments)
+	if err != nil {
+		return Expr[K]{}, fmt.Errorf("error while parsing arguments for call to %q: %w", ed.Function, err)
+	}
+
+	scoped := *p
+	scoped.lambdaParams = nil
+	scoped.userFunctionArgs = args
+
+	if uf.expression != nil {
+		getter, err := scoped.newGetter(*uf.expression)
+		if err != nil {
+			return Expr[K]{}, fmt.Errorf("error while expanding call to %q: %w", ed.Function, err)
+		}
+		return Expr[K]{exprFunc: getter.Get}, nil
+	}
+
+	if uf.condition != nil {
+		condition, err := scoped.newBoolExpr(uf.condition)
+		if err != nil {
+			return Expr[K]{}, fmt.Errorf("error while expanding call to %q: %w", ed.Function, err)
+		}
+		return Expr[K]{exprFunc: func(ctx context.Context, tCtx K) (any, error) {
+			return condition.Eval(ctx, tCtx)
+		}}, nil
+	}
+
+	statements := make([]*Statement[K], len(uf.statements))
+	for i, parsed := range uf.statements {
+		function, err := scoped.newFunctionCall(parsed.Editor)
+		if err != nil {
+			return Expr[K]{}, fmt.Errorf("error while expanding call to %q: %w", ed.Function, err)
+		}
+		condition, err := scoped.newBoolExpr(parsed.WhereClause)
+		if err != nil {
+			return Expr[K]{}, fmt.Errorf("error while expanding call to %q: %w", ed.Function, err)
+		}
+		statements[i] = &Statement[K]{
+			function:          function,
+			condition:         condition,
+			origText:          uf.definition.Statements[i],
+			telemetrySettings: p.telemetrySettings,
+		}
+	}
+	return Expr[K]{exprFunc: func(ctx context.Context, tCtx K) (any, error) {
+		for _, statement := range statements {
+			if _, _, err := statement.Execute(ctx, tCtx); err != nil {
+				return nil, fmt.Errorf("failed to execute statement %q of function %q: %w", statement.origText, ed.Function, err)
+			}
+		}
+		return nil, nil
+	}}, nil
+}
+
+func (p *Parser[K]) bindUserFunctionArgs(uf *userFunction, arguments []argument) (map[string]*userFunctionArg[K], error) {
+	params := uf.definition.Params
+	if len(arguments) > len(params) {
+		return nil, fmt.Errorf("incorrect number of arguments. Expected: %d Received: %d", len(params), len(arguments))
+	}
+
+	bound := make([]*argument, len(params))
+	seenNamed := false
+	for i := range arguments {
+		arg := &arguments[i]
+		if arg.Name == "" {
+			if seenNamed {
+				return nil, errors.New("unnamed argument used after named argument")
+			}
+			bound[i] = arg
+			continue
+		}
+		seenNamed = true
+		idx := -1
+		for j, param := range params {
+			if param.Name == arg.Name {
+				idx = j
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, fmt.Errorf("no such parameter: %s", arg.Name)
+		}
+		if bound[idx] != nil {
+			return nil, fmt.Errorf("duplicate argument for parameter %q", arg.Name)
+		}
+		bound[idx] = arg
+	}
+
+	args := make(map[string]*userFunctionArg[K], len(params))
+	for i, param := range params {
+		if bound[i] == nil {
+			return nil, fmt.Errorf("missing argument for parameter %q", param.Name)
+		}
+		arg, err := p.newUserFunctionArg(param, bound[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument for parameter %q: %w", param.Name, err)
+		}
+		args[param.Name] = arg
+	}
+	return args, nil
+}
+
+func (p *Parser[K]) newUserFunctionArg(param ParameterDefinition, arg *argument) (*userFunctionArg[K], error) {
+	if arg.Lambda != nil {
+		return nil, errors.New("lambda expressions are not supported for user-defined functions")
+	}
+	if arg.FunctionName != nil {
+		return nil, errors.New("function names are not supported for user-defined functions")
+	}
+
+	if param.Type == PathParameter {
+		if arg.Value.Literal == nil || arg.Value.Literal.Path == nil {
+			return nil, errors.New("must be a path")
+		}
+		argPath := arg.Value.Literal.Path
+		if _, ok := argPath.paramName(p.lambdaParamNames()); ok {
+			return nil, errors.New("must be a path, not a lambda parameter")
+		}
+		if name, ok := argPath.paramName(p.userFunctionArgNames()); ok {
+			forwarded := p.userFunctionArgs[name]
+			if forwarded.param.Type != PathParameter {
+				return nil, fmt.Errorf("must be a path, but parameter %q is of type %q", name, forwarded.param.typ())
+			}
+			if argPath.Context != "" || len(argPath.Fields) > 1 {
+				return nil, fmt.Errorf("parameter %q cannot have path segments, use keys instead (e.g. %s[\"key\"])", name, name)
+			}
+			keys, err := p.newKeys(argPath.Fields[0].Keys)
+			if err != nil {
+				return nil, err
+			}
+			return &userFunctionArg[K]{
+				param:  param,
+				path:   forwarded.path,
+				parser: forwarded.parser,
+				keys:   append(append([]Key[K]{}, forwarded.keys...), keys...),
+			}, nil
+		}
+		return &userFunctionArg[K]{param: param, path: argPath, parser: p}, nil
+	}
+
+	if t, ok := staticValueType(arg.Value); ok && !param.typ().accepts(t) {
+		return nil, fmt.Errorf("expected %s but got %s", param.typ(), t)
+	}
+	getter, err := p.newGetter(arg.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &userFunctionArg[K]{param: param, getter: newTypedGetter(param, getter)}, nil
+}
+
+// newUserFunctionArgGetSetter returns a GetSetter for paths referencing a parameter of the
+// user-defined function being expanded. The returned bool is false when the path does not
+// reference any parameter.
+func (p *Parser[K]) newUserFunctionArgGetSetter(path *path) (GetSetter[K], bool, error) {
+	if len(p.userFunctionArgs) == 0 {
+		return nil, false, nil
+	}
+	name, ok := path.paramName(p.userFunctionArgNames())
+	if !ok {
+		return nil, false, nil
+	}
+	if path.Context != "" || len(path.Fields) > 1 {
+		return nil, true, fmt.Errorf("parameter %q cannot have path segments, use keys instead (e.g. %s[\"key\"])", name, name)
+	}
+	arg := p.userFunctionArgs[name]
+	keys := path.Fields[0].Keys
+
+	if arg.param.Type == PathParameter {
+		np, err := arg.parser.newPath(arg.path)
+		if err != nil {
+			return nil, true, err
+		}
+		extraKeys, err := p.newKeys(keys)
+		if err != nil {
+			return nil, true, err
+		}
+		if extra := append(append([]Key[K]{}, arg.keys...), extraKeys...); len(extra) > 0 {
+			last := np
+			for last.nextPath != nil {
+				last = last.nextPath
+			}
+			last.keys = append(last.keys, extra...)
+		}
+		gs, err := p.parsePath(np)
+		return gs, true, err
+	}
+
+	setter := func(context.Context, K, any) error {
+		return fmt.Errorf("parameter %q cannot be set, declare it with the %q type instead", name, PathParameter)
+	}
+	if len(keys) == 0 {
+		return &StandardGetSetter[K]{Getter: arg.getter.Get, Setter: setter}, true, nil
+	}
+	for _, k := range keys {
+		if k.String == nil && k.Int == nil {
+			return nil, true, fmt.Errorf("parameter %q can only be indexed with string or int literals", name)
+		}
+	}
+	indexed := exprGetter[K]{
+		expr: Expr[K]{exprFunc: arg.getter.Get},
+		keys: keys,
+	}
+	return &StandardGetSetter[K]{Getter: indexed.Get, Setter: setter}, true, nil
+}
+
+func (p *Parser[K]) userFunctionArgNames() []string {
+	names := make([]string, 0, len(p.userFunctionArgs))
+	for name := range p.userFunctionArgs {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (d ParameterDefinition) typ() ParameterType {
+	if d.Type == "" {
+		return AnyParameter
+	}
+	return d.Type
+}
+
+func (t ParameterType) accepts(other ParameterType) bool {
+	return t == AnyParameter || t == other
+}
+
+// staticValueType returns the type of literal values, which is known while parsing.
+func staticValueType(val value) (ParameterType, bool) {
+	switch {
+	case val.IsNil != nil:
+		return "nil", true
+	case val.String != nil:
+		return StringParameter, true
+	case val.Bool != nil:
+		return BoolParameter, true
+	case val.Bytes != nil:
+		return "bytes", true
+	case val.Enum != nil:
+		return IntParameter, true
+	case val.Map != nil:
+		return MapParameter, true
+	case val.List != nil:
+		return ListParameter, true
+	case val.Literal != nil && val.Literal.Int != nil:
+		return IntParameter, true
+	case val.Literal != nil && val.Literal.Float != nil:
+		return FloatParameter, true
+	default:
+		return "", false
+	}
+}
+
+// newTypedGetter wraps the getter of an argument so its value is checked against the
+// type of the parameter when evaluated.
+func newTypedGetter[K any](param ParameterDefinition, getter Getter[K]) Getter[K] {
+	var get func(ctx context.Context, tCtx K) (any, error)
+	switch param.typ() {
+	case StringParameter:
+		g := StandardStringGetter[K]{Getter: getter.Get}
+		get = func(ctx context.Context, tCtx K) (any, error) { return g.Get(ctx, tCtx) }
+	case IntParameter:
+		g := StandardIntGetter[K]{Getter: getter.Get}
+		get = func(ctx context.Context, tCtx K) (any, error) { return g.Get(ctx, tCtx) }
+	case FloatParameter:
+		g := StandardFloatGetter[K]{Getter: getter.Get}
+		get = func(ctx context.Context, tCtx K) (any, error) { return g.Get(ctx, tCtx) }
+	case BoolParameter:
+		g := StandardBoolGetter[K]{Getter: getter.Get}
+		get = func(ctx context.Context, tCtx K) (any, error) { return g.Get(ctx, tCtx) }
+	case MapParameter:
+		g := StandardPMapGetter[K]{Getter: getter.Get}
+		get = func(ctx context.Context, tCtx K) (any, error) { return g.Get(ctx, tCtx) }
+	case ListParameter:
+		get = func(ctx context.Context, tCtx K) (any, error) {
+			val, err := getter.Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			switch v := val.(type) {
+			case pcommon.Slice, []any, []string, []int64, []float64, []bool:
+				return v, nil
+			case pcommon.Value:
+				if v.Type() == pcommon.ValueTypeSlice {
+					return v.Slice(), nil
+				}
+				return nil, TypeError(fmt.Sprintf("expected list but got %v", v.Type()))
+			default:
+				return nil, TypeError(fmt.Sprintf("expected list but got %T", val))
+			}
+		}
+	default:
+		return getter
+	}
+	return &StandardGetSetter[K]{
+		Getter: func(ctx context.Context, tCtx K) (any, error) {
+			val, err := get(ctx, tCtx)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for parameter %q: %w", param.Name, err)
+			}
+			return val, nil
+		},
+	}
+}
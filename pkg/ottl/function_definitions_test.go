@@ -0,0 +1,458 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+func newFunctionDefinitionsTestParser(definitions []FunctionDefinition) (Parser[any], error) {
+	return NewParser[any](
+		CreateFactoryMap[any](
+			NewFactory("Call", &callLambdaArguments{}, createCallLambdaFunction),
+			createFactory[any](
+				"testing_getsetter",
+				&getSetterArguments{},
+				functionWithGetSetter,
+			),
+			NewFactory("testing_set", &getSetterArguments{}, func(_ FunctionContext, oArgs Arguments) (ExprFunc[any], error) {
+				target := oArgs.(*getSetterArguments).GetSetterArg
+				return func(ctx context.Context, tCtx any) (any, error) {
+					return nil, target.Set(ctx, tCtx, "value")
+				}, nil
+			}),
+		),
+		testParsePath[any],
+		componenttest.NewNopTelemetrySettings(),
+		WithEnumParser[any](testParseEnum),
+		WithFunctionDefinitions[any](definitions),
+	)
+}
+
+func Test_FunctionDefinitions(t *testing.T) {
+	definitions := []FunctionDefinition{
+		{
+			Name:       "Sum",
+			Params:     []ParameterDefinition{{Name: "a", Type: IntParameter}, {Name: "b", Type: IntParameter}},
+			Expression: "a + b",
+		},
+		{
+			Name:       "Double",
+			Params:     []ParameterDefinition{{Name: "value"}},
+			Expression: "Sum(value, value)",
+		},
+		{
+			Name:       "Get",
+			Params:     []ParameterDefinition{{Name: "m", Type: MapParameter}},
+			Expression: `m["key"]`,
+		},
+		{
+			Name:       "Constant",
+			Expression: `"constant"`,
+		},
+		{
+			Name:       "Apply",
+			Params:     []ParameterDefinition{{Name: "value"}, {Name: "offset", Type: IntParameter}},
+			Expression: "Call([value], x => x + offset)",
+		},
+		{
+			Name:       "First",
+			Params:     []ParameterDefinition{{Name: "list", Type: ListParameter}},
+			Expression: "list[0]",
+		},
+		{
+			Name:       "Name",
+			Params:     []ParameterDefinition{{Name: "target", Type: PathParameter}},
+			Expression: "target",
+		},
+		{
+			Name:       "Between",
+			Params:     []ParameterDefinition{{Name: "value", Type: IntParameter}, {Name: "low", Type: IntParameter}, {Name: "high", Type: IntParameter}},
+			Expression: "value >= low and value <= high",
+		},
+	}
+	p, err := newFunctionDefinitionsTestParser(definitions)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		expression string
+		tCtx       any
+		want       any
+	}{
+		{
+			name:       "typed parameters",
+			expression: "Sum(1, 2)",
+			want:       int64(3),
+		},
+		{
+			name:       "named arguments",
+			expression: "Sum(b = 1, a = 2)",
+			want:       int64(3),
+		},
+		{
+			name:       "nested functions",
+			expression: "Double(2)",
+			want:       int64(4),
+		},
+		{
+			name:       "math expressions",
+			expression: "Sum(1, 2) * Double(3)",
+			want:       int64(18),
+		},
+		{
+			name:       "indexed parameter",
+			expression: `Get({"key": "value"})`,
+			want:       "value",
+		},
+		{
+			name:       "indexed result",
+			expression: `Get({"key": {"nested": true}})["nested"]`,
+			want:       true,
+		},
+		{
+			name:       "condition expression",
+			expression: "Between(2, 1, 3)",
+			want:       true,
+		},
+		{
+			name:       "condition expression not met",
+			expression: "Between(Sum(2, 2), 1, 3)",
+			want:       false,
+		},
+		{
+			name:       "no parameters",
+			expression: "Constant()",
+			want:       "constant",
+		},
+		{
+			name:       "parameters in lambdas",
+			expression: "Apply(1, 2)",
+			want:       int64(3),
+		},
+		{
+			name:       "from lambdas",
+			expression: "Call([1], x => Sum(x, 2))",
+			want:       int64(3),
+		},
+		{
+			name:       "list parameter",
+			expression: `First(["a", "b"])`,
+			want:       "a",
+		},
+		{
+			name:       "path parameter",
+			expression: "Name(name)",
+			tCtx:       "name",
+			want:       "name",
+		},
+		{
+			name:       "runtime typed parameter",
+			expression: "Sum(name, 1)",
+			tCtx:       int64(1),
+			want:       int64(2),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := p.ParseValueExpression(tt.expression)
+			require.NoError(t, err)
+
+			result, err := expr.Eval(context.Background(), tt.tCtx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func Test_FunctionDefinitions_editors(t *testing.T) {
+	definitions := []FunctionDefinition{
+		{
+			Name:   "set_name",
+			Params: []ParameterDefinition{{Name: "target", Type: PathParameter}},
+			Statements: []string{
+				`testing_getsetter(target)`,
+				`testing_getsetter(target["key"]) where target != nil`,
+			},
+		},
+		{
+			Name:       "set_all",
+			Params:     []ParameterDefinition{{Name: "target", Type: PathParameter}},
+			Statements: []string{`set_name(target["nested"])`},
+		},
+	}
+	p, err := newFunctionDefinitionsTestParser(definitions)
+	require.NoError(t, err)
+
+	_, err = p.ParseStatement(`set_name(name)`)
+	assert.ErrorContains(t, err, `the keys indexing "name" were not used by the context`)
+
+	for _, statement := range []string{
+		`set_name(attributes["foo"])`,
+		`set_name(target = attributes) where name != nil`,
+		`set_all(attributes)`,
+	} {
+		t.Run(statement, func(t *testing.T) {
+			s, err := p.ParseStatement(statement)
+			require.NoError(t, err)
+			_, condition, err := s.Execute(context.Background(), "value")
+			require.NoError(t, err)
+			assert.True(t, condition)
+		})
+	}
+}
+
+func Test_FunctionDefinitions_invalid_definitions(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition FunctionDefinition
+		wantErr    string
+	}{
+		{
+			name:       "invalid name",
+			definition: FunctionDefinition{Name: "1foo", Expression: "1"},
+			wantErr:    "names must start with a lowercase letter for editors or with an uppercase letter for converters",
+		},
+		{
+			name:       "reserved name",
+			definition: FunctionDefinition{Name: "where", Statements: []string{"testing_getsetter(name)"}},
+			wantErr:    `"where" is a reserved word`,
+		},
+		{
+			name:       "existing function",
+			definition: FunctionDefinition{Name: "Call", Expression: "1"},
+			wantErr:    `function "Call" is already defined and cannot be redefined`,
+		},
+		{
+			name:       "invalid parameter name",
+			definition: FunctionDefinition{Name: "Foo", Params: []ParameterDefinition{{Name: "Bar"}}, Expression: "1"},
+			wantErr:    `parameter "Bar" must start with a lowercase letter`,
+		},
+		{
+			name:       "reserved parameter name",
+			definition: FunctionDefinition{Name: "Foo", Params: []ParameterDefinition{{Name: "nil"}}, Expression: "1"},
+			wantErr:    `parameter "nil" is a reserved word`,
+		},
+		{
+			name:       "duplicate parameter",
+			definition: FunctionDefinition{Name: "Foo", Params: []ParameterDefinition{{Name: "a"}, {Name: "a"}}, Expression: "1"},
+			wantErr:    `duplicate parameter "a"`,
+		},
+		{
+			name:       "unknown parameter type",
+			definition: FunctionDefinition{Name: "Foo", Params: []ParameterDefinition{{Name: "a", Type: "number"}}, Expression: "1"},
+			wantErr:    `parameter "a" has unknown type "number"`,
+		},
+		{
+			name:       "editor with expression",
+			definition: FunctionDefinition{Name: "foo", Expression: "1"},
+			wantErr:    "editors must be defined by statements, not by an expression",
+		},
+		{
+			name:       "editor without statements",
+			definition: FunctionDefinition{Name: "foo"},
+			wantErr:    "editors must define at least one statement",
+		},
+		{
+			name:       "converter with statements",
+			definition: FunctionDefinition{Name: "Foo", Statements: []string{"testing_getsetter(name)"}},
+			wantErr:    "converters must be defined by an expression, not by statements",
+		},
+		{
+			name:       "converter without expression",
+			definition: FunctionDefinition{Name: "Foo"},
+			wantErr:    "converters must define an expression",
+		},
+		{
+			name:       "invalid statement",
+			definition: FunctionDefinition{Name: "foo", Statements: []string{"testing_getsetter(name"}},
+			wantErr:    `unable to parse statement "testing_getsetter(name"`,
+		},
+		{
+			name:       "invalid expression",
+			definition: FunctionDefinition{Name: "Foo", Expression: "1 +"},
+			wantErr:    `unable to parse expression "1 +"`,
+		},
+		{
+			name:       "recursive",
+			definition: FunctionDefinition{Name: "Foo", Params: []ParameterDefinition{{Name: "a"}}, Expression: "Foo(a)"},
+			wantErr:    `function "Foo" cannot be called recursively: Foo -> Foo`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newFunctionDefinitionsTestParser([]FunctionDefinition{tt.definition})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_FunctionDefinitions_invalid_definitions_set(t *testing.T) {
+	_, err := newFunctionDefinitionsTestParser([]FunctionDefinition{
+		{Name: "Foo", Expression: "1"},
+		{Name: "Foo", Expression: "2"},
+	})
+	assert.ErrorContains(t, err, `function "Foo" is defined more than once`)
+
+	_, err = newFunctionDefinitionsTestParser([]FunctionDefinition{
+		{Name: "Foo", Expression: "Bar()"},
+		{Name: "Bar", Expression: "Baz()"},
+		{Name: "Baz", Expression: "Foo()"},
+	})
+	assert.ErrorContains(t, err, "cannot be called recursively")
+
+	_, err = NewParser[any](
+		CreateFactoryMap[any](),
+		testParsePath[any],
+		componenttest.NewNopTelemetrySettings(),
+		WithPathContextNames[any]([]string{"log"}),
+		WithFunctionDefinitions[any]([]FunctionDefinition{
+			{Name: "Foo", Params: []ParameterDefinition{{Name: "log"}}, Expression: "1"},
+		}),
+	)
+	assert.ErrorContains(t, err, `parameter "log" conflicts with the context of the same name`)
+}
+
+func Test_FunctionDefinitions_invalid_calls(t *testing.T) {
+	definitions := []FunctionDefinition{
+		{
+			Name:       "Sum",
+			Params:     []ParameterDefinition{{Name: "a", Type: IntParameter}, {Name: "b", Type: IntParameter}},
+			Expression: "a + b",
+		},
+		{
+			Name:       "Get",
+			Params:     []ParameterDefinition{{Name: "target", Type: PathParameter}},
+			Expression: "target",
+		},
+		{
+			Name:       "Segment",
+			Params:     []ParameterDefinition{{Name: "value"}},
+			Expression: "value.foo",
+		},
+		{
+			Name:       "DynamicKey",
+			Params:     []ParameterDefinition{{Name: "value"}},
+			Expression: "value[name]",
+		},
+		{
+			Name:       "Undefined",
+			Expression: "Unknown()",
+		},
+		{
+			Name:       "Forward",
+			Params:     []ParameterDefinition{{Name: "value"}},
+			Expression: "Get(value)",
+		},
+		{
+			Name:       "set_value",
+			Params:     []ParameterDefinition{{Name: "value"}},
+			Statements: []string{"testing_set(value)"},
+		},
+	}
+	p, err := newFunctionDefinitionsTestParser(definitions)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		expression string
+		wantErr    string
+	}{
+		{
+			name:       "too many arguments",
+			expression: "Sum(1, 2, 3)",
+			wantErr:    "incorrect number of arguments. Expected: 2 Received: 3",
+		},
+		{
+			name:       "missing argument",
+			expression: "Sum(1)",
+			wantErr:    `missing argument for parameter "b"`,
+		},
+		{
+			name:       "unknown named argument",
+			expression: "Sum(1, c = 2)",
+			wantErr:    "no such parameter: c",
+		},
+		{
+			name:       "duplicate named argument",
+			expression: "Sum(a = 1, a = 2)",
+			wantErr:    `duplicate argument for parameter "a"`,
+		},
+		{
+			name:       "unnamed after named",
+			expression: "Sum(a = 1, 2)",
+			wantErr:    "unnamed argument used after named argument",
+		},
+		{
+			name:       "literal type mismatch",
+			expression: `Sum(1, "2")`,
+			wantErr:    `invalid argument for parameter "b": expected int but got string`,
+		},
+		{
+			name:       "not a path",
+			expression: `Get("name")`,
+			wantErr:    `invalid argument for parameter "target": must be a path`,
+		},
+		{
+			name:       "lambda argument",
+			expression: `Sum(x => x, 1)`,
+			wantErr:    "lambda expressions are not supported for user-defined functions",
+		},
+		{
+			name:       "lambda parameter as path",
+			expression: `Call([1], x => Get(x))`,
+			wantErr:    "must be a path, not a lambda parameter",
+		},
+		{
+			name:       "parameter with path segments",
+			expression: "Segment(1)",
+			wantErr:    `parameter "value" cannot have path segments`,
+		},
+		{
+			name:       "parameter with dynamic keys",
+			expression: "DynamicKey(1)",
+			wantErr:    `parameter "value" can only be indexed with string or int literals`,
+		},
+		{
+			name:       "undefined function in body",
+			expression: "Undefined()",
+			wantErr:    `error while expanding call to "Undefined": undefined function "Unknown"`,
+		},
+		{
+			name:       "forwarding non-path parameter",
+			expression: "Forward(1)",
+			wantErr:    `must be a path, but parameter "value" is of type "any"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ParseValueExpression(tt.expression)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	expr, err := p.ParseValueExpression("Sum(name, 1)")
+	require.NoError(t, err)
+	_, err = expr.Eval(context.Background(), "not an int")
+	assert.ErrorContains(t, err, `invalid value for parameter "a"`)
+	var typeErr TypeError
+	assert.ErrorAs(t, err, &typeErr)
+
+	s, err := p.ParseStatement("set_value(1)")
+	require.NoError(t, err)
+	_, _, err = s.Execute(context.Background(), nil)
+	assert.ErrorContains(t, err, `parameter "value" cannot be set, declare it with the "path" type instead`)
+}
+
+func Test_ParameterType_UnmarshalText(t *testing.T) {
+	var pt ParameterType
+	require.NoError(t, pt.UnmarshalText([]byte("PATH")))
+	assert.Equal(t, PathParameter, pt)
+	assert.ErrorContains(t, pt.UnmarshalText([]byte("number")), "unknown parameter type number")
+}
@@ -315,6 +315,9 @@ func (p *Parser[K]) parsePath(ip *basePath[K]) (GetSetter[K], error) {
 }
 
 func (p *Parser[K]) newFunctionCall(ed editor) (Expr[K], error) {
+	if uf, ok := p.userFunctions[ed.Function]; ok {
+		return p.newUserFunctionCall(uf, ed)
+	}
 	f, ok := p.functions[ed.Function]
 	if !ok {
 		return Expr[K]{}, fmt.Errorf("undefined function %q", ed.Function)
@@ -404,7 +407,25 @@ func (p *Parser[K]) buildArgs(ed editor, argsVal reflect.Value) error {
 			fieldType = manager.get().Type()
 		}
 
+		isLambda := strings.HasPrefix(fieldType.Name(), "Lambda") || strings.HasPrefix(fieldType.Name(), "BoolLambda")
+		if arg.Lambda != nil && !isLambda {
+			return fmt.Errorf("invalid argument at position %v: lambda expressions are not supported for this parameter", i)
+		}
+
 		switch {
+		case isLambda:
+			if arg.Lambda == nil {
+				return fmt.Errorf("invalid argument at position %v: must be a lambda expression", i)
+			}
+			var l *lambdaFunc[K]
+			l, err = p.newLambda(arg.Lambda)
+			if err == nil {
+				if strings.HasPrefix(fieldType.Name(), "BoolLambda") {
+					val = StandardBoolLambda[K]{Lambda: l}
+				} else {
+					val = Lambda[K](l)
+				}
+			}
 		case strings.HasPrefix(fieldType.Name(), "FunctionGetter"):
 			var name string
 			switch {
@@ -530,6 +551,12 @@ func (p *Parser[K]) buildSliceArg(argVal value, argType reflect.Type) (any, erro
 }
 
 func (p *Parser[K]) buildGetSetterFromPath(path *path) (GetSetter[K], error) {
+	if g, ok, err := p.newLambdaParamGetSetter(path); ok {
+		return g, err
+	}
+	if g, ok, err := p.newUserFunctionArgGetSetter(path); ok {
+		return g, err
+	}
 	np, err := p.newPath(path)
 	if err != nil {
 		return nil, err
@@ -223,14 +223,46 @@ func (c *converter) accept(v grammarVisitor) {
 
 type argument struct {
 	Name         string  `parser:"(@(Lowercase(Uppercase | Lowercase)*) Equal)?"`
-	Value        value   `parser:"( @@"`
+	Lambda       *lambda `parser:"( @@"`
+	Value        value   `parser:"| @@"`
 	FunctionName *string `parser:"| @(Uppercase(Uppercase | Lowercase)*) )"`
 }
 
 func (a *argument) accept(v grammarVisitor) {
+	if a.Lambda != nil {
+		a.Lambda.accept(v)
+		return
+	}
 	a.Value.accept(v)
 }
 
+// lambda represents an anonymous function passed as an argument to another function,
+// such as `x => x != "foo"` or `(k, v) => IsMatch(k, "^http")`.
+type lambda struct {
+	Params []string    `parser:"( @Lowercase | '(' @Lowercase ( ',' @Lowercase )* ')' ) Arrow"`
+	Body   *lambdaBody `parser:"@@"`
+}
+
+func (l *lambda) accept(v grammarVisitor) {
+	if l.Body == nil {
+		return
+	}
+	scoped := &lambdaScopeVisitor{grammarVisitor: v, params: l.Params}
+	if l.Body.Value != nil {
+		l.Body.Value.accept(scoped)
+	}
+	if l.Body.Condition != nil {
+		l.Body.Condition.accept(scoped)
+	}
+}
+
+// lambdaBody is the expression evaluated by a lambda. Bodies that are followed by a
+// comparison or a boolean operator are parsed as boolean expressions, all others as values.
+type lambdaBody struct {
+	Value     *value             `parser:"( @@ (?! OpComparison | OpAnd | OpOr)"`
+	Condition *booleanExpression `parser:"| @@ )"`
+}
+
 // value represents a part of a parsed statement which is resolved to a value of some sort. This can be a telemetry path
 // mathExpression, function call, or literal.
 type value struct {
@@ -269,6 +301,21 @@ func (v *value) accept(vis grammarVisitor) {
 	}
 }
 
+// paramName returns the name of the lambda or function parameter referenced by the path, if
+// any of the given params is used as its first segment.
+func (p *path) paramName(params []string) (string, bool) {
+	name := p.Context
+	if name == "" && len(p.Fields) > 0 {
+		name = p.Fields[0].Name
+	}
+	for _, param := range params {
+		if name == param {
+			return name, true
+		}
+	}
+	return "", false
+}
+
 // path represents a telemetry path mathExpression.
 type path struct {
 	Pos     lexer.Position
@@ -507,6 +554,7 @@ func buildLexer() *lexer.StatefulDefinition {
 		{Name: `OpNot`, Pattern: `\b(not)\b`},
 		{Name: `OpOr`, Pattern: `\b(or)\b`},
 		{Name: `OpAnd`, Pattern: `\b(and)\b`},
+		{Name: `Arrow`, Pattern: `=>`},
 		{Name: `OpComparison`, Pattern: `==|!=|>=|<=|>|<`},
 		{Name: `OpAddSub`, Pattern: `\+|\-`},
 		{Name: `OpMultDiv`, Pattern: `\/|\*`},
@@ -594,3 +642,17 @@ func (g *grammarCustomErrorsVisitor) visitMathExprLiteral(v *mathExprLiteral) {
 		g.add(fmt.Errorf("converter names must start with an uppercase letter but got '%v'", v.Editor.Function))
 	}
 }
+
+// lambdaScopeVisitor wraps a grammarVisitor and hides the paths referencing the parameters
+// of a lambda, as those are not telemetry paths and must not be handled as such.
+type lambdaScopeVisitor struct {
+	grammarVisitor
+	params []string
+}
+
+func (l *lambdaScopeVisitor) visitPath(v *path) {
+	if _, ok := v.paramName(l.params); ok {
+		return
+	}
+	l.grammarVisitor.visitPath(v)
+}
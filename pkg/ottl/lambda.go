@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Lambda is an anonymous function passed as an argument to an OTTL function, such as
+// `x => x != "foo"` or `(k, v) => IsMatch(k, "^http")`. Functions accepting a Lambda
+// invoke it with the values to bind to its parameters, usually once per element of a collection.
+type Lambda[K any] interface {
+	// Params returns the number of parameters declared by the Lambda.
+	Params() int
+
+	// Call binds the given arguments to the Lambda's parameters, in order, and evaluates its body.
+	// An error is returned if the number of arguments does not match the number of parameters.
+	Call(ctx context.Context, tCtx K, args ...any) (any, error)
+}
+
+// BoolLambda is a Lambda whose body must evaluate to a bool.
+type BoolLambda[K any] interface {
+	// Params returns the number of parameters declared by the Lambda.
+	Params() int
+
+	// Call binds the given arguments to the Lambda's parameters, in order, and evaluates its body.
+	// If the body does not evaluate to a bool a new TypeError is returned.
+	Call(ctx context.Context, tCtx K, args ...any) (bool, error)
+}
+
+var (
+	_ Lambda[any]     = &lambdaFunc[any]{}
+	_ Lambda[any]     = &StandardLambda[any]{}
+	_ BoolLambda[any] = &StandardBoolLambda[any]{}
+)
+
+// StandardLambda is a basic implementation of Lambda
+type StandardLambda[K any] struct {
+	Arity int
+	Body  func(ctx context.Context, tCtx K, args ...any) (any, error)
+}
+
+// Params returns the Arity of the StandardLambda.
+func (l StandardLambda[K]) Params() int {
+	return l.Arity
+}
+
+// Call evaluates the Body with the given arguments.
+// An error is returned if the number of arguments does not match the Arity.
+func (l StandardLambda[K]) Call(ctx context.Context, tCtx K, args ...any) (any, error) {
+	if len(args) != l.Arity {
+		return nil, fmt.Errorf("lambda expects %d argument(s) but received %d", l.Arity, len(args))
+	}
+	return l.Body(ctx, tCtx, args...)
+}
+
+// lambdaParam is the unique identity of a lambda parameter. It is used as the
+// context.Context key holding the value bound to the parameter at runtime, so nested
+// lambdas declaring parameters with the same name shadow each other as expected.
+type lambdaParam struct {
+	name string
+}
+
+type lambdaFunc[K any] struct {
+	params []*lambdaParam
+	body   Getter[K]
+}
+
+func (l *lambdaFunc[K]) Params() int {
+	return len(l.params)
+}
+
+func (l *lambdaFunc[K]) Call(ctx context.Context, tCtx K, args ...any) (any, error) {
+	if len(args) != len(l.params) {
+		return nil, fmt.Errorf("lambda expects %d argument(s) but received %d", len(l.params), len(args))
+	}
+	for i, param := range l.params {
+		ctx = context.WithValue(ctx, param, lambdaValue{value: args[i]})
+	}
+	return l.body.Get(ctx, tCtx)
+}
+
+// StandardBoolLambda is a basic implementation of BoolLambda
+type StandardBoolLambda[K any] struct {
+	Lambda Lambda[K]
+}
+
+// Params returns the number of parameters declared by the underlying Lambda.
+func (l StandardBoolLambda[K]) Params() int {
+	return l.Lambda.Params()
+}
+
+// Call evaluates the underlying Lambda with the given arguments.
+// If the value is not a bool a new TypeError is returned.
+// If there is an error getting the value it will be returned.
+func (l StandardBoolLambda[K]) Call(ctx context.Context, tCtx K, args ...any) (bool, error) {
+	val, err := l.Lambda.Call(ctx, tCtx, args...)
+	if err != nil {
+		return false, err
+	}
+	b, ok := val.(bool)
+	if !ok {
+		return false, TypeError(fmt.Sprintf("expected lambda to return bool but got %T", val))
+	}
+	return b, nil
+}
+
+// lambdaParamGetter reads the value bound to a lambda parameter from the context.
+type lambdaParamGetter[K any] struct {
+	param *lambdaParam
+}
+
+func (g lambdaParamGetter[K]) Get(ctx context.Context, _ K) (any, error) {
+	val, ok := ctx.Value(g.param).(lambdaValue)
+	if !ok {
+		return nil, fmt.Errorf("lambda parameter %q is not bound; it can only be used within its lambda", g.param.name)
+	}
+	return val.value, nil
+}
+
+func (g lambdaParamGetter[K]) Set(context.Context, K, any) error {
+	return fmt.Errorf("lambda parameter %q cannot be set", g.param.name)
+}
+
+// lambdaValue wraps the values bound to lambda parameters, so nil values can be
+// told apart from unbound parameters.
+type lambdaValue struct {
+	value any
+}
+
+func (p *Parser[K]) newLambda(l *lambda) (*lambdaFunc[K], error) {
+	if l.Body == nil {
+		return nil, errors.New("lambda has no body")
+	}
+	scoped := *p
+	scoped.lambdaParams = make(map[string]*lambdaParam, len(p.lambdaParams)+len(l.Params))
+	for name, param := range p.lambdaParams {
+		scoped.lambdaParams[name] = param
+	}
+
+	params := make([]*lambdaParam, len(l.Params))
+	seen := make(map[string]struct{}, len(l.Params))
+	for i, name := range l.Params {
+		if _, ok := seen[name]; ok {
+			return nil, fmt.Errorf("duplicate lambda parameter %q", name)
+		}
+		if _, ok := p.pathContextNames[name]; ok {
+			return nil, fmt.Errorf("lambda parameter %q conflicts with the context of the same name", name)
+		}
+		seen[name] = struct{}{}
+		params[i] = &lambdaParam{name: name}
+		scoped.lambdaParams[name] = params[i]
+	}
+
+	var body Getter[K]
+	switch {
+	case l.Body.Value != nil:
+		g, err := scoped.newGetter(*l.Body.Value)
+		if err != nil {
+			return nil, err
+		}
+		body = g
+	case l.Body.Condition != nil:
+		expr, err := scoped.newBoolExpr(l.Body.Condition)
+		if err != nil {
+			return nil, err
+		}
+		body = &StandardGetSetter[K]{
+			Getter: func(ctx context.Context, tCtx K) (any, error) {
+				return expr.Eval(ctx, tCtx)
+			},
+		}
+	default:
+		return nil, errors.New("lambda has no body")
+	}
+
+	return &lambdaFunc[K]{params: params, body: body}, nil
+}
+
+// newLambdaParamGetSetter returns a GetSetter for paths referencing a lambda parameter in scope.
+// The returned bool is false when the path does not reference any lambda parameter.
+func (p *Parser[K]) newLambdaParamGetSetter(path *path) (GetSetter[K], bool, error) {
+	if len(p.lambdaParams) == 0 {
+		return nil, false, nil
+	}
+	name, ok := path.paramName(p.lambdaParamNames())
+	if !ok {
+		return nil, false, nil
+	}
+	if path.Context != "" || len(path.Fields) > 1 {
+		return nil, true, fmt.Errorf("lambda parameter %q cannot have path segments, use keys instead (e.g. %s[\"key\"])", name, name)
+	}
+	getter := lambdaParamGetter[K]{param: p.lambdaParams[name]}
+	keys := path.Fields[0].Keys
+	if len(keys) == 0 {
+		return getter, true, nil
+	}
+	for _, k := range keys {
+		if k.String == nil && k.Int == nil {
+			return nil, true, fmt.Errorf("lambda parameter %q can only be indexed with string or int literals", name)
+		}
+	}
+	indexed := exprGetter[K]{
+		expr: Expr[K]{exprFunc: getter.Get},
+		keys: keys,
+	}
+	return &StandardGetSetter[K]{
+		Getter: indexed.Get,
+		Setter: getter.Set,
+	}, true, nil
+}
+
+func (p *Parser[K]) lambdaParamNames() []string {
+	names := make([]string, 0, len(p.lambdaParams))
+	for name := range p.lambdaParams {
+		names = append(names, name)
+	}
+	return names
+}
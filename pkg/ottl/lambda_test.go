@@ -0,0 +1,298 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+type callLambdaArguments struct {
+	Args   []Getter[any]
+	Lambda Lambda[any]
+}
+
+func createCallLambdaFunction(_ FunctionContext, oArgs Arguments) (ExprFunc[any], error) {
+	args := oArgs.(*callLambdaArguments)
+	return func(ctx context.Context, tCtx any) (any, error) {
+		values := make([]any, len(args.Args))
+		for i, g := range args.Args {
+			v, err := g.Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = v
+		}
+		return args.Lambda.Call(ctx, tCtx, values...)
+	}, nil
+}
+
+type callBoolLambdaArguments struct {
+	Arg    Getter[any]
+	Lambda BoolLambda[any]
+}
+
+func createCallBoolLambdaFunction(_ FunctionContext, oArgs Arguments) (ExprFunc[any], error) {
+	args := oArgs.(*callBoolLambdaArguments)
+	return func(ctx context.Context, tCtx any) (any, error) {
+		v, err := args.Arg.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		return args.Lambda.Call(ctx, tCtx, v)
+	}, nil
+}
+
+type concatArguments struct {
+	Vals      []Getter[any]
+	Delimiter string
+}
+
+func newLambdaTestParser(t *testing.T) Parser[any] {
+	p, err := NewParser[any](
+		CreateFactoryMap[any](
+			NewFactory("Call", &callLambdaArguments{}, createCallLambdaFunction),
+			NewFactory("CallBool", &callBoolLambdaArguments{}, createCallBoolLambdaFunction),
+			NewFactory("Lambda", &callLambdaArguments{}, func(_ FunctionContext, oArgs Arguments) (ExprFunc[any], error) {
+				l := oArgs.(*callLambdaArguments).Lambda
+				return func(context.Context, any) (any, error) {
+					return l, nil
+				}, nil
+			}),
+		),
+		testParsePath[any],
+		componenttest.NewNopTelemetrySettings(),
+		WithEnumParser[any](testParseEnum),
+		WithPathContextNames[any]([]string{"log"}),
+	)
+	require.NoError(t, err)
+	return p
+}
+
+func Test_lambda(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		tCtx      any
+		want      any
+	}{
+		{
+			name:      "identity",
+			statement: `Call(["a"], x => x)`,
+			want:      "a",
+		},
+		{
+			name:      "math expression body",
+			statement: `Call([2, 3], (a, b) => a * b + 1)`,
+			want:      int64(7),
+		},
+		{
+			name:      "condition body",
+			statement: `Call([2, 3], (a, b) => a < b and b != 4)`,
+			want:      true,
+		},
+		{
+			name:      "nil argument",
+			statement: `Call([nil], x => x == nil)`,
+			want:      true,
+		},
+		{
+			name:      "path in body",
+			statement: `Call(["foo"], x => Concat([x, log.name], "-"))`,
+			tCtx:      "bar",
+			want:      "foo-bar",
+		},
+		{
+			name:      "map keys",
+			statement: `Call([{"a": {"b": "c"}}], x => x["a"]["b"])`,
+			want:      "c",
+		},
+		{
+			name:      "list index",
+			statement: `Call([["a", "b"]], x => x[1])`,
+			want:      "b",
+		},
+		{
+			name:      "nested lambdas",
+			statement: `Call([1], x => Call([2], y => x + y))`,
+			want:      int64(3),
+		},
+		{
+			name:      "nested lambdas shadowing",
+			statement: `Call([1], x => Call([2], x => x))`,
+			want:      int64(2),
+		},
+		{
+			name:      "bool lambda",
+			statement: `CallBool("foo", x => IsString(x))`,
+			want:      true,
+		},
+		{
+			name:      "named lambda argument",
+			statement: `CallBool(lambda = x => x == "foo", arg = "foo")`,
+			want:      true,
+		},
+	}
+
+	p := newLambdaTestParser(t)
+	p.functions["IsString"] = NewFactory("IsString", &getterArguments{}, func(_ FunctionContext, oArgs Arguments) (ExprFunc[any], error) {
+		g := oArgs.(*getterArguments).GetterArg
+		return func(ctx context.Context, tCtx any) (any, error) {
+			v, err := g.Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			_, ok := v.(string)
+			return ok, nil
+		}, nil
+	})
+	p.functions["Concat"] = NewFactory("Concat", &concatArguments{}, func(_ FunctionContext, oArgs Arguments) (ExprFunc[any], error) {
+		args := oArgs.(*concatArguments)
+		return func(ctx context.Context, tCtx any) (any, error) {
+			result := ""
+			for i, g := range args.Vals {
+				v, err := g.Get(ctx, tCtx)
+				if err != nil {
+					return nil, err
+				}
+				if i > 0 {
+					result += args.Delimiter
+				}
+				result += v.(string)
+			}
+			return result, nil
+		}, nil
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := p.ParseValueExpression(tt.statement)
+			require.NoError(t, err)
+
+			result, err := expr.Eval(context.Background(), tt.tCtx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func Test_lambda_invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		wantErr   string
+	}{
+		{
+			name:      "duplicate parameters",
+			statement: `Call([1, 2], (x, x) => x)`,
+			wantErr:   `duplicate lambda parameter "x"`,
+		},
+		{
+			name:      "parameter named as a context",
+			statement: `Call([1], log => log)`,
+			wantErr:   `lambda parameter "log" conflicts with the context of the same name`,
+		},
+		{
+			name:      "parameter with path segments",
+			statement: `Call([1], x => x.foo)`,
+			wantErr:   `lambda parameter "x" cannot have path segments`,
+		},
+		{
+			name:      "parameter with dynamic keys",
+			statement: `Call([1], x => x[name])`,
+			wantErr:   `lambda parameter "x" can only be indexed with string or int literals`,
+		},
+		{
+			name:      "lambda for a non-lambda parameter",
+			statement: `Call(x => x, x => x)`,
+			wantErr:   "lambda expressions are not supported for this parameter",
+		},
+		{
+			name:      "non-lambda for a lambda parameter",
+			statement: `Call([1], name)`,
+			wantErr:   "must be a lambda expression",
+		},
+	}
+
+	p := newLambdaTestParser(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ParseValueExpression(tt.statement)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_lambda_Call_errors(t *testing.T) {
+	p := newLambdaTestParser(t)
+
+	expr, err := p.ParseValueExpression(`Call([1], (x, y) => x)`)
+	require.NoError(t, err)
+	_, err = expr.Eval(context.Background(), nil)
+	assert.ErrorContains(t, err, "lambda expects 2 argument(s) but received 1")
+
+	expr, err = p.ParseValueExpression(`CallBool(1, x => x)`)
+	require.NoError(t, err)
+	_, err = expr.Eval(context.Background(), nil)
+	var typeErr TypeError
+	assert.ErrorAs(t, err, &typeErr)
+
+	expr, err = p.ParseValueExpression(`Lambda([], x => x)`)
+	require.NoError(t, err)
+	val, err := expr.Eval(context.Background(), nil)
+	require.NoError(t, err)
+	l, ok := val.(Lambda[any])
+	require.True(t, ok)
+	assert.Equal(t, 1, l.Params())
+	result, err := l.Call(context.Background(), nil, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "a", result)
+}
+
+func Test_lambdaParamGetter(t *testing.T) {
+	g := lambdaParamGetter[any]{param: &lambdaParam{name: "x"}}
+
+	_, err := g.Get(context.Background(), nil)
+	assert.ErrorContains(t, err, `lambda parameter "x" is not bound`)
+	assert.ErrorContains(t, g.Set(context.Background(), nil, "a"), `lambda parameter "x" cannot be set`)
+
+	ctx := context.WithValue(context.Background(), g.param, lambdaValue{})
+	val, err := g.Get(ctx, nil)
+	require.NoError(t, err)
+	assert.Nil(t, val)
+}
+
+func Test_StandardLambda(t *testing.T) {
+	l := StandardLambda[any]{
+		Arity: 2,
+		Body: func(_ context.Context, _ any, args ...any) (any, error) {
+			if args[0] == nil {
+				return nil, errors.New("error")
+			}
+			return args[0].(int64) + args[1].(int64), nil
+		},
+	}
+	assert.Equal(t, 2, l.Params())
+
+	result, err := l.Call(context.Background(), nil, int64(1), int64(2))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), result)
+
+	_, err = l.Call(context.Background(), nil, int64(1))
+	assert.ErrorContains(t, err, "lambda expects 2 argument(s) but received 1")
+
+	_, err = l.Call(context.Background(), nil, nil, int64(1))
+	assert.EqualError(t, err, "error")
+
+	b := StandardBoolLambda[any]{Lambda: l}
+	assert.Equal(t, 2, b.Params())
+	_, err = b.Call(context.Background(), nil, int64(1), int64(2))
+	var typeErr TypeError
+	assert.ErrorAs(t, err, &typeErr)
+}
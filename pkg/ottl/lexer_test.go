@@ -130,6 +130,17 @@ func Test_lexer(t *testing.T) {
 			{"String", `"bar"`},
 			{"RBrace", "}"},
 		}},
+		{"Lambda", `(k, v) => v != "foo"`, false, []result{
+			{"LParen", "("},
+			{"Lowercase", "k"},
+			{"Punct", ","},
+			{"Lowercase", "v"},
+			{"RParen", ")"},
+			{"Arrow", "=>"},
+			{"Lowercase", "v"},
+			{"OpComparison", "!="},
+			{"String", `"foo"`},
+		}},
 		{"Dynamic path", `attributes[attributes["foo"]]`, false, []result{
 			{"Lowercase", "attributes"},
 			{"Punct", "["},
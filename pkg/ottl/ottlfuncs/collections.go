@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+// collection is a list or a map value that functions can iterate over.
+// Exactly one of slice or m is set, depending on isMap.
+type collection struct {
+	slice pcommon.Slice
+	m     pcommon.Map
+	isMap bool
+}
+
+// toCollection converts the supported list and map representations into a collection.
+func toCollection(val any) (collection, error) {
+	switch v := val.(type) {
+	case pcommon.Slice:
+		return collection{slice: v}, nil
+	case pcommon.Map:
+		return collection{m: v, isMap: true}, nil
+	case pcommon.Value:
+		switch v.Type() {
+		case pcommon.ValueTypeSlice:
+			return collection{slice: v.Slice()}, nil
+		case pcommon.ValueTypeMap:
+			return collection{m: v.Map(), isMap: true}, nil
+		default:
+			return collection{}, fmt.Errorf("expected a list or a map but got %v", v.Type())
+		}
+	case map[string]any:
+		m := pcommon.NewMap()
+		if err := m.FromRaw(v); err != nil {
+			return collection{}, err
+		}
+		return collection{m: m, isMap: true}, nil
+	case []any:
+		return sliceToCollection(v)
+	case []string:
+		return sliceToCollection(v)
+	case []int64:
+		return sliceToCollection(v)
+	case []float64:
+		return sliceToCollection(v)
+	case []bool:
+		return sliceToCollection(v)
+	default:
+		return collection{}, fmt.Errorf("expected a list or a map but got %T", val)
+	}
+}
+
+func sliceToCollection[T any](values []T) (collection, error) {
+	s := pcommon.NewSlice()
+	s.EnsureCapacity(len(values))
+	for _, v := range values {
+		if err := setValue(s.AppendEmpty(), v); err != nil {
+			return collection{}, err
+		}
+	}
+	return collection{slice: s}, nil
+}
+
+// forEach calls fn for every element of the collection, in order for lists.
+// key is the int64 index of list elements and the string key of map entries.
+// Iteration stops as soon as fn returns false or an error.
+func (c collection) forEach(fn func(key any, v pcommon.Value) (bool, error)) error {
+	if !c.isMap {
+		for i := 0; i < c.slice.Len(); i++ {
+			next, err := fn(int64(i), c.slice.At(i))
+			if err != nil || !next {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var err error
+	c.m.Range(func(k string, v pcommon.Value) bool {
+		var next bool
+		next, err = fn(k, v)
+		return err == nil && next
+	})
+	return err
+}
+
+// lambdaArgs returns the arguments passed to a lambda iterating over a collection.
+// Lambdas declaring a single parameter receive the element's value, while lambdas
+// declaring two parameters receive the element's index or key followed by its value.
+func lambdaArgs(params int, key any, v pcommon.Value) []any {
+	if params == 2 {
+		return []any{key, ottlcommon.GetValue(v)}
+	}
+	return []any{ottlcommon.GetValue(v)}
+}
+
+func validateLambdaParams(name string, params int) error {
+	if params != 1 && params != 2 {
+		return fmt.Errorf("the %s lambda must declare either one (value) or two (index or key, value) parameters, but declares %d", name, params)
+	}
+	return nil
+}
+
+// setValue sets the given value, as returned by OTTL getters, into the pcommon.Value.
+func setValue(value pcommon.Value, val any) error {
+	switch v := val.(type) {
+	case nil:
+		return nil
+	case string:
+		value.SetStr(v)
+	case bool:
+		value.SetBool(v)
+	case int64:
+		value.SetInt(v)
+	case float64:
+		value.SetDouble(v)
+	case []byte:
+		value.SetEmptyBytes().FromRaw(v)
+	case pcommon.Value:
+		v.CopyTo(value)
+	case pcommon.Map:
+		v.CopyTo(value.SetEmptyMap())
+	case pcommon.Slice:
+		v.CopyTo(value.SetEmptySlice())
+	case map[string]any:
+		return value.SetEmptyMap().FromRaw(v)
+	case []any:
+		return setSliceValue(value, v)
+	case []string:
+		return setSliceValue(value, v)
+	case []int64:
+		return setSliceValue(value, v)
+	case []float64:
+		return setSliceValue(value, v)
+	case []bool:
+		return setSliceValue(value, v)
+	default:
+		return fmt.Errorf("unsupported value type %T", val)
+	}
+	return nil
+}
+
+func setSliceValue[T any](value pcommon.Value, values []T) error {
+	s := value.SetEmptySlice()
+	s.EnsureCapacity(len(values))
+	for _, v := range values {
+		if err := setValue(s.AppendEmpty(), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// valueEquals reports whether the collection element v is equal to val, as returned by
+// OTTL getters. Values of different types are never equal, except for ints and floats,
+// which are compared numerically. Maps and lists are compared by content.
+func valueEquals(v pcommon.Value, val any) (bool, error) {
+	other := pcommon.NewValueEmpty()
+	if err := setValue(other, val); err != nil {
+		return false, err
+	}
+	switch {
+	case v.Type() == pcommon.ValueTypeInt && other.Type() == pcommon.ValueTypeDouble:
+		return float64(v.Int()) == other.Double(), nil
+	case v.Type() == pcommon.ValueTypeDouble && other.Type() == pcommon.ValueTypeInt:
+		return v.Double() == float64(other.Int()), nil
+	default:
+		return v.Equal(other), nil
+	}
+}
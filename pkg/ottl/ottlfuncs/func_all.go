@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type AllArguments[K any] struct {
+	Target    ottl.Getter[K]
+	Predicate ottl.BoolLambda[K]
+}
+
+func NewAllFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("All", &AllArguments[K]{}, createAllFunction[K])
+}
+
+func createAllFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*AllArguments[K])
+	if !ok {
+		return nil, errors.New("AllFactory args must be of type *AllArguments[K]")
+	}
+
+	return allMatch(args.Target, args.Predicate)
+}
+
+func allMatch[K any](target ottl.Getter[K], predicate ottl.BoolLambda[K]) (ottl.ExprFunc[K], error) {
+	if err := validateLambdaParams("predicate", predicate.Params()); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		c, err := toCollection(val)
+		if err != nil {
+			return nil, err
+		}
+
+		all := true
+		err = c.forEach(func(key any, v pcommon.Value) (bool, error) {
+			match, err := predicate.Call(ctx, tCtx, lambdaArgs(predicate.Params(), key, v)...)
+			if err != nil {
+				return false, err
+			}
+			all = match
+			return match, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return all, nil
+	}, nil
+}
@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_allMatch(t *testing.T) {
+	mapValue := pcommon.NewMap()
+	mapValue.PutStr("foo", "bar")
+	mapValue.PutInt("int", 1)
+
+	calls := 0
+	isString := ottl.StandardLambda[any]{
+		Arity: 1,
+		Body: func(_ context.Context, _ any, args ...any) (any, error) {
+			calls++
+			_, ok := args[0].(string)
+			return ok, nil
+		},
+	}
+
+	tests := []struct {
+		name          string
+		target        any
+		predicate     ottl.StandardLambda[any]
+		expected      bool
+		expectedCalls int
+	}{
+		{
+			name:          "match",
+			target:        []string{"a", "b"},
+			predicate:     isString,
+			expected:      true,
+			expectedCalls: 2,
+		},
+		{
+			name:          "no match",
+			target:        []any{"a", int64(1), "b"},
+			predicate:     isString,
+			expected:      false,
+			expectedCalls: 2,
+		},
+		{
+			name:          "empty",
+			target:        []any{},
+			predicate:     isString,
+			expected:      true,
+			expectedCalls: 0,
+		},
+		{
+			name:   "map with key",
+			target: mapValue,
+			predicate: ottl.StandardLambda[any]{
+				Arity: 2,
+				Body: func(_ context.Context, _ any, args ...any) (any, error) {
+					calls++
+					return args[0] != "", nil
+				},
+			},
+			expected:      true,
+			expectedCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = 0
+			target := &ottl.StandardGetSetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc, err := allMatch[any](target, ottl.StandardBoolLambda[any]{Lambda: tt.predicate})
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.expectedCalls, calls)
+		})
+	}
+}
+
+func Test_allMatch_error(t *testing.T) {
+	target := &ottl.StandardGetSetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return []any{"a"}, nil
+		},
+	}
+
+	_, err := allMatch[any](target, ottl.StandardBoolLambda[any]{Lambda: ottl.StandardLambda[any]{Arity: 3}})
+	assert.ErrorContains(t, err, "the predicate lambda must declare either one (value) or two (index or key, value) parameters")
+
+	exprFunc, err := allMatch[any](target, ottl.StandardBoolLambda[any]{Lambda: ottl.StandardLambda[any]{
+		Arity: 1,
+		Body: func(context.Context, any, ...any) (any, error) {
+			return nil, errors.New("predicate error")
+		},
+	}})
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	assert.EqualError(t, err, "predicate error")
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ContainsValueArguments[K any] struct {
+	Target ottl.Getter[K]
+	Value  ottl.Getter[K]
+}
+
+func NewContainsValueFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ContainsValue", &ContainsValueArguments[K]{}, createContainsValueFunction[K])
+}
+
+func createContainsValueFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ContainsValueArguments[K])
+	if !ok {
+		return nil, errors.New("ContainsValueFactory args must be of type *ContainsValueArguments[K]")
+	}
+
+	return containsValue(args.Target, args.Value), nil
+}
+
+func containsValue[K any](target ottl.Getter[K], value ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if val == nil {
+			return false, nil
+		}
+		c, err := toCollection(val)
+		if err != nil {
+			return nil, err
+		}
+		searched, err := value.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		found := false
+		err = c.forEach(func(_ any, v pcommon.Value) (bool, error) {
+			equal, err := valueEquals(v, searched)
+			if err != nil {
+				return false, err
+			}
+			found = equal
+			return !found, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return found, nil
+	}
+}
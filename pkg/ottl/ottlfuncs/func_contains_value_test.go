@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_containsValue(t *testing.T) {
+	roles := pcommon.NewValueSlice()
+	require.NoError(t, roles.Slice().FromRaw([]any{"user", "admin"}))
+
+	m := pcommon.NewMap()
+	m.PutStr("foo", "bar")
+	m.PutDouble("double", 1.0)
+
+	tests := []struct {
+		name     string
+		target   any
+		value    any
+		expected bool
+	}{
+		{
+			name:     "list contains value",
+			target:   roles,
+			value:    "admin",
+			expected: true,
+		},
+		{
+			name:     "list does not contain value",
+			target:   roles.Slice(),
+			value:    "root",
+			expected: false,
+		},
+		{
+			name:     "typed list",
+			target:   []int64{1, 2, 3},
+			value:    int64(3),
+			expected: true,
+		},
+		{
+			name:     "map contains value",
+			target:   m,
+			value:    "bar",
+			expected: true,
+		},
+		{
+			name:     "map does not check keys",
+			target:   m,
+			value:    "foo",
+			expected: false,
+		},
+		{
+			name:     "float matching int",
+			target:   m,
+			value:    int64(1),
+			expected: true,
+		},
+		{
+			name:     "nested list",
+			target:   []any{[]any{"a"}, "b"},
+			value:    []any{"a"},
+			expected: true,
+		},
+		{
+			name:     "nil target",
+			target:   nil,
+			value:    "admin",
+			expected: false,
+		},
+		{
+			name:     "nil value",
+			target:   []any{"a", nil},
+			value:    nil,
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := containsValue[any](
+				ottl.StandardGetSetter[any]{
+					Getter: func(_ context.Context, _ any) (any, error) {
+						return tt.target, nil
+					},
+				},
+				ottl.StandardGetSetter[any]{
+					Getter: func(_ context.Context, _ any) (any, error) {
+						return tt.value, nil
+					},
+				},
+			)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_containsValue_error(t *testing.T) {
+	exprFunc := containsValue[any](
+		ottl.StandardGetSetter[any]{
+			Getter: func(_ context.Context, _ any) (any, error) {
+				return "admin", nil
+			},
+		},
+		ottl.StandardGetSetter[any]{
+			Getter: func(_ context.Context, _ any) (any, error) {
+				return "admin", nil
+			},
+		},
+	)
+	_, err := exprFunc(context.Background(), nil)
+	assert.ErrorContains(t, err, "expected a list or a map but got string")
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type FilterArguments[K any] struct {
+	Target    ottl.Getter[K]
+	Predicate ottl.BoolLambda[K]
+}
+
+func NewFilterFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Filter", &FilterArguments[K]{}, createFilterFunction[K])
+}
+
+func createFilterFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*FilterArguments[K])
+	if !ok {
+		return nil, errors.New("FilterFactory args must be of type *FilterArguments[K]")
+	}
+
+	return filter(args.Target, args.Predicate)
+}
+
+func filter[K any](target ottl.Getter[K], predicate ottl.BoolLambda[K]) (ottl.ExprFunc[K], error) {
+	if err := validateLambdaParams("predicate", predicate.Params()); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		c, err := toCollection(val)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.isMap {
+			result := pcommon.NewMap()
+			err = c.forEach(func(key any, v pcommon.Value) (bool, error) {
+				keep, err := predicate.Call(ctx, tCtx, lambdaArgs(predicate.Params(), key, v)...)
+				if err != nil {
+					return false, err
+				}
+				if keep {
+					v.CopyTo(result.PutEmpty(key.(string)))
+				}
+				return true, nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			return result, nil
+		}
+
+		result := pcommon.NewSlice()
+		err = c.forEach(func(key any, v pcommon.Value) (bool, error) {
+			keep, err := predicate.Call(ctx, tCtx, lambdaArgs(predicate.Params(), key, v)...)
+			if err != nil {
+				return false, err
+			}
+			if keep {
+				v.CopyTo(result.AppendEmpty())
+			}
+			return true, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}, nil
+}
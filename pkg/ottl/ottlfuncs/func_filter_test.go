@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_filter(t *testing.T) {
+	sliceValue := pcommon.NewValueSlice()
+	require.NoError(t, sliceValue.Slice().FromRaw([]any{"a", int64(1), "b"}))
+
+	mapValue := pcommon.NewMap()
+	mapValue.PutStr("foo", "bar")
+	mapValue.PutInt("int", 1)
+	mapValue.PutStr("test", "value")
+
+	isString := func(_ context.Context, _ any, args ...any) (any, error) {
+		_, ok := args[len(args)-1].(string)
+		return ok, nil
+	}
+
+	tests := []struct {
+		name      string
+		target    any
+		predicate ottl.StandardLambda[any]
+		expected  any
+	}{
+		{
+			name:      "pcommon.Slice",
+			target:    sliceValue.Slice(),
+			predicate: ottl.StandardLambda[any]{Arity: 1, Body: isString},
+			expected:  []any{"a", "b"},
+		},
+		{
+			name:      "pcommon.Value slice",
+			target:    sliceValue,
+			predicate: ottl.StandardLambda[any]{Arity: 1, Body: isString},
+			expected:  []any{"a", "b"},
+		},
+		{
+			name:      "[]any",
+			target:    []any{"a", int64(1), 2.5, "b"},
+			predicate: ottl.StandardLambda[any]{Arity: 1, Body: isString},
+			expected:  []any{"a", "b"},
+		},
+		{
+			name:   "[]int64 with index",
+			target: []int64{10, 20, 30, 40},
+			predicate: ottl.StandardLambda[any]{
+				Arity: 2,
+				Body: func(_ context.Context, _ any, args ...any) (any, error) {
+					return args[0].(int64)%2 == 0, nil
+				},
+			},
+			expected: []any{int64(10), int64(30)},
+		},
+		{
+			name:      "empty slice",
+			target:    []string{},
+			predicate: ottl.StandardLambda[any]{Arity: 1, Body: isString},
+			expected:  []any{},
+		},
+		{
+			name:      "pcommon.Map",
+			target:    mapValue,
+			predicate: ottl.StandardLambda[any]{Arity: 1, Body: isString},
+			expected:  map[string]any{"foo": "bar", "test": "value"},
+		},
+		{
+			name:   "map[string]any with key",
+			target: map[string]any{"foo": "bar", "test": "value"},
+			predicate: ottl.StandardLambda[any]{
+				Arity: 2,
+				Body: func(_ context.Context, _ any, args ...any) (any, error) {
+					return args[0] == "test", nil
+				},
+			},
+			expected: map[string]any{"test": "value"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardGetSetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc, err := filter[any](target, ottl.StandardBoolLambda[any]{Lambda: tt.predicate})
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			switch r := result.(type) {
+			case pcommon.Slice:
+				assert.Equal(t, tt.expected, r.AsRaw())
+			case pcommon.Map:
+				assert.Equal(t, tt.expected, r.AsRaw())
+			default:
+				assert.Fail(t, "unexpected result type", "%T", result)
+			}
+		})
+	}
+}
+
+func Test_filter_error(t *testing.T) {
+	target := &ottl.StandardGetSetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return []any{"a"}, nil
+		},
+	}
+
+	_, err := filter[any](target, ottl.StandardBoolLambda[any]{Lambda: ottl.StandardLambda[any]{Arity: 3}})
+	assert.ErrorContains(t, err, "the predicate lambda must declare either one (value) or two (index or key, value) parameters, but declares 3")
+
+	exprFunc, err := filter[any](target, ottl.StandardBoolLambda[any]{Lambda: ottl.StandardLambda[any]{
+		Arity: 1,
+		Body: func(context.Context, any, ...any) (any, error) {
+			return "not a bool", nil
+		},
+	}})
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	var typeErr ottl.TypeError
+	assert.ErrorAs(t, err, &typeErr)
+
+	exprFunc, err = filter[any](target, ottl.StandardBoolLambda[any]{Lambda: ottl.StandardLambda[any]{
+		Arity: 1,
+		Body: func(context.Context, any, ...any) (any, error) {
+			return nil, errors.New("predicate error")
+		},
+	}})
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	assert.EqualError(t, err, "predicate error")
+
+	invalidTarget := &ottl.StandardGetSetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return "not a collection", nil
+		},
+	}
+	exprFunc, err = filter[any](invalidTarget, ottl.StandardBoolLambda[any]{Lambda: ottl.StandardLambda[any]{Arity: 1}})
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	assert.ErrorContains(t, err, "expected a list or a map but got string")
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type IndexOfArguments[K any] struct {
+	Target ottl.Getter[K]
+	Value  ottl.Getter[K]
+}
+
+func NewIndexOfFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("IndexOf", &IndexOfArguments[K]{}, createIndexOfFunction[K])
+}
+
+func createIndexOfFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*IndexOfArguments[K])
+	if !ok {
+		return nil, errors.New("IndexOfFactory args must be of type *IndexOfArguments[K]")
+	}
+
+	return indexOf(args.Target, args.Value), nil
+}
+
+func indexOf[K any](target ottl.Getter[K], value ottl.Getter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if val == nil {
+			return int64(-1), nil
+		}
+		c, err := toCollection(val)
+		if err != nil {
+			return nil, err
+		}
+		if c.isMap {
+			return nil, errors.New("expected a list but got a map")
+		}
+		searched, err := value.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		index := int64(-1)
+		err = c.forEach(func(key any, v pcommon.Value) (bool, error) {
+			equal, err := valueEquals(v, searched)
+			if err != nil {
+				return false, err
+			}
+			if equal {
+				index = key.(int64)
+				return false, nil
+			}
+			return true, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return index, nil
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_indexOf(t *testing.T) {
+	sliceValue := pcommon.NewValueSlice()
+	require.NoError(t, sliceValue.Slice().FromRaw([]any{"a", int64(1), 2.5, map[string]any{"foo": "bar"}, "a"}))
+
+	tests := []struct {
+		name     string
+		target   any
+		value    any
+		expected int64
+	}{
+		{
+			name:     "string",
+			target:   sliceValue,
+			value:    "a",
+			expected: 0,
+		},
+		{
+			name:     "int",
+			target:   sliceValue.Slice(),
+			value:    int64(1),
+			expected: 1,
+		},
+		{
+			name:     "int matching float",
+			target:   []float64{1.5, 2},
+			value:    int64(2),
+			expected: 1,
+		},
+		{
+			name:     "float",
+			target:   sliceValue,
+			value:    2.5,
+			expected: 2,
+		},
+		{
+			name:     "map",
+			target:   sliceValue,
+			value:    map[string]any{"foo": "bar"},
+			expected: 3,
+		},
+		{
+			name:     "pcommon.Value",
+			target:   []string{"a", "b"},
+			value:    pcommon.NewValueStr("b"),
+			expected: 1,
+		},
+		{
+			name:     "different type",
+			target:   []string{"1"},
+			value:    int64(1),
+			expected: -1,
+		},
+		{
+			name:     "not found",
+			target:   []any{"a", "b"},
+			value:    "c",
+			expected: -1,
+		},
+		{
+			name:     "nil target",
+			target:   nil,
+			value:    "a",
+			expected: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := indexOf[any](
+				ottl.StandardGetSetter[any]{
+					Getter: func(_ context.Context, _ any) (any, error) {
+						return tt.target, nil
+					},
+				},
+				ottl.StandardGetSetter[any]{
+					Getter: func(_ context.Context, _ any) (any, error) {
+						return tt.value, nil
+					},
+				},
+			)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_indexOf_error(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        any
+		expectedError string
+	}{
+		{
+			name:          "map",
+			target:        map[string]any{"foo": "bar"},
+			expectedError: "expected a list but got a map",
+		},
+		{
+			name:          "string",
+			target:        "foo",
+			expectedError: "expected a list or a map but got string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := indexOf[any](
+				ottl.StandardGetSetter[any]{
+					Getter: func(_ context.Context, _ any) (any, error) {
+						return tt.target, nil
+					},
+				},
+				ottl.StandardGetSetter[any]{
+					Getter: func(_ context.Context, _ any) (any, error) {
+						return "foo", nil
+					},
+				},
+			)
+			_, err := exprFunc(context.Background(), nil)
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
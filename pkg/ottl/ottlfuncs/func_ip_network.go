@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type IPNetworkArguments[K any] struct {
+	Target           ottl.StringGetter[K]
+	PrefixLength     ottl.IntGetter[K]
+	IPv6PrefixLength ottl.Optional[ottl.IntGetter[K]]
+}
+
+func NewIPNetworkFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("IPNetwork", &IPNetworkArguments[K]{}, createIPNetworkFunction[K])
+}
+
+func createIPNetworkFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*IPNetworkArguments[K])
+
+	if !ok {
+		return nil, errors.New("IPNetworkFactory args must be of type *IPNetworkArguments[K]")
+	}
+
+	return ipNetwork(args.Target, args.PrefixLength, args.IPv6PrefixLength), nil
+}
+
+func ipNetwork[K any](target ottl.StringGetter[K], prefixLength ottl.IntGetter[K], ipv6PrefixLength ottl.Optional[ottl.IntGetter[K]]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := parseIPAddress(val)
+		if err != nil {
+			return nil, err
+		}
+
+		bitsGetter := prefixLength
+		if addr.Is6() && !ipv6PrefixLength.IsEmpty() {
+			bitsGetter = ipv6PrefixLength.Get()
+		}
+		bits, err := bitsGetter.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if bits < 0 || bits > int64(addr.BitLen()) {
+			return nil, fmt.Errorf("invalid prefix length %d for IP address %q", bits, addr)
+		}
+
+		prefix, err := addr.WithZone("").Prefix(int(bits))
+		if err != nil {
+			return nil, err
+		}
+		return prefix.String(), nil
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_ipNetwork(t *testing.T) {
+	tests := []struct {
+		name             string
+		target           string
+		prefixLength     int64
+		ipv6PrefixLength ottl.Optional[ottl.IntGetter[any]]
+		expected         string
+	}{
+		{name: "ipv4", target: "192.168.10.42", prefixLength: 24, expected: "192.168.10.0/24"},
+		{name: "ipv4 full length", target: "192.168.10.42", prefixLength: 32, expected: "192.168.10.42/32"},
+		{name: "ipv4 zero length", target: "192.168.10.42", prefixLength: 0, expected: "0.0.0.0/0"},
+		{name: "ipv6", target: "2001:db8:1234:5678::1", prefixLength: 48, expected: "2001:db8:1234::/48"},
+		{name: "ipv6 with zone", target: "fe80::1%eth0", prefixLength: 64, expected: "fe80::/64"},
+		{name: "ipv4-mapped ipv6", target: "::ffff:10.1.2.3", prefixLength: 8, expected: "10.0.0.0/8"},
+		{
+			name:             "ipv6 prefix length for ipv4",
+			target:           "10.1.2.3",
+			prefixLength:     16,
+			ipv6PrefixLength: ottl.NewTestingOptional[ottl.IntGetter[any]](ipNetworkPrefixLength(64)),
+			expected:         "10.1.0.0/16",
+		},
+		{
+			name:             "ipv6 prefix length for ipv6",
+			target:           "2001:db8:1234:5678::1",
+			prefixLength:     16,
+			ipv6PrefixLength: ottl.NewTestingOptional[ottl.IntGetter[any]](ipNetworkPrefixLength(64)),
+			expected:         "2001:db8:1234:5678::/64",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := ipNetwork[any](ipNetworkTarget(tt.target), ipNetworkPrefixLength(tt.prefixLength), tt.ipv6PrefixLength)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_ipNetwork_error(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        string
+		prefixLength  int64
+		expectedError string
+	}{
+		{name: "invalid ip", target: "10.0.0", prefixLength: 8, expectedError: `invalid IP address "10.0.0"`},
+		{name: "ipv4 prefix too long", target: "10.0.0.1", prefixLength: 33, expectedError: `invalid prefix length 33 for IP address "10.0.0.1"`},
+		{name: "negative prefix", target: "2001:db8::1", prefixLength: -1, expectedError: `invalid prefix length -1 for IP address "2001:db8::1"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := ipNetwork[any](ipNetworkTarget(tt.target), ipNetworkPrefixLength(tt.prefixLength), ottl.Optional[ottl.IntGetter[any]]{})
+			_, err := exprFunc(context.Background(), nil)
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
+
+func ipNetworkTarget(val string) ottl.StringGetter[any] {
+	return &ottl.StandardStringGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return val, nil
+		},
+	}
+}
+
+func ipNetworkPrefixLength(val int64) ottl.IntGetter[any] {
+	return &ottl.StandardIntGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return val, nil
+		},
+	}
+}
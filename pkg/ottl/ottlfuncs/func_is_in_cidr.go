@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type IsInCIDRArguments[K any] struct {
+	Target ottl.StringLikeGetter[K]
+	CIDRs  []string
+}
+
+func NewIsInCIDRFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("IsInCIDR", &IsInCIDRArguments[K]{}, createIsInCIDRFunction[K])
+}
+
+func createIsInCIDRFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*IsInCIDRArguments[K])
+
+	if !ok {
+		return nil, errors.New("IsInCIDRFactory args must be of type *IsInCIDRArguments[K]")
+	}
+
+	return isInCIDR(args.Target, args.CIDRs)
+}
+
+func isInCIDR[K any](target ottl.StringLikeGetter[K], cidrs []string) (ottl.ExprFunc[K], error) {
+	if len(cidrs) == 0 {
+		return nil, errors.New("at least one CIDR must be supplied to IsInCIDR")
+	}
+	prefixes := make([]netip.Prefix, len(cidrs))
+	for i, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("the CIDR %q supplied to IsInCIDR is not valid: %w", cidr, err)
+		}
+		prefixes[i] = prefix.Masked()
+	}
+	return ipPredicate(target, func(addr netip.Addr) bool {
+		// Prefixes never contain addresses with a zone.
+		addr = addr.WithZone("")
+		for _, prefix := range prefixes {
+			if prefix.Contains(addr) {
+				return true
+			}
+		}
+		return false
+	}), nil
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_isInCIDR(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   any
+		cidrs    []string
+		expected bool
+	}{
+		{
+			name:     "ipv4 in cidr",
+			target:   "10.1.2.3",
+			cidrs:    []string{"10.0.0.0/8"},
+			expected: true,
+		},
+		{
+			name:     "ipv4 not in cidr",
+			target:   "11.1.2.3",
+			cidrs:    []string{"10.0.0.0/8"},
+			expected: false,
+		},
+		{
+			name:     "ipv4 in any of the cidrs",
+			target:   "192.168.1.10",
+			cidrs:    []string{"10.0.0.0/8", "192.168.0.0/16"},
+			expected: true,
+		},
+		{
+			name:     "unmasked cidr",
+			target:   "192.168.1.10",
+			cidrs:    []string{"192.168.1.1/24"},
+			expected: true,
+		},
+		{
+			name:     "ipv6 in cidr",
+			target:   "2001:db8::1",
+			cidrs:    []string{"2001:db8::/32"},
+			expected: true,
+		},
+		{
+			name:     "ipv6 with zone in cidr",
+			target:   "fe80::1%eth0",
+			cidrs:    []string{"fe80::/10"},
+			expected: true,
+		},
+		{
+			name:     "ipv6 not in ipv4 cidr",
+			target:   "2001:db8::1",
+			cidrs:    []string{"0.0.0.0/0"},
+			expected: false,
+		},
+		{
+			name:     "ipv4-mapped ipv6 in ipv4 cidr",
+			target:   "::ffff:10.1.2.3",
+			cidrs:    []string{"10.0.0.0/8"},
+			expected: true,
+		},
+		{
+			name:     "invalid ip",
+			target:   "not an ip",
+			cidrs:    []string{"10.0.0.0/8"},
+			expected: false,
+		},
+		{
+			name:     "nil",
+			target:   nil,
+			cidrs:    []string{"10.0.0.0/8"},
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardStringLikeGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc, err := isInCIDR[any](target, tt.cidrs)
+			require.NoError(t, err)
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_isInCIDR_invalid(t *testing.T) {
+	target := &ottl.StandardStringLikeGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return "10.0.0.1", nil
+		},
+	}
+	_, err := isInCIDR[any](target, []string{"10.0.0.0/33"})
+	assert.ErrorContains(t, err, `the CIDR "10.0.0.0/33" supplied to IsInCIDR is not valid`)
+
+	_, err = isInCIDR[any](target, nil)
+	assert.EqualError(t, err, "at least one CIDR must be supplied to IsInCIDR")
+}
@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"errors"
+	"net/netip"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type IsLinkLocalIPArguments[K any] struct {
+	Target ottl.StringLikeGetter[K]
+}
+
+func NewIsLinkLocalIPFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("IsLinkLocalIP", &IsLinkLocalIPArguments[K]{}, createIsLinkLocalIPFunction[K])
+}
+
+func createIsLinkLocalIPFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*IsLinkLocalIPArguments[K])
+
+	if !ok {
+		return nil, errors.New("IsLinkLocalIPFactory args must be of type *IsLinkLocalIPArguments[K]")
+	}
+
+	return isLinkLocalIP(args.Target), nil
+}
+
+func isLinkLocalIP[K any](target ottl.StringLikeGetter[K]) ottl.ExprFunc[K] {
+	return ipPredicate(target, func(addr netip.Addr) bool {
+		return addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast()
+	})
+}
@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_isLinkLocalIP(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   any
+		expected bool
+	}{
+		{name: "ipv4 link-local unicast", target: "169.254.10.1", expected: true},
+		{name: "ipv4 link-local multicast", target: "224.0.0.251", expected: true},
+		{name: "ipv6 link-local unicast", target: "fe80::1", expected: true},
+		{name: "ipv6 link-local unicast with zone", target: "fe80::1%eth0", expected: true},
+		{name: "ipv6 link-local multicast", target: "ff02::1", expected: true},
+		{name: "private ipv4", target: "192.168.0.1", expected: false},
+		{name: "global ipv6", target: "2001:db8::1", expected: false},
+		{name: "invalid ip", target: "fe80::g", expected: false},
+		{name: "nil", target: nil, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := isLinkLocalIP[any](&ottl.StandardStringLikeGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
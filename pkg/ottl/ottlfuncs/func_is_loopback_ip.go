@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"errors"
+	"net/netip"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type IsLoopbackIPArguments[K any] struct {
+	Target ottl.StringLikeGetter[K]
+}
+
+func NewIsLoopbackIPFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("IsLoopbackIP", &IsLoopbackIPArguments[K]{}, createIsLoopbackIPFunction[K])
+}
+
+func createIsLoopbackIPFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*IsLoopbackIPArguments[K])
+
+	if !ok {
+		return nil, errors.New("IsLoopbackIPFactory args must be of type *IsLoopbackIPArguments[K]")
+	}
+
+	return isLoopbackIP(args.Target), nil
+}
+
+func isLoopbackIP[K any](target ottl.StringLikeGetter[K]) ottl.ExprFunc[K] {
+	return ipPredicate(target, func(addr netip.Addr) bool {
+		return addr.IsLoopback()
+	})
+}
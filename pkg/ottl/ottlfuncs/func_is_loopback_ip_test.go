@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_isLoopbackIP(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   any
+		expected bool
+	}{
+		{name: "ipv4 loopback", target: "127.0.0.1", expected: true},
+		{name: "ipv4 loopback range", target: "127.10.0.1", expected: true},
+		{name: "ipv6 loopback", target: "::1", expected: true},
+		{name: "ipv4-mapped ipv6", target: "::ffff:127.0.0.1", expected: true},
+		{name: "private ipv4", target: "10.0.0.1", expected: false},
+		{name: "unspecified", target: "::", expected: false},
+		{name: "invalid ip", target: "localhost", expected: false},
+		{name: "nil", target: nil, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := isLoopbackIP[any](&ottl.StandardStringLikeGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
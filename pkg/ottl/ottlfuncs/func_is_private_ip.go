@@ -0,0 +1,35 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"errors"
+	"net/netip"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type IsPrivateIPArguments[K any] struct {
+	Target ottl.StringLikeGetter[K]
+}
+
+func NewIsPrivateIPFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("IsPrivateIP", &IsPrivateIPArguments[K]{}, createIsPrivateIPFunction[K])
+}
+
+func createIsPrivateIPFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*IsPrivateIPArguments[K])
+
+	if !ok {
+		return nil, errors.New("IsPrivateIPFactory args must be of type *IsPrivateIPArguments[K]")
+	}
+
+	return isPrivateIP(args.Target), nil
+}
+
+func isPrivateIP[K any](target ottl.StringLikeGetter[K]) ottl.ExprFunc[K] {
+	return ipPredicate(target, func(addr netip.Addr) bool {
+		return addr.IsPrivate()
+	})
+}
@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_isPrivateIP(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   any
+		expected bool
+	}{
+		{name: "class A", target: "10.20.30.40", expected: true},
+		{name: "class B", target: "172.16.0.1", expected: true},
+		{name: "class C", target: "192.168.1.1", expected: true},
+		{name: "ipv6 unique local", target: "fd00::1", expected: true},
+		{name: "ipv4-mapped ipv6", target: "::ffff:192.168.1.1", expected: true},
+		{name: "public ipv4", target: "8.8.8.8", expected: false},
+		{name: "public ipv6", target: "2001:4860:4860::8888", expected: false},
+		{name: "loopback", target: "127.0.0.1", expected: false},
+		{name: "invalid ip", target: "10.0.0", expected: false},
+		{name: "nil", target: nil, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := isPrivateIP[any](&ottl.StandardStringLikeGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
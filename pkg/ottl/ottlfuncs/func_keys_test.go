@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_keys(t *testing.T) {
+	m := pcommon.NewMap()
+	m.PutStr("foo", "bar")
+	m.PutInt("int", 1)
+	m.PutEmptyMap("nested").PutStr("test", "pass")
+
+	tests := []struct {
+		name     string
+		target   any
+		expected []string
+	}{
+		{
+			name:     "pcommon.Map",
+			target:   m,
+			expected: []string{"foo", "int", "nested"},
+		},
+		{
+			name:     "map[string]any",
+			target:   map[string]any{"foo": "bar"},
+			expected: []string{"foo"},
+		},
+		{
+			name:     "empty map",
+			target:   pcommon.NewMap(),
+			expected: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := keys[any](&ottl.StandardPMapGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_keys_error(t *testing.T) {
+	exprFunc := keys[any](&ottl.StandardPMapGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return []any{"a"}, nil
+		},
+	})
+	_, err := exprFunc(context.Background(), nil)
+	assert.ErrorContains(t, err, "expected pcommon.Map but got []interface {}")
+}
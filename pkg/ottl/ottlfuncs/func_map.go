@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type MapArguments[K any] struct {
+	Target ottl.Getter[K]
+	Mapper ottl.Lambda[K]
+}
+
+func NewMapFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Map", &MapArguments[K]{}, createMapFunction[K])
+}
+
+func createMapFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*MapArguments[K])
+	if !ok {
+		return nil, errors.New("MapFactory args must be of type *MapArguments[K]")
+	}
+
+	return mapValues(args.Target, args.Mapper)
+}
+
+func mapValues[K any](target ottl.Getter[K], mapper ottl.Lambda[K]) (ottl.ExprFunc[K], error) {
+	if err := validateLambdaParams("mapper", mapper.Params()); err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		c, err := toCollection(val)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.isMap {
+			result := pcommon.NewMap()
+			err = c.forEach(func(key any, v pcommon.Value) (bool, error) {
+				mapped, err := mapper.Call(ctx, tCtx, lambdaArgs(mapper.Params(), key, v)...)
+				if err != nil {
+					return false, err
+				}
+				return true, setValue(result.PutEmpty(key.(string)), mapped)
+			})
+			if err != nil {
+				return nil, err
+			}
+			return result, nil
+		}
+
+		result := pcommon.NewSlice()
+		result.EnsureCapacity(c.slice.Len())
+		err = c.forEach(func(key any, v pcommon.Value) (bool, error) {
+			mapped, err := mapper.Call(ctx, tCtx, lambdaArgs(mapper.Params(), key, v)...)
+			if err != nil {
+				return false, err
+			}
+			return true, setValue(result.AppendEmpty(), mapped)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}, nil
+}
@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_mapValues(t *testing.T) {
+	sliceValue := pcommon.NewValueSlice()
+	require.NoError(t, sliceValue.Slice().FromRaw([]any{
+		map[string]any{"name": "foo"},
+		map[string]any{"name": "bar"},
+	}))
+
+	mapValue := pcommon.NewMap()
+	mapValue.PutInt("a", 1)
+	mapValue.PutInt("b", 2)
+
+	tests := []struct {
+		name     string
+		target   any
+		mapper   ottl.StandardLambda[any]
+		expected any
+	}{
+		{
+			name:   "pcommon.Value slice of maps",
+			target: sliceValue,
+			mapper: ottl.StandardLambda[any]{
+				Arity: 1,
+				Body: func(_ context.Context, _ any, args ...any) (any, error) {
+					return args[0].(pcommon.Map).AsRaw()["name"], nil
+				},
+			},
+			expected: []any{"foo", "bar"},
+		},
+		{
+			name:   "[]string with index",
+			target: []string{"a", "b"},
+			mapper: ottl.StandardLambda[any]{
+				Arity: 2,
+				Body: func(_ context.Context, _ any, args ...any) (any, error) {
+					return fmt.Sprintf("%d-%s", args[0], args[1]), nil
+				},
+			},
+			expected: []any{"0-a", "1-b"},
+		},
+		{
+			name:   "to lists",
+			target: []int64{1, 2},
+			mapper: ottl.StandardLambda[any]{
+				Arity: 1,
+				Body: func(_ context.Context, _ any, args ...any) (any, error) {
+					return []int64{args[0].(int64), args[0].(int64) * 10}, nil
+				},
+			},
+			expected: []any{[]any{int64(1), int64(10)}, []any{int64(2), int64(20)}},
+		},
+		{
+			name:   "nil values",
+			target: []any{"a"},
+			mapper: ottl.StandardLambda[any]{
+				Arity: 1,
+				Body: func(context.Context, any, ...any) (any, error) {
+					return nil, nil
+				},
+			},
+			expected: []any{nil},
+		},
+		{
+			name:   "pcommon.Map with key",
+			target: mapValue,
+			mapper: ottl.StandardLambda[any]{
+				Arity: 2,
+				Body: func(_ context.Context, _ any, args ...any) (any, error) {
+					return fmt.Sprintf("%s=%d", args[0], args[1]), nil
+				},
+			},
+			expected: map[string]any{"a": "a=1", "b": "b=2"},
+		},
+		{
+			name:   "map[string]any",
+			target: map[string]any{"a": 1.5},
+			mapper: ottl.StandardLambda[any]{
+				Arity: 1,
+				Body: func(_ context.Context, _ any, args ...any) (any, error) {
+					return args[0].(float64) * 2, nil
+				},
+			},
+			expected: map[string]any{"a": 3.0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &ottl.StandardGetSetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc, err := mapValues[any](target, tt.mapper)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			switch r := result.(type) {
+			case pcommon.Slice:
+				assert.Equal(t, tt.expected, r.AsRaw())
+			case pcommon.Map:
+				assert.Equal(t, tt.expected, r.AsRaw())
+			default:
+				assert.Fail(t, "unexpected result type", "%T", result)
+			}
+		})
+	}
+}
+
+func Test_mapValues_error(t *testing.T) {
+	target := &ottl.StandardGetSetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return []any{"a"}, nil
+		},
+	}
+
+	_, err := mapValues[any](target, ottl.StandardLambda[any]{Arity: 0})
+	assert.ErrorContains(t, err, "the mapper lambda must declare either one (value) or two (index or key, value) parameters, but declares 0")
+
+	exprFunc, err := mapValues[any](target, ottl.StandardLambda[any]{
+		Arity: 1,
+		Body: func(context.Context, any, ...any) (any, error) {
+			return struct{}{}, nil
+		},
+	})
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	assert.ErrorContains(t, err, "unsupported value type struct {}")
+
+	exprFunc, err = mapValues[any](target, ottl.StandardLambda[any]{
+		Arity: 1,
+		Body: func(context.Context, any, ...any) (any, error) {
+			return nil, errors.New("mapper error")
+		},
+	})
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	assert.EqualError(t, err, "mapper error")
+}
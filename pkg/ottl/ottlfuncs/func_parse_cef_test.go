@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_parseCEF(t *testing.T) {
+	target := ottl.StandardStringGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return `<134>host CEF:0|Security|threatmanager|1.0|100|worm\|stopped|10|src=10.0.0.1 msg=Detected a threat\=high`, nil
+		},
+	}
+
+	exprFunc := parseCEF[any](target)
+	result, err := exprFunc(context.Background(), nil)
+	require.NoError(t, err)
+
+	resultMap, ok := result.(pcommon.Map)
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{
+		"version":               "0",
+		"device_vendor":         "Security",
+		"device_product":        "threatmanager",
+		"device_version":        "1.0",
+		"device_event_class_id": "100",
+		"name":                  "worm|stopped",
+		"severity":              "10",
+		"extensions": map[string]any{
+			"src": "10.0.0.1",
+			"msg": "Detected a threat=high",
+		},
+	}, resultMap.AsRaw())
+}
+
+func Test_parseCEF_error(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		expected string
+	}{
+		{
+			name:     "empty target",
+			target:   "",
+			expected: "cannot parse from empty target",
+		},
+		{
+			name:     "invalid message",
+			target:   "CEF:0|Security|threatmanager",
+			expected: "invalid CEF header: expected 7 fields, got 3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc := parseCEF[any](target)
+			_, err := exprFunc(context.Background(), nil)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ParseIPArguments[K any] struct {
+	Target ottl.StringGetter[K]
+}
+
+func NewParseIPFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseIP", &ParseIPArguments[K]{}, createParseIPFunction[K])
+}
+
+func createParseIPFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseIPArguments[K])
+
+	if !ok {
+		return nil, errors.New("ParseIPFactory args must be of type *ParseIPArguments[K]")
+	}
+
+	return parseIP(args.Target), nil
+}
+
+func parseIP[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		addr, err := parseIPAddress(val)
+		if err != nil {
+			return nil, err
+		}
+		return addr.String(), nil
+	}
+}
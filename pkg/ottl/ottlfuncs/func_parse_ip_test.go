@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_parseIP(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		expected string
+	}{
+		{name: "ipv4", target: "10.0.0.1", expected: "10.0.0.1"},
+		{name: "ipv6 compressed", target: "2001:0db8:0000:0000:0000:0000:0000:0001", expected: "2001:db8::1"},
+		{name: "ipv6 upper case", target: "2001:DB8::AB", expected: "2001:db8::ab"},
+		{name: "ipv4-mapped ipv6", target: "::ffff:192.168.1.1", expected: "192.168.1.1"},
+		{name: "ipv6 with zone", target: "fe80::1%eth0", expected: "fe80::1%eth0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := parseIP[any](&ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_parseIP_error(t *testing.T) {
+	tests := []struct {
+		name          string
+		target        any
+		expectedError string
+	}{
+		{name: "invalid ip", target: "10.0.0.256", expectedError: `invalid IP address "10.0.0.256"`},
+		{name: "cidr", target: "10.0.0.0/8", expectedError: `invalid IP address "10.0.0.0/8"`},
+		{name: "not a string", target: int64(1), expectedError: "expected string but got int64"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := parseIP[any](&ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			})
+			_, err := exprFunc(context.Background(), nil)
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_parseLEEF(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    string
+		delimiter ottl.Optional[string]
+		expected  map[string]any
+	}{
+		{
+			name:   "leef 1.0",
+			target: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tsev=5",
+			expected: map[string]any{
+				"version":        "1.0",
+				"device_vendor":  "Microsoft",
+				"device_product": "MSExchange",
+				"device_version": "4.0 SP1",
+				"event_id":       "15345",
+				"extensions": map[string]any{
+					"src": "192.0.2.0",
+					"sev": "5",
+				},
+			},
+		},
+		{
+			name:   "leef 2.0 declared delimiter",
+			target: "LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5",
+			expected: map[string]any{
+				"version":        "2.0",
+				"device_vendor":  "Lancope",
+				"device_product": "StealthWatch",
+				"device_version": "1.0",
+				"event_id":       "41",
+				"extensions": map[string]any{
+					"src": "10.0.1.8",
+					"dst": "10.0.0.5",
+				},
+			},
+		},
+		{
+			name:      "custom delimiter",
+			target:    "LEEF:1.0|Vendor|Product|1.0|id|a=1;b=2",
+			delimiter: ottl.NewTestingOptional(";"),
+			expected: map[string]any{
+				"version":        "1.0",
+				"device_vendor":  "Vendor",
+				"device_product": "Product",
+				"device_version": "1.0",
+				"event_id":       "id",
+				"extensions": map[string]any{
+					"a": "1",
+					"b": "2",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardStringGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc, err := parseLEEF[any](target, tt.delimiter)
+			require.NoError(t, err)
+
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+
+			resultMap, ok := result.(pcommon.Map)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, resultMap.AsRaw())
+		})
+	}
+}
+
+func Test_parseLEEF_error(t *testing.T) {
+	target := ottl.StandardStringGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return "LEEF:1.0|Vendor|Product|1.0|id|invalid", nil
+		},
+	}
+	exprFunc, err := parseLEEF[any](target, ottl.Optional[string]{})
+	require.NoError(t, err)
+	_, err = exprFunc(context.Background(), nil)
+	assert.EqualError(t, err, `invalid LEEF attributes: cannot split "invalid" into a key and a value`)
+
+	_, err = parseLEEF[any](target, ottl.NewTestingOptional(""))
+	assert.EqualError(t, err, "delimiter cannot be set to an empty string")
+}
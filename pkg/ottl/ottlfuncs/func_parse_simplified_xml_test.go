@@ -81,6 +81,15 @@ func Test_ParseSimplifiedXML(t *testing.T) {
 				return m
 			}(),
 		},
+		{
+			name:     "ISO-8859-1 encoding",
+			document: "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><a>caf\xe9</a>",
+			want: func() pcommon.Map {
+				m := pcommon.NewMap()
+				m.PutStr("a", "café")
+				return m
+			}(),
+		},
 		{
 			name:     "ignore declaration",
 			document: `<?xml version="1.0" encoding="UTF-8"?><a>b</a>`,
@@ -87,7 +87,9 @@ func validateXPath(xPath string) error {
 
 // Aside from parsing the XML document, this function also ensures that
 // the XML declaration is included in the result only if it was present in
-// the original document.
+// the original document. The converters evaluating XPath expressions need the
+// xmlquery document, so unlike ParseXML and ParseSimplifiedXML they don't use
+// the parser of the parseutils package.
 func parseNodesXML(targetVal string) (*xmlquery.Node, error) {
 	preserveDeclearation := strings.HasPrefix(targetVal, "<?xml")
 	top, err := xmlquery.Parse(strings.NewReader(targetVal))
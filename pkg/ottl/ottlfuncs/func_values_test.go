@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_values(t *testing.T) {
+	m := pcommon.NewMap()
+	m.PutStr("foo", "bar")
+	m.PutInt("int", 1)
+	m.PutEmptyMap("nested").PutStr("test", "pass")
+
+	tests := []struct {
+		name     string
+		target   any
+		expected []any
+	}{
+		{
+			name:     "pcommon.Map",
+			target:   m,
+			expected: []any{"bar", int64(1), map[string]any{"test": "pass"}},
+		},
+		{
+			name:     "map[string]any",
+			target:   map[string]any{"foo": []any{"a", "b"}},
+			expected: []any{[]any{"a", "b"}},
+		},
+		{
+			name:     "empty map",
+			target:   pcommon.NewMap(),
+			expected: []any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exprFunc := values[any](&ottl.StandardPMapGetter[any]{
+				Getter: func(_ context.Context, _ any) (any, error) {
+					return tt.target, nil
+				},
+			})
+			result, err := exprFunc(context.Background(), nil)
+			require.NoError(t, err)
+			require.IsType(t, pcommon.Slice{}, result)
+			assert.Equal(t, tt.expected, result.(pcommon.Slice).AsRaw())
+		})
+	}
+}
+
+func Test_values_copies(t *testing.T) {
+	m := pcommon.NewMap()
+	m.PutEmptyMap("nested").PutStr("test", "pass")
+
+	exprFunc := values[any](&ottl.StandardPMapGetter[any]{
+		Getter: func(_ context.Context, _ any) (any, error) {
+			return m, nil
+		},
+	})
+	result, err := exprFunc(context.Background(), nil)
+	require.NoError(t, err)
+	result.(pcommon.Slice).At(0).Map().PutStr("test", "modified")
+
+	nested, _ := m.Get("nested")
+	assert.Equal(t, map[string]any{"test": "pass"}, nested.Map().AsRaw())
+}
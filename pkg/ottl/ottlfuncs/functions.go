@@ -38,9 +38,12 @@ func StandardConverters[K any]() map[string]ottl.Factory[K] {
 func converters[K any]() []ottl.Factory[K] {
 	return []ottl.Factory[K]{
 		// Converters
+		NewAllFactory[K](),
+		NewAnyFactory[K](),
 		NewBase64DecodeFactory[K](),
 		NewDecodeFactory[K](),
 		NewConcatFactory[K](),
+		NewContainsValueFactory[K](),
 		NewConvertCaseFactory[K](),
 		NewConvertAttributesToElementsXMLFactory[K](),
 		NewConvertTextToElementsXMLFactory[K](),
@@ -49,23 +52,32 @@ func converters[K any]() []ottl.Factory[K] {
 		NewDurationFactory[K](),
 		NewExtractPatternsFactory[K](),
 		NewExtractGrokPatternsFactory[K](),
+		NewFilterFactory[K](),
 		NewFnvFactory[K](),
 		NewGetXMLFactory[K](),
 		NewHasPrefixFactory[K](),
 		NewHasSuffixFactory[K](),
 		NewHourFactory[K](),
 		NewHoursFactory[K](),
+		NewIndexOfFactory[K](),
+		NewIPNetworkFactory[K](),
 		NewInsertXMLFactory[K](),
 		NewIntFactory[K](),
 		NewIsBoolFactory[K](),
 		NewIsDoubleFactory[K](),
 		NewIsListFactory[K](),
 		NewIsIntFactory[K](),
+		NewIsInCIDRFactory[K](),
+		NewIsLinkLocalIPFactory[K](),
+		NewIsLoopbackIPFactory[K](),
 		NewIsMapFactory[K](),
 		NewIsMatchFactory[K](),
+		NewIsPrivateIPFactory[K](),
 		NewIsStringFactory[K](),
+		NewKeysFactory[K](),
 		NewLenFactory[K](),
 		NewLogFactory[K](),
+		NewMapFactory[K](),
 		NewIsValidLuhnFactory[K](),
 		NewMD5Factory[K](),
 		NewMicrosecondsFactory[K](),
@@ -78,9 +90,12 @@ func converters[K any]() []ottl.Factory[K] {
 		NewNanosecondFactory[K](),
 		NewNanosecondsFactory[K](),
 		NewNowFactory[K](),
+		NewParseCEFFactory[K](),
 		NewParseCSVFactory[K](),
+		NewParseIPFactory[K](),
 		NewParseJSONFactory[K](),
 		NewParseKeyValueFactory[K](),
+		NewParseLEEFFactory[K](),
 		NewParseSimplifiedXMLFactory[K](),
 		NewParseXMLFactory[K](),
 		NewRemoveXMLFactory[K](),
@@ -111,6 +126,7 @@ func converters[K any]() []ottl.Factory[K] {
 		NewUnixNanoFactory[K](),
 		NewUnixSecondsFactory[K](),
 		NewUUIDFactory[K](),
+		NewValuesFactory[K](),
 		NewURLFactory[K](),
 		NewWeekdayFactory[K](),
 		NewUserAgentFactory[K](),
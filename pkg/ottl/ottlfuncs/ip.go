@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// parseIPAddress parses an IPv4 or IPv6 address. IPv4-mapped IPv6 addresses, e.g. "::ffff:10.0.0.1",
+// are converted into plain IPv4 addresses, so they are handled like their IPv4 representation.
+func parseIPAddress(val string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(val)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("invalid IP address %q", val)
+	}
+	return addr.Unmap(), nil
+}
+
+// ipPredicate returns an ExprFunc that reports whether the target IP address satisfies
+// the given predicate. Targets that are nil or aren't valid IP addresses never satisfy it.
+func ipPredicate[K any](target ottl.StringLikeGetter[K], predicate func(netip.Addr) bool) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		if val == nil {
+			return false, nil
+		}
+		addr, err := parseIPAddress(*val)
+		if err != nil {
+			return false, nil
+		}
+		return predicate(addr), nil
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/alecthomas/participle/v2"
 	"go.opentelemetry.io/collector/component"
@@ -68,6 +69,14 @@ type Parser[K any] struct {
 	enumParser        EnumParser
 	telemetrySettings component.TelemetrySettings
 	pathContextNames  map[string]struct{}
+	lambdaParams      map[string]*lambdaParam
+
+	functionDefinitions []FunctionDefinition
+	userFunctions       map[string]*userFunction
+	userFunctionArgs    map[string]*userFunctionArg[K]
+
+	newAnalysisContext    func() K
+	staticAnalysisLogging bool
 }
 
 // NewParser creates a new Parser
@@ -91,6 +100,9 @@ func NewParser[K any](
 	for _, opt := range options {
 		opt(&p)
 	}
+	if err := p.addFunctionDefinitions(p.functionDefinitions); err != nil {
+		return Parser[K]{}, err
+	}
 	return p, nil
 }
 
@@ -140,6 +152,11 @@ func (p *Parser[K]) ParseStatements(statements []string) ([]*Statement[K], error
 		return nil, errors.Join(parseErrs...)
 	}
 
+	if p.staticAnalysisLogging {
+		diagnostics, _ := p.AnalyzeStatements(statements)
+		p.logDiagnostics("statement", diagnostics)
+	}
+
 	return parsedStatements, nil
 }
 
@@ -187,6 +204,11 @@ func (p *Parser[K]) ParseConditions(conditions []string) ([]*Condition[K], error
 		return nil, errors.Join(parseErrs...)
 	}
 
+	if p.staticAnalysisLogging {
+		diagnostics, _ := p.AnalyzeConditions(conditions)
+		p.logDiagnostics("condition", diagnostics)
+	}
+
 	return parsedConditions, nil
 }
 
@@ -349,6 +371,7 @@ type StatementSequence[K any] struct {
 	statements        []*Statement[K]
 	errorMode         ErrorMode
 	telemetrySettings component.TelemetrySettings
+	telemetry         *statementTelemetry
 }
 
 // StatementSequenceOption is an option for a StatementSequence
@@ -361,6 +384,21 @@ func WithStatementSequenceErrorMode[K any](errorMode ErrorMode) StatementSequenc
 	}
 }
 
+// WithStatementSequenceTelemetry enables the statement-level telemetry of a StatementSequence.
+// For each statement, the number of evaluations, condition matches and errors, as well as the
+// cumulative execution time, are recorded using the MeterProvider of the component.TelemetrySettings.
+// The metrics are labeled with the index of the statement in the sequence and its StatementFingerprint.
+func WithStatementSequenceTelemetry[K any]() StatementSequenceOption[K] {
+	return func(s *StatementSequence[K]) {
+		telemetry, err := newStatementTelemetry(s.statements, s.telemetrySettings)
+		if err != nil {
+			s.telemetrySettings.Logger.Warn("failed to create the OTTL statement telemetry", zap.Error(err))
+			return
+		}
+		s.telemetry = telemetry
+	}
+}
+
 // NewStatementSequence creates a new StatementSequence with the provided Statement slice and component.TelemetrySettings.
 // The default ErrorMode is `Propagate`.
 // You may also augment the StatementSequence with a slice of StatementSequenceOption.
@@ -382,8 +420,15 @@ func NewStatementSequence[K any](statements []*Statement[K], telemetrySettings c
 // When the ErrorMode of the StatementSequence is `silent`, errors are not logged and execution continues to the next statement.
 func (s *StatementSequence[K]) Execute(ctx context.Context, tCtx K) error {
 	s.telemetrySettings.Logger.Debug("initial TransformContext before executing StatementSequence", zap.Any("TransformContext", tCtx))
-	for _, statement := range s.statements {
-		_, _, err := statement.Execute(ctx, tCtx)
+	for i, statement := range s.statements {
+		var start time.Time
+		if s.telemetry != nil {
+			start = time.Now()
+		}
+		_, matched, err := statement.Execute(ctx, tCtx)
+		if s.telemetry != nil {
+			s.telemetry.record(ctx, i, matched, err, time.Since(start))
+		}
 		if err != nil {
 			if s.errorMode == PropagateError {
 				err = fmt.Errorf("failed to execute statement: %v, %w", statement.origText, err)
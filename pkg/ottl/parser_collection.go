@@ -61,8 +61,10 @@ type ParserCollection[R any] struct {
 	contextInferrerCandidates map[string]*priorityContextInferrerCandidate
 	candidatesLowerContexts   map[string][]string
 	modifiedLogging           bool
+	functionDefinitions       []FunctionDefinition
 	Settings                  component.TelemetrySettings
 	ErrorMode                 ErrorMode
+	StatementTelemetry        bool
 }
 
 // ParserCollectionOption is a configurable ParserCollection option.
@@ -93,6 +95,14 @@ func NewParserCollection[R any](
 		}
 	}
 
+	if len(pc.functionDefinitions) > 0 {
+		for context, pcp := range pc.contextParsers {
+			if err := pcp.addFunctionDefinitions(pc.functionDefinitions); err != nil {
+				return nil, fmt.Errorf(`invalid function definitions for context "%s": %w`, context, err)
+			}
+		}
+	}
+
 	return pc, nil
 }
 
@@ -151,11 +161,37 @@ type (
 	//
 	// Experimental: *NOTE* this API is subject to change or removal in the future.
 	ParserCollectionContextParser[R any] struct {
-		parseStatements parserCollectionContextParserFunc[R, StatementsGetter]
-		parseConditions parserCollectionContextParserFunc[R, ConditionsGetter]
+		parseStatements        parserCollectionContextParserFunc[R, StatementsGetter]
+		parseConditions        parserCollectionContextParserFunc[R, ConditionsGetter]
+		analyzeStatements      parserCollectionContextAnalyzerFunc
+		analyzeConditions      parserCollectionContextAnalyzerFunc
+		addFunctionDefinitions func([]FunctionDefinition) error
 	}
+
+	// parserCollectionContextAnalyzerFunc is the internal type that statically analyzes the given
+	// statements or conditions using the specified context's OTTL parser.
+	parserCollectionContextAnalyzerFunc func(context string, values []string, prependPathsContext bool) ([]Diagnostic, error)
 )
 
+// createContextAnalyzer is a method to create the necessary analyzer wrapper and shadowing the K type.
+func createContextAnalyzer(prependContext func(context string, ottl string) (string, error), analyze func([]string) ([]Diagnostic, error)) parserCollectionContextAnalyzerFunc {
+	return func(context string, values []string, prependPathsContext bool) ([]Diagnostic, error) {
+		if prependPathsContext {
+			prepended := make([]string, 0, len(values))
+			for _, value := range values {
+				// Values that cannot be parsed are kept as they are, so the
+				// analysis reports their syntax errors.
+				if prependedValue, err := prependContext(context, value); err == nil {
+					value = prependedValue
+				}
+				prepended = append(prepended, value)
+			}
+			values = prepended
+		}
+		return analyze(values)
+	}
+}
+
 // createConditionsParserWithConverter is a method to create the necessary parser wrapper and shadowing the K type.
 func createConditionsParserWithConverter[K any, R any](converter ParsedConditionsConverter[K, R], parser *Parser[K]) parserCollectionContextParserFunc[R, ConditionsGetter] {
 	return func(pc *ParserCollection[R], context string, conditions ConditionsGetter, prependPathsContext bool) (R, error) {
@@ -266,7 +302,11 @@ func WithParserCollectionContext[K any, R any](
 		if _, ok := parser.pathContextNames[context]; !ok {
 			return fmt.Errorf(`context "%s" must be a valid "%T" path context name`, context, parser)
 		}
-		pcp := &ParserCollectionContextParser[R]{}
+		pcp := &ParserCollectionContextParser[R]{
+			analyzeStatements:      createContextAnalyzer(parser.prependContextToStatementPaths, parser.AnalyzeStatements),
+			analyzeConditions:      createContextAnalyzer(parser.prependContextToConditionPaths, parser.AnalyzeConditions),
+			addFunctionDefinitions: parser.addFunctionDefinitions,
+		}
 		for _, o := range opts {
 			o(pcp, parser)
 		}
@@ -284,6 +324,9 @@ func WithParserCollectionContext[K any, R any](
 				return err == nil
 			},
 			hasFunctionName: func(name string) bool {
+				if _, ok := parser.userFunctions[name]; ok {
+					return true
+				}
 				_, ok := parser.functions[name]
 				return ok
 			},
@@ -308,6 +351,30 @@ func WithParserCollectionErrorMode[R any](errorMode ErrorMode) ParserCollectionO
 	}
 }
 
+// EnableParserCollectionStatementTelemetry controls whether the statement sequences created
+// by the ParserCollection's statement converters should record statement-level telemetry.
+// See WithStatementSequenceTelemetry.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func EnableParserCollectionStatementTelemetry[R any](enabled bool) ParserCollectionOption[R] {
+	return func(tp *ParserCollection[R]) error {
+		tp.StatementTelemetry = enabled
+		return nil
+	}
+}
+
+// WithParserCollectionFunctionDefinitions adds the given user-defined functions to all the
+// ParserCollection's context parsers, so they can be invoked from statements and conditions
+// of any context. The definitions are validated once all contexts are configured.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func WithParserCollectionFunctionDefinitions[R any](definitions []FunctionDefinition) ParserCollectionOption[R] {
+	return func(tp *ParserCollection[R]) error {
+		tp.functionDefinitions = append(tp.functionDefinitions, definitions...)
+		return nil
+	}
+}
+
 // EnableParserCollectionModifiedPathsLogging controls the modification logs.
 // When enabled, it logs any modifications performed by the parsing operations,
 // instructing users to rewrite the statements accordingly.
@@ -355,8 +422,14 @@ func WithContextInferenceConditions(conditions []string) ParserCollectionContext
 //
 // Experimental: *NOTE* this API is subject to change or removal in the future.
 func (pc *ParserCollection[R]) ParseStatements(statements StatementsGetter, options ...ParserCollectionContextInferenceOption) (R, error) {
-	statementsValues := statements.GetStatements()
+	inferredContext, err := pc.inferStatementsContext(statements.GetStatements(), options...)
+	if err != nil {
+		return *new(R), err
+	}
+	return pc.ParseStatementsWithContext(inferredContext, statements, false)
+}
 
+func (pc *ParserCollection[R]) inferStatementsContext(statementsValues []string, options ...ParserCollectionContextInferenceOption) (string, error) {
 	parseStatementsOpts := parseCollectionContextInferenceOptions{}
 	for _, opt := range options {
 		opt(&parseStatementsOpts)
@@ -373,19 +446,19 @@ func (pc *ParserCollection[R]) ParseStatements(statements StatementsGetter, opti
 	}
 
 	if err != nil {
-		return *new(R), fmt.Errorf("unable to infer a valid context (%+q) from statements %+q and conditions %+q: %w", pc.supportedContextNames(), statementsValues, conditionsValues, err)
+		return "", fmt.Errorf("unable to infer a valid context (%+q) from statements %+q and conditions %+q: %w", pc.supportedContextNames(), statementsValues, conditionsValues, err)
 	}
 
 	if inferredContext == "" {
-		return *new(R), fmt.Errorf("unable to infer context from statements %+q and conditions %+q, path's first segment must be a valid context name %+q, and at least one context must be capable of parsing all statements", pc.supportedContextNames(), statementsValues, conditionsValues)
+		return "", fmt.Errorf("unable to infer context from statements %+q and conditions %+q, path's first segment must be a valid context name %+q, and at least one context must be capable of parsing all statements", pc.supportedContextNames(), statementsValues, conditionsValues)
 	}
 
 	_, ok := pc.contextParsers[inferredContext]
 	if !ok {
-		return *new(R), fmt.Errorf(`context "%s" inferred from the statements %+q and conditions %+q is not a supported context: %+q`, inferredContext, statementsValues, conditionsValues, pc.supportedContextNames())
+		return "", fmt.Errorf(`context "%s" inferred from the statements %+q and conditions %+q is not a supported context: %+q`, inferredContext, statementsValues, conditionsValues, pc.supportedContextNames())
 	}
 
-	return pc.ParseStatementsWithContext(inferredContext, statements, false)
+	return inferredContext, nil
 }
 
 // ParseStatementsWithContext parses the given statements into [R] using the configured
@@ -425,22 +498,29 @@ func (pc *ParserCollection[R]) ParseStatementsWithContext(context string, statem
 //
 // Experimental: *NOTE* this API is subject to change or removal in the future.
 func (pc *ParserCollection[R]) ParseConditions(conditions ConditionsGetter) (R, error) {
-	conditionsValues := conditions.GetConditions()
-	inferredContext, err := pc.contextInferrer.inferFromConditions(conditionsValues)
+	inferredContext, err := pc.inferConditionsContext(conditions.GetConditions())
 	if err != nil {
 		return *new(R), err
 	}
+	return pc.ParseConditionsWithContext(inferredContext, conditions, false)
+}
+
+func (pc *ParserCollection[R]) inferConditionsContext(conditionsValues []string) (string, error) {
+	inferredContext, err := pc.contextInferrer.inferFromConditions(conditionsValues)
+	if err != nil {
+		return "", err
+	}
 
 	if inferredContext == "" {
-		return *new(R), fmt.Errorf("unable to infer context from conditions, path's first segment must be a valid context name: %+q, and at least one context must be capable of parsing all conditions: %+q", pc.supportedContextNames(), conditionsValues)
+		return "", fmt.Errorf("unable to infer context from conditions, path's first segment must be a valid context name: %+q, and at least one context must be capable of parsing all conditions: %+q", pc.supportedContextNames(), conditionsValues)
 	}
 
 	_, ok := pc.contextParsers[inferredContext]
 	if !ok {
-		return *new(R), fmt.Errorf(`context "%s" inferred from the conditions %+q is not a supported context: %+q`, inferredContext, conditionsValues, pc.supportedContextNames())
+		return "", fmt.Errorf(`context "%s" inferred from the conditions %+q is not a supported context: %+q`, inferredContext, conditionsValues, pc.supportedContextNames())
 	}
 
-	return pc.ParseConditionsWithContext(inferredContext, conditions, false)
+	return inferredContext, nil
 }
 
 // ParseConditionsWithContext parses the given conditions into [R] using the configured
@@ -471,6 +551,58 @@ func (pc *ParserCollection[R]) ParseConditionsWithContext(context string, condit
 	)
 }
 
+// AnalyzeStatements statically analyzes the given statements using the ottl.Parser of the
+// context inferred as described in ParseStatements. See Parser.AnalyzeStatements for the
+// list of checks.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func (pc *ParserCollection[R]) AnalyzeStatements(statements StatementsGetter, options ...ParserCollectionContextInferenceOption) ([]Diagnostic, error) {
+	inferredContext, err := pc.inferStatementsContext(statements.GetStatements(), options...)
+	if err != nil {
+		return nil, err
+	}
+	return pc.AnalyzeStatementsWithContext(inferredContext, statements, false)
+}
+
+// AnalyzeStatementsWithContext statically analyzes the given statements using the provided
+// context's ottl.Parser. The context and prependPathsContext arguments work as they do in
+// ParseStatementsWithContext.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func (pc *ParserCollection[R]) AnalyzeStatementsWithContext(context string, statements StatementsGetter, prependPathsContext bool) ([]Diagnostic, error) {
+	contextParser, ok := pc.contextParsers[context]
+	if !ok {
+		return nil, fmt.Errorf(`unknown context "%s" for statements: %v`, context, statements.GetStatements())
+	}
+	return contextParser.analyzeStatements(context, statements.GetStatements(), prependPathsContext)
+}
+
+// AnalyzeConditions statically analyzes the given conditions using the ottl.Parser of the
+// context inferred as described in ParseConditions. See Parser.AnalyzeConditions for the
+// list of checks.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func (pc *ParserCollection[R]) AnalyzeConditions(conditions ConditionsGetter) ([]Diagnostic, error) {
+	inferredContext, err := pc.inferConditionsContext(conditions.GetConditions())
+	if err != nil {
+		return nil, err
+	}
+	return pc.AnalyzeConditionsWithContext(inferredContext, conditions, false)
+}
+
+// AnalyzeConditionsWithContext statically analyzes the given conditions using the provided
+// context's ottl.Parser. The context and prependPathsContext arguments work as they do in
+// ParseConditionsWithContext.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func (pc *ParserCollection[R]) AnalyzeConditionsWithContext(context string, conditions ConditionsGetter, prependPathsContext bool) ([]Diagnostic, error) {
+	contextParser, ok := pc.contextParsers[context]
+	if !ok {
+		return nil, fmt.Errorf(`unknown context "%s" for conditions: %v`, context, conditions.GetConditions())
+	}
+	return contextParser.analyzeConditions(context, conditions.GetConditions(), prependPathsContext)
+}
+
 func (pc *ParserCollection[R]) logModifications(originalStatements, modifiedStatements []string) {
 	var fields []zap.Field
 	for i, original := range originalStatements {
@@ -674,6 +674,61 @@ func Test_NewConditionsGetter(t *testing.T) {
 	assert.Equal(t, conditions, conditionsGetter.GetConditions())
 }
 
+func Test_ParserCollection_AnalyzeStatements(t *testing.T) {
+	ps := mockParser(t, WithPathContextNames[any]([]string{"dummy"}), WithStaticAnalysisContext[any](func() any { return "" }))
+	pc, err := NewParserCollection(
+		componenttest.NewNopTelemetrySettings(),
+		WithParserCollectionContext("dummy", ps, WithStatementConverter(newNopParsedStatementsConverter[any]())),
+	)
+	require.NoError(t, err)
+
+	want := []Diagnostic{{
+		Rule:    DiagnosticRuleTypeMismatch,
+		Text:    `set(dummy.attributes["foo"], "foo") where dummy.name == 1`,
+		Message: `comparing string to int with "==" is always false`,
+	}}
+
+	diagnostics, err := pc.AnalyzeStatements(mockGetter{[]string{`set(dummy.attributes["foo"], "foo") where dummy.name == 1`}})
+	require.NoError(t, err)
+	assert.Equal(t, want, diagnostics)
+
+	diagnostics, err = pc.AnalyzeStatementsWithContext("dummy", mockGetter{[]string{`set(attributes["foo"], "foo") where name == 1`}}, true)
+	require.NoError(t, err)
+	assert.Equal(t, want, diagnostics)
+
+	_, err = pc.AnalyzeStatementsWithContext("bar", mockGetter{[]string{`set(attributes["foo"], "foo")`}}, false)
+	assert.ErrorContains(t, err, `unknown context "bar"`)
+
+	_, err = pc.AnalyzeStatements(mockGetter{[]string{`set(attributes["foo"], "foo")`}})
+	assert.ErrorContains(t, err, "unable to infer context from statements")
+}
+
+func Test_ParserCollection_AnalyzeConditions(t *testing.T) {
+	ps := mockParser(t, WithPathContextNames[any]([]string{"dummy"}), WithStaticAnalysisContext[any](func() any { return "" }))
+	pc, err := NewParserCollection(
+		componenttest.NewNopTelemetrySettings(),
+		WithParserCollectionContext("dummy", ps, WithConditionConverter(newNopParsedConditionsConverter[any]())),
+	)
+	require.NoError(t, err)
+
+	want := []Diagnostic{{
+		Rule:    DiagnosticRuleNilPath,
+		Text:    `dummy.cache["foo"] == "foo"`,
+		Message: `path "dummy.cache[foo]" is always nil because conditions cannot set it`,
+	}}
+
+	diagnostics, err := pc.AnalyzeConditions(mockGetter{[]string{`dummy.cache["foo"] == "foo"`}})
+	require.NoError(t, err)
+	assert.Equal(t, want, diagnostics)
+
+	diagnostics, err = pc.AnalyzeConditionsWithContext("dummy", mockGetter{[]string{`cache["foo"] == "foo"`}}, true)
+	require.NoError(t, err)
+	assert.Equal(t, want, diagnostics)
+
+	_, err = pc.AnalyzeConditionsWithContext("bar", mockGetter{[]string{`cache["foo"] == "foo"`}}, false)
+	assert.ErrorContains(t, err, `unknown context "bar"`)
+}
+
 func mockParser(t *testing.T, options ...Option[any]) *Parser[any] {
 	mockSetFactory := NewFactory("set", &mockSetArguments[any]{},
 		func(_ FunctionContext, _ Arguments) (ExprFunc[any], error) {
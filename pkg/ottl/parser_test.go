@@ -1370,6 +1370,164 @@ func Test_parse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "editor with lambda",
+			statement: `set(name, Filter(tags, (k, v) => v != "foo"))`,
+			expected: &parsedStatement{
+				Editor: editor{
+					Function: "set",
+					Arguments: []argument{
+						{
+							Value: value{
+								Literal: &mathExprLiteral{
+									Path: &path{
+										Pos: lexer.Position{
+											Offset: 4,
+											Line:   1,
+											Column: 5,
+										},
+										Fields: []field{
+											{
+												Name: "name",
+											},
+										},
+									},
+								},
+							},
+						},
+						{
+							Value: value{
+								Literal: &mathExprLiteral{
+									Converter: &converter{
+										Function: "Filter",
+										Arguments: []argument{
+											{
+												Value: value{
+													Literal: &mathExprLiteral{
+														Path: &path{
+															Pos: lexer.Position{
+																Offset: 17,
+																Line:   1,
+																Column: 18,
+															},
+															Fields: []field{
+																{
+																	Name: "tags",
+																},
+															},
+														},
+													},
+												},
+											},
+											{
+												Lambda: &lambda{
+													Params: []string{"k", "v"},
+													Body: &lambdaBody{
+														Condition: &booleanExpression{
+															Left: &term{
+																Left: &booleanValue{
+																	Comparison: &comparison{
+																		Left: value{
+																			Literal: &mathExprLiteral{
+																				Path: &path{
+																					Pos: lexer.Position{
+																						Offset: 33,
+																						Line:   1,
+																						Column: 34,
+																					},
+																					Fields: []field{
+																						{
+																							Name: "v",
+																						},
+																					},
+																				},
+																			},
+																		},
+																		Op: ne,
+																		Right: value{
+																			String: ottltest.Strp("foo"),
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
+		{
+			name:      "editor with lambda value body",
+			statement: `set(Map(tags, x => x))`,
+			expected: &parsedStatement{
+				Editor: editor{
+					Function: "set",
+					Arguments: []argument{
+						{
+							Value: value{
+								Literal: &mathExprLiteral{
+									Converter: &converter{
+										Function: "Map",
+										Arguments: []argument{
+											{
+												Value: value{
+													Literal: &mathExprLiteral{
+														Path: &path{
+															Pos: lexer.Position{
+																Offset: 8,
+																Line:   1,
+																Column: 9,
+															},
+															Fields: []field{
+																{
+																	Name: "tags",
+																},
+															},
+														},
+													},
+												},
+											},
+											{
+												Lambda: &lambda{
+													Params: []string{"x"},
+													Body: &lambdaBody{
+														Value: &value{
+															Literal: &mathExprLiteral{
+																Path: &path{
+																	Pos: lexer.Position{
+																		Offset: 19,
+																		Line:   1,
+																		Column: 20,
+																	},
+																	Fields: []field{
+																		{
+																			Name: "x",
+																		},
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				WhereClause: nil,
+			},
+		},
 		{
 			name:      "editor with named arg",
 			statement: `set(name="foo")`,
@@ -2381,6 +2539,20 @@ func Test_parseStatement(t *testing.T) {
 		{statement: `Test()`, wantErr: true},
 		{statement: `set() where test(foo)["key"] == "bar"`, wantErrContaining: converterNameErrorPrefix},
 		{statement: `set() where test(foo)["key"] == "bar"`, wantErrContaining: editorWithIndexErrorPrefix},
+		{statement: `set(foo, Filter(bar, x => x == "baz"))`},
+		{statement: `set(foo, Filter(bar, (k, v) => IsMatch(k, "^a") or v == 1))`},
+		{statement: `set(foo, Map(bar, x => x * 2 + 1))`},
+		{statement: `set(foo, Map(bar, x => Concat([x, "a"], "")))`},
+		{statement: `set(foo, Map(bar, x => x["a"]))`},
+		{statement: `set(foo, Any(bar, x => Any(x, y => y > 1)))`},
+		{statement: `set(foo, Any(bar, x => not IsMatch(x, "a")))`},
+		{statement: `set(foo, Any(predicate = x => true, target = bar))`},
+		{statement: `set(foo, true) where Any(bar, x => x == 1)`},
+		{statement: `set(foo, Filter(bar, () => true))`, wantErr: true},
+		{statement: `set(foo, Filter(bar, (x,) => true))`, wantErr: true},
+		{statement: `set(foo, Filter(bar, X => true))`, wantErr: true},
+		{statement: `set(foo, Filter(bar, x =>))`, wantErr: true},
+		{statement: `set(foo, Filter(bar, x => x == int()))`, wantErrContaining: converterNameErrorPrefix},
 	}
 	pat := regexp.MustCompile("[^a-zA-Z0-9]+")
 	for _, tt := range tests {
@@ -2989,6 +3161,13 @@ func Test_prependContextToStatementPaths_Success(t *testing.T) {
 			pathContextNames: []string{"log"},
 			expected:         `set(log.attributes["test"], "pass") where IsMatch(log.name, "operation[AC]")`,
 		},
+		{
+			name:             "lambda parameters are not prefixed",
+			statement:        `set(attributes["test"], Filter(attributes["list"], (i, x) => x != name and attributes[x] != nil and i > 0))`,
+			context:          "log",
+			pathContextNames: []string{"log"},
+			expected:         `set(log.attributes["test"], Filter(log.attributes["list"], (i, x) => x != log.name and log.attributes[x] != nil and i > 0))`,
+		},
 		{
 			name:             "function path parameter with context",
 			statement:        `set(attributes["test"], "pass") where IsMatch(resource.name, "operation[AC]")`,
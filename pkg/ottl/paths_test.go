@@ -372,6 +372,86 @@ func Test_getParsedStatementPaths(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "converter with lambda",
+			statement: `set(attributes["test"], Filter(tags, (i, x) => x != bear.honey and attributes[x] != nil and y == i))`,
+			expected: []path{
+				{
+					Pos: lexer.Position{
+						Offset: 4,
+						Line:   1,
+						Column: 5,
+					},
+					Fields: []field{
+						{
+							Name: "attributes",
+							Keys: []key{
+								{
+									String: ottltest.Strp("test"),
+								},
+							},
+						},
+					},
+				},
+				{
+					Pos: lexer.Position{
+						Offset: 31,
+						Line:   1,
+						Column: 32,
+					},
+					Fields: []field{{Name: "tags"}},
+				},
+				{
+					Pos: lexer.Position{
+						Offset: 52,
+						Line:   1,
+						Column: 53,
+					},
+					Context: "bear",
+					Fields:  []field{{Name: "honey"}},
+				},
+				{
+					Pos: lexer.Position{
+						Offset: 67,
+						Line:   1,
+						Column: 68,
+					},
+					Fields: []field{
+						{
+							Name: "attributes",
+							Keys: []key{
+								{
+									MathExpression: &mathExpression{
+										Left: &addSubTerm{
+											Left: &mathValue{
+												Literal: &mathExprLiteral{
+													Path: &path{
+														Pos: lexer.Position{
+															Offset: 78,
+															Line:   1,
+															Column: 79,
+														},
+														Fields: []field{{Name: "x"}},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Pos: lexer.Position{
+						Offset: 92,
+						Line:   1,
+						Column: 93,
+					},
+					Fields: []field{{Name: "y"}},
+				},
+			},
+		},
 		{
 			name:      "converter math math expression",
 			statement: `set(attributes["test"], 1000 - 600) where 1 + 1 * 2 == three / One()`,
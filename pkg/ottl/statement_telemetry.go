@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/metadata"
+)
+
+const (
+	// StatementIndexAttribute is the attribute holding the position of the statement within its StatementSequence.
+	StatementIndexAttribute = "ottl.statement.index"
+	// StatementFingerprintAttribute is the attribute holding the fingerprint of the statement's text.
+	StatementFingerprintAttribute = "ottl.statement.fingerprint"
+)
+
+// StatementFingerprint returns a short, stable identifier of the given statement text.
+// It is used to tell statements apart in the statement-level telemetry.
+func StatementFingerprint(statement string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(statement))
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
+// statementTelemetry records the internal metrics of each statement of a StatementSequence.
+type statementTelemetry struct {
+	builder    *metadata.TelemetryBuilder
+	attributes []metric.MeasurementOption
+}
+
+func newStatementTelemetry[K any](statements []*Statement[K], settings component.TelemetrySettings) (*statementTelemetry, error) {
+	builder, err := metadata.NewTelemetryBuilder(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &statementTelemetry{builder: builder}
+	t.attributes = make([]metric.MeasurementOption, len(statements))
+	for i, statement := range statements {
+		fingerprint := StatementFingerprint(statement.origText)
+		t.attributes[i] = metric.WithAttributeSet(attribute.NewSet(
+			attribute.Int(StatementIndexAttribute, i),
+			attribute.String(StatementFingerprintAttribute, fingerprint),
+		))
+		settings.Logger.Debug("statement telemetry enabled",
+			zap.Int(StatementIndexAttribute, i),
+			zap.String(StatementFingerprintAttribute, fingerprint),
+			zap.String("statement", statement.origText),
+		)
+	}
+	return t, nil
+}
+
+func (t *statementTelemetry) record(ctx context.Context, index int, matched bool, err error, elapsed time.Duration) {
+	attrs := t.attributes[index]
+	t.builder.OttlStatementEvaluations.Add(ctx, 1, attrs)
+	if matched {
+		t.builder.OttlStatementConditionMatches.Add(ctx, 1, attrs)
+	}
+	if err != nil {
+		t.builder.OttlStatementErrors.Add(ctx, 1, attrs)
+	}
+	t.builder.OttlStatementExecutionTime.Add(ctx, elapsed.Seconds(), attrs)
+}
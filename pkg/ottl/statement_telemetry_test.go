@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/metadatatest"
+)
+
+func Test_StatementFingerprint(t *testing.T) {
+	fingerprint := StatementFingerprint(`set(attributes["a"], "b")`)
+	assert.Len(t, fingerprint, 8)
+	assert.Equal(t, fingerprint, StatementFingerprint(`set(attributes["a"], "b")`))
+	assert.NotEqual(t, fingerprint, StatementFingerprint(`set(attributes["a"], "c")`))
+}
+
+func Test_StatementSequence_Telemetry(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	settings := tel.NewTelemetrySettings()
+
+	newStatement := func(text string, condition bool, err error) *Statement[any] {
+		return &Statement[any]{
+			condition: BoolExpr[any]{func(context.Context, any) (bool, error) {
+				return condition, nil
+			}},
+			function: Expr[any]{exprFunc: func(context.Context, any) (any, error) {
+				return nil, err
+			}},
+			origText:          text,
+			telemetrySettings: settings,
+		}
+	}
+	statements := []*Statement[any]{
+		newStatement("matched", true, nil),
+		newStatement("not matched", false, nil),
+		newStatement("failed", true, errors.New("test")),
+	}
+
+	sequence := NewStatementSequence(statements, settings, WithStatementSequenceErrorMode[any](IgnoreError), WithStatementSequenceTelemetry[any]())
+	for range 3 {
+		require.NoError(t, sequence.Execute(context.Background(), nil))
+	}
+
+	attrs := func(index int) attribute.Set {
+		return attribute.NewSet(
+			attribute.Int(StatementIndexAttribute, index),
+			attribute.String(StatementFingerprintAttribute, StatementFingerprint(statements[index].origText)),
+		)
+	}
+	metadatatest.AssertEqualOttlStatementEvaluations(t, tel, []metricdata.DataPoint[int64]{
+		{Attributes: attrs(0), Value: 3},
+		{Attributes: attrs(1), Value: 3},
+		{Attributes: attrs(2), Value: 3},
+	}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualOttlStatementConditionMatches(t, tel, []metricdata.DataPoint[int64]{
+		{Attributes: attrs(0), Value: 3},
+		{Attributes: attrs(2), Value: 3},
+	}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualOttlStatementErrors(t, tel, []metricdata.DataPoint[int64]{
+		{Attributes: attrs(2), Value: 3},
+	}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualOttlStatementExecutionTime(t, tel, []metricdata.DataPoint[float64]{
+		{Attributes: attrs(0)},
+		{Attributes: attrs(1)},
+		{Attributes: attrs(2)},
+	}, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreValue())
+}
+
+func Test_StatementSequence_Telemetry_disabled(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	settings := componenttest.NewNopTelemetrySettings()
+	settings.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	sequence := NewStatementSequence([]*Statement[any]{{
+		condition: BoolExpr[any]{alwaysTrue[any]},
+		function: Expr[any]{exprFunc: func(context.Context, any) (any, error) {
+			return nil, nil
+		}},
+		telemetrySettings: settings,
+	}}, settings)
+	require.NoError(t, sequence.Execute(context.Background(), nil))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	assert.Empty(t, rm.ScopeMetrics)
+}
@@ -15,6 +15,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/internal/converter"
 )
 
 func BenchmarkConvertSimple(b *testing.B) {
@@ -971,6 +972,6 @@ func TestGetResourceIDEmptyAndNilAreEqual(t *testing.T) {
 // convert converts one entry.Entry into plog.LogRecord allocating it.
 func convert(ent *entry.Entry) plog.LogRecord {
 	dest := plog.NewLogRecord()
-	convertInto(ent, dest)
+	converter.ToLogRecord(ent, dest)
 	return dest
 }
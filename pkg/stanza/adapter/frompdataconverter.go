@@ -4,11 +4,9 @@
 package adapter // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/adapter"
 
 import (
-	"encoding/binary"
 	"math"
 	"runtime"
 	"sync"
-	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -16,6 +14,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/internal/converter"
 )
 
 // FromPdataConverter converts plog.Logs into a set of entry.Entry
@@ -157,70 +156,8 @@ func convertFromLogs(workerItem fromConverterWorkerItem) []*entry.Entry {
 
 		entry.ScopeName = workerItem.Scope.Scope().Name()
 		entry.Resource = workerItem.Resource.Attributes().AsRaw()
-		convertFrom(record, &entry)
+		converter.FromLogRecord(record, &entry)
 		result = append(result, &entry)
 	}
 	return result
 }
-
-// convertFrom converts plog.LogRecord into provided entry.Entry.
-func convertFrom(src plog.LogRecord, ent *entry.Entry) {
-	// if src.Timestamp == 0, then leave ent.Timestamp as nil
-	if src.Timestamp() != 0 {
-		ent.Timestamp = src.Timestamp().AsTime()
-	}
-
-	if src.ObservedTimestamp() == 0 {
-		ent.ObservedTimestamp = time.Now()
-	} else {
-		ent.ObservedTimestamp = src.ObservedTimestamp().AsTime()
-	}
-
-	ent.Severity = fromPdataSevMap[src.SeverityNumber()]
-	ent.SeverityText = src.SeverityText()
-
-	ent.Attributes = src.Attributes().AsRaw()
-	ent.Body = src.Body().AsRaw()
-
-	if !src.TraceID().IsEmpty() {
-		buffer := src.TraceID()
-		ent.TraceID = buffer[:]
-	}
-	if !src.SpanID().IsEmpty() {
-		buffer := src.SpanID()
-		ent.SpanID = buffer[:]
-	}
-	if src.Flags() != 0 {
-		a := make([]byte, 4)
-		binary.LittleEndian.PutUint32(a, uint32(src.Flags()))
-		ent.TraceFlags = []byte{a[0]}
-	}
-}
-
-var fromPdataSevMap = map[plog.SeverityNumber]entry.Severity{
-	plog.SeverityNumberUnspecified: entry.Default,
-	plog.SeverityNumberTrace:       entry.Trace,
-	plog.SeverityNumberTrace2:      entry.Trace2,
-	plog.SeverityNumberTrace3:      entry.Trace3,
-	plog.SeverityNumberTrace4:      entry.Trace4,
-	plog.SeverityNumberDebug:       entry.Debug,
-	plog.SeverityNumberDebug2:      entry.Debug2,
-	plog.SeverityNumberDebug3:      entry.Debug3,
-	plog.SeverityNumberDebug4:      entry.Debug4,
-	plog.SeverityNumberInfo:        entry.Info,
-	plog.SeverityNumberInfo2:       entry.Info2,
-	plog.SeverityNumberInfo3:       entry.Info3,
-	plog.SeverityNumberInfo4:       entry.Info4,
-	plog.SeverityNumberWarn:        entry.Warn,
-	plog.SeverityNumberWarn2:       entry.Warn2,
-	plog.SeverityNumberWarn3:       entry.Warn3,
-	plog.SeverityNumberWarn4:       entry.Warn4,
-	plog.SeverityNumberError:       entry.Error,
-	plog.SeverityNumberError2:      entry.Error2,
-	plog.SeverityNumberError3:      entry.Error3,
-	plog.SeverityNumberError4:      entry.Error4,
-	plog.SeverityNumberFatal:       entry.Fatal,
-	plog.SeverityNumberFatal2:      entry.Fatal2,
-	plog.SeverityNumberFatal3:      entry.Fatal3,
-	plog.SeverityNumberFatal4:      entry.Fatal4,
-}
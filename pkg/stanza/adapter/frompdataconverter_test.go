@@ -15,6 +15,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/internal/converter"
 )
 
 func fillBaseMap(m pcommon.Map) {
@@ -106,7 +107,7 @@ func TestConvertFromSeverity(t *testing.T) {
 			entry := entry.New()
 			logRecord := plog.NewLogRecord()
 			logRecord.SetSeverityNumber(tc.severityNumber)
-			convertFrom(logRecord, entry)
+			converter.FromLogRecord(logRecord, entry)
 			require.Equal(t, tc.expectedSeverity, entry.Severity)
 		})
 	}
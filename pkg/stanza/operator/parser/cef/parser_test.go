@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cef
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	config := NewConfigWithID("test")
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := config.Build(set)
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("cef_parser")
+	require.True(t, ok, "expected cef_parser to be registered")
+	require.Equal(t, "cef_parser", builder().Type())
+}
+
+func TestConfigBuild(t *testing.T) {
+	config := NewConfigWithID("test")
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := config.Build(set)
+	require.NoError(t, err)
+	require.IsType(t, &Parser{}, op)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.OnError = "invalid_on_error"
+	set := componenttest.NewNopTelemetrySettings()
+	_, err := config.Build(set)
+	require.ErrorContains(t, err, "invalid `on_error` field")
+}
+
+func TestParserInvalidType(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse([]int{})
+	require.ErrorContains(t, err, "type []int cannot be parsed as CEF")
+}
+
+func TestParserEmptyInput(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse("")
+	require.ErrorContains(t, err, "parse from field body is empty")
+}
+
+func TestParserInvalidMessage(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse("CEF:0|Vendor|Product")
+	require.ErrorContains(t, err, "invalid CEF header")
+}
+
+func TestParser(t *testing.T) {
+	const message = "CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|7|src=10.0.0.1 msg=Detected a threat"
+	parsed := func() map[string]any {
+		return map[string]any{
+			"version":               "0",
+			"device_vendor":         "Security",
+			"device_product":        "threatmanager",
+			"device_version":        "1.0",
+			"device_event_class_id": "100",
+			"name":                  "worm successfully stopped",
+			"severity":              "7",
+			"extensions": map[string]any{
+				"src": "10.0.0.1",
+				"msg": "Detected a threat",
+			},
+		}
+	}
+
+	cases := []struct {
+		name        string
+		configure   func(*Config)
+		input       *entry.Entry
+		expect      *entry.Entry
+		expectError bool
+	}{
+		{
+			"simple",
+			func(_ *Config) {},
+			&entry.Entry{
+				Body: message,
+			},
+			&entry.Entry{
+				Attributes:   parsed(),
+				Body:         message,
+				Severity:     entry.Error,
+				SeverityText: "7",
+			},
+			false,
+		},
+		{
+			"parse-to-body",
+			func(c *Config) {
+				c.ParseTo = entry.RootableField{Field: entry.NewBodyField("cef")}
+			},
+			&entry.Entry{
+				Body: message,
+			},
+			&entry.Entry{
+				Body: map[string]any{
+					"cef": parsed(),
+				},
+				Severity:     entry.Error,
+				SeverityText: "7",
+			},
+			false,
+		},
+		{
+			"textual-severity",
+			func(_ *Config) {},
+			&entry.Entry{
+				Body: "CEF:0|V|P|1|id|n|Very-High|",
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"version":               "0",
+					"device_vendor":         "V",
+					"device_product":        "P",
+					"device_version":        "1",
+					"device_event_class_id": "id",
+					"name":                  "n",
+					"severity":              "Very-High",
+					"extensions":            map[string]any{},
+				},
+				Body:         "CEF:0|V|P|1|id|n|Very-High|",
+				Severity:     entry.Fatal,
+				SeverityText: "Very-High",
+			},
+			false,
+		},
+		{
+			"severity-parser",
+			func(c *Config) {
+				parseFrom := entry.NewAttributeField("extensions", "src")
+				severity := helper.NewSeverityConfig()
+				severity.ParseFrom = &parseFrom
+				severity.Mapping = map[string]any{"info": "10.0.0.1"}
+				c.SeverityConfig = &severity
+			},
+			&entry.Entry{
+				Body: message,
+			},
+			&entry.Entry{
+				Attributes:   parsed(),
+				Body:         message,
+				Severity:     entry.Info,
+				SeverityText: "10.0.0.1",
+			},
+			false,
+		},
+		{
+			"invalid-severity",
+			func(_ *Config) {},
+			&entry.Entry{
+				Body: "CEF:0|V|P|1|id|n|42|",
+			},
+			nil,
+			true,
+		},
+		{
+			"invalid-message",
+			func(_ *Config) {},
+			&entry.Entry{
+				Body: "not a CEF message",
+			},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			tc.configure(cfg)
+
+			set := componenttest.NewNopTelemetrySettings()
+			op, err := cfg.Build(set)
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			ots := time.Now()
+			tc.input.ObservedTimestamp = ots
+
+			err = op.Process(context.Background(), tc.input)
+			if tc.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			tc.expect.ObservedTimestamp = ots
+			fake.ExpectEntry(t, tc.expect)
+		})
+	}
+}
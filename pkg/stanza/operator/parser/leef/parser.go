@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leef // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/leef"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// Parser is an operator that parses IBM Log Event Extended Format (LEEF) messages.
+type Parser struct {
+	helper.ParserOperator
+	delimiter string
+}
+
+func (p *Parser) ProcessBatch(ctx context.Context, entries []*entry.Entry) error {
+	return p.ProcessBatchWith(ctx, entries, p.Process)
+}
+
+// Process will parse an entry as a LEEF message.
+// Unless a severity parser is configured, the `sev` attribute is mapped to the entry severity.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	if p.SeverityParser != nil {
+		return p.ProcessWith(ctx, entry, p.parse)
+	}
+	return p.ProcessWithCallback(ctx, entry, p.parse, p.setSeverity)
+}
+
+// parse will parse a value as a LEEF message.
+func (p *Parser) parse(value any) (any, error) {
+	switch m := value.(type) {
+	case string:
+		if m == "" {
+			return nil, fmt.Errorf("parse from field %s is empty", p.ParseFrom.String())
+		}
+		return parseutils.ParseLEEF(m, p.delimiter)
+	default:
+		return nil, fmt.Errorf("type %T cannot be parsed as LEEF", value)
+	}
+}
+
+func (p *Parser) setSeverity(e *entry.Entry) error {
+	parsed, ok := e.Get(p.ParseTo)
+	if !ok {
+		return nil
+	}
+	values, ok := parsed.(map[string]any)
+	if !ok {
+		return nil
+	}
+	attributes, ok := values[parseutils.SecurityEventExtensions].(map[string]any)
+	if !ok {
+		return nil
+	}
+	severity, ok := attributes[parseutils.LEEFSeverityKey].(string)
+	if !ok {
+		return nil
+	}
+	level, err := parseutils.SecuritySeverityLevel(severity)
+	if err != nil {
+		return err
+	}
+	e.Severity = severityMapping[level]
+	e.SeverityText = severity
+	return nil
+}
+
+var severityMapping = map[string]entry.Severity{
+	parseutils.SecuritySeverityUnknown:  entry.Default,
+	parseutils.SecuritySeverityLow:      entry.Info,
+	parseutils.SecuritySeverityMedium:   entry.Warn,
+	parseutils.SecuritySeverityHigh:     entry.Error,
+	parseutils.SecuritySeverityVeryHigh: entry.Fatal,
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xml // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/xml"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "xml_parser"
+
+	// ModeFull preserves the tag, attributes, text and children of every element.
+	ModeFull = "full"
+	// ModeSimplified keys elements by name and discards their attributes.
+	ModeSimplified = "simplified"
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new XML parser config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new XML parser config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		ParserConfig: helper.NewParserConfig(operatorID, operatorType),
+		Mode:         ModeFull,
+	}
+}
+
+// Config is the configuration of an XML parser operator.
+type Config struct {
+	helper.ParserConfig `mapstructure:",squash"`
+
+	Mode string `mapstructure:"mode"`
+}
+
+// Build will build an XML parser operator.
+func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error) {
+	parserOperator, err := c.ParserConfig.Build(set)
+	if err != nil {
+		return nil, err
+	}
+
+	var parseXML func(string) (pcommon.Map, error)
+	switch c.Mode {
+	case ModeFull:
+		parseXML = parseutils.ParseXML
+	case ModeSimplified:
+		parseXML = parseutils.ParseSimplifiedXML
+	default:
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'mode', must be either '%s' or '%s'", c.Mode, ModeFull, ModeSimplified)
+	}
+
+	return &Parser{
+		ParserOperator: parserOperator,
+		parseXML:       parseXML,
+	}, nil
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xml // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/parser/xml"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// Parser is an operator that parses XML documents.
+type Parser struct {
+	helper.ParserOperator
+	parseXML func(string) (pcommon.Map, error)
+}
+
+func (p *Parser) ProcessBatch(ctx context.Context, entries []*entry.Entry) error {
+	return p.ProcessBatchWith(ctx, entries, p.Process)
+}
+
+// Process will parse an entry as an XML document.
+func (p *Parser) Process(ctx context.Context, entry *entry.Entry) error {
+	return p.ProcessWith(ctx, entry, p.parse)
+}
+
+// parse will parse a value as an XML document.
+// Documents spanning multiple lines, such as those combined by the recombine operator, are supported.
+func (p *Parser) parse(value any) (any, error) {
+	var input string
+	switch m := value.(type) {
+	case string:
+		input = m
+	case []byte:
+		input = string(m)
+	default:
+		return nil, fmt.Errorf("type %T cannot be parsed as XML", value)
+	}
+
+	if strings.TrimSpace(input) == "" {
+		return nil, fmt.Errorf("parse from field %s is empty", p.ParseFrom.String())
+	}
+	parsed, err := p.parseXML(input)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.AsRaw(), nil
+}
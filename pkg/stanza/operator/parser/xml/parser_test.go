@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xml
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+func newTestParser(t *testing.T) *Parser {
+	config := NewConfigWithID("test")
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := config.Build(set)
+	require.NoError(t, err)
+	return op.(*Parser)
+}
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("xml_parser")
+	require.True(t, ok, "expected xml_parser to be registered")
+	require.Equal(t, "xml_parser", builder().Type())
+}
+
+func TestConfigBuild(t *testing.T) {
+	config := NewConfigWithID("test")
+	set := componenttest.NewNopTelemetrySettings()
+	op, err := config.Build(set)
+	require.NoError(t, err)
+	require.IsType(t, &Parser{}, op)
+}
+
+func TestConfigBuildFailure(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.OnError = "invalid_on_error"
+	set := componenttest.NewNopTelemetrySettings()
+	_, err := config.Build(set)
+	require.ErrorContains(t, err, "invalid `on_error` field")
+}
+
+func TestConfigBuildInvalidMode(t *testing.T) {
+	config := NewConfigWithID("test")
+	config.Mode = "flat"
+	set := componenttest.NewNopTelemetrySettings()
+	_, err := config.Build(set)
+	require.ErrorContains(t, err, "invalid value 'flat' for parameter 'mode'")
+}
+
+func TestParserInvalidType(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse([]int{})
+	require.ErrorContains(t, err, "type []int cannot be parsed as XML")
+}
+
+func TestParserEmptyInput(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse("\n")
+	require.ErrorContains(t, err, "parse from field body is empty")
+}
+
+func TestParserInvalidXML(t *testing.T) {
+	parser := newTestParser(t)
+	_, err := parser.parse("<event><name>test</event>")
+	require.ErrorContains(t, err, "unmarshal xml")
+}
+
+func TestParser(t *testing.T) {
+	// A record spanning multiple lines, as emitted by the recombine operator.
+	const multiline = "<event id=\"42\">\n  <time>1136214245</time>\n  <level>error</level>\n  <message>disk full</message>\n</event>"
+
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		input     *entry.Entry
+		expect    *entry.Entry
+	}{
+		{
+			"full",
+			func(_ *Config) {},
+			&entry.Entry{
+				Body: `<log level="info">started</log>`,
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"tag":        "log",
+					"content":    "started",
+					"attributes": map[string]any{"level": "info"},
+				},
+				Body: `<log level="info">started</log>`,
+			},
+		},
+		{
+			"full-multiline",
+			func(_ *Config) {},
+			&entry.Entry{
+				Body: multiline,
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"tag":        "event",
+					"attributes": map[string]any{"id": "42"},
+					"children": []any{
+						map[string]any{"tag": "time", "content": "1136214245"},
+						map[string]any{"tag": "level", "content": "error"},
+						map[string]any{"tag": "message", "content": "disk full"},
+					},
+				},
+				Body: multiline,
+			},
+		},
+		{
+			"simplified-with-timestamp-and-severity",
+			func(c *Config) {
+				c.Mode = ModeSimplified
+				timeFrom := entry.NewAttributeField("event", "time")
+				c.TimeParser = &helper.TimeParser{
+					ParseFrom:  &timeFrom,
+					LayoutType: "epoch",
+					Layout:     "s",
+				}
+				severityFrom := entry.NewAttributeField("event", "level")
+				severity := helper.NewSeverityConfig()
+				severity.ParseFrom = &severityFrom
+				c.SeverityConfig = &severity
+			},
+			&entry.Entry{
+				Body: multiline,
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"event": map[string]any{
+						"time":    "1136214245",
+						"level":   "error",
+						"message": "disk full",
+					},
+				},
+				Body:         multiline,
+				Timestamp:    time.Unix(1136214245, 0),
+				Severity:     entry.Error,
+				SeverityText: "error",
+			},
+		},
+		{
+			"parse-to-body",
+			func(c *Config) {
+				c.Mode = ModeSimplified
+				c.ParseTo = entry.RootableField{Field: entry.NewBodyField()}
+			},
+			&entry.Entry{
+				Body: []byte("<log><message>started</message></log>"),
+			},
+			&entry.Entry{
+				Body: map[string]any{
+					"log": map[string]any{"message": "started"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			tc.configure(cfg)
+
+			set := componenttest.NewNopTelemetrySettings()
+			op, err := cfg.Build(set)
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			ots := time.Now()
+			tc.input.ObservedTimestamp = ots
+			tc.expect.ObservedTimestamp = ots
+
+			err = op.Process(context.Background(), tc.input)
+			require.NoError(t, err)
+			fake.ExpectEntry(t, tc.expect)
+		})
+	}
+}
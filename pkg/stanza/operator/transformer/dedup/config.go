@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dedup // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/dedup"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType = "dedup"
+
+	// FirstObservedTimestampAttribute is the attribute set to the observed timestamp of the first deduplicated entry.
+	FirstObservedTimestampAttribute = "first_observed_timestamp"
+	// LastObservedTimestampAttribute is the attribute set to the observed timestamp of the last deduplicated entry.
+	LastObservedTimestampAttribute = "last_observed_timestamp"
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new dedup config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new dedup config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		TransformerConfig: helper.NewTransformerConfig(operatorID, operatorType),
+		Interval:          10 * time.Second,
+		CountField:        entry.NewAttributeField("log_count"),
+		MaxKeys:           1000,
+	}
+}
+
+// Config is the configuration of a dedup operator
+type Config struct {
+	helper.TransformerConfig `mapstructure:",squash"`
+	Fields                   []entry.Field `mapstructure:"fields"`
+	Interval                 time.Duration `mapstructure:"interval"`
+	CountField               entry.Field   `mapstructure:"count_field"`
+	MaxKeys                  int           `mapstructure:"max_keys"`
+}
+
+// Build creates a new Transformer from a config
+func (c *Config) Build(set component.TelemetrySettings) (operator.Operator, error) {
+	transformer, err := c.TransformerConfig.Build(set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transformer config: %w", err)
+	}
+
+	if c.Interval <= 0 {
+		return nil, errors.New("'interval' must be greater than 0")
+	}
+
+	if c.MaxKeys <= 0 {
+		return nil, errors.New("'max_keys' must be greater than 0")
+	}
+
+	if c.CountField.FieldInterface == nil {
+		return nil, errors.New("missing required argument 'count_field'")
+	}
+
+	fields := c.Fields
+	if len(fields) == 0 {
+		fields = []entry.Field{entry.NewBodyField()}
+	}
+
+	// Expired windows are checked for 5 times per interval
+	checkPeriod := c.Interval / 5
+	if checkPeriod <= 0 {
+		checkPeriod = c.Interval
+	}
+
+	return &Transformer{
+		TransformerOperator: transformer,
+		fields:              fields,
+		interval:            c.Interval,
+		countField:          c.CountField,
+		maxKeys:             c.MaxKeys,
+		aggregates:          make(map[string]*aggregate),
+		ticker:              time.NewTicker(checkPeriod),
+		chClose:             make(chan struct{}),
+	}, nil
+}
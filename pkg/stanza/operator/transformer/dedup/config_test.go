@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dedup
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+func TestUnmarshal(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:   "default",
+				Expect: NewConfig(),
+			},
+			{
+				Name: "count_field",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.CountField = entry.NewBodyField("count")
+					return cfg
+				}(),
+			},
+			{
+				Name: "fields",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Fields = []entry.Field{
+						entry.NewBodyField(),
+						entry.NewAttributeField("log.file.path"),
+					}
+					return cfg
+				}(),
+			},
+			{
+				Name: "interval",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Interval = time.Minute
+					return cfg
+				}(),
+			},
+			{
+				Name: "max_keys",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.MaxKeys = 50
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dedup // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/dedup"
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// Transformer is an operator that collapses entries sharing the same key fields
+// within a time window into a single entry carrying their count.
+type Transformer struct {
+	helper.TransformerOperator
+	fields     []entry.Field
+	interval   time.Duration
+	countField entry.Field
+	maxKeys    int
+	ticker     *time.Ticker
+	chClose    chan struct{}
+
+	sync.Mutex
+	aggregates map[string]*aggregate
+	sequence   uint64
+}
+
+// aggregate holds the first entry of a window and the status info of its duplicates
+type aggregate struct {
+	baseEntry     *entry.Entry
+	count         int64
+	firstObserved time.Time
+	lastObserved  time.Time
+	windowStart   time.Time
+	sequence      uint64
+}
+
+func (t *Transformer) Start(_ operator.Persister) error {
+	go t.flushLoop()
+	return nil
+}
+
+func (t *Transformer) flushLoop() {
+	for {
+		select {
+		case <-t.ticker.C:
+			t.Lock()
+			expired := time.Now().Add(-t.interval)
+			if err := t.flush(context.Background(), func(a *aggregate) bool {
+				return !a.windowStart.After(expired)
+			}); err != nil {
+				t.Logger().Error("there was error flushing deduplicated logs", zap.Error(err))
+			}
+			t.Unlock()
+		case <-t.chClose:
+			t.ticker.Stop()
+			return
+		}
+	}
+}
+
+func (t *Transformer) Stop() error {
+	t.Lock()
+	defer t.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := t.flushAll(ctx); err != nil {
+		t.Logger().Error("there was error flushing deduplicated logs", zap.Error(err))
+	}
+
+	close(t.chClose)
+	return nil
+}
+
+func (t *Transformer) ProcessBatch(ctx context.Context, entries []*entry.Entry) error {
+	return t.ProcessBatchWith(ctx, entries, t.Process)
+}
+
+func (t *Transformer) Process(ctx context.Context, e *entry.Entry) error {
+	skip, err := t.Skip(ctx, e)
+	if err != nil {
+		return t.HandleEntryError(ctx, e, err)
+	}
+	if skip {
+		return t.Write(ctx, e)
+	}
+
+	key, err := t.key(e)
+	if err != nil {
+		return t.HandleEntryError(ctx, e, err)
+	}
+
+	// Lock the dedup operator because process can't run concurrently
+	t.Lock()
+	defer t.Unlock()
+
+	if a, ok := t.aggregates[key]; ok {
+		a.count++
+		if e.ObservedTimestamp.After(a.lastObserved) {
+			a.lastObserved = e.ObservedTimestamp
+		}
+		return nil
+	}
+
+	if len(t.aggregates) >= t.maxKeys {
+		t.Logger().Warn("Too many distinct keys. Flushing all deduplicated logs. Consider increasing max_keys parameter")
+		if err := t.flushAll(ctx); err != nil {
+			t.Logger().Error("there was error flushing deduplicated logs", zap.Error(err))
+		}
+	}
+
+	t.sequence++
+	t.aggregates[key] = &aggregate{
+		baseEntry:     e,
+		count:         1,
+		firstObserved: e.ObservedTimestamp,
+		lastObserved:  e.ObservedTimestamp,
+		windowStart:   time.Now(),
+		sequence:      t.sequence,
+	}
+	return nil
+}
+
+// key returns the identity of an entry, built from the values of its key fields.
+// Missing fields are part of the key as null values.
+func (t *Transformer) key(e *entry.Entry) (string, error) {
+	values := make([]any, len(t.fields))
+	for i, field := range t.fields {
+		values[i], _ = e.Get(field)
+	}
+	key, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("build deduplication key: %w", err)
+	}
+	return string(key), nil
+}
+
+// flushAll emits all the aggregated entries.
+func (t *Transformer) flushAll(ctx context.Context) error {
+	return t.flush(ctx, func(*aggregate) bool { return true })
+}
+
+// flush emits the aggregated entries selected by the given function,
+// in the order their first entry was observed.
+func (t *Transformer) flush(ctx context.Context, selected func(*aggregate) bool) error {
+	var flushed []*aggregate
+	for key, a := range t.aggregates {
+		if !selected(a) {
+			continue
+		}
+		flushed = append(flushed, a)
+		delete(t.aggregates, key)
+	}
+	slices.SortFunc(flushed, func(a, b *aggregate) int {
+		return cmp.Compare(a.sequence, b.sequence)
+	})
+
+	var errs error
+	for _, a := range flushed {
+		errs = multierr.Append(errs, t.emit(ctx, a))
+	}
+	return errs
+}
+
+// emit sets the count and the observed timestamps on the first entry of the
+// window, then forwards it to the next operator in the pipeline.
+func (t *Transformer) emit(ctx context.Context, a *aggregate) error {
+	e := a.baseEntry
+	if err := e.Set(t.countField, a.count); err != nil {
+		return t.HandleEntryError(ctx, e, err)
+	}
+	if err := e.Set(entry.NewAttributeField(FirstObservedTimestampAttribute), a.firstObserved.UTC().Format(time.RFC3339Nano)); err != nil {
+		return t.HandleEntryError(ctx, e, err)
+	}
+	if err := e.Set(entry.NewAttributeField(LastObservedTimestampAttribute), a.lastObserved.UTC().Format(time.RFC3339Nano)); err != nil {
+		return t.HandleEntryError(ctx, e, err)
+	}
+	return t.Write(ctx, e)
+}
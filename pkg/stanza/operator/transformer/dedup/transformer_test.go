@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dedup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+var t0 = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newEntry(body any, attributes map[string]any, observed time.Time) *entry.Entry {
+	e := entry.New()
+	e.Body = body
+	e.Attributes = attributes
+	e.ObservedTimestamp = observed
+	return e
+}
+
+func aggregated(body any, attributes map[string]any, count int64, first, last time.Time) *entry.Entry {
+	e := newEntry(body, attributes, first)
+	if e.Attributes == nil {
+		e.Attributes = map[string]any{}
+	}
+	e.Attributes["log_count"] = count
+	e.Attributes[FirstObservedTimestampAttribute] = first.Format(time.RFC3339Nano)
+	e.Attributes[LastObservedTimestampAttribute] = last.Format(time.RFC3339Nano)
+	return e
+}
+
+func TestBuild(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		expectErr string
+	}{
+		{
+			"default",
+			func(_ *Config) {},
+			"",
+		},
+		{
+			"invalid-interval",
+			func(c *Config) {
+				c.Interval = 0
+			},
+			"'interval' must be greater than 0",
+		},
+		{
+			"invalid-max-keys",
+			func(c *Config) {
+				c.MaxKeys = 0
+			},
+			"'max_keys' must be greater than 0",
+		},
+		{
+			"missing-count-field",
+			func(c *Config) {
+				c.CountField = entry.Field{}
+			},
+			"missing required argument 'count_field'",
+		},
+		{
+			"invalid-if",
+			func(c *Config) {
+				c.IfExpr = "body =="
+			},
+			"failed to compile expression",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			tc.configure(cfg)
+			op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.IsType(t, &Transformer{}, op)
+		})
+	}
+}
+
+func TestTransformer(t *testing.T) {
+	cases := []struct {
+		name      string
+		configure func(*Config)
+		input     []*entry.Entry
+		expected  []*entry.Entry
+	}{
+		{
+			"identical-bodies",
+			func(_ *Config) {},
+			[]*entry.Entry{
+				newEntry("stack trace", nil, t0),
+				newEntry("other", nil, t0.Add(time.Second)),
+				newEntry("stack trace", nil, t0.Add(2*time.Second)),
+				newEntry("stack trace", nil, t0.Add(3*time.Second)),
+			},
+			[]*entry.Entry{
+				aggregated("stack trace", nil, 3, t0, t0.Add(3*time.Second)),
+				aggregated("other", nil, 1, t0.Add(time.Second), t0.Add(time.Second)),
+			},
+		},
+		{
+			"map-bodies",
+			func(_ *Config) {},
+			[]*entry.Entry{
+				newEntry(map[string]any{"msg": "failed", "code": 1}, nil, t0),
+				newEntry(map[string]any{"code": 1, "msg": "failed"}, nil, t0.Add(time.Second)),
+			},
+			[]*entry.Entry{
+				aggregated(map[string]any{"msg": "failed", "code": 1}, nil, 2, t0, t0.Add(time.Second)),
+			},
+		},
+		{
+			"key-fields",
+			func(c *Config) {
+				c.Fields = []entry.Field{entry.NewAttributeField("pod")}
+			},
+			[]*entry.Entry{
+				newEntry("crash 1", map[string]any{"pod": "a", "line": 1}, t0),
+				newEntry("crash 2", map[string]any{"pod": "a", "line": 2}, t0.Add(time.Second)),
+				newEntry("crash 1", map[string]any{"pod": "b", "line": 1}, t0.Add(2*time.Second)),
+				newEntry("crash 3", map[string]any{"line": 3}, t0.Add(3*time.Second)),
+			},
+			[]*entry.Entry{
+				aggregated("crash 1", map[string]any{"pod": "a", "line": 1}, 2, t0, t0.Add(time.Second)),
+				aggregated("crash 1", map[string]any{"pod": "b", "line": 1}, 1, t0.Add(2*time.Second), t0.Add(2*time.Second)),
+				aggregated("crash 3", map[string]any{"line": 3}, 1, t0.Add(3*time.Second), t0.Add(3*time.Second)),
+			},
+		},
+		{
+			"count-field",
+			func(c *Config) {
+				c.Fields = []entry.Field{entry.NewBodyField("msg")}
+				c.CountField = entry.NewBodyField("count")
+			},
+			[]*entry.Entry{
+				newEntry(map[string]any{"msg": "failed"}, nil, t0),
+				newEntry(map[string]any{"msg": "failed"}, nil, t0),
+			},
+			[]*entry.Entry{
+				func() *entry.Entry {
+					e := aggregated(map[string]any{"msg": "failed", "count": int64(2)}, nil, 2, t0, t0)
+					delete(e.Attributes, "log_count")
+					return e
+				}(),
+			},
+		},
+		{
+			"if-expression",
+			func(c *Config) {
+				c.IfExpr = `body == "repeated"`
+			},
+			[]*entry.Entry{
+				newEntry("unique", nil, t0),
+				newEntry("repeated", nil, t0),
+				newEntry("unique", nil, t0),
+				newEntry("repeated", nil, t0.Add(time.Second)),
+			},
+			[]*entry.Entry{
+				newEntry("unique", nil, t0),
+				newEntry("unique", nil, t0),
+				aggregated("repeated", nil, 2, t0, t0.Add(time.Second)),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			cfg.Interval = time.Hour
+			tc.configure(cfg)
+
+			op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+			require.NoError(t, op.Start(testutil.NewUnscopedMockPersister()))
+
+			require.NoError(t, op.ProcessBatch(context.Background(), tc.input))
+			require.NoError(t, op.Stop())
+
+			for _, expected := range tc.expected {
+				fake.ExpectEntry(t, expected)
+			}
+			fake.ExpectNoEntry(t, 10*time.Millisecond)
+		})
+	}
+}
+
+func TestFlushAfterInterval(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	cfg.Interval = 100 * time.Millisecond
+	op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	require.NoError(t, op.Start(testutil.NewUnscopedMockPersister()))
+	defer func() { require.NoError(t, op.Stop()) }()
+
+	ctx := context.Background()
+	require.NoError(t, op.Process(ctx, newEntry("stack trace", nil, t0)))
+	require.NoError(t, op.Process(ctx, newEntry("stack trace", nil, t0.Add(time.Second))))
+	fake.ExpectNoEntry(t, 50*time.Millisecond)
+
+	fake.ExpectEntry(t, aggregated("stack trace", nil, 2, t0, t0.Add(time.Second)))
+
+	// A new window starts after the flush
+	require.NoError(t, op.Process(ctx, newEntry("stack trace", nil, t0.Add(2*time.Second))))
+	fake.ExpectEntry(t, aggregated("stack trace", nil, 1, t0.Add(2*time.Second), t0.Add(2*time.Second)))
+}
+
+func TestMaxKeys(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	cfg.Interval = time.Hour
+	cfg.MaxKeys = 2
+	op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	require.NoError(t, op.Start(testutil.NewUnscopedMockPersister()))
+	defer func() { require.NoError(t, op.Stop()) }()
+
+	ctx := context.Background()
+	require.NoError(t, op.Process(ctx, newEntry("a", nil, t0)))
+	require.NoError(t, op.Process(ctx, newEntry("b", nil, t0)))
+	fake.ExpectNoEntry(t, 10*time.Millisecond)
+
+	require.NoError(t, op.Process(ctx, newEntry("c", nil, t0)))
+	fake.ExpectEntry(t, aggregated("a", nil, 1, t0, t0))
+	fake.ExpectEntry(t, aggregated("b", nil, 1, t0, t0))
+	fake.ExpectNoEntry(t, 10*time.Millisecond)
+}
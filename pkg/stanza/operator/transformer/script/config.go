@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package script // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/script"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const operatorType = "script"
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new script config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new script config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		TransformerConfig: helper.NewTransformerConfig(operatorID, operatorType),
+	}
+}
+
+// Config is the configuration of a script operator
+type Config struct {
+	helper.TransformerConfig `mapstructure:",squash"`
+	Statements               []string `mapstructure:"statements"`
+}
+
+// Build will build a script operator from the supplied configuration
+func (c Config) Build(set component.TelemetrySettings) (operator.Operator, error) {
+	transformerOperator, err := c.TransformerConfig.Build(set)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(c.Statements) == 0 {
+		return nil, errors.New("script: missing statements")
+	}
+
+	parser, err := ottllog.NewParser(ottlfuncs.StandardFuncs[ottllog.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+	statements, err := parser.ParseStatements(c.Statements)
+	if err != nil {
+		return nil, fmt.Errorf("script: failed to parse statements: %w", err)
+	}
+
+	// Errors are handled by the operator, according to its on_error setting
+	return &Transformer{
+		TransformerOperator: transformerOperator,
+		statements:          ottllog.NewStatementSequence(statements, set, ottllog.WithStatementSequenceErrorMode(ottl.PropagateError)),
+	}, nil
+}
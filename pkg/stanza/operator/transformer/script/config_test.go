@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package script
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+func TestUnmarshal(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:   "default",
+				Expect: NewConfig(),
+			},
+			{
+				Name: "if",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.IfExpr = `body matches "^ERROR"`
+					cfg.Statements = []string{
+						"set(severity_number, SEVERITY_NUMBER_ERROR)",
+					}
+					return cfg
+				}(),
+			},
+			{
+				Name: "on_error_drop",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.OnError = helper.DropOnError
+					cfg.Statements = []string{
+						`set(attributes["parsed"], ParseJSON(body))`,
+					}
+					return cfg
+				}(),
+			},
+			{
+				Name: "statements",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Statements = []string{
+						`merge_maps(attributes, ParseJSON(body), "upsert")`,
+						`set(resource.attributes["service.name"], attributes["service"])`,
+						`delete_key(attributes, "service")`,
+					}
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package script // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/script"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/internal/converter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// Transformer executes OTTL log statements on entries
+type Transformer struct {
+	helper.TransformerOperator
+	statements ottl.StatementSequence[ottllog.TransformContext]
+}
+
+func (t *Transformer) ProcessBatch(ctx context.Context, entries []*entry.Entry) error {
+	return t.ProcessBatchWith(ctx, entries, t.Process)
+}
+
+// Process will process an entry with the OTTL statements.
+func (t *Transformer) Process(ctx context.Context, e *entry.Entry) error {
+	return t.ProcessWith(ctx, e, func(e *entry.Entry) error {
+		return t.Transform(ctx, e)
+	})
+}
+
+// Transform converts an entry to a log record, executes the OTTL statements
+// on it, and converts the result back into the entry. The values which were not
+// modified by the statements are kept as they are in the entry, as the conversion
+// to a log record doesn't preserve their types and the default severity text.
+func (t *Transformer) Transform(ctx context.Context, e *entry.Entry) error {
+	logs := plog.NewLogs()
+	resourceLogs := logs.ResourceLogs().AppendEmpty()
+	converter.UpsertToMap(e.Resource, resourceLogs.Resource().Attributes())
+	scopeLogs := resourceLogs.ScopeLogs().AppendEmpty()
+	scopeLogs.Scope().SetName(e.ScopeName)
+	logRecord := scopeLogs.LogRecords().AppendEmpty()
+	converter.ToLogRecord(e, logRecord)
+
+	original := plog.NewLogRecord()
+	logRecord.CopyTo(original)
+	originalResource := pcommon.NewMap()
+	resourceLogs.Resource().Attributes().CopyTo(originalResource)
+
+	tCtx := ottllog.NewTransformContext(logRecord, scopeLogs.Scope(), resourceLogs.Resource(), scopeLogs, resourceLogs)
+	if err := t.statements.Execute(ctx, tCtx); err != nil {
+		return err
+	}
+
+	result := entry.Entry{
+		ScopeName: scopeLogs.Scope().Name(),
+	}
+	if resource := resourceLogs.Resource().Attributes(); resource.Len() > 0 {
+		result.Resource = unmodifiedValues(e.Resource, originalResource, resource)
+	}
+	converter.FromLogRecord(logRecord, &result)
+	result.Attributes = unmodifiedValues(e.Attributes, original.Attributes(), logRecord.Attributes())
+
+	if logRecord.Body().Equal(original.Body()) {
+		result.Body = e.Body
+	}
+	if logRecord.Timestamp() == original.Timestamp() {
+		result.Timestamp = e.Timestamp
+	}
+	if logRecord.ObservedTimestamp() == original.ObservedTimestamp() {
+		result.ObservedTimestamp = e.ObservedTimestamp
+	}
+	if logRecord.SeverityNumber() == original.SeverityNumber() {
+		result.Severity = e.Severity
+	}
+	if logRecord.SeverityText() == original.SeverityText() {
+		result.SeverityText = e.SeverityText
+	}
+	if logRecord.TraceID() == original.TraceID() {
+		result.TraceID = e.TraceID
+	}
+	if logRecord.SpanID() == original.SpanID() {
+		result.SpanID = e.SpanID
+	}
+	if logRecord.Flags() == original.Flags() {
+		result.TraceFlags = e.TraceFlags
+	}
+
+	*e = result
+	return nil
+}
+
+// unmodifiedValues returns the values of the modified map, the values of the entry
+// being used for the keys whose values are equal to the ones of the original map.
+func unmodifiedValues(values map[string]any, original pcommon.Map, modified pcommon.Map) map[string]any {
+	if modified.Equal(original) {
+		return values
+	}
+	result := make(map[string]any, modified.Len())
+	modified.Range(func(k string, v pcommon.Value) bool {
+		if originalValue, ok := original.Get(k); ok && originalValue.Equal(v) {
+			if value, ok := values[k]; ok {
+				result[k] = value
+				return true
+			}
+		}
+		result[k] = v.AsRaw()
+		return true
+	})
+	return result
+}
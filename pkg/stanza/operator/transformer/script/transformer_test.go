@@ -0,0 +1,280 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package script
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+var observed = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestEntry() *entry.Entry {
+	e := entry.New()
+	e.ObservedTimestamp = observed
+	e.Body = `{"level":"error","message":"disk full","service":"checkout"}`
+	e.Attributes = map[string]any{
+		"log.file.name": "app.log",
+	}
+	return e
+}
+
+func TestBuild(t *testing.T) {
+	cases := []struct {
+		name       string
+		statements []string
+		expectErr  string
+	}{
+		{
+			"valid",
+			[]string{`set(attributes["a"], "b")`},
+			"",
+		},
+		{
+			"missing-statements",
+			nil,
+			"script: missing statements",
+		},
+		{
+			"invalid-statement",
+			[]string{`set(attributes["a"]`},
+			"script: failed to parse statements",
+		},
+		{
+			"unknown-function",
+			[]string{`unknown(attributes["a"])`},
+			"script: failed to parse statements",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.Statements = tc.statements
+			op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+			if tc.expectErr != "" {
+				require.ErrorContains(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.IsType(t, &Transformer{}, op)
+		})
+	}
+}
+
+func TestProcess(t *testing.T) {
+	cases := []struct {
+		name       string
+		ifExpr     string
+		statements []string
+		input      func() *entry.Entry
+		output     func() *entry.Entry
+	}{
+		{
+			"parse-body",
+			"",
+			[]string{
+				`merge_maps(attributes, ParseJSON(body), "upsert")`,
+				`set(body, attributes["message"])`,
+				`delete_key(attributes, "message")`,
+			},
+			newTestEntry,
+			func() *entry.Entry {
+				e := newTestEntry()
+				e.Body = "disk full"
+				e.Attributes = map[string]any{
+					"log.file.name": "app.log",
+					"level":         "error",
+					"service":       "checkout",
+				}
+				return e
+			},
+		},
+		{
+			"severity",
+			"",
+			[]string{
+				`set(severity_number, SEVERITY_NUMBER_ERROR) where IsMatch(body, "\"level\":\"error\"")`,
+				`set(severity_text, "error") where severity_number == SEVERITY_NUMBER_ERROR`,
+			},
+			newTestEntry,
+			func() *entry.Entry {
+				e := newTestEntry()
+				e.Severity = entry.Error
+				e.SeverityText = "error"
+				return e
+			},
+		},
+		{
+			"resource-and-scope",
+			"",
+			[]string{
+				`set(resource.attributes["service.name"], ParseJSON(body)["service"])`,
+				`set(instrumentation_scope.name, "filelog")`,
+			},
+			newTestEntry,
+			func() *entry.Entry {
+				e := newTestEntry()
+				e.Resource = map[string]any{
+					"service.name": "checkout",
+				}
+				e.ScopeName = "filelog"
+				return e
+			},
+		},
+		{
+			"trace-context",
+			"",
+			[]string{
+				`set(trace_id.string, "480140f3d770a5ae32f0a22b6a812cff")`,
+				`set(span_id.string, "92c3792d7ba9d571")`,
+			},
+			newTestEntry,
+			func() *entry.Entry {
+				e := newTestEntry()
+				e.TraceID = []byte{0x48, 0x01, 0x40, 0xf3, 0xd7, 0x70, 0xa5, 0xae, 0x32, 0xf0, 0xa2, 0x2b, 0x6a, 0x81, 0x2c, 0xff}
+				e.SpanID = []byte{0x92, 0xc3, 0x79, 0x2d, 0x7b, 0xa9, 0xd5, 0x71}
+				return e
+			},
+		},
+		{
+			"unmatched-where",
+			"",
+			[]string{
+				`set(attributes["matched"], true) where attributes["log.file.name"] == "other.log"`,
+			},
+			newTestEntry,
+			newTestEntry,
+		},
+		{
+			"unmatched-if",
+			`attributes["log.file.name"] == "other.log"`,
+			[]string{
+				`set(attributes["matched"], true)`,
+			},
+			newTestEntry,
+			newTestEntry,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			cfg.IfExpr = tc.ifExpr
+			cfg.Statements = tc.statements
+
+			op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			require.NoError(t, op.Process(context.Background(), tc.input()))
+			fake.ExpectEntry(t, tc.output())
+		})
+	}
+}
+
+func TestProcessRoundTrip(t *testing.T) {
+	newEntry := func() *entry.Entry {
+		e := entry.New()
+		e.ObservedTimestamp = observed
+		e.Severity = entry.Info
+		e.Body = map[string]any{
+			"count":   3,
+			"ratio":   float32(0.5),
+			"message": "started",
+		}
+		e.Attributes = map[string]any{
+			"log.file.name": "app.log",
+			"attempt":       uint8(2),
+			"tags":          []string{"a", "b"},
+			"nested":        map[string]any{"size": int32(4)},
+		}
+		e.Resource = map[string]any{
+			"pid": 42,
+		}
+		return e
+	}
+
+	cases := []struct {
+		name       string
+		statements []string
+		output     func() *entry.Entry
+	}{
+		{
+			"unmodified",
+			[]string{`set(attributes["matched"], true) where attributes["log.file.name"] == "other.log"`},
+			newEntry,
+		},
+		{
+			"modified-attribute",
+			[]string{`set(attributes["log.file.name"], "other.log")`},
+			func() *entry.Entry {
+				e := newEntry()
+				e.Attributes["log.file.name"] = "other.log"
+				return e
+			},
+		},
+		{
+			"modified-severity-number",
+			[]string{`set(severity_number, SEVERITY_NUMBER_ERROR)`},
+			func() *entry.Entry {
+				e := newEntry()
+				e.Severity = entry.Error
+				return e
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfigWithID("test")
+			cfg.OutputIDs = []string{"fake"}
+			cfg.Statements = tc.statements
+
+			op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			fake := testutil.NewFakeOutput(t)
+			require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+			require.NoError(t, op.Process(context.Background(), newEntry()))
+			fake.ExpectEntry(t, tc.output())
+		})
+	}
+}
+
+func TestProcessError(t *testing.T) {
+	cfg := NewConfigWithID("test")
+	cfg.OutputIDs = []string{"fake"}
+	cfg.Statements = []string{
+		`set(attributes["added"], true)`,
+		`merge_maps(attributes, ParseJSON(body), "upsert")`,
+	}
+
+	op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+
+	e := newTestEntry()
+	e.Body = "not json"
+	require.Error(t, op.Process(context.Background(), e))
+
+	// The entry is sent unchanged, following the default on_error setting
+	expected := newTestEntry()
+	expected.Body = "not json"
+	fake.ExpectEntry(t, expected)
+}
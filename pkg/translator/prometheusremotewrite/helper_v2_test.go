@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	prometheustranslator "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheus"
+)
+
+// seriesByLabels returns the time series of the converter keyed by their labels, resolved from
+// the symbols table, e.g. `__name__=test,le=1`.
+func seriesByLabels(t *testing.T, converter *prometheusConverterV2) map[string]*writev2.TimeSeries {
+	symbols := converter.symbolTable.Symbols()
+	out := make(map[string]*writev2.TimeSeries, len(converter.unique))
+	for _, ts := range converter.unique {
+		out[resolveLabelsRefs(t, ts.LabelsRefs, symbols)] = ts
+	}
+	return out
+}
+
+func resolveLabelsRefs(t *testing.T, refs []uint32, symbols []string) string {
+	require.Zero(t, len(refs)%2)
+	pairs := make([]string, 0, len(refs)/2)
+	for i := 0; i < len(refs); i += 2 {
+		pairs = append(pairs, symbols[refs[i]]+"="+symbols[refs[i+1]])
+	}
+	return strings.Join(pairs, ",")
+}
+
+func TestPrometheusConverterV2_addHistogramDataPoints(t *testing.T) {
+	start := pcommon.NewTimestampFromTime(time.Now().Add(-time.Minute))
+	ts := pcommon.NewTimestampFromTime(time.Now())
+
+	metric := getHistogramMetric("test_hist", pcommon.NewMap(), pmetric.AggregationTemporalityCumulative, uint64(ts), 6, 3, []float64{1, 5}, []uint64{1, 1, 1})
+	dp := metric.Histogram().DataPoints().At(0)
+	dp.SetStartTimestamp(start)
+	exemplar := dp.Exemplars().AppendEmpty()
+	exemplar.SetTimestamp(ts)
+	exemplar.SetDoubleValue(2)
+	exemplar.SetTraceID(pcommon.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10})
+
+	metadata := writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM}
+	converter := newPrometheusConverterV2()
+	converter.addHistogramDataPoints(metric.Histogram().DataPoints(), pcommon.NewResource(), Settings{}, metric.Name(), metadata)
+
+	series := seriesByLabels(t, converter)
+	want := map[string]float64{
+		"__name__=test_hist_sum":            6,
+		"__name__=test_hist_count":          3,
+		"__name__=test_hist_bucket,le=1":    1,
+		"__name__=test_hist_bucket,le=5":    2,
+		"__name__=test_hist_bucket,le=+Inf": 3,
+	}
+	require.Len(t, series, len(want))
+	for lbls, value := range want {
+		s, ok := series[lbls]
+		require.True(t, ok, "missing series %s", lbls)
+		require.Len(t, s.Samples, 1)
+		assert.Equal(t, writev2.Sample{Value: value, Timestamp: convertTimeStamp(ts)}, s.Samples[0])
+		assert.Equal(t, convertTimeStamp(start), s.CreatedTimestamp)
+		assert.Equal(t, metadata, s.Metadata)
+	}
+
+	// The exemplar belongs to the first bucket whose bound is greater than or equal to its value.
+	for lbls, s := range series {
+		if lbls != "__name__=test_hist_bucket,le=5" {
+			assert.Empty(t, s.Exemplars, lbls)
+			continue
+		}
+		require.Len(t, s.Exemplars, 1)
+		assert.Equal(t, float64(2), s.Exemplars[0].Value)
+		assert.Equal(t, prometheustranslator.ExemplarTraceIDKey+"=0102030405060708090a0b0c0d0e0f10",
+			resolveLabelsRefs(t, s.Exemplars[0].LabelsRefs, converter.symbolTable.Symbols()))
+	}
+}
+
+func TestPrometheusConverterV2_addSummaryDataPoints(t *testing.T) {
+	ts := pcommon.NewTimestampFromTime(time.Now())
+	quantiles := pmetric.NewSummaryDataPointValueAtQuantileSlice()
+	q := quantiles.AppendEmpty()
+	q.SetQuantile(0.5)
+	q.SetValue(2)
+	q = quantiles.AppendEmpty()
+	q.SetQuantile(0.9)
+	q.SetValue(4)
+	metric := getSummaryMetric("test_summary", pcommon.NewMap(), uint64(ts), 10, 5, quantiles)
+
+	converter := newPrometheusConverterV2()
+	converter.addSummaryDataPoints(metric.Summary().DataPoints(), pcommon.NewResource(), Settings{}, metric.Name(), writev2.Metadata{})
+
+	series := seriesByLabels(t, converter)
+	want := map[string]float64{
+		"__name__=test_summary_sum":          10,
+		"__name__=test_summary_count":        5,
+		"__name__=test_summary,quantile=0.5": 2,
+		"__name__=test_summary,quantile=0.9": 4,
+	}
+	require.Len(t, series, len(want))
+	for lbls, value := range want {
+		s, ok := series[lbls]
+		require.True(t, ok, "missing series %s", lbls)
+		assert.Equal(t, []writev2.Sample{{Value: value, Timestamp: convertTimeStamp(ts)}}, s.Samples)
+		// The start timestamp is not set, so is the created timestamp.
+		assert.Zero(t, s.CreatedTimestamp)
+	}
+}
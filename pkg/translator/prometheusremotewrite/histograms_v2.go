@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/prometheusremotewrite"
+
+import (
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/prompb"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func (c *prometheusConverterV2) addExponentialHistogramDataPoints(dataPoints pmetric.ExponentialHistogramDataPointSlice,
+	resource pcommon.Resource, settings Settings, baseName string, metadata writev2.Metadata,
+) error {
+	for x := 0; x < dataPoints.Len(); x++ {
+		pt := dataPoints.At(x)
+		lbls := createAttributes(
+			resource,
+			pt.Attributes(),
+			settings.ExternalLabels,
+			nil,
+			true,
+			model.MetricNameLabel,
+			baseName,
+		)
+
+		histogram, err := exponentialToNativeHistogramV2(pt)
+		if err != nil {
+			return err
+		}
+		ts := c.addHistogram(histogram, lbls, metadata, convertCreatedTimeStamp(pt.StartTimestamp()))
+
+		exemplars := getPromExemplarsV2[pmetric.ExponentialHistogramDataPoint](pt, &c.symbolTable)
+		ts.Exemplars = append(ts.Exemplars, exemplars...)
+	}
+
+	return nil
+}
+
+// exponentialToNativeHistogramV2 translates OTel Exponential Histogram data point
+// to Prometheus Native Histogram of the Remote Write 2.0 format.
+func exponentialToNativeHistogramV2(p pmetric.ExponentialHistogramDataPoint) (writev2.Histogram, error) {
+	h, err := exponentialToNativeHistogram(p)
+	if err != nil {
+		return writev2.Histogram{}, err
+	}
+
+	return writev2.Histogram{
+		// The reset hint is left unspecified for the same reasons it is UNKNOWN
+		// in exponentialToNativeHistogram.
+		ResetHint: writev2.Histogram_RESET_HINT_UNSPECIFIED,
+		Schema:    h.Schema,
+
+		ZeroCount:     &writev2.Histogram_ZeroCountInt{ZeroCountInt: h.GetZeroCountInt()},
+		ZeroThreshold: h.ZeroThreshold,
+
+		PositiveSpans:  convertBucketSpansV2(h.PositiveSpans),
+		PositiveDeltas: h.PositiveDeltas,
+		NegativeSpans:  convertBucketSpansV2(h.NegativeSpans),
+		NegativeDeltas: h.NegativeDeltas,
+
+		Count:     &writev2.Histogram_CountInt{CountInt: h.GetCountInt()},
+		Sum:       h.Sum,
+		Timestamp: h.Timestamp,
+	}, nil
+}
+
+func convertBucketSpansV2(spans []prompb.BucketSpan) []writev2.BucketSpan {
+	if spans == nil {
+		return nil
+	}
+	out := make([]writev2.BucketSpan, len(spans))
+	for i, span := range spans {
+		out[i] = writev2.BucketSpan{Offset: span.Offset, Length: span.Length}
+	}
+	return out
+}
@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewrite
+
+import (
+	"testing"
+	"time"
+
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestExponentialToNativeHistogramV2(t *testing.T) {
+	ts := pcommon.NewTimestampFromTime(time.Now())
+	pt := pmetric.NewExponentialHistogramDataPoint()
+	pt.SetTimestamp(ts)
+	pt.SetScale(1)
+	pt.SetCount(6)
+	pt.SetSum(10.1)
+	pt.SetZeroCount(1)
+	pt.Positive().SetOffset(1)
+	pt.Positive().BucketCounts().FromRaw([]uint64{1, 0, 2})
+	pt.Negative().SetOffset(0)
+	pt.Negative().BucketCounts().FromRaw([]uint64{2})
+
+	got, err := exponentialToNativeHistogramV2(pt)
+	require.NoError(t, err)
+
+	want, err := exponentialToNativeHistogram(pt)
+	require.NoError(t, err)
+	assert.Equal(t, writev2.Histogram_RESET_HINT_UNSPECIFIED, got.ResetHint)
+	assert.Equal(t, want.Schema, got.Schema)
+	assert.Equal(t, want.GetCountInt(), got.GetCountInt())
+	assert.Equal(t, want.GetZeroCountInt(), got.GetZeroCountInt())
+	assert.Equal(t, want.ZeroThreshold, got.ZeroThreshold)
+	assert.Equal(t, want.Sum, got.Sum)
+	assert.Equal(t, want.Timestamp, got.Timestamp)
+	assert.Equal(t, want.PositiveDeltas, got.PositiveDeltas)
+	assert.Equal(t, want.NegativeDeltas, got.NegativeDeltas)
+	require.Len(t, got.PositiveSpans, len(want.PositiveSpans))
+	for i, span := range want.PositiveSpans {
+		assert.Equal(t, writev2.BucketSpan{Offset: span.Offset, Length: span.Length}, got.PositiveSpans[i])
+	}
+	require.Len(t, got.NegativeSpans, len(want.NegativeSpans))
+	for i, span := range want.NegativeSpans {
+		assert.Equal(t, writev2.BucketSpan{Offset: span.Offset, Length: span.Length}, got.NegativeSpans[i])
+	}
+
+	pt.SetScale(-5)
+	_, err = exponentialToNativeHistogramV2(pt)
+	require.Error(t, err)
+}
+
+func TestPrometheusConverterV2_addExponentialHistogramDataPoints(t *testing.T) {
+	start := pcommon.NewTimestampFromTime(time.Now().Add(-time.Minute))
+	ts := pcommon.NewTimestampFromTime(time.Now())
+
+	metric := pmetric.NewMetric()
+	metric.SetName("test_exponential_histogram")
+	metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	pt := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+	pt.SetStartTimestamp(start)
+	pt.SetTimestamp(ts)
+	pt.SetCount(2)
+	pt.SetSum(3)
+	pt.Positive().BucketCounts().FromRaw([]uint64{2})
+	pt.Attributes().PutStr("attr", "test_attr")
+	exemplar := pt.Exemplars().AppendEmpty()
+	exemplar.SetTimestamp(ts)
+	exemplar.SetDoubleValue(1.5)
+
+	metadata := writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM}
+	converter := newPrometheusConverterV2()
+	require.NoError(t, converter.addExponentialHistogramDataPoints(
+		metric.ExponentialHistogram().DataPoints(),
+		pcommon.NewResource(),
+		Settings{},
+		metric.Name(),
+		metadata,
+	))
+
+	series := seriesByLabels(t, converter)
+	require.Len(t, series, 1)
+	s, ok := series["__name__=test_exponential_histogram,attr=test_attr"]
+	require.True(t, ok)
+	assert.Empty(t, s.Samples)
+	require.Len(t, s.Histograms, 1)
+	assert.Equal(t, uint64(2), s.Histograms[0].GetCountInt())
+	assert.Equal(t, float64(3), s.Histograms[0].Sum)
+	assert.Equal(t, convertTimeStamp(start), s.CreatedTimestamp)
+	assert.Equal(t, metadata, s.Metadata)
+	require.Len(t, s.Exemplars, 1)
+	assert.Equal(t, 1.5, s.Exemplars[0].Value)
+}
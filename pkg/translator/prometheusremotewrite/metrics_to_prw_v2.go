@@ -69,6 +69,7 @@ func (c *prometheusConverterV2) fromMetrics(md pmetric.Metrics, settings Setting
 				}
 
 				promName := prometheustranslator.BuildCompliantName(metric, settings.Namespace, settings.AddMetricSuffixes)
+				metadata := c.metadata(metric, settings)
 
 				// handle individual metrics based on type
 				//exhaustive:enforce
@@ -78,23 +79,41 @@ func (c *prometheusConverterV2) fromMetrics(md pmetric.Metrics, settings Setting
 					if dataPoints.Len() == 0 {
 						break
 					}
-					c.addGaugeNumberDataPoints(dataPoints, resource, settings, promName)
+					c.addGaugeNumberDataPoints(dataPoints, resource, settings, promName, metadata)
 				case pmetric.MetricTypeSum:
 					dataPoints := metric.Sum().DataPoints()
 					if dataPoints.Len() == 0 {
 						break
 					}
 					if !metric.Sum().IsMonotonic() {
-						c.addGaugeNumberDataPoints(dataPoints, resource, settings, promName)
+						c.addGaugeNumberDataPoints(dataPoints, resource, settings, promName, metadata)
 					} else {
-						c.addSumNumberDataPoints(dataPoints, resource, metric, settings, promName)
+						c.addSumNumberDataPoints(dataPoints, resource, metric, settings, promName, metadata)
 					}
 				case pmetric.MetricTypeHistogram:
-					// TODO implement
+					dataPoints := metric.Histogram().DataPoints()
+					if dataPoints.Len() == 0 {
+						break
+					}
+					c.addHistogramDataPoints(dataPoints, resource, settings, promName, metadata)
 				case pmetric.MetricTypeExponentialHistogram:
-					// TODO implement
+					dataPoints := metric.ExponentialHistogram().DataPoints()
+					if dataPoints.Len() == 0 {
+						break
+					}
+					errs = multierr.Append(errs, c.addExponentialHistogramDataPoints(
+						dataPoints,
+						resource,
+						settings,
+						promName,
+						metadata,
+					))
 				case pmetric.MetricTypeSummary:
-					// TODO implement
+					dataPoints := metric.Summary().DataPoints()
+					if dataPoints.Len() == 0 {
+						break
+					}
+					c.addSummaryDataPoints(dataPoints, resource, settings, promName, metadata)
 				default:
 					errs = multierr.Append(errs, errors.New("unsupported metric type"))
 				}
@@ -116,7 +135,44 @@ func (c *prometheusConverterV2) timeSeries() []writev2.TimeSeries {
 	return allTS
 }
 
-func (c *prometheusConverterV2) addSample(sample *writev2.Sample, lbls []prompb.Label) {
+// metadata returns the metadata of the time series of the metric. It is empty unless
+// settings.SendMetadata is set.
+func (c *prometheusConverterV2) metadata(metric pmetric.Metric, settings Settings) writev2.Metadata {
+	if !settings.SendMetadata {
+		return writev2.Metadata{}
+	}
+	return writev2.Metadata{
+		Type:    otelMetricTypeToPromMetricTypeV2(metric),
+		HelpRef: c.symbolTable.Symbolize(metric.Description()),
+		UnitRef: c.symbolTable.Symbolize(prometheustranslator.BuildCompliantPrometheusUnit(metric.Unit())),
+	}
+}
+
+// addSample creates the TimeSeries that corresponds to lbls and adds sample to it.
+// The created TimeSeries is returned.
+// If either lbls is nil/empty or sample is nil, nothing is done.
+func (c *prometheusConverterV2) addSample(sample *writev2.Sample, lbls []prompb.Label, metadata writev2.Metadata, createdTimestamp int64) *writev2.TimeSeries {
+	if sample == nil || len(lbls) == 0 {
+		// This shouldn't happen
+		return nil
+	}
+
+	ts := c.createTimeSeries(lbls, metadata, createdTimestamp)
+	ts.Samples = append(ts.Samples, *sample)
+	return ts
+}
+
+// addHistogram creates the TimeSeries that corresponds to lbls and adds the native histogram to it.
+// The created TimeSeries is returned.
+func (c *prometheusConverterV2) addHistogram(histogram writev2.Histogram, lbls []prompb.Label, metadata writev2.Metadata, createdTimestamp int64) *writev2.TimeSeries {
+	ts := c.createTimeSeries(lbls, metadata, createdTimestamp)
+	ts.Histograms = append(ts.Histograms, histogram)
+	return ts
+}
+
+// createTimeSeries creates the TimeSeries that corresponds to lbls, with its labels interned
+// in the symbols table. An existing TimeSeries with the same labels is replaced.
+func (c *prometheusConverterV2) createTimeSeries(lbls []prompb.Label, metadata writev2.Metadata, createdTimestamp int64) *writev2.TimeSeries {
 	buf := make([]uint32, 0, len(lbls)*2)
 
 	// TODO: Read the PRW spec to see if labels need to be sorted. If it is, then we need to sort in export code. If not, we can sort in the test. (@dashpole have more context on this)
@@ -131,9 +187,20 @@ func (c *prometheusConverterV2) addSample(sample *writev2.Sample, lbls []prompb.
 		off = c.symbolTable.Symbolize(l.Value)
 		buf = append(buf, off)
 	}
-	ts := writev2.TimeSeries{
-		LabelsRefs: buf,
-		Samples:    []writev2.Sample{*sample},
+	ts := &writev2.TimeSeries{
+		LabelsRefs:       buf,
+		Metadata:         metadata,
+		CreatedTimestamp: createdTimestamp,
+	}
+	c.unique[timeSeriesSignature(lbls)] = ts
+	return ts
+}
+
+// convertCreatedTimeStamp converts the start timestamp of a cumulative data point to the
+// created timestamp of its time series, which is left unset when the start timestamp is.
+func convertCreatedTimeStamp(startTimestamp pcommon.Timestamp) int64 {
+	if startTimestamp == 0 {
+		return 0
 	}
-	c.unique[timeSeriesSignature(lbls)] = &ts
+	return convertTimeStamp(startTimestamp)
 }
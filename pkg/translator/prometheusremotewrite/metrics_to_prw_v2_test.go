@@ -10,8 +10,10 @@ import (
 	"time"
 
 	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 )
 
 func TestFromMetricsV2(t *testing.T) {
@@ -45,3 +47,34 @@ func TestFromMetricsV2(t *testing.T) {
 	require.ElementsMatch(t, want, slices.Collect(maps.Values(tsMap)))
 	require.ElementsMatch(t, wantedSymbols, symbolsTable.Symbols())
 }
+
+func TestFromMetricsV2WithMetadata(t *testing.T) {
+	settings := Settings{
+		SendMetadata: true,
+	}
+
+	start := pcommon.NewTimestampFromTime(time.Now().Add(-time.Minute))
+	ts := pcommon.NewTimestampFromTime(time.Now())
+	md := pmetric.NewMetrics()
+	metric := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	metric.SetName("test_duration")
+	metric.SetDescription("The duration of the tests.")
+	metric.SetUnit("s")
+	metric.SetEmptySum().SetIsMonotonic(true)
+	metric.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := metric.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(1.5)
+
+	tsMap, symbolsTable, err := FromMetricsV2(md, settings)
+	require.NoError(t, err)
+	require.Len(t, tsMap, 1)
+	series := slices.Collect(maps.Values(tsMap))[0]
+	symbols := symbolsTable.Symbols()
+	assert.Equal(t, writev2.Metadata_METRIC_TYPE_COUNTER, series.Metadata.Type)
+	assert.Equal(t, "The duration of the tests.", symbols[series.Metadata.HelpRef])
+	assert.Equal(t, "seconds", symbols[series.Metadata.UnitRef])
+	assert.Equal(t, convertTimeStamp(start), series.CreatedTimestamp)
+	assert.Equal(t, []writev2.Sample{{Value: 1.5, Timestamp: convertTimeStamp(ts)}}, series.Samples)
+}
@@ -7,7 +7,6 @@ import (
 	"math"
 
 	"github.com/prometheus/common/model"
-	"github.com/prometheus/prometheus/model/timestamp"
 	"github.com/prometheus/prometheus/model/value"
 	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -15,7 +14,7 @@ import (
 )
 
 func (c *prometheusConverterV2) addGaugeNumberDataPoints(dataPoints pmetric.NumberDataPointSlice,
-	resource pcommon.Resource, settings Settings, name string,
+	resource pcommon.Resource, settings Settings, name string, metadata writev2.Metadata,
 ) {
 	for x := 0; x < dataPoints.Len(); x++ {
 		pt := dataPoints.At(x)
@@ -43,12 +42,12 @@ func (c *prometheusConverterV2) addGaugeNumberDataPoints(dataPoints pmetric.Numb
 		if pt.Flags().NoRecordedValue() {
 			sample.Value = math.Float64frombits(value.StaleNaN)
 		}
-		c.addSample(sample, labels)
+		c.addSample(sample, labels, metadata, 0)
 	}
 }
 
 func (c *prometheusConverterV2) addSumNumberDataPoints(dataPoints pmetric.NumberDataPointSlice,
-	resource pcommon.Resource, _ pmetric.Metric, settings Settings, name string,
+	resource pcommon.Resource, _ pmetric.Metric, settings Settings, name string, metadata writev2.Metadata,
 ) {
 	for x := 0; x < dataPoints.Len(); x++ {
 		pt := dataPoints.At(x)
@@ -75,34 +74,29 @@ func (c *prometheusConverterV2) addSumNumberDataPoints(dataPoints pmetric.Number
 		if pt.Flags().NoRecordedValue() {
 			sample.Value = math.Float64frombits(value.StaleNaN)
 		}
-		// TODO: properly add exemplars to the TimeSeries
-		c.addSample(sample, lbls)
+		ts := c.addSample(sample, lbls, metadata, convertCreatedTimeStamp(pt.StartTimestamp()))
+		if ts != nil {
+			exemplars := getPromExemplarsV2[pmetric.NumberDataPoint](pt, &c.symbolTable)
+			ts.Exemplars = append(ts.Exemplars, exemplars...)
+		}
 	}
 }
 
-// getPromExemplarsV2 returns a slice of writev2.Exemplar from pdata exemplars.
-func getPromExemplarsV2[T exemplarType](pt T) []writev2.Exemplar {
-	promExemplars := make([]writev2.Exemplar, 0, pt.Exemplars().Len())
-	for i := 0; i < pt.Exemplars().Len(); i++ {
-		exemplar := pt.Exemplars().At(i)
-
-		var promExemplar writev2.Exemplar
-
-		switch exemplar.ValueType() {
-		case pmetric.ExemplarValueTypeInt:
-			promExemplar = writev2.Exemplar{
-				Value:     float64(exemplar.IntValue()),
-				Timestamp: timestamp.FromTime(exemplar.Timestamp().AsTime()),
-			}
-		case pmetric.ExemplarValueTypeDouble:
-			promExemplar = writev2.Exemplar{
-				Value:     exemplar.DoubleValue(),
-				Timestamp: timestamp.FromTime(exemplar.Timestamp().AsTime()),
-			}
+// getPromExemplarsV2 returns a slice of writev2.Exemplar from pdata exemplars, with their
+// labels interned in the symbols table.
+func getPromExemplarsV2[T exemplarType](pt T, symbolTable *writev2.SymbolsTable) []writev2.Exemplar {
+	exemplars := getPromExemplars(pt)
+	promExemplars := make([]writev2.Exemplar, 0, len(exemplars))
+	for _, exemplar := range exemplars {
+		labelsRefs := make([]uint32, 0, len(exemplar.Labels)*2)
+		for _, l := range exemplar.Labels {
+			labelsRefs = append(labelsRefs, symbolTable.Symbolize(l.Name), symbolTable.Symbolize(l.Value))
 		}
-		// TODO append labels to promExemplar.Labels
-
-		promExemplars = append(promExemplars, promExemplar)
+		promExemplars = append(promExemplars, writev2.Exemplar{
+			LabelsRefs: labelsRefs,
+			Value:      exemplar.Value,
+			Timestamp:  exemplar.Timestamp,
+		})
 	}
 
 	return promExemplars
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -18,8 +19,27 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset/regexp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
 )
 
+var staticAnalysisLoggingFeatureGate = featuregate.GlobalRegistry().MustRegister("filter.staticAnalysisLogging", featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("When enabled, the processor logs a warning for each potential problem found by the OTTL static analysis of its conditions."),
+	featuregate.WithRegisterFromVersion("v0.126.0"),
+)
+
+// staticAnalysisLogging returns the option making the parsers log the problems found by the
+// static analysis, which does nothing unless the filter.staticAnalysisLogging feature gate is enabled.
+func staticAnalysisLogging[K any]() ottl.Option[K] {
+	if !staticAnalysisLoggingFeatureGate.IsEnabled() {
+		return func(*ottl.Parser[K]) {}
+	}
+	return ottl.EnableStaticAnalysisLogging[K]()
+}
+
 // Config defines configuration for Resource processor.
 type Config struct {
 	// ErrorMode determines how the processor reacts to errors that occur while processing an OTTL condition.
@@ -36,6 +56,10 @@ type Config struct {
 	Spans filterconfig.MatchConfig `mapstructure:"spans"`
 
 	Traces TraceFilters `mapstructure:"traces"`
+
+	// Functions declares reusable OTTL functions which can be called from any of the
+	// configured conditions, like any other converter.
+	Functions []ottl.FunctionDefinition `mapstructure:"functions"`
 }
 
 // MetricFilters filters by Metric properties.
@@ -279,27 +303,27 @@ func (cfg *Config) Validate() error {
 	var errors error
 
 	if cfg.Traces.SpanConditions != nil {
-		_, err := filterottl.NewBoolExprForSpan(cfg.Traces.SpanConditions, filterottl.StandardSpanFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := filterottl.NewBoolExprForSpanWithOptions(cfg.Traces.SpanConditions, filterottl.StandardSpanFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}, []ottl.Option[ottlspan.TransformContext]{ottl.WithFunctionDefinitions[ottlspan.TransformContext](cfg.Functions)})
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Traces.SpanEventConditions != nil {
-		_, err := filterottl.NewBoolExprForSpanEvent(cfg.Traces.SpanEventConditions, filterottl.StandardSpanEventFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := filterottl.NewBoolExprForSpanEventWithOptions(cfg.Traces.SpanEventConditions, filterottl.StandardSpanEventFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}, []ottl.Option[ottlspanevent.TransformContext]{ottl.WithFunctionDefinitions[ottlspanevent.TransformContext](cfg.Functions)})
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Metrics.MetricConditions != nil {
-		_, err := filterottl.NewBoolExprForMetric(cfg.Metrics.MetricConditions, filterottl.StandardMetricFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := filterottl.NewBoolExprForMetricWithOptions(cfg.Metrics.MetricConditions, filterottl.StandardMetricFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}, []ottl.Option[ottlmetric.TransformContext]{ottl.WithFunctionDefinitions[ottlmetric.TransformContext](cfg.Functions)})
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Metrics.DataPointConditions != nil {
-		_, err := filterottl.NewBoolExprForDataPoint(cfg.Metrics.DataPointConditions, filterottl.StandardDataPointFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := filterottl.NewBoolExprForDataPointWithOptions(cfg.Metrics.DataPointConditions, filterottl.StandardDataPointFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}, []ottl.Option[ottldatapoint.TransformContext]{ottl.WithFunctionDefinitions[ottldatapoint.TransformContext](cfg.Functions)})
 		errors = multierr.Append(errors, err)
 	}
 
 	if cfg.Logs.LogConditions != nil {
-		_, err := filterottl.NewBoolExprForLog(cfg.Logs.LogConditions, filterottl.StandardLogFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()})
+		_, err := filterottl.NewBoolExprForLogWithOptions(cfg.Logs.LogConditions, filterottl.StandardLogFuncs(), ottl.PropagateError, component.TelemetrySettings{Logger: zap.NewNop()}, []ottl.Option[ottllog.TransformContext]{ottl.WithFunctionDefinitions[ottllog.TransformContext](cfg.Functions)})
 		errors = multierr.Append(errors, err)
 	}
 
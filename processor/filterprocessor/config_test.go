@@ -876,6 +876,31 @@ func TestLoadingConfigOTTL(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "functions"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				Traces: TraceFilters{
+					SpanConditions: []string{
+						`IsNamed("pass")`,
+					},
+				},
+				Logs: LogFilters{
+					LogConditions: []string{
+						`IsNamed("pass")`,
+					},
+				},
+				Functions: []ottl.FunctionDefinition{
+					{
+						Name: "IsNamed",
+						Params: []ottl.ParameterDefinition{
+							{Name: "value", Type: ottl.StringParameter},
+						},
+						Expression: `attributes["name"] == value`,
+					},
+				},
+			},
+		},
 		{
 			id:           component.NewIDWithName(metadata.Type, "spans_mix_config"),
 			errorMessage: "cannot use ottl conditions and include/exclude for spans at the same time",
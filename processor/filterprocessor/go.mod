@@ -13,6 +13,7 @@ require (
 	go.opentelemetry.io/collector/confmap/xconfmap v0.125.1-0.20250508034258-ac520a5c14cc
 	go.opentelemetry.io/collector/consumer v1.31.1-0.20250508034258-ac520a5c14cc
 	go.opentelemetry.io/collector/consumer/consumertest v0.125.1-0.20250508034258-ac520a5c14cc
+	go.opentelemetry.io/collector/featuregate v1.31.1-0.20250508034258-ac520a5c14cc
 	go.opentelemetry.io/collector/pdata v1.31.1-0.20250508034258-ac520a5c14cc
 	go.opentelemetry.io/collector/pipeline v0.125.1-0.20250508034258-ac520a5c14cc
 	go.opentelemetry.io/collector/processor v1.31.1-0.20250508034258-ac520a5c14cc
@@ -64,7 +65,6 @@ require (
 	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
 	go.opentelemetry.io/collector/component/componentstatus v0.125.1-0.20250508034258-ac520a5c14cc // indirect
 	go.opentelemetry.io/collector/consumer/xconsumer v0.125.1-0.20250508034258-ac520a5c14cc // indirect
-	go.opentelemetry.io/collector/featuregate v1.31.1-0.20250508034258-ac520a5c14cc // indirect
 	go.opentelemetry.io/collector/internal/telemetry v0.125.1-0.20250508034258-ac520a5c14cc // indirect
 	go.opentelemetry.io/collector/pdata/pprofile v0.125.1-0.20250508034258-ac520a5c14cc // indirect
 	go.opentelemetry.io/collector/pdata/testdata v0.125.1-0.20250508034258-ac520a5c14cc // indirect
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/processor/processorhelper"
@@ -19,6 +20,8 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
@@ -706,6 +709,7 @@ func TestFilterLogProcessorWithOTTL(t *testing.T) {
 	tests := []struct {
 		name             string
 		conditions       []string
+		functions        []ottl.FunctionDefinition
 		filterEverything bool
 		want             func(ld plog.Logs)
 		errorMode        ottl.ErrorMode
@@ -750,10 +754,35 @@ func TestFilterLogProcessorWithOTTL(t *testing.T) {
 			want:      func(_ plog.Logs) {},
 			errorMode: ottl.IgnoreError,
 		},
+		{
+			name: "with user-defined functions",
+			conditions: []string{
+				`IsOperation(body, "A")`,
+			},
+			functions: []ottl.FunctionDefinition{
+				{
+					Name: "IsOperation",
+					Params: []ottl.ParameterDefinition{
+						{Name: "value", Type: ottl.AnyParameter},
+						{Name: "suffix", Type: ottl.StringParameter},
+					},
+					Expression: `value == Concat(["operation", suffix], "")`,
+				},
+			},
+			want: func(ld plog.Logs) {
+				ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().RemoveIf(func(log plog.LogRecord) bool {
+					return log.Body().AsString() == "operationA"
+				})
+				ld.ResourceLogs().At(0).ScopeLogs().At(1).LogRecords().RemoveIf(func(log plog.LogRecord) bool {
+					return log.Body().AsString() == "operationA"
+				})
+			},
+			errorMode: ottl.IgnoreError,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			processor, err := newFilterLogsProcessor(processortest.NewNopSettings(metadata.Type), &Config{Logs: LogFilters{LogConditions: tt.conditions}})
+			processor, err := newFilterLogsProcessor(processortest.NewNopSettings(metadata.Type), &Config{Logs: LogFilters{LogConditions: tt.conditions}, Functions: tt.functions})
 			assert.NoError(t, err)
 
 			got, err := processor.processLogs(context.Background(), constructLogs())
@@ -769,6 +798,28 @@ func TestFilterLogProcessorWithOTTL(t *testing.T) {
 	}
 }
 
+func TestFilterLogProcessorStaticAnalysisLogging(t *testing.T) {
+	cfg := &Config{Logs: LogFilters{LogConditions: []string{`severity_number == "INFO"`}}}
+	core, logs := observer.New(zap.WarnLevel)
+	set := processortest.NewNopSettings(metadata.Type)
+	set.Logger = zap.New(core)
+
+	_, err := newFilterLogsProcessor(set, cfg)
+	require.NoError(t, err)
+	assert.Empty(t, logs.FilterMessageSnippet("potential problem found in OTTL").All())
+
+	require.NoError(t, featuregate.GlobalRegistry().Set(staticAnalysisLoggingFeatureGate.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(staticAnalysisLoggingFeatureGate.ID(), false))
+	})
+	_, err = newFilterLogsProcessor(set, cfg)
+	require.NoError(t, err)
+
+	entries := logs.FilterMessageSnippet("potential problem found in OTTL").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, ottl.DiagnosticRuleTypeMismatch, entries[0].ContextMap()["rule"])
+}
+
 func TestFilterLogProcessorTelemetry(t *testing.T) {
 	tel := componenttest.NewTelemetry()
 	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
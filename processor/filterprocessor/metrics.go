@@ -21,6 +21,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filtermetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
@@ -48,14 +49,14 @@ func newFilterMetricProcessor(set processor.Settings, cfg *Config) (*filterMetri
 
 	if cfg.Metrics.MetricConditions != nil || cfg.Metrics.DataPointConditions != nil {
 		if cfg.Metrics.MetricConditions != nil {
-			fsp.skipMetricExpr, err = filterottl.NewBoolExprForMetric(cfg.Metrics.MetricConditions, filterottl.StandardMetricFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+			fsp.skipMetricExpr, err = filterottl.NewBoolExprForMetricWithOptions(cfg.Metrics.MetricConditions, filterottl.StandardMetricFuncs(), cfg.ErrorMode, set.TelemetrySettings, []ottl.Option[ottlmetric.TransformContext]{staticAnalysisLogging[ottlmetric.TransformContext](), ottl.WithFunctionDefinitions[ottlmetric.TransformContext](cfg.Functions)})
 			if err != nil {
 				return nil, err
 			}
 		}
 
 		if cfg.Metrics.DataPointConditions != nil {
-			fsp.skipDataPointExpr, err = filterottl.NewBoolExprForDataPoint(cfg.Metrics.DataPointConditions, filterottl.StandardDataPointFuncs(), cfg.ErrorMode, set.TelemetrySettings)
+			fsp.skipDataPointExpr, err = filterottl.NewBoolExprForDataPointWithOptions(cfg.Metrics.DataPointConditions, filterottl.StandardDataPointFuncs(), cfg.ErrorMode, set.TelemetrySettings, []ottl.Option[ottldatapoint.TransformContext]{staticAnalysisLogging[ottldatapoint.TransformContext](), ottl.WithFunctionDefinitions[ottldatapoint.TransformContext](cfg.Functions)})
 			if err != nil {
 				return nil, err
 			}
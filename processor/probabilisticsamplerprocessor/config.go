@@ -9,6 +9,7 @@ import (
 	"math"
 
 	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 )
@@ -93,40 +94,67 @@ type Config struct {
 
 	// SamplingPriority (logs only) enables using a log record attribute as the sampling priority of the log record.
 	SamplingPriority string `mapstructure:"sampling_priority"`
+
+	// SamplingRules (logs only) selects the sampling percentage of each log record from the first rule whose
+	// condition matches it. Log records matching no rule are sampled at SamplingPercentage. When rules are
+	// configured, the sampled log records get a `sampling.adjusted_count` attribute.
+	SamplingRules []SamplingRule `mapstructure:"sampling_rules"`
+}
+
+// SamplingRule selects a sampling percentage for the log records matching an OTTL condition.
+type SamplingRule struct {
+	// Condition is an OTTL condition in the log context, for instance `severity_number >= SEVERITY_NUMBER_ERROR`.
+	Condition string `mapstructure:"condition"`
+
+	// SamplingPercentage is the percentage rate at which the matching log records are sampled.
+	SamplingPercentage float32 `mapstructure:"sampling_percentage"`
 }
 
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the processor configuration is valid
 func (cfg *Config) Validate() error {
-	pct := float64(cfg.SamplingPercentage)
+	if err := validateSamplingPercentage(cfg.SamplingPercentage); err != nil {
+		return err
+	}
+
+	if cfg.AttributeSource != "" && !validAttributeSource[cfg.AttributeSource] {
+		return fmt.Errorf("invalid attribute source: %v. Expected: %v or %v", cfg.AttributeSource, traceIDAttributeSource, recordAttributeSource)
+	}
+
+	if cfg.SamplingPrecision == 0 {
+		return errors.New("invalid sampling precision: 0")
+	} else if cfg.SamplingPrecision > sampling.NumHexDigits {
+		return fmt.Errorf("sampling precision is too great, should be <= 14: %d", cfg.SamplingPrecision)
+	}
+
+	if len(cfg.SamplingRules) > 0 {
+		if _, err := newSamplingRules(cfg, component.TelemetrySettings{Logger: zap.NewNop()}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateSamplingPercentage(pct32 float32) error {
+	pct := float64(pct32)
 
 	if math.IsInf(pct, 0) || math.IsNaN(pct) {
-		return fmt.Errorf("sampling rate is invalid: %f%%", cfg.SamplingPercentage)
+		return fmt.Errorf("sampling rate is invalid: %f%%", pct32)
 	}
 	ratio := pct / 100.0
 
 	switch {
 	case ratio < 0:
-		return fmt.Errorf("sampling rate is negative: %f%%", cfg.SamplingPercentage)
+		return fmt.Errorf("sampling rate is negative: %f%%", pct32)
 	case ratio == 0:
 		// Special case
 	case ratio < sampling.MinSamplingProbability:
 		// Too-small case
-		return fmt.Errorf("sampling rate is too small: %g%%", cfg.SamplingPercentage)
+		return fmt.Errorf("sampling rate is too small: %g%%", pct32)
 	default:
 		// Note that ratio > 1 is specifically allowed by the README, taken to mean 100%
 	}
-
-	if cfg.AttributeSource != "" && !validAttributeSource[cfg.AttributeSource] {
-		return fmt.Errorf("invalid attribute source: %v. Expected: %v or %v", cfg.AttributeSource, traceIDAttributeSource, recordAttributeSource)
-	}
-
-	if cfg.SamplingPrecision == 0 {
-		return errors.New("invalid sampling precision: 0")
-	} else if cfg.SamplingPrecision > sampling.NumHexDigits {
-		return fmt.Errorf("sampling precision is too great, should be <= 14: %d", cfg.SamplingPrecision)
-	}
-
 	return nil
 }
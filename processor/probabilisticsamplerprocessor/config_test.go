@@ -46,6 +46,19 @@ func TestLoadConfig(t *testing.T) {
 				FailClosed:         true,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "rules"),
+			expected: &Config{
+				SamplingPercentage: 10,
+				SamplingPrecision:  defaultPrecision,
+				AttributeSource:    "traceID",
+				FailClosed:         true,
+				SamplingRules: []SamplingRule{
+					{Condition: "severity_number >= SEVERITY_NUMBER_ERROR", SamplingPercentage: 100},
+					{Condition: `resource.attributes["k8s.namespace.name"] == "noisy"`, SamplingPercentage: 1},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -78,6 +91,7 @@ func TestLoadInvalidConfig(t *testing.T) {
 		{"invalid_inf.yaml", "sampling rate is invalid: +Inf%"},
 		{"invalid_prec.yaml", "sampling precision is too great"},
 		{"invalid_zero.yaml", "invalid sampling precision"},
+		{"invalid_rule.yaml", "sampling rule 0"},
 	} {
 		t.Run(test.file, func(t *testing.T) {
 			factories, err := otelcoltest.NopFactories()
@@ -14,12 +14,14 @@ import (
 	"go.opentelemetry.io/collector/processor/processorhelper"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor/internal/metadata"
 )
 
 type logsProcessor struct {
 	sampler dataSampler
+	rules   []samplingRule
 
 	samplingPriority string
 	precision        int
@@ -190,7 +192,12 @@ func newLogsProcessor(ctx context.Context, set processor.Settings, nextConsumer
 	if err != nil {
 		return nil, err
 	}
+	rules, err := newSamplingRules(cfg, set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
 	lsp := &logsProcessor{
+		rules:            rules,
 		sampler:          makeSampler(cfg, true),
 		samplingPriority: cfg.SamplingPriority,
 		precision:        cfg.SamplingPrecision,
@@ -212,17 +219,26 @@ func (lsp *logsProcessor) processLogs(ctx context.Context, logsData plog.Logs) (
 	logsData.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
 		rl.ScopeLogs().RemoveIf(func(ill plog.ScopeLogs) bool {
 			ill.LogRecords().RemoveIf(func(l plog.LogRecord) bool {
-				return !commonShouldSampleLogic(
+				sampler := lsp.sampler
+				if len(lsp.rules) > 0 {
+					tCtx := ottllog.NewTransformContext(l, ill.Scope(), rl.Resource(), ill, rl)
+					sampler = selectSampler(ctx, lsp.rules, tCtx, lsp.sampler)
+				}
+				sampled, threshold := commonShouldSampleLogic(
 					ctx,
 					l,
-					lsp.sampler,
+					sampler,
 					lsp.failClosed,
-					lsp.sampler.randomnessFromLogRecord,
+					sampler.randomnessFromLogRecord,
 					lsp.priorityFunc,
 					"logs sampler",
 					lsp.logger,
 					lsp.telemetryBuilder.ProcessorProbabilisticSamplerCountLogsSampled,
 				)
+				if sampled && len(lsp.rules) > 0 {
+					l.Attributes().PutDouble(adjustedCountAttribute, recordedThreshold(l, threshold).AdjustedCount())
+				}
+				return !sampled
 			})
 			// Filter out empty ScopeLogs
 			return ill.LogRecords().Len() == 0
@@ -236,6 +252,17 @@ func (lsp *logsProcessor) processLogs(ctx context.Context, logsData plog.Logs) (
 	return logsData, nil
 }
 
+// recordedThreshold returns the threshold written to the sampled log record, which
+// is kept when it arrived with a higher threshold than the one the record is sampled with.
+func recordedThreshold(l plog.LogRecord, threshold sampling.Threshold) sampling.Threshold {
+	if tvalue, ok := l.Attributes().Get("sampling.threshold"); ok {
+		if th, err := sampling.TValueToThreshold(tvalue.Str()); err == nil {
+			return th
+		}
+	}
+	return threshold
+}
+
 func (lsp *logsProcessor) priorityFunc(logRec plog.LogRecord, rnd randomnessNamer, threshold sampling.Threshold) (randomnessNamer, sampling.Threshold) {
 	// Note: in logs, unlike traces, the sampling priority
 	// attribute is interpreted as a request to be sampled.
@@ -6,6 +6,8 @@ package probabilisticsamplerprocessor
 import (
 	"context"
 	"fmt"
+	"math"
+	"math/rand/v2"
 	"testing"
 	"time"
 
@@ -19,6 +21,7 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest/observer"
 
+	idutils "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/core/xidutils"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor/internal/metadata"
 )
@@ -45,6 +48,15 @@ func TestNewLogs(t *testing.T) {
 				HashSeed:           4321,
 			},
 		},
+		{
+			name:         "invalid_sampling_rule",
+			nextConsumer: consumertest.NewNop(),
+			cfg: &Config{
+				SamplingPercentage: 10,
+				SamplingRules:      []SamplingRule{{Condition: "severity_number >=", SamplingPercentage: 100}},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -574,3 +586,93 @@ func TestLogsMissingRandomness(t *testing.T) {
 		}
 	}
 }
+
+func TestLogsSamplingRules(t *testing.T) {
+	cfg := &Config{
+		SamplingPercentage: 10,
+		SamplingPrecision:  defaultPrecision,
+		Mode:               Proportional,
+		AttributeSource:    traceIDAttributeSource,
+		SamplingRules: []SamplingRule{
+			{Condition: "severity_number >= SEVERITY_NUMBER_ERROR", SamplingPercentage: 100},
+			{Condition: `resource.attributes["k8s.namespace.name"] == "noisy"`, SamplingPercentage: 1},
+		},
+	}
+	sink := new(consumertest.LogsSink)
+	processor, err := newLogsProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), sink, cfg)
+	require.NoError(t, err)
+
+	r := rand.New(rand.NewPCG(123, 456))
+	logs := plog.NewLogs()
+	addRecords := func(namespace string, severity plog.SeverityNumber, count int) {
+		rl := logs.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("k8s.namespace.name", namespace)
+		lr := rl.ScopeLogs().AppendEmpty().LogRecords()
+		for i := 0; i < count; i++ {
+			record := lr.AppendEmpty()
+			record.SetSeverityNumber(severity)
+			record.SetTraceID(idutils.UInt64ToTraceID(r.Uint64(), r.Uint64()))
+		}
+	}
+	addRecords("noisy", plog.SeverityNumberError, 100)
+	addRecords("noisy", plog.SeverityNumberDebug, 2000)
+	addRecords("app", plog.SeverityNumberInfo, 1000)
+
+	require.NoError(t, processor.ConsumeLogs(context.Background(), logs))
+
+	received := map[plog.SeverityNumber]int{}
+	adjustedCounts := map[plog.SeverityNumber]float64{}
+	for _, ld := range sink.AllLogs() {
+		for i := 0; i < ld.ResourceLogs().Len(); i++ {
+			lr := ld.ResourceLogs().At(i).ScopeLogs().At(0).LogRecords()
+			for j := 0; j < lr.Len(); j++ {
+				record := lr.At(j)
+				adjustedCount, ok := record.Attributes().Get("sampling.adjusted_count")
+				require.True(t, ok)
+				received[record.SeverityNumber()]++
+				adjustedCounts[record.SeverityNumber()] = adjustedCount.Double()
+			}
+		}
+	}
+
+	assert.Equal(t, 100, received[plog.SeverityNumberError])
+	assert.InDelta(t, 1, adjustedCounts[plog.SeverityNumberError], 0.001)
+	assert.InDelta(t, 20, received[plog.SeverityNumberDebug], 10)
+	assert.InDelta(t, 100, adjustedCounts[plog.SeverityNumberDebug], 0.1)
+	assert.InDelta(t, 100, received[plog.SeverityNumberInfo], 30)
+	assert.InDelta(t, 10, adjustedCounts[plog.SeverityNumberInfo], 0.01)
+}
+
+func TestLogsSamplingRulesAdjustedCountFromRecordedThreshold(t *testing.T) {
+	cfg := &Config{
+		SamplingPercentage: 10,
+		SamplingPrecision:  defaultPrecision,
+		Mode:               Equalizing,
+		AttributeSource:    traceIDAttributeSource,
+		SamplingPriority:   "priority",
+		SamplingRules: []SamplingRule{
+			{Condition: `attributes["priority"] != nil`, SamplingPercentage: 100},
+		},
+	}
+	sink := new(consumertest.LogsSink)
+	processor, err := newLogsProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), sink, cfg)
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	record := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	record.SetTraceID(idutils.UInt64ToTraceID(0, math.MaxUint64))
+	record.Attributes().PutDouble("priority", 100)
+	// The record arrives sampled at 50%, the priority can't lower its threshold.
+	record.Attributes().PutStr("sampling.threshold", "8")
+
+	require.NoError(t, processor.ConsumeLogs(context.Background(), logs))
+	require.Equal(t, 1, sink.LogRecordCount())
+
+	sampled := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	threshold, ok := sampled.Attributes().Get("sampling.threshold")
+	require.True(t, ok)
+	assert.Equal(t, "8", threshold.Str())
+	adjustedCount, ok := sampled.Attributes().Get("sampling.adjusted_count")
+	require.True(t, ok)
+	assert.InDelta(t, 2, adjustedCount.Double(), 0.001)
+}
@@ -476,7 +476,7 @@ func commonShouldSampleLogic[T any](
 	description string,
 	logger *zap.Logger,
 	counter metric.Int64Counter,
-) bool {
+) (bool, sampling.Threshold) {
 	rnd, carrier, err := randFunc(item)
 
 	if err == nil {
@@ -523,5 +523,5 @@ func commonShouldSampleLogic[T any](
 
 	counter.Add(ctx, 1, metric.WithAttributes(attribute.String("policy", rnd.policyName()), attribute.String("sampled", strconv.FormatBool(sampled))))
 
-	return sampled
+	return sampled, threshold
 }
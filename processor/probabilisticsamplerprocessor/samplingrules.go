@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package probabilisticsamplerprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+// adjustedCountAttribute is the log record attribute holding the number of
+// records represented by a sampled record.
+const adjustedCountAttribute = "sampling.adjusted_count"
+
+// samplingRule samples the log records matching its condition with its own sampler.
+type samplingRule struct {
+	condition ottl.ConditionSequence[ottllog.TransformContext]
+	sampler   dataSampler
+}
+
+// newSamplingRules builds the sampling rules of the configuration. The rules
+// share the settings of the configuration except for the sampling percentage.
+func newSamplingRules(cfg *Config, set component.TelemetrySettings) ([]samplingRule, error) {
+	parser, err := ottllog.NewParser(ottlfuncs.StandardConverters[ottllog.TransformContext](), set)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]samplingRule, 0, len(cfg.SamplingRules))
+	for i, rule := range cfg.SamplingRules {
+		if err := validateSamplingPercentage(rule.SamplingPercentage); err != nil {
+			return nil, fmt.Errorf("sampling rule %d: %w", i, err)
+		}
+		condition, err := parser.ParseCondition(rule.Condition)
+		if err != nil {
+			return nil, fmt.Errorf("sampling rule %d: %w", i, err)
+		}
+
+		ruleCfg := *cfg
+		ruleCfg.SamplingPercentage = rule.SamplingPercentage
+		rules = append(rules, samplingRule{
+			condition: ottllog.NewConditionSequence(
+				[]*ottl.Condition[ottllog.TransformContext]{condition},
+				set,
+				ottllog.WithConditionSequenceErrorMode(ottl.IgnoreError),
+			),
+			sampler: makeSampler(&ruleCfg, true),
+		})
+	}
+	return rules, nil
+}
+
+// selectSampler returns the sampler of the first rule matching the log record,
+// or the default sampler when none matches.
+func selectSampler(ctx context.Context, rules []samplingRule, tCtx ottllog.TransformContext, defaultSampler dataSampler) dataSampler {
+	for _, rule := range rules {
+		// Evaluation errors are logged by the condition sequence, the rule doesn't match then.
+		if match, err := rule.condition.Eval(ctx, tCtx); err == nil && match {
+			return rule.sampler
+		}
+	}
+	return defaultSampler
+}
@@ -174,7 +174,7 @@ func (tp *traceProcessor) processTraces(ctx context.Context, td ptrace.Traces) (
 	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
 		rs.ScopeSpans().RemoveIf(func(ils ptrace.ScopeSpans) bool {
 			ils.Spans().RemoveIf(func(s ptrace.Span) bool {
-				return !commonShouldSampleLogic(
+				sampled, _ := commonShouldSampleLogic(
 					ctx,
 					s,
 					tp.sampler,
@@ -185,6 +185,7 @@ func (tp *traceProcessor) processTraces(ctx context.Context, td ptrace.Traces) (
 					tp.logger,
 					tp.telemetryBuilder.ProcessorProbabilisticSamplerCountTracesSampled,
 				)
+				return !sampled
 			})
 			// Filter out empty ScopeMetrics
 			return ils.Spans().Len() == 0
@@ -4,8 +4,11 @@
 package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
 
 import (
+	"errors"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
@@ -45,6 +48,9 @@ const (
 	// OTTLCondition sample traces which match user provided OpenTelemetry Transformation Language
 	// conditions.
 	OTTLCondition PolicyType = "ottl_condition"
+	// Throughput samples traces so that the spans of each key reach a target throughput,
+	// adapting the sampling rate of each key to its traffic.
+	Throughput PolicyType = "throughput"
 )
 
 // sharedPolicyCfg holds the common configuration to all policies that are used in derivative policy configurations
@@ -74,6 +80,8 @@ type sharedPolicyCfg struct {
 	BooleanAttributeCfg BooleanAttributeCfg `mapstructure:"boolean_attribute"`
 	// Configs for OTTL condition filter sampling policy evaluator
 	OTTLConditionCfg OTTLConditionCfg `mapstructure:"ottl_condition"`
+	// Configs for throughput sampling policy evaluator.
+	ThroughputCfg ThroughputCfg `mapstructure:"throughput"`
 }
 
 // CompositeSubPolicyCfg holds the common configuration to all policies under composite policy.
@@ -168,8 +176,28 @@ type ProbabilisticCfg struct {
 	// SamplingPercentage is the percentage rate at which traces are going to be sampled. Defaults to zero, i.e.: no sample.
 	// Values greater or equal 100 are treated as "sample all traces".
 	SamplingPercentage float64 `mapstructure:"sampling_percentage"`
+	// Mode selects how traces are sampled. Defaults to hash_seed, i.e.: the trace ID is hashed
+	// with the HashSalt and the tracestate is ignored.
+	Mode ProbabilisticMode `mapstructure:"mode"`
+	// SamplingPrecision is the number of hex digits used to encode the sampling threshold
+	// in the tracestate with the equalizing and proportional modes. Defaults to 4.
+	SamplingPrecision int `mapstructure:"sampling_precision"`
 }
 
+// ProbabilisticMode determines how the probabilistic policy samples traces.
+type ProbabilisticMode string
+
+const (
+	// HashSeed samples traces by hashing their trace ID with a salt.
+	HashSeed ProbabilisticMode = "hash_seed"
+	// Equalizing samples traces using OpenTelemetry consistent probability thresholds,
+	// raising the threshold found in the tracestate up to the one of the sampling percentage.
+	Equalizing ProbabilisticMode = "equalizing"
+	// Proportional samples traces using OpenTelemetry consistent probability thresholds,
+	// multiplying the probability found in the tracestate by the sampling percentage.
+	Proportional ProbabilisticMode = "proportional"
+)
+
 // StatusCodeCfg holds the configurable settings to create a status code filter sampling
 // policy evaluator.
 type StatusCodeCfg struct {
@@ -233,6 +261,22 @@ type OTTLConditionCfg struct {
 	SpanEventConditions []string       `mapstructure:"spanevent"`
 }
 
+// ThroughputCfg holds the configurable settings to create a throughput sampling
+// policy evaluator.
+type ThroughputCfg struct {
+	// KeyAttributes are the attributes, looked up on the root span and then its resource,
+	// whose values form the key the throughput is tracked for.
+	KeyAttributes []string `mapstructure:"key_attributes"`
+	// SpansPerSecond is the target number of spans sampled per second for all keys.
+	SpansPerSecond int64 `mapstructure:"spans_per_second"`
+	// Window is the period over which the throughput of each key is measured.
+	// Defaults to 30s.
+	Window time.Duration `mapstructure:"window"`
+	// MaxKeys is the maximum number of keys tracked at once, traces of additional
+	// keys share a single key. Defaults to 10000.
+	MaxKeys int `mapstructure:"max_keys"`
+}
+
 type DecisionCacheConfig struct {
 	// SampledCacheSize specifies the size of the cache that holds the sampled trace IDs.
 	// This value will be the maximum amount of trace IDs that the cache can hold before overwriting previous IDs.
@@ -246,6 +290,40 @@ type DecisionCacheConfig struct {
 	NonSampledCacheSize int `mapstructure:"non_sampled_cache_size"`
 }
 
+// StorageConfig holds the configuration of the storage used to persist the traces
+// pending a sampling decision and the decision caches.
+type StorageConfig struct {
+	// Extension is the ID of the storage extension to use. When unset, traces and
+	// decisions are only kept in memory.
+	Extension *component.ID `mapstructure:"extension"`
+	// MaxSizeMiB is the maximum size, in MiB, of the spans held in storage. Once it is
+	// reached, the spans of pending traces are kept in memory until space is released.
+	MaxSizeMiB int64 `mapstructure:"max_size_mib"`
+}
+
+// ShadowConfig holds the configuration of the policies evaluated alongside the live
+// ones to report the decisions they would make, without affecting the sampled data.
+type ShadowConfig struct {
+	// PolicyCfgs sets the candidate policies. Their decisions are reported in the shadow
+	// telemetry only.
+	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// RecordDecisions adds a `sampling.decision.<policy>` attribute to the spans of each
+	// trace, holding the decision of each shadow policy.
+	RecordDecisions bool `mapstructure:"record_decisions"`
+}
+
+// LateSpansConfig holds the configuration of the handling of the spans arriving after
+// the sampling decision of their trace was taken.
+type LateSpansConfig struct {
+	// ReopenWindow is how long the decision of a trace is kept once taken to be applied
+	// to its late spans, independently of the decision caches. Spans arriving after it
+	// reopen the trace, and are evaluated again as a new trace. Disabled when 0.
+	ReopenWindow time.Duration `mapstructure:"reopen_window"`
+	// AddAttribute adds the `tailsampling.late_span` attribute to the late spans
+	// forwarded for sampled traces.
+	AddAttribute bool `mapstructure:"add_attribute"`
+}
+
 // Config holds the configuration for tail-based sampling.
 type Config struct {
 	// DecisionWait is the desired wait time from the arrival of the first span of
@@ -262,6 +340,23 @@ type Config struct {
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
 	// DecisionCache holds configuration for the decision cache(s)
 	DecisionCache DecisionCacheConfig `mapstructure:"decision_cache"`
+	// Storage holds configuration for persisting pending traces and decisions in a storage extension.
+	Storage StorageConfig `mapstructure:"storage"`
+	// Shadow holds configuration for candidate policies evaluated in dry-run mode.
+	Shadow ShadowConfig `mapstructure:"shadow"`
+	// LateSpans holds configuration for the spans arriving after the sampling decision.
+	LateSpans LateSpansConfig `mapstructure:"late_spans"`
 	// Options allows for additional configuration of the tail-based sampling processor in code.
 	Options []Option `mapstructure:"-"`
 }
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Storage.Extension != nil && cfg.Storage.MaxSizeMiB <= 0 {
+		return errors.New("storage max_size_mib must be greater than zero")
+	}
+	if cfg.LateSpans.ReopenWindow < 0 {
+		return errors.New("late_spans reopen_window must not be negative")
+	}
+	return nil
+}
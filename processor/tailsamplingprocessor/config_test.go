@@ -36,6 +36,8 @@ func TestLoadConfig(t *testing.T) {
 			NumTraces:               100,
 			ExpectedNewTracesPerSec: 10,
 			DecisionCache:           DecisionCacheConfig{SampledCacheSize: 1_000, NonSampledCacheSize: 10_000},
+			Storage:                 StorageConfig{MaxSizeMiB: 1024},
+			LateSpans:               LateSpansConfig{ReopenWindow: 5 * time.Minute, AddAttribute: true},
 			PolicyCfgs: []PolicyCfg{
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
@@ -117,6 +119,13 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name:          "test-policy-12",
+						Type:          Throughput,
+						ThroughputCfg: ThroughputCfg{KeyAttributes: []string{"service.name", "http.route"}, SpansPerSecond: 100, Window: time.Minute, MaxKeys: 500},
+					},
+				},
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
 						Name: "and-policy-1",
@@ -186,3 +195,20 @@ func TestLoadConfig(t *testing.T) {
 			},
 		}, cfg)
 }
+
+func TestValidateConfig(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+
+	cfg := createDefaultConfig().(*Config)
+	require.NoError(t, cfg.Validate())
+
+	cfg.Storage.Extension = &storageID
+	require.NoError(t, cfg.Validate())
+
+	cfg.Storage.MaxSizeMiB = 0
+	require.EqualError(t, cfg.Validate(), "storage max_size_mib must be greater than zero")
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.LateSpans.ReopenWindow = -time.Second
+	require.EqualError(t, cfg.Validate(), "late_spans reopen_window must not be negative")
+}
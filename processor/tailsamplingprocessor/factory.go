@@ -29,6 +29,9 @@ func createDefaultConfig() component.Config {
 	return &Config{
 		DecisionWait: 30 * time.Second,
 		NumTraces:    50000,
+		Storage: StorageConfig{
+			MaxSizeMiB: 1024,
+		},
 	}
 }
 
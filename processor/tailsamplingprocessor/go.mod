@@ -9,6 +9,7 @@ require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal v0.125.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter v0.125.0
 	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl v0.125.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling v0.125.0
 	github.com/stretchr/testify v1.10.0
 	go.opentelemetry.io/collector/component v1.31.1-0.20250508034258-ac520a5c14cc
 	go.opentelemetry.io/collector/confmap v1.31.1-0.20250508034258-ac520a5c14cc
@@ -28,6 +29,7 @@ require (
 require (
 	go.opentelemetry.io/collector/component/componenttest v0.125.1-0.20250508034258-ac520a5c14cc
 	go.opentelemetry.io/collector/consumer/consumertest v0.125.1-0.20250508034258-ac520a5c14cc
+	go.opentelemetry.io/collector/extension/xextension v0.125.1-0.20250508034258-ac520a5c14cc
 	go.opentelemetry.io/collector/processor/processortest v0.125.1-0.20250508034258-ac520a5c14cc
 )
 
@@ -62,6 +64,7 @@ require (
 	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
 	go.opentelemetry.io/collector/component/componentstatus v0.125.1-0.20250508034258-ac520a5c14cc // indirect
 	go.opentelemetry.io/collector/consumer/xconsumer v0.125.1-0.20250508034258-ac520a5c14cc // indirect
+	go.opentelemetry.io/collector/extension v1.31.1-0.20250508034258-ac520a5c14cc // indirect
 	go.opentelemetry.io/collector/internal/telemetry v0.125.1-0.20250508034258-ac520a5c14cc // indirect
 	go.opentelemetry.io/collector/pdata/pprofile v0.125.1-0.20250508034258-ac520a5c14cc // indirect
 	go.opentelemetry.io/collector/pdata/testdata v0.125.1-0.20250508034258-ac520a5c14cc // indirect
@@ -100,3 +103,5 @@ replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/filte
 replace github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal => ../../internal/coreinternal
 
 replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden => ../../pkg/golden
+
+replace github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling => ../../pkg/sampling
@@ -34,10 +34,13 @@ type TelemetryBuilder struct {
 	ProcessorTailSamplingSamplingDecisionLatency        metric.Int64Histogram
 	ProcessorTailSamplingSamplingDecisionTimerLatency   metric.Int64Histogram
 	ProcessorTailSamplingSamplingLateSpanAge            metric.Int64Histogram
+	ProcessorTailSamplingSamplingLateSpans              metric.Int64Counter
 	ProcessorTailSamplingSamplingPolicyEvaluationError  metric.Int64Counter
 	ProcessorTailSamplingSamplingTraceDroppedTooEarly   metric.Int64Counter
 	ProcessorTailSamplingSamplingTraceRemovalAge        metric.Int64Histogram
 	ProcessorTailSamplingSamplingTracesOnMemory         metric.Int64Gauge
+	ProcessorTailSamplingShadowCountTracesSampled       metric.Int64Counter
+	ProcessorTailSamplingShadowGlobalCountTracesSampled metric.Int64Counter
 }
 
 // TelemetryBuilderOption applies changes to default builder.
@@ -119,6 +122,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("s"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorTailSamplingSamplingLateSpans, err = builder.meter.Int64Counter(
+		"otelcol_processor_tail_sampling_sampling_late_spans",
+		metric.WithDescription("Count of spans that arrived after the sampling decision of their trace was taken"),
+		metric.WithUnit("{spans}"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ProcessorTailSamplingSamplingPolicyEvaluationError, err = builder.meter.Int64Counter(
 		"otelcol_processor_tail_sampling_sampling_policy_evaluation_error",
 		metric.WithDescription("Count of sampling policy evaluation errors"),
@@ -143,5 +152,17 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("{traces}"),
 	)
 	errs = errors.Join(errs, err)
+	builder.ProcessorTailSamplingShadowCountTracesSampled, err = builder.meter.Int64Counter(
+		"otelcol_processor_tail_sampling_shadow_count_traces_sampled",
+		metric.WithDescription("Count of traces that would be sampled or not per shadow sampling policy"),
+		metric.WithUnit("{traces}"),
+	)
+	errs = errors.Join(errs, err)
+	builder.ProcessorTailSamplingShadowGlobalCountTracesSampled, err = builder.meter.Int64Counter(
+		"otelcol_processor_tail_sampling_shadow_global_count_traces_sampled",
+		metric.WithDescription("Global count of traces that would be sampled or not by at least one shadow policy"),
+		metric.WithUnit("{traces}"),
+	)
+	errs = errors.Join(errs, err)
 	return &builder, errs
 }
@@ -16,6 +16,8 @@ type And struct {
 	logger      *zap.Logger
 }
 
+var _ MetadataEvaluator = (*And)(nil)
+
 func NewAnd(
 	logger *zap.Logger,
 	subpolicies []PolicyEvaluator,
@@ -28,16 +30,27 @@ func NewAnd(
 
 // Evaluate looks at the trace data and returns a corresponding SamplingDecision.
 func (c *And) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
+	decision, _, err := c.EvaluateWithMetadata(ctx, traceID, trace)
+	return decision, err
+}
+
+// EvaluateWithMetadata returns the decision for the trace, along with the rate it is sampled at
+// when sub-policies sample it at an adaptive rate, which is the product of their rates.
+func (c *And) EvaluateWithMetadata(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, DecisionMetadata, error) {
 	// The policy iterates over all sub-policies and returns Sampled if all sub-policies returned a Sampled Decision.
 	// If any subpolicy returns NotSampled or InvertNotSampled, it returns NotSampled Decision.
+	var metadata DecisionMetadata
 	for _, sub := range c.subpolicies {
-		decision, err := sub.Evaluate(ctx, traceID, trace)
+		decision, subMetadata, err := EvaluateWithMetadata(ctx, sub, traceID, trace)
 		if err != nil {
-			return Unspecified, err
+			return Unspecified, DecisionMetadata{}, err
 		}
 		if decision == NotSampled || decision == InvertNotSampled {
-			return NotSampled, nil
+			return NotSampled, DecisionMetadata{}, nil
+		}
+		if subMetadata.SampleRate > 0 {
+			metadata.SampleRate = max(metadata.SampleRate, 1) * subMetadata.SampleRate
 		}
 	}
-	return Sampled, nil
+	return Sampled, metadata, nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 )
@@ -112,3 +113,58 @@ func TestAndEvaluatorStringInvertNotSampled(t *testing.T) {
 	require.NoError(t, err, "Failed to evaluate and policy: %v", err)
 	assert.Equal(t, NotSampled, decision)
 }
+
+// sampleRateEvaluator samples every trace at the given rate.
+type sampleRateEvaluator float64
+
+func (e sampleRateEvaluator) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
+	decision, _, err := e.EvaluateWithMetadata(ctx, traceID, trace)
+	return decision, err
+}
+
+func (e sampleRateEvaluator) EvaluateWithMetadata(context.Context, pcommon.TraceID, *TraceData) (Decision, DecisionMetadata, error) {
+	return Sampled, DecisionMetadata{SampleRate: float64(e)}, nil
+}
+
+func TestAndEvaluatorSampleRate(t *testing.T) {
+	always := NewAlwaysSample(componenttest.NewNopTelemetrySettings())
+
+	tests := []struct {
+		name        string
+		subpolicies []PolicyEvaluator
+		want        DecisionMetadata
+	}{
+		{
+			name:        "no adaptive rate",
+			subpolicies: []PolicyEvaluator{always, always},
+		},
+		{
+			name:        "single adaptive rate",
+			subpolicies: []PolicyEvaluator{always, sampleRateEvaluator(4)},
+			want:        DecisionMetadata{SampleRate: 4},
+		},
+		{
+			name:        "multiple adaptive rates",
+			subpolicies: []PolicyEvaluator{sampleRateEvaluator(2), always, sampleRateEvaluator(4)},
+			want:        DecisionMetadata{SampleRate: 8},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			and := NewAnd(zap.NewNop(), tt.subpolicies)
+			decision, metadata, err := EvaluateWithMetadata(context.Background(), and, traceID, createTrace())
+			require.NoError(t, err)
+			assert.Equal(t, Sampled, decision)
+			assert.Equal(t, tt.want, metadata)
+		})
+	}
+
+	min0 := int64(0)
+	max100 := int64(100)
+	notSampled := NewNumericAttributeFilter(componenttest.NewNopTelemetrySettings(), "tag", &min0, &max100, false)
+	and := NewAnd(zap.NewNop(), []PolicyEvaluator{sampleRateEvaluator(4), notSampled})
+	decision, metadata, err := EvaluateWithMetadata(context.Background(), and, traceID, createTrace())
+	require.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+	assert.Zero(t, metadata.SampleRate)
+}
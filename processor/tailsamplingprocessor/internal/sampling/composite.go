@@ -41,7 +41,7 @@ type Composite struct {
 	recordSubPolicy bool
 }
 
-var _ PolicyEvaluator = (*Composite)(nil)
+var _ MetadataEvaluator = (*Composite)(nil)
 
 // SubPolicyEvalParams defines the evaluator and max rate for a sub-policy
 type SubPolicyEvalParams struct {
@@ -82,6 +82,14 @@ func NewComposite(
 
 // Evaluate looks at the trace data and returns a corresponding SamplingDecision.
 func (c *Composite) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
+	decision, _, err := c.EvaluateWithMetadata(ctx, traceID, trace)
+	return decision, err
+}
+
+// EvaluateWithMetadata returns the decision for the trace, along with the rate the subpolicy that
+// sampled it sampled it at. The threshold of the subpolicy isn't returned, as the rate limiting
+// of the subpolicies makes the sampling inconsistent with it.
+func (c *Composite) EvaluateWithMetadata(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, DecisionMetadata, error) {
 	// Rate limiting works by counting spans that are sampled during each 1 second
 	// time period. Until the total number of spans during a particular second
 	// exceeds the allocated number of spans-per-second the traces are sampled,
@@ -100,9 +108,9 @@ func (c *Composite) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace
 	}
 
 	for _, sub := range c.subpolicies {
-		decision, err := sub.evaluator.Evaluate(ctx, traceID, trace)
+		decision, metadata, err := EvaluateWithMetadata(ctx, sub.evaluator, traceID, trace)
 		if err != nil {
-			return Unspecified, err
+			return Unspecified, DecisionMetadata{}, err
 		}
 
 		if decision == Sampled || decision == InvertSampled {
@@ -119,16 +127,16 @@ func (c *Composite) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace
 				if c.recordSubPolicy {
 					SetAttrOnScopeSpans(trace, "tailsampling.composite_policy", sub.name)
 				}
-				return Sampled, nil
+				return Sampled, DecisionMetadata{SampleRate: metadata.SampleRate}, nil
 			}
 
 			// We exceeded the rate limit. Don't sample this trace.
 			// Note that we will continue evaluating new incoming traces against
 			// allocated SPS, we do not update sub.sampledSPS here in order to give
 			// chance to another smaller trace to be accepted later.
-			return NotSampled, nil
+			return NotSampled, DecisionMetadata{}, nil
 		}
 	}
 
-	return NotSampled, nil
+	return NotSampled, DecisionMetadata{}, nil
 }
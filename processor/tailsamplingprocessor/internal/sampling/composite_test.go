@@ -314,3 +314,35 @@ func TestCompositeEvaluator2SubpolicyThrottling(t *testing.T) {
 		assert.Equal(t, expected, decision)
 	}
 }
+
+func TestCompositeEvaluatorSamplingThreshold(t *testing.T) {
+	n1 := NewThresholdSampler(componenttest.NewNopTelemetrySettings(), 50, false, 4)
+	c := NewComposite(zap.NewNop(), 1000, []SubPolicyEvalParams{{n1, 100, "eval-1"}}, FakeTimeProvider{}, false)
+
+	// The threshold of the subpolicy isn't returned, as its rate limiting lowers
+	// the sampling probability
+	sampledID := pcommon.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	decision, metadata, err := EvaluateWithMetadata(context.Background(), c, sampledID, createTrace())
+	require.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+	assert.Equal(t, DecisionMetadata{}, metadata)
+}
+
+func TestCompositeEvaluatorSampleRate(t *testing.T) {
+	min0 := int64(0)
+	max100 := int64(100)
+	n1 := NewNumericAttributeFilter(componenttest.NewNopTelemetrySettings(), "tag", &min0, &max100, false)
+	c := NewComposite(zap.NewNop(), 1, []SubPolicyEvalParams{{n1, 1, "eval-1"}, {sampleRateEvaluator(4), 1, "eval-2"}}, FakeTimeProvider{}, false)
+
+	// The rate of the subpolicy sampling the trace is returned
+	decision, metadata, err := EvaluateWithMetadata(context.Background(), c, traceID, createTrace())
+	require.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+	assert.Equal(t, DecisionMetadata{SampleRate: 4}, metadata)
+
+	// The trace isn't sampled once the subpolicy exceeds its spans per second
+	decision, metadata, err = EvaluateWithMetadata(context.Background(), c, traceID, createTrace())
+	require.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+	assert.Zero(t, metadata.SampleRate)
+}
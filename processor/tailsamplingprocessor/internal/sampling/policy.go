@@ -11,6 +11,8 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	pkgsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 )
 
 // TraceData stores the sampling related trace data.
@@ -61,3 +63,31 @@ type PolicyEvaluator interface {
 	// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
 	Evaluate(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, error)
 }
+
+// DecisionMetadata holds what a policy evaluator determined about a trace along with its decision.
+type DecisionMetadata struct {
+	// SampleRate is the number of traces a trace sampled at an adaptive rate stands for,
+	// which is recorded on its spans. It is zero when the trace wasn't sampled at such a rate.
+	SampleRate float64
+	// Threshold is the consistent probability threshold, as described in OTEP 235, the trace
+	// was sampled with when HasThreshold is set.
+	Threshold    pkgsampling.Threshold
+	HasThreshold bool
+}
+
+// MetadataEvaluator is implemented by the policy evaluators returning metadata along with their decision.
+type MetadataEvaluator interface {
+	PolicyEvaluator
+	// EvaluateWithMetadata evaluates the trace like Evaluate, and returns the metadata of the decision.
+	EvaluateWithMetadata(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, DecisionMetadata, error)
+}
+
+// EvaluateWithMetadata evaluates the trace with the policy evaluator, along with the metadata
+// of the decision when the evaluator returns some.
+func EvaluateWithMetadata(ctx context.Context, evaluator PolicyEvaluator, traceID pcommon.TraceID, trace *TraceData) (Decision, DecisionMetadata, error) {
+	if e, ok := evaluator.(MetadataEvaluator); ok {
+		return e.EvaluateWithMetadata(ctx, traceID, trace)
+	}
+	decision, err := evaluator.Evaluate(ctx, traceID, trace)
+	return decision, DecisionMetadata{}, err
+}
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	pkgsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+)
+
+type thresholdSampler struct {
+	logger *zap.Logger
+	// threshold is the threshold corresponding to the sampling percentage.
+	threshold pkgsampling.Threshold
+	ratio     float64
+	// proportional multiplies the incoming sampling probability by the ratio,
+	// instead of raising the incoming threshold up to the configured one.
+	proportional bool
+	precision    int
+}
+
+var _ MetadataEvaluator = (*thresholdSampler)(nil)
+
+// NewThresholdSampler creates a policy evaluator that samples a percentage of traces
+// using the consistent probability sampling thresholds of OTEP 235. The randomness
+// and the threshold found in the tracestate of the spans are honoured.
+func NewThresholdSampler(settings component.TelemetrySettings, samplingPercentage float64, proportional bool, precision int) PolicyEvaluator {
+	ratio := samplingPercentage / 100
+	threshold := pkgsampling.NeverSampleThreshold
+	if ratio > 0 {
+		// Percentages outside of the supported range are rounded to the closest one.
+		ratio = min(max(ratio, pkgsampling.MinSamplingProbability), 1)
+		threshold, _ = pkgsampling.ProbabilityToThresholdWithPrecision(ratio, precision)
+	}
+
+	return &thresholdSampler{
+		logger:       settings.Logger,
+		threshold:    threshold,
+		ratio:        ratio,
+		proportional: proportional,
+		precision:    precision,
+	}
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (s *thresholdSampler) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
+	decision, _, err := s.EvaluateWithMetadata(ctx, traceID, trace)
+	return decision, err
+}
+
+// EvaluateWithMetadata returns the decision for the trace, along with the threshold it is sampled with.
+func (s *thresholdSampler) EvaluateWithMetadata(_ context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, DecisionMetadata, error) {
+	s.logger.Debug("Evaluating spans in threshold sampling filter")
+
+	threshold, ok := s.samplingThreshold(traceID, trace)
+	if !ok {
+		return NotSampled, DecisionMetadata{}, nil
+	}
+	return Sampled, DecisionMetadata{Threshold: threshold, HasThreshold: true}, nil
+}
+
+// samplingThreshold returns the threshold the trace is compared to, and whether it is sampled.
+func (s *thresholdSampler) samplingThreshold(traceID pcommon.TraceID, trace *TraceData) (pkgsampling.Threshold, bool) {
+	trace.Lock()
+	defer trace.Unlock()
+
+	incoming, hasIncoming, rnd := traceSamplingState(traceID, trace.ReceivedBatches)
+
+	threshold := s.threshold
+	switch {
+	case !hasIncoming:
+	case s.proportional:
+		var err error
+		threshold, err = pkgsampling.ProbabilityToThresholdWithPrecision(incoming.Probability()*s.ratio, s.precision)
+		if errors.Is(err, pkgsampling.ErrProbabilityRange) {
+			// The sampling probability fell below the minimum supported one.
+			threshold = pkgsampling.NeverSampleThreshold
+		}
+	case pkgsampling.ThresholdLessThan(threshold, incoming):
+		// The trace was sampled with a lower probability upstream.
+		threshold = incoming
+	}
+
+	return threshold, threshold.ShouldSample(rnd)
+}
+
+// traceSamplingState returns the threshold and randomness found in the tracestate
+// of the first span of the trace having an OpenTelemetry value. The randomness
+// defaults to the one of the trace ID.
+func traceSamplingState(traceID pcommon.TraceID, td ptrace.Traces) (pkgsampling.Threshold, bool, pkgsampling.Randomness) {
+	rnd := pkgsampling.TraceIDToRandomness(traceID)
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				w3c, err := pkgsampling.NewW3CTraceState(spans.At(k).TraceState().AsRaw())
+				if err != nil || !w3c.OTelValue().HasAnyValue() {
+					continue
+				}
+				otts := w3c.OTelValue()
+				if rv, ok := otts.RValueRandomness(); ok {
+					rnd = rv
+				}
+				threshold, ok := otts.TValueThreshold()
+				if ok && !threshold.ShouldSample(rnd) {
+					// The incoming threshold is inconsistent with the randomness
+					// of the trace, it can't be relied on.
+					ok = false
+				}
+				return threshold, ok, rnd
+			}
+		}
+	}
+	return pkgsampling.AlwaysSampleThreshold, false, rnd
+}
+
+// UpdateThresholdOnSpans records the threshold a trace was sampled with in the
+// tracestate of its spans, so that their adjusted count reflects the sampling.
+func UpdateThresholdOnSpans(data *TraceData, threshold pkgsampling.Threshold) {
+	data.Lock()
+	defer data.Unlock()
+
+	rss := data.ReceivedBatches.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				ts := spans.At(k).TraceState()
+				w3c, err := pkgsampling.NewW3CTraceState(ts.AsRaw())
+				if err != nil {
+					continue
+				}
+				if w3c.OTelValue().UpdateTValueWithSampling(threshold) != nil {
+					continue
+				}
+				var w strings.Builder
+				if w3c.Serialize(&w) == nil {
+					ts.FromRaw(w.String())
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	pkgsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
+)
+
+func newTraceWithTraceState(traceState ...string) *TraceData {
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, ts := range traceState {
+		spans.AppendEmpty().TraceState().FromRaw(ts)
+	}
+
+	spanCount := &atomic.Int64{}
+	spanCount.Store(int64(len(traceState)))
+	return &TraceData{
+		ReceivedBatches: traces,
+		SpanCount:       spanCount,
+	}
+}
+
+func TestThresholdSampling(t *testing.T) {
+	tests := []struct {
+		name                       string
+		samplingPercentage         float64
+		proportional               bool
+		traceState                 string
+		expectedSamplingPercentage float64
+	}{
+		{
+			name:                       "100%",
+			samplingPercentage:         100,
+			expectedSamplingPercentage: 100,
+		},
+		{
+			name:                       "0%",
+			samplingPercentage:         0,
+			expectedSamplingPercentage: 0,
+		},
+		{
+			name:                       "25%",
+			samplingPercentage:         25,
+			expectedSamplingPercentage: 25,
+		},
+		{
+			name:                       "150%",
+			samplingPercentage:         150,
+			expectedSamplingPercentage: 100,
+		},
+		{
+			name:                       "equalizing with a lower incoming probability",
+			samplingPercentage:         50,
+			traceState:                 "ot=th:c",
+			expectedSamplingPercentage: 100,
+		},
+		{
+			name:                       "equalizing with a higher incoming probability",
+			samplingPercentage:         25,
+			traceState:                 "ot=th:8",
+			expectedSamplingPercentage: 50,
+		},
+		{
+			name:                       "proportional",
+			samplingPercentage:         50,
+			proportional:               true,
+			traceState:                 "ot=th:8",
+			expectedSamplingPercentage: 50,
+		},
+		{
+			name:                       "proportional without incoming probability",
+			samplingPercentage:         50,
+			proportional:               true,
+			expectedSamplingPercentage: 50,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			traceCount := 100_000
+			sampler := NewThresholdSampler(componenttest.NewNopTelemetrySettings(), tt.samplingPercentage, tt.proportional, 4)
+
+			sampled := 0
+			consistent := 0
+			for _, traceID := range genRandomTraceIDs(traceCount) {
+				trace := newTraceWithTraceState(tt.traceState)
+				// Only the traces sampled upstream are received
+				if incoming, ok, rnd := traceSamplingState(traceID, trace.ReceivedBatches); tt.traceState != "" && (!ok || !incoming.ShouldSample(rnd)) {
+					continue
+				}
+				consistent++
+
+				decision, err := sampler.Evaluate(context.Background(), traceID, trace)
+				assert.NoError(t, err)
+
+				if decision == Sampled {
+					sampled++
+				}
+			}
+
+			effectiveSamplingPercentage := float32(sampled) / float32(consistent) * 100
+			assert.InDelta(t, tt.expectedSamplingPercentage, effectiveSamplingPercentage, 0.5,
+				"Effective sampling percentage is %f, expected %f", effectiveSamplingPercentage, tt.expectedSamplingPercentage,
+			)
+		})
+	}
+}
+
+func TestThresholdSamplingThreshold(t *testing.T) {
+	tests := []struct {
+		name              string
+		proportional      bool
+		traceState        []string
+		expectedThreshold string
+		expectedSampled   bool
+	}{
+		{
+			name:              "no tracestate",
+			traceState:        []string{""},
+			expectedThreshold: "8",
+			expectedSampled:   false,
+		},
+		{
+			name:              "explicit randomness",
+			traceState:        []string{"ot=rv:ffffffffffffff"},
+			expectedThreshold: "8",
+			expectedSampled:   true,
+		},
+		{
+			name:              "incoming threshold kept",
+			traceState:        []string{"ot=rv:ffffffffffffff;th:c"},
+			expectedThreshold: "c",
+			expectedSampled:   true,
+		},
+		{
+			name:              "incoming threshold multiplied",
+			proportional:      true,
+			traceState:        []string{"ot=rv:ffffffffffffff;th:c"},
+			expectedThreshold: "e",
+			expectedSampled:   true,
+		},
+		{
+			name:              "first span with a value used",
+			traceState:        []string{"vendor=value", "ot=rv:ffffffffffffff;th:c", "ot=rv:00000000000000"},
+			expectedThreshold: "c",
+			expectedSampled:   true,
+		},
+		{
+			name:              "inconsistent incoming threshold ignored",
+			traceState:        []string{"ot=rv:90000000000000;th:c"},
+			expectedThreshold: "8",
+			expectedSampled:   true,
+		},
+		{
+			name:              "not sampled",
+			traceState:        []string{"ot=rv:10000000000000"},
+			expectedThreshold: "8",
+			expectedSampled:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sampler := NewThresholdSampler(componenttest.NewNopTelemetrySettings(), 50, tt.proportional, 4).(*thresholdSampler)
+			traceID := pcommon.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}
+			trace := newTraceWithTraceState(tt.traceState...)
+
+			threshold, sampled := sampler.samplingThreshold(traceID, trace)
+			assert.Equal(t, tt.expectedThreshold, threshold.TValue())
+			assert.Equal(t, tt.expectedSampled, sampled)
+
+			decision, metadata, err := sampler.EvaluateWithMetadata(context.Background(), traceID, trace)
+			require.NoError(t, err)
+			if !tt.expectedSampled {
+				assert.Equal(t, NotSampled, decision)
+				assert.False(t, metadata.HasThreshold)
+				return
+			}
+			assert.Equal(t, Sampled, decision)
+			assert.Equal(t, DecisionMetadata{Threshold: threshold, HasThreshold: true}, metadata)
+		})
+	}
+}
+
+func TestUpdateThresholdOnSpans(t *testing.T) {
+	trace := newTraceWithTraceState("", "ot=rv:ffffffffffffff;th:8", "ot=th:f", "vendor=value", "invalid")
+
+	threshold, err := pkgsampling.TValueToThreshold("c")
+	require.NoError(t, err)
+	UpdateThresholdOnSpans(trace, threshold)
+
+	var traceStates []string
+	spans := trace.ReceivedBatches.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	for i := 0; i < spans.Len(); i++ {
+		traceStates = append(traceStates, spans.At(i).TraceState().AsRaw())
+	}
+	assert.Equal(t, []string{
+		"ot=th:c",
+		"ot=rv:ffffffffffffff;th:c",
+		// A threshold can't be lowered
+		"ot=th:f",
+		"ot=th:c,vendor=value",
+		"invalid",
+	}, traceStates)
+}
@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	// SampleRateAttribute is the span attribute recording the number of spans each
+	// span sampled by the throughput policy stands for.
+	SampleRateAttribute = "tailsampling.sample_rate"
+
+	// throughputBuckets is the number of buckets the sliding window is divided in,
+	// sampling rates are recomputed each time the window slides by one bucket.
+	throughputBuckets = 10
+	keySeparator      = "\x1f"
+	// overflowKey groups the traces whose key can't be tracked because of the
+	// maximum number of keys.
+	overflowKey = "\x00"
+)
+
+type throughputKey struct {
+	// counts holds the number of spans received in each bucket of the window.
+	counts [throughputBuckets]int64
+	total  int64
+	// ratio is the sampling probability of the traces with this key.
+	ratio float64
+	// fresh is set on the keys added since the last computation of the ratios, whose
+	// ratio is estimated from their spans in the current bucket.
+	fresh bool
+}
+
+type throughput struct {
+	logger         *zap.Logger
+	keyAttributes  []string
+	spansPerSecond int64
+	window         time.Duration
+	maxKeys        int
+
+	keys        map[string]*throughputKey
+	bucket      int
+	bucketStart time.Time
+	timeNow     func() time.Time
+}
+
+var _ MetadataEvaluator = (*throughput)(nil)
+
+// NewThroughput creates a policy evaluator sampling traces so that the spans of
+// each key, built from the given attributes, are sampled at an adaptive rate reaching
+// the target throughput over the window. Rare keys are sampled first.
+func NewThroughput(settings component.TelemetrySettings, keyAttributes []string, spansPerSecond int64, window time.Duration, maxKeys int) (PolicyEvaluator, error) {
+	if len(keyAttributes) == 0 {
+		return nil, errors.New("expected at least one key attribute")
+	}
+	if spansPerSecond <= 0 {
+		return nil, errors.New("spans_per_second must be greater than zero")
+	}
+	if window < throughputBuckets*time.Millisecond {
+		return nil, fmt.Errorf("window must be at least %v", throughputBuckets*time.Millisecond)
+	}
+	if maxKeys <= 0 {
+		return nil, errors.New("max_keys must be greater than zero")
+	}
+
+	return &throughput{
+		logger:         settings.Logger,
+		keyAttributes:  keyAttributes,
+		spansPerSecond: spansPerSecond,
+		window:         window,
+		maxKeys:        maxKeys,
+		keys:           make(map[string]*throughputKey),
+		timeNow:        time.Now,
+	}, nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (t *throughput) Evaluate(ctx context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, error) {
+	decision, _, err := t.EvaluateWithMetadata(ctx, traceID, trace)
+	return decision, err
+}
+
+// EvaluateWithMetadata returns the decision for the trace, along with the rate it is sampled at.
+func (t *throughput) EvaluateWithMetadata(_ context.Context, traceID pcommon.TraceID, trace *TraceData) (Decision, DecisionMetadata, error) {
+	t.slide(t.timeNow())
+
+	trace.Lock()
+	defer trace.Unlock()
+
+	key := t.key(trace.ReceivedBatches)
+	if _, ok := t.keys[key]; !ok && len(t.keys) >= t.maxKeys {
+		key = overflowKey
+	}
+	k, ok := t.keys[key]
+	if !ok {
+		if key == overflowKey {
+			t.logger.Debug("Maximum number of keys reached, using the overflow key")
+		}
+		k = &throughputKey{fresh: true}
+		t.keys[key] = k
+	}
+	spanCount := trace.SpanCount.Load()
+	k.counts[t.bucket] += spanCount
+	k.total += spanCount
+	if k.fresh {
+		t.estimateRatio(k)
+	}
+
+	if k.ratio < 1 && hashTraceID(key, traceID[:]) > calculateThreshold(k.ratio) {
+		return NotSampled, DecisionMetadata{}, nil
+	}
+	return Sampled, DecisionMetadata{SampleRate: 1 / k.ratio}, nil
+}
+
+// estimateRatio sets the ratio of a key added since the last computation of the ratios from
+// its spans in the current bucket, allotting it an equal share of the target throughput until
+// the ratios are computed again. Keys not seen in the window are sampled fully until they
+// exceed their share.
+func (t *throughput) estimateRatio(k *throughputKey) {
+	share := float64(t.spansPerSecond) * t.window.Seconds() / float64(len(t.keys))
+	k.ratio = min(1, share/float64(k.total))
+}
+
+// key joins the values of the key attributes found on the root span of the trace
+// or its resource.
+func (t *throughput) key(td ptrace.Traces) string {
+	values := make([]string, len(t.keyAttributes))
+	if resource, span, ok := rootSpan(td); ok {
+		for i, attr := range t.keyAttributes {
+			if v, ok := span.Attributes().Get(attr); ok {
+				values[i] = v.AsString()
+			} else if v, ok := resource.Attributes().Get(attr); ok {
+				values[i] = v.AsString()
+			}
+		}
+	}
+	return strings.Join(values, keySeparator)
+}
+
+// rootSpan returns the root span of the trace along with its resource, or the
+// first span of the trace if the root span wasn't received.
+func rootSpan(td ptrace.Traces) (pcommon.Resource, ptrace.Span, bool) {
+	var resource pcommon.Resource
+	var first ptrace.Span
+	found := false
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if span.ParentSpanID().IsEmpty() {
+					return rss.At(i).Resource(), span, true
+				}
+				if !found {
+					resource, first, found = rss.At(i).Resource(), span, true
+				}
+			}
+		}
+	}
+	return resource, first, found
+}
+
+// slide moves the window forward to the given time, recomputing the sampling
+// rates each time a bucket is completed.
+func (t *throughput) slide(now time.Time) {
+	bucketDuration := t.window / throughputBuckets
+	if t.bucketStart.IsZero() {
+		t.bucketStart = now
+		return
+	}
+
+	elapsed := int(now.Sub(t.bucketStart) / bucketDuration)
+	if elapsed == 0 {
+		return
+	}
+	// After a full window without traces, none of the counts are relevant anymore.
+	elapsed = min(elapsed, throughputBuckets)
+	t.bucketStart = now
+
+	for i := 0; i < elapsed; i++ {
+		t.bucket = (t.bucket + 1) % throughputBuckets
+		for key, k := range t.keys {
+			k.total -= k.counts[t.bucket]
+			k.counts[t.bucket] = 0
+			if k.total == 0 {
+				delete(t.keys, key)
+			}
+		}
+	}
+	t.computeRatios()
+}
+
+// computeRatios allots an equal share of the target throughput to each key.
+// Keys not using their share entirely are sampled fully, and their unused
+// share is split between the other keys.
+func (t *throughput) computeRatios() {
+	keys := make([]*throughputKey, 0, len(t.keys))
+	for _, k := range t.keys {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b *throughputKey) int {
+		return cmp.Compare(a.total, b.total)
+	})
+
+	budget := float64(t.spansPerSecond) * t.window.Seconds()
+	for i, k := range keys {
+		k.fresh = false
+		share := budget / float64(len(keys)-i)
+		if float64(k.total) <= share {
+			k.ratio = 1
+			budget -= float64(k.total)
+			continue
+		}
+		k.ratio = share / float64(k.total)
+		budget -= share
+	}
+}
+
+// SetSampleRateOnSpans records on every span of the trace the number of spans it stands for.
+func SetSampleRateOnSpans(trace *TraceData, rate float64) {
+	trace.Lock()
+	defer trace.Unlock()
+
+	rss := trace.ReceivedBatches.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				spans.At(k).Attributes().PutDouble(SampleRateAttribute, rate)
+			}
+		}
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func newThroughputTrace(resourceAttrs map[string]any, spanAttrs map[string]any, spanCount int64) *TraceData {
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	//nolint:errcheck
+	rs.Resource().Attributes().FromRaw(resourceAttrs)
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	root := spans.AppendEmpty()
+	root.SetSpanID([8]byte{1})
+	//nolint:errcheck
+	root.Attributes().FromRaw(spanAttrs)
+	for i := int64(1); i < spanCount; i++ {
+		child := spans.AppendEmpty()
+		child.SetSpanID([8]byte{byte(i + 1)})
+		child.SetParentSpanID(root.SpanID())
+	}
+
+	count := &atomic.Int64{}
+	count.Store(spanCount)
+	return &TraceData{
+		ReceivedBatches: traces,
+		SpanCount:       count,
+	}
+}
+
+func newTestThroughput(t *testing.T, spansPerSecond int64, maxKeys int) (*throughput, *time.Time) {
+	evaluator, err := NewThroughput(componenttest.NewNopTelemetrySettings(), []string{"service.name"}, spansPerSecond, 10*time.Second, maxKeys)
+	require.NoError(t, err)
+	tp := evaluator.(*throughput)
+	now := time.Unix(1700000000, 0)
+	tp.timeNow = func() time.Time { return now }
+	return tp, &now
+}
+
+func evaluateThroughput(t *testing.T, tp *throughput, service string, traces int) (sampled int) {
+	for _, id := range genRandomTraceIDs(traces) {
+		decision, err := tp.Evaluate(context.Background(), id, newThroughputTrace(map[string]any{"service.name": service}, nil, 1))
+		require.NoError(t, err)
+		if decision == Sampled {
+			sampled++
+		}
+	}
+	return sampled
+}
+
+func TestNewThroughputErrors(t *testing.T) {
+	settings := componenttest.NewNopTelemetrySettings()
+	tests := []struct {
+		name           string
+		keyAttributes  []string
+		spansPerSecond int64
+		window         time.Duration
+		maxKeys        int
+		expectedErr    string
+	}{
+		{
+			name:           "no key attributes",
+			spansPerSecond: 100,
+			window:         time.Second,
+			maxKeys:        10,
+			expectedErr:    "expected at least one key attribute",
+		},
+		{
+			name:          "no spans per second",
+			keyAttributes: []string{"service.name"},
+			window:        time.Second,
+			maxKeys:       10,
+			expectedErr:   "spans_per_second must be greater than zero",
+		},
+		{
+			name:           "window too small",
+			keyAttributes:  []string{"service.name"},
+			spansPerSecond: 100,
+			window:         time.Millisecond,
+			maxKeys:        10,
+			expectedErr:    "window must be at least 10ms",
+		},
+		{
+			name:           "no max keys",
+			keyAttributes:  []string{"service.name"},
+			spansPerSecond: 100,
+			window:         time.Second,
+			expectedErr:    "max_keys must be greater than zero",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewThroughput(settings, tt.keyAttributes, tt.spansPerSecond, tt.window, tt.maxKeys)
+			require.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestThroughputPrefersRareKeys(t *testing.T) {
+	// 10 spans per second over 10s allows 100 spans per window
+	tp, now := newTestThroughput(t, 10, 100)
+
+	// New keys are allotted an equal share of the budget until the first bucket is complete,
+	// the frequent key being sampled fully until it exceeds it
+	assert.InDelta(t, 100+100*math.Log(9), evaluateThroughput(t, tp, "frequent", 900), 40)
+	assert.Equal(t, 50, evaluateThroughput(t, tp, "rare", 50))
+
+	// The first bucket is complete, the rare key fits in its share and leaves the
+	// rest of the budget to the frequent one
+	*now = now.Add(time.Second)
+	tp.slide(*now)
+	assert.Equal(t, 1.0, tp.keys["rare"].ratio)
+	assert.InDelta(t, 50.0/900.0, tp.keys["frequent"].ratio, 1e-9)
+
+	assert.Equal(t, 50, evaluateThroughput(t, tp, "rare", 50))
+	assert.InDelta(t, 50, evaluateThroughput(t, tp, "frequent", 900), 15)
+}
+
+func TestThroughputSampleRate(t *testing.T) {
+	tp, now := newTestThroughput(t, 1, 100)
+
+	evaluateThroughput(t, tp, "frontend", 40)
+	*now = now.Add(time.Second)
+
+	for _, id := range genRandomTraceIDs(100) {
+		trace := newThroughputTrace(map[string]any{"service.name": "frontend"}, nil, 2)
+		decision, metadata, err := tp.EvaluateWithMetadata(context.Background(), id, trace)
+		require.NoError(t, err)
+
+		if decision != Sampled {
+			assert.Zero(t, metadata.SampleRate)
+			continue
+		}
+		assert.InDelta(t, 4.0, metadata.SampleRate, 1e-9)
+
+		// The spans are left untouched, the rate is recorded by the processor
+		_, ok := trace.ReceivedBatches.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().Get(SampleRateAttribute)
+		assert.False(t, ok)
+		return
+	}
+	require.Fail(t, "no trace was sampled")
+}
+
+func TestThroughputNewKeyRatio(t *testing.T) {
+	tp, now := newTestThroughput(t, 10, 100)
+	evaluateThroughput(t, tp, "frontend", 10)
+	*now = now.Add(time.Second)
+	tp.slide(*now)
+	assert.Equal(t, 1.0, tp.keys["frontend"].ratio)
+
+	// The ratio of a new key is estimated from its spans in the current bucket,
+	// with a share of half of the 100 spans budget
+	evaluateThroughput(t, tp, "backend", 40)
+	assert.Equal(t, 1.0, tp.keys["backend"].ratio)
+	evaluateThroughput(t, tp, "backend", 60)
+	assert.InDelta(t, 0.5, tp.keys["backend"].ratio, 1e-9)
+	assert.True(t, tp.keys["backend"].fresh)
+
+	*now = now.Add(time.Second)
+	tp.slide(*now)
+	assert.False(t, tp.keys["backend"].fresh)
+	assert.InDelta(t, 0.9, tp.keys["backend"].ratio, 1e-9)
+}
+
+func TestSetSampleRateOnSpans(t *testing.T) {
+	trace := newThroughputTrace(map[string]any{"service.name": "frontend"}, nil, 2)
+	SetSampleRateOnSpans(trace, 4)
+
+	spans := trace.ReceivedBatches.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	for i := 0; i < spans.Len(); i++ {
+		rate, ok := spans.At(i).Attributes().Get(SampleRateAttribute)
+		require.True(t, ok)
+		assert.InDelta(t, 4.0, rate.Double(), 1e-9)
+	}
+}
+
+func TestThroughputKey(t *testing.T) {
+	evaluator, err := NewThroughput(componenttest.NewNopTelemetrySettings(), []string{"service.name", "http.route"}, 10, time.Second, 10)
+	require.NoError(t, err)
+	tp := evaluator.(*throughput)
+
+	trace := newThroughputTrace(
+		map[string]any{"service.name": "checkout", "http.route": "/resource"},
+		map[string]any{"http.route": "/cart"},
+		1,
+	)
+	assert.Equal(t, "checkout"+keySeparator+"/cart", tp.key(trace.ReceivedBatches))
+
+	// The first span is used when the root span wasn't received
+	trace.ReceivedBatches.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetParentSpanID([8]byte{9})
+	assert.Equal(t, "checkout"+keySeparator+"/cart", tp.key(trace.ReceivedBatches))
+
+	assert.Equal(t, keySeparator, tp.key(ptrace.NewTraces()))
+}
+
+func TestThroughputMaxKeys(t *testing.T) {
+	tp, _ := newTestThroughput(t, 10, 2)
+
+	evaluateThroughput(t, tp, "a", 1)
+	evaluateThroughput(t, tp, "b", 1)
+	evaluateThroughput(t, tp, "c", 1)
+	evaluateThroughput(t, tp, "d", 1)
+
+	assert.Len(t, tp.keys, 3)
+	assert.Contains(t, tp.keys, overflowKey)
+	assert.Equal(t, int64(2), tp.keys[overflowKey].total)
+}
+
+func TestThroughputWindowExpiry(t *testing.T) {
+	tp, now := newTestThroughput(t, 10, 100)
+
+	evaluateThroughput(t, tp, "old", 10)
+	*now = now.Add(5 * time.Second)
+	evaluateThroughput(t, tp, "new", 10)
+	require.Len(t, tp.keys, 2)
+
+	// The spans of the old key leave the window first
+	*now = now.Add(5 * time.Second)
+	tp.slide(*now)
+	assert.NotContains(t, tp.keys, "old")
+	assert.Equal(t, int64(10), tp.keys["new"].total)
+
+	// Nothing is left after a full window without traces
+	*now = now.Add(time.Minute)
+	tp.slide(*now)
+	assert.Empty(t, tp.keys)
+
+	_, err := tp.Evaluate(context.Background(), pcommon.TraceID{1}, newThroughputTrace(nil, nil, 1))
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, tp.keys[""].ratio)
+}
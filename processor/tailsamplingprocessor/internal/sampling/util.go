@@ -107,3 +107,20 @@ func SetAttrOnScopeSpans(data *TraceData, attrName string, attrKey string) {
 		}
 	}
 }
+
+// SetAttrOnSpans sets the given string attribute on all the spans of the trace.
+func SetAttrOnSpans(data *TraceData, attrName string, attrValue string) {
+	data.Lock()
+	defer data.Unlock()
+
+	rs := data.ReceivedBatches.ResourceSpans()
+	for i := 0; i < rs.Len(); i++ {
+		ilss := rs.At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				spans.At(k).Attributes().PutStr(attrName, attrValue)
+			}
+		}
+	}
+}
@@ -59,6 +59,25 @@ func TestSetAttrOnScopeSpans_Many(t *testing.T) {
 	assert.False(t, ok)
 }
 
+func TestSetAttrOnSpans(t *testing.T) {
+	traces := ptrace.NewTraces()
+	ss1 := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	span1 := ss1.Spans().AppendEmpty()
+	span2 := ss1.Spans().AppendEmpty()
+	ss2 := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	span3 := ss2.Spans().AppendEmpty()
+
+	SetAttrOnSpans(&TraceData{ReceivedBatches: traces}, "test.attr", "value")
+
+	for _, span := range []ptrace.Span{span1, span2, span3} {
+		v, ok := span.Attributes().Get("test.attr")
+		assert.True(t, ok)
+		assert.Equal(t, "value", v.Str())
+	}
+	_, ok := ss1.Scope().Attributes().Get("test.attr")
+	assert.False(t, ok)
+}
+
 func BenchmarkSetAttrOnScopeSpans(b *testing.B) {
 	for n := 0; n < b.N; n++ {
 		traces := ptrace.NewTraces()
@@ -0,0 +1,302 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracestore holds the spans of traces pending a sampling decision
+// in a storage extension client, so that they don't need to be kept in memory
+// and can be recovered after a restart.
+package tracestore // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/tracestore"
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	indexKey    = "trace_index"
+	traceIDSize = len(pcommon.TraceID{})
+)
+
+var (
+	marshaler   = &ptrace.ProtoMarshaler{}
+	unmarshaler = &ptrace.ProtoUnmarshaler{}
+)
+
+// Trace describes a pending trace recovered from storage.
+type Trace struct {
+	ID          pcommon.TraceID
+	ArrivalTime time.Time
+	SpanCount   int64
+}
+
+// indexRecord is the fixed size representation of a trace in the index.
+type indexRecord struct {
+	ID          [16]byte
+	ArrivalTime int64
+	SpanCount   int64
+	Batches     int32
+	Size        int64
+}
+
+type storedTrace struct {
+	arrivalTime time.Time
+	spanCount   int64
+	// batches is the number of span batches held for the trace, the first
+	// committed ones are persisted while the others are staged.
+	batches   int32
+	committed int32
+	size      int64
+}
+
+// Store keeps track of the span batches held in storage for each trace. Batches
+// are staged in memory by Append and written, along with an index listing the
+// stored traces, in a single batch operation by Commit.
+type Store struct {
+	client  storage.Client
+	maxSize int64
+
+	mu      sync.Mutex
+	traces  map[pcommon.TraceID]*storedTrace
+	staged  map[string][]byte
+	deleted []string
+	size    int64
+	// dirty indicates whether the index changed since the last Commit.
+	dirty bool
+}
+
+// New creates a Store holding at most maxSize bytes of spans in the given client.
+func New(client storage.Client, maxSize int64) *Store {
+	return &Store{
+		client:  client,
+		maxSize: maxSize,
+		traces:  make(map[pcommon.TraceID]*storedTrace),
+		staged:  make(map[string][]byte),
+	}
+}
+
+// Recover reads the index written by the last Commit and returns the traces it lists.
+func (s *Store) Recover(ctx context.Context) ([]Trace, error) {
+	data, err := s.client.Get(ctx, indexKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trace index: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	records := make([]indexRecord, len(data)/binary.Size(indexRecord{}))
+	if err = binary.Read(bytes.NewReader(data), binary.LittleEndian, records); err != nil {
+		return nil, fmt.Errorf("failed to decode trace index: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	traces := make([]Trace, 0, len(records))
+	for _, r := range records {
+		st := &storedTrace{
+			arrivalTime: time.Unix(0, r.ArrivalTime),
+			spanCount:   r.SpanCount,
+			batches:     r.Batches,
+			committed:   r.Batches,
+			size:        r.Size,
+		}
+		s.traces[r.ID] = st
+		s.size += r.Size
+		traces = append(traces, Trace{
+			ID:          r.ID,
+			ArrivalTime: st.arrivalTime,
+			SpanCount:   st.spanCount,
+		})
+	}
+	return traces, nil
+}
+
+// Append stages the given spans to be stored for the trace. It returns false,
+// leaving the spans to the caller, when storing them would exceed the maximum size.
+func (s *Store) Append(id pcommon.TraceID, arrivalTime time.Time, spanCount int64, td ptrace.Traces) (bool, error) {
+	data, err := marshaler.MarshalTraces(td)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.size+int64(len(data)) > s.maxSize {
+		return false, nil
+	}
+
+	st, ok := s.traces[id]
+	if !ok {
+		st = &storedTrace{arrivalTime: arrivalTime}
+		s.traces[id] = st
+	}
+	s.staged[batchKey(id, st.batches)] = data
+	st.batches++
+	st.spanCount = spanCount
+	st.size += int64(len(data))
+	s.size += int64(len(data))
+	s.dirty = true
+	return true, nil
+}
+
+// Load returns the spans stored for the trace, in the order they were appended.
+func (s *Store) Load(ctx context.Context, id pcommon.TraceID) (ptrace.Traces, error) {
+	td := ptrace.NewTraces()
+
+	s.mu.Lock()
+	st, ok := s.traces[id]
+	if !ok {
+		s.mu.Unlock()
+		return td, nil
+	}
+	ops := make([]*storage.Operation, 0, st.committed)
+	for i := int32(0); i < st.committed; i++ {
+		ops = append(ops, storage.GetOperation(batchKey(id, i)))
+	}
+	staged := make([][]byte, 0, st.batches-st.committed)
+	for i := st.committed; i < st.batches; i++ {
+		staged = append(staged, s.staged[batchKey(id, i)])
+	}
+	s.mu.Unlock()
+
+	if len(ops) > 0 {
+		if err := s.client.Batch(ctx, ops...); err != nil {
+			return td, fmt.Errorf("failed to read stored spans: %w", err)
+		}
+	}
+
+	var errs error
+	for _, data := range append(valuesOf(ops), staged...) {
+		batch, err := unmarshaler.UnmarshalTraces(data)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		batch.ResourceSpans().MoveAndAppendTo(td.ResourceSpans())
+	}
+	return td, errs
+}
+
+// Remove forgets the spans stored for the trace. Their deletion from storage
+// happens on the next Commit.
+func (s *Store) Remove(id pcommon.TraceID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, ok := s.traces[id]
+	if !ok {
+		return
+	}
+	for i := int32(0); i < st.batches; i++ {
+		key := batchKey(id, i)
+		if i < st.committed {
+			s.deleted = append(s.deleted, key)
+		} else {
+			delete(s.staged, key)
+		}
+	}
+	delete(s.traces, id)
+	s.size -= st.size
+	s.dirty = true
+}
+
+// Commit writes the staged spans, deletes the removed ones and updates the
+// index in a single batch operation.
+func (s *Store) Commit(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.dirty {
+		return nil
+	}
+
+	// Deletions go first, the keys of a removed trace may be staged again if
+	// more of its spans are received.
+	ops := make([]*storage.Operation, 0, len(s.deleted)+len(s.staged)+1)
+	for _, key := range s.deleted {
+		ops = append(ops, storage.DeleteOperation(key))
+	}
+	for key, data := range s.staged {
+		ops = append(ops, storage.SetOperation(key, data))
+	}
+
+	records := make([]indexRecord, 0, len(s.traces))
+	for id, st := range s.traces {
+		records = append(records, indexRecord{
+			ID:          id,
+			ArrivalTime: st.arrivalTime.UnixNano(),
+			SpanCount:   st.spanCount,
+			Batches:     st.batches,
+			Size:        st.size,
+		})
+	}
+	var index bytes.Buffer
+	if err := binary.Write(&index, binary.LittleEndian, records); err != nil {
+		return err
+	}
+	ops = append(ops, storage.SetOperation(indexKey, index.Bytes()))
+
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		return fmt.Errorf("failed to commit stored spans: %w", err)
+	}
+
+	for _, st := range s.traces {
+		st.committed = st.batches
+	}
+	clear(s.staged)
+	s.deleted = s.deleted[:0]
+	s.dirty = false
+	return nil
+}
+
+// Size returns the number of bytes of spans held by the store.
+func (s *Store) Size() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.size
+}
+
+// SaveIDs stores the given trace IDs under the key.
+func (s *Store) SaveIDs(ctx context.Context, key string, ids []pcommon.TraceID) error {
+	data := make([]byte, 0, len(ids)*traceIDSize)
+	for _, id := range ids {
+		data = append(data, id[:]...)
+	}
+	return s.client.Set(ctx, key, data)
+}
+
+// LoadIDs returns the trace IDs stored under the key by SaveIDs.
+func (s *Store) LoadIDs(ctx context.Context, key string) ([]pcommon.TraceID, error) {
+	data, err := s.client.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]pcommon.TraceID, 0, len(data)/traceIDSize)
+	for len(data) >= traceIDSize {
+		ids = append(ids, pcommon.TraceID(data[:traceIDSize]))
+		data = data[traceIDSize:]
+	}
+	return ids, nil
+}
+
+func batchKey(id pcommon.TraceID, i int32) string {
+	return fmt.Sprintf("trace_%s_%d", id, i)
+}
+
+func valuesOf(ops []*storage.Operation) [][]byte {
+	values := make([][]byte, 0, len(ops))
+	for _, op := range ops {
+		values = append(values, op.Value)
+	}
+	return values
+}
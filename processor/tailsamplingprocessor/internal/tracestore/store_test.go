@@ -0,0 +1,237 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tracestore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type mapClient struct {
+	data    map[string][]byte
+	failing bool
+}
+
+func newMapClient() *mapClient {
+	return &mapClient{data: map[string][]byte{}}
+}
+
+func (c *mapClient) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	err := c.Batch(ctx, op)
+	return op.Value, err
+}
+
+func (c *mapClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+func (c *mapClient) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+func (c *mapClient) Batch(_ context.Context, ops ...*storage.Operation) error {
+	if c.failing {
+		return errors.New("storage unavailable")
+	}
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = c.data[op.Key]
+		case storage.Set:
+			c.data[op.Key] = op.Value
+		case storage.Delete:
+			delete(c.data, op.Key)
+		}
+	}
+	return nil
+}
+
+func (c *mapClient) Close(context.Context) error {
+	return nil
+}
+
+func newTraces(id pcommon.TraceID, names ...string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, name := range names {
+		span := spans.AppendEmpty()
+		span.SetTraceID(id)
+		span.SetName(name)
+	}
+	return td
+}
+
+func spanNames(td ptrace.Traces) []string {
+	var names []string
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		ilss := td.ResourceSpans().At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				names = append(names, spans.At(k).Name())
+			}
+		}
+	}
+	return names
+}
+
+func TestAppendLoad(t *testing.T) {
+	ctx := context.Background()
+	client := newMapClient()
+	s := New(client, 1<<20)
+	id := pcommon.TraceID{1}
+	arrival := time.Unix(1700000000, 0)
+
+	ok, err := s.Append(id, arrival, 2, newTraces(id, "a", "b"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, s.Commit(ctx))
+
+	// The second batch is only staged
+	ok, err = s.Append(id, arrival, 3, newTraces(id, "c"))
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	td, err := s.Load(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, spanNames(td))
+
+	td, err = s.Load(ctx, pcommon.TraceID{2})
+	require.NoError(t, err)
+	assert.Equal(t, 0, td.SpanCount())
+}
+
+func TestRecover(t *testing.T) {
+	ctx := context.Background()
+	client := newMapClient()
+	s := New(client, 1<<20)
+	id1 := pcommon.TraceID{1}
+	id2 := pcommon.TraceID{2}
+	arrival := time.Unix(1700000000, 0)
+
+	_, err := s.Append(id1, arrival, 1, newTraces(id1, "a"))
+	require.NoError(t, err)
+	_, err = s.Append(id2, arrival.Add(time.Second), 2, newTraces(id2, "b", "c"))
+	require.NoError(t, err)
+	require.NoError(t, s.Commit(ctx))
+
+	// Staged spans are lost if the process stops before they are committed
+	_, err = s.Append(id1, arrival, 2, newTraces(id1, "d"))
+	require.NoError(t, err)
+
+	recovered := New(client, 1<<20)
+	traces, err := recovered.Recover(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []Trace{
+		{ID: id1, ArrivalTime: arrival, SpanCount: 1},
+		{ID: id2, ArrivalTime: arrival.Add(time.Second), SpanCount: 2},
+	}, traces)
+	assert.Equal(t, s.Size()-int64(len(s.staged[batchKey(id1, 1)])), recovered.Size())
+
+	td, err := recovered.Load(ctx, id2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, spanNames(td))
+}
+
+func TestRecoverEmpty(t *testing.T) {
+	traces, err := New(newMapClient(), 1<<20).Recover(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, traces)
+}
+
+func TestRemove(t *testing.T) {
+	ctx := context.Background()
+	client := newMapClient()
+	s := New(client, 1<<20)
+	id := pcommon.TraceID{1}
+
+	_, err := s.Append(id, time.Now(), 1, newTraces(id, "a"))
+	require.NoError(t, err)
+	require.NoError(t, s.Commit(ctx))
+	_, err = s.Append(id, time.Now(), 2, newTraces(id, "b"))
+	require.NoError(t, err)
+
+	s.Remove(id)
+	assert.Zero(t, s.Size())
+	assert.Empty(t, s.staged)
+
+	// The trace is received again after it was removed
+	_, err = s.Append(id, time.Now(), 1, newTraces(id, "c"))
+	require.NoError(t, err)
+	require.NoError(t, s.Commit(ctx))
+
+	assert.Len(t, client.data, 2)
+	td, err := s.Load(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c"}, spanNames(td))
+
+	s.Remove(id)
+	require.NoError(t, s.Commit(ctx))
+	assert.Len(t, client.data, 1)
+	assert.Contains(t, client.data, indexKey)
+}
+
+func TestMaxSize(t *testing.T) {
+	id := pcommon.TraceID{1}
+	td := newTraces(id, "a")
+	size, err := marshaler.MarshalTraces(td)
+	require.NoError(t, err)
+
+	s := New(newMapClient(), int64(len(size)))
+	ok, err := s.Append(id, time.Now(), 1, td)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = s.Append(id, time.Now(), 2, newTraces(id, "b"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	s.Remove(id)
+	ok, err = s.Append(id, time.Now(), 1, newTraces(id, "b"))
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestCommitError(t *testing.T) {
+	ctx := context.Background()
+	client := newMapClient()
+	s := New(client, 1<<20)
+	id := pcommon.TraceID{1}
+
+	_, err := s.Append(id, time.Now(), 1, newTraces(id, "a"))
+	require.NoError(t, err)
+
+	client.failing = true
+	require.ErrorContains(t, s.Commit(ctx), "storage unavailable")
+
+	// The staged spans are kept until the commit succeeds
+	client.failing = false
+	require.NoError(t, s.Commit(ctx))
+	assert.Len(t, client.data, 2)
+}
+
+func TestDecisionIDs(t *testing.T) {
+	ctx := context.Background()
+	s := New(newMapClient(), 1<<20)
+	ids := []pcommon.TraceID{{1}, {2}, {3}}
+
+	require.NoError(t, s.SaveIDs(ctx, "sampled", ids))
+
+	loaded, err := s.LoadIDs(ctx, "sampled")
+	require.NoError(t, err)
+	assert.Equal(t, ids, loaded)
+
+	loaded, err = s.LoadIDs(ctx, "unknown")
+	require.NoError(t, err)
+	assert.Empty(t, loaded)
+}
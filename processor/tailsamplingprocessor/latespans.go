@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+const (
+	// lateSpanAttribute is the span attribute marking the late spans forwarded for sampled traces.
+	lateSpanAttribute = "tailsampling.late_span"
+	serviceNameKey    = "service.name"
+)
+
+type lateDecision struct {
+	decision sampling.Decision
+	time     time.Time
+}
+
+type lateDecisionExpiry struct {
+	id      pcommon.TraceID
+	expires time.Time
+}
+
+// lateDecisions keeps the decisions taken for the traces over the reopen window, so
+// that they can be applied to the spans of the traces arriving late.
+type lateDecisions struct {
+	window time.Duration
+
+	mu        sync.Mutex
+	decisions map[pcommon.TraceID]lateDecision
+	// expiries holds the trace IDs in the order their decisions were taken, which is
+	// also the order in which they expire.
+	expiries []lateDecisionExpiry
+}
+
+func newLateDecisions(window time.Duration) *lateDecisions {
+	return &lateDecisions{
+		window:    window,
+		decisions: make(map[pcommon.TraceID]lateDecision),
+	}
+}
+
+// put records the decision taken for the trace at the given time.
+func (ld *lateDecisions) put(id pcommon.TraceID, decision sampling.Decision, now time.Time) {
+	ld.mu.Lock()
+	defer ld.mu.Unlock()
+	ld.decisions[id] = lateDecision{decision: decision, time: now}
+	ld.expiries = append(ld.expiries, lateDecisionExpiry{id: id, expires: now.Add(ld.window)})
+}
+
+// get returns the decision taken for the trace, if it is still in the reopen window.
+func (ld *lateDecisions) get(id pcommon.TraceID, now time.Time) (lateDecision, bool) {
+	ld.mu.Lock()
+	defer ld.mu.Unlock()
+	d, ok := ld.decisions[id]
+	if !ok || now.Sub(d.time) >= ld.window {
+		return lateDecision{}, false
+	}
+	return d, true
+}
+
+// evict removes the decisions which are out of the reopen window.
+func (ld *lateDecisions) evict(now time.Time) {
+	ld.mu.Lock()
+	defer ld.mu.Unlock()
+	i := 0
+	for ; i < len(ld.expiries) && !ld.expiries[i].expires.After(now); i++ {
+		id := ld.expiries[i].id
+		// The trace may have been reopened and decided again since.
+		if d, ok := ld.decisions[id]; ok && !d.time.Add(ld.window).After(now) {
+			delete(ld.decisions, id)
+		}
+	}
+	clear(ld.expiries[:i])
+	ld.expiries = ld.expiries[i:]
+}
+
+// markLateSpans sets the late span attribute on all the spans.
+func markLateSpans(td ptrace.Traces) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				spans.At(k).Attributes().PutBool(lateSpanAttribute, true)
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+func TestLateDecisions(t *testing.T) {
+	ld := newLateDecisions(time.Minute)
+	now := time.Unix(1700000000, 0)
+
+	ld.put(uInt64ToTraceID(1), sampling.Sampled, now)
+	ld.put(uInt64ToTraceID(2), sampling.NotSampled, now.Add(30*time.Second))
+
+	d, ok := ld.get(uInt64ToTraceID(1), now.Add(59*time.Second))
+	require.True(t, ok)
+	assert.Equal(t, sampling.Sampled, d.decision)
+	assert.Equal(t, now, d.time)
+
+	_, ok = ld.get(uInt64ToTraceID(1), now.Add(time.Minute))
+	assert.False(t, ok)
+	_, ok = ld.get(uInt64ToTraceID(3), now)
+	assert.False(t, ok)
+
+	ld.evict(now.Add(time.Minute))
+	assert.Len(t, ld.decisions, 1)
+	assert.Len(t, ld.expiries, 1)
+
+	// A reopened trace keeps its latest decision until it expires
+	ld.put(uInt64ToTraceID(2), sampling.Sampled, now.Add(80*time.Second))
+	ld.evict(now.Add(90 * time.Second))
+	d, ok = ld.get(uInt64ToTraceID(2), now.Add(90*time.Second))
+	require.True(t, ok)
+	assert.Equal(t, sampling.Sampled, d.decision)
+
+	ld.evict(now.Add(140 * time.Second))
+	assert.Empty(t, ld.decisions)
+	assert.Empty(t, ld.expiries)
+}
+
+func TestMarkLateSpans(t *testing.T) {
+	td := simpleTraces()
+	markLateSpans(td)
+
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	for i := 0; i < spans.Len(); i++ {
+		marker, ok := spans.At(i).Attributes().Get(lateSpanAttribute)
+		require.True(t, ok)
+		assert.True(t, marker.Bool())
+	}
+}
@@ -9,12 +9,14 @@ import (
 	"fmt"
 	"math"
 	"runtime"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
@@ -23,11 +25,13 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/timeutils"
+	pkgsampling "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/sampling"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/telemetry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/tracestore"
 )
 
 // policy combines a sampling policy evaluator with the destinations to be
@@ -59,11 +63,28 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher   idbatcher.Batcher
 	sampledIDCache    cache.Cache[bool]
 	nonSampledIDCache cache.Cache[bool]
-	deleteChan        chan pcommon.TraceID
+	deleteChan        chan traceDeletion
 	numTracesOnMap    *atomic.Uint64
 	recordPolicy      bool
 	setPolicyMux      sync.Mutex
 	pendingPolicy     []PolicyCfg
+	storageID         *component.ID
+	maxStorageSize    int64
+	storageClient     storage.Client
+	traceStore        *tracestore.Store
+	shadowPolicies    []*policy
+	recordShadow      bool
+	lateDecisions     *lateDecisions
+	markLateSpans     bool
+}
+
+// traceDeletion is an entry of the queue bounding the number of traces held in
+// memory. The trace data tells the entry apart from the ones of the previous
+// instances of the same trace ID, which may have been dropped early by their
+// decision and reopened by late spans since.
+type traceDeletion struct {
+	id    pcommon.TraceID
+	trace *sampling.TraceData
 }
 
 // spanAndScope a structure for holding information about span and its instrumentation scope.
@@ -84,6 +105,23 @@ var (
 		sampling.InvertSampled:    attrSampledTrue,
 		sampling.Dropped:          attrSampledFalse,
 	}
+	decisionToString = map[sampling.Decision]string{
+		sampling.Sampled:          "sampled",
+		sampling.NotSampled:       "not_sampled",
+		sampling.InvertNotSampled: "not_sampled",
+		sampling.InvertSampled:    "sampled",
+		sampling.Dropped:          "dropped",
+	}
+)
+
+const (
+	// shadowDecisionAttributePrefix is the prefix of the span attributes holding the
+	// decisions of the shadow policies.
+	shadowDecisionAttributePrefix = "sampling.decision."
+
+	defaultSamplingPrecision = 4
+	defaultThroughputWindow  = 30 * time.Second
+	defaultThroughputMaxKeys = 10000
 )
 
 type Option func(*tailSamplingSpanProcessor)
@@ -122,7 +160,14 @@ func newTracesProcessor(ctx context.Context, set processor.Settings, nextConsume
 		nonSampledIDCache: nonSampledDecisions,
 		logger:            telemetrySettings.Logger,
 		numTracesOnMap:    &atomic.Uint64{},
-		deleteChan:        make(chan pcommon.TraceID, cfg.NumTraces),
+		deleteChan:        make(chan traceDeletion, cfg.NumTraces),
+		storageID:         cfg.Storage.Extension,
+		maxStorageSize:    cfg.Storage.MaxSizeMiB << 20,
+		recordShadow:      cfg.Shadow.RecordDecisions,
+		markLateSpans:     cfg.LateSpans.AddAttribute,
+	}
+	if cfg.LateSpans.ReopenWindow > 0 {
+		tsp.lateDecisions = newLateDecisions(cfg.LateSpans.ReopenWindow)
 	}
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
 
@@ -134,6 +179,16 @@ func newTracesProcessor(ctx context.Context, set processor.Settings, nextConsume
 		tsp.tickerFrequency = time.Second
 	}
 
+	if tsp.storageID != nil {
+		// Record the decisions so that they can be persisted and restored on start.
+		if cfg.DecisionCache.SampledCacheSize > 0 {
+			tsp.sampledIDCache = newRecordingDecisionCache(tsp.sampledIDCache, cfg.DecisionCache.SampledCacheSize)
+		}
+		if cfg.DecisionCache.NonSampledCacheSize > 0 {
+			tsp.nonSampledIDCache = newRecordingDecisionCache(tsp.nonSampledIDCache, cfg.DecisionCache.NonSampledCacheSize)
+		}
+	}
+
 	if tsp.policies == nil {
 		err := tsp.loadSamplingPolicy(cfg.PolicyCfgs)
 		if err != nil {
@@ -141,6 +196,13 @@ func newTracesProcessor(ctx context.Context, set processor.Settings, nextConsume
 		}
 	}
 
+	if tsp.shadowPolicies == nil && len(cfg.Shadow.PolicyCfgs) > 0 {
+		tsp.shadowPolicies, err = tsp.newPolicies(cfg.Shadow.PolicyCfgs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load shadow policies: %w", err)
+		}
+	}
+
 	if tsp.decisionBatcher == nil {
 		// this will start a goroutine in the background, so we run it only if everything went
 		// well in creating the policies
@@ -169,6 +231,13 @@ func withPolicies(policies []*policy) Option {
 	}
 }
 
+// withShadowPolicies sets the shadow policies evaluated alongside the sampling policies.
+func withShadowPolicies(policies []*policy) Option {
+	return func(tsp *tailSamplingSpanProcessor) {
+		tsp.shadowPolicies = policies
+	}
+}
+
 // withTickerFrequency sets the frequency at which the processor will evaluate the sampling policies.
 func withTickerFrequency(frequency time.Duration) Option {
 	return func(tsp *tailSamplingSpanProcessor) {
@@ -225,7 +294,21 @@ func getSharedPolicyEvaluator(settings component.TelemetrySettings, cfg *sharedP
 		return sampling.NewNumericAttributeFilter(settings, nafCfg.Key, &minValue, &maxValue, nafCfg.InvertMatch), nil
 	case Probabilistic:
 		pCfg := cfg.ProbabilisticCfg
-		return sampling.NewProbabilisticSampler(settings, pCfg.HashSalt, pCfg.SamplingPercentage), nil
+		precision := pCfg.SamplingPrecision
+		if precision == 0 {
+			precision = defaultSamplingPrecision
+		}
+		if precision < 0 || precision > pkgsampling.NumHexDigits {
+			return nil, fmt.Errorf("sampling_precision must be between 1 and %d", pkgsampling.NumHexDigits)
+		}
+		switch pCfg.Mode {
+		case "", HashSeed:
+			return sampling.NewProbabilisticSampler(settings, pCfg.HashSalt, pCfg.SamplingPercentage), nil
+		case Equalizing, Proportional:
+			return sampling.NewThresholdSampler(settings, pCfg.SamplingPercentage, pCfg.Mode == Proportional, precision), nil
+		default:
+			return nil, fmt.Errorf("unknown probabilistic sampling mode %q", pCfg.Mode)
+		}
 	case StringAttribute:
 		safCfg := cfg.StringAttributeCfg
 		return sampling.NewStringAttributeFilter(settings, safCfg.Key, safCfg.Values, safCfg.EnabledRegexMatching, safCfg.CacheMaxSize, safCfg.InvertMatch), nil
@@ -247,6 +330,17 @@ func getSharedPolicyEvaluator(settings component.TelemetrySettings, cfg *sharedP
 	case OTTLCondition:
 		ottlfCfg := cfg.OTTLConditionCfg
 		return sampling.NewOTTLConditionFilter(settings, ottlfCfg.SpanConditions, ottlfCfg.SpanEventConditions, ottlfCfg.ErrorMode)
+	case Throughput:
+		tpCfg := cfg.ThroughputCfg
+		window := tpCfg.Window
+		if window == 0 {
+			window = defaultThroughputWindow
+		}
+		maxKeys := tpCfg.MaxKeys
+		if maxKeys == 0 {
+			maxKeys = defaultThroughputMaxKeys
+		}
+		return sampling.NewThroughput(settings, tpCfg.KeyAttributes, tpCfg.SpansPerSecond, window, maxKeys)
 
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
@@ -258,6 +352,19 @@ type policyMetrics struct {
 }
 
 func (tsp *tailSamplingSpanProcessor) loadSamplingPolicy(cfgs []PolicyCfg) error {
+	policies, err := tsp.newPolicies(cfgs)
+	if err != nil {
+		return err
+	}
+
+	tsp.policies = policies
+
+	tsp.logger.Debug("Loaded sampling policy", zap.Int("policies.len", len(policies)))
+
+	return nil
+}
+
+func (tsp *tailSamplingSpanProcessor) newPolicies(cfgs []PolicyCfg) ([]*policy, error) {
 	telemetrySettings := tsp.set.TelemetrySettings
 	componentID := tsp.set.ID.Name()
 
@@ -267,17 +374,17 @@ func (tsp *tailSamplingSpanProcessor) loadSamplingPolicy(cfgs []PolicyCfg) error
 
 	for _, cfg := range cfgs {
 		if cfg.Name == "" {
-			return errors.New("policy name cannot be empty")
+			return nil, errors.New("policy name cannot be empty")
 		}
 
 		if _, exists := policyNames[cfg.Name]; exists {
-			return fmt.Errorf("duplicate policy name %q", cfg.Name)
+			return nil, fmt.Errorf("duplicate policy name %q", cfg.Name)
 		}
 		policyNames[cfg.Name] = struct{}{}
 
 		eval, err := getPolicyEvaluator(telemetrySettings, &cfg)
 		if err != nil {
-			return fmt.Errorf("failed to create policy evaluator for %q: %w", cfg.Name, err)
+			return nil, fmt.Errorf("failed to create policy evaluator for %q: %w", cfg.Name, err)
 		}
 
 		uniquePolicyName := cfg.Name
@@ -292,11 +399,7 @@ func (tsp *tailSamplingSpanProcessor) loadSamplingPolicy(cfgs []PolicyCfg) error
 		})
 	}
 
-	tsp.policies = policies
-
-	tsp.logger.Debug("Loaded sampling policy", zap.Int("policies.len", len(policies)))
-
-	return nil
+	return policies, nil
 }
 
 func (tsp *tailSamplingSpanProcessor) SetSamplingPolicy(cfgs []PolicyCfg) {
@@ -351,9 +454,15 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 			continue
 		}
 		trace := d.(*sampling.TraceData)
+		if tsp.traceStore != nil {
+			tsp.loadStoredSpans(ctx, id, trace)
+		}
 		trace.DecisionTime = time.Now()
 
 		decision := tsp.makeDecision(id, trace, &metrics)
+		if len(tsp.shadowPolicies) > 0 {
+			tsp.makeShadowDecision(id, trace)
+		}
 
 		tsp.telemetry.ProcessorTailSamplingGlobalCountTracesSampled.Add(tsp.ctx, 1, decisionToAttribute[decision])
 
@@ -364,6 +473,10 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace.ReceivedBatches = ptrace.NewTraces()
 		trace.Unlock()
 
+		if tsp.lateDecisions != nil && (decision == sampling.Sampled || decision == sampling.NotSampled) {
+			tsp.lateDecisions.put(id, decision, trace.DecisionTime)
+		}
+
 		switch decision {
 		case sampling.Sampled:
 			tsp.releaseSampledTrace(ctx, id, allSpans)
@@ -372,6 +485,14 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		}
 	}
 
+	if tsp.traceStore != nil {
+		tsp.spillTraces(ctx)
+		tsp.saveDecisions(ctx)
+	}
+	if tsp.lateDecisions != nil {
+		tsp.lateDecisions.evict(time.Now())
+	}
+
 	tsp.telemetry.ProcessorTailSamplingSamplingDecisionTimerLatency.Record(tsp.ctx, int64(time.Since(startTime)/time.Millisecond))
 	tsp.telemetry.ProcessorTailSamplingSamplingTracesOnMemory.Record(tsp.ctx, int64(tsp.numTracesOnMap.Load()))
 	tsp.telemetry.ProcessorTailSamplingSamplingTraceDroppedTooEarly.Add(tsp.ctx, metrics.idNotFoundOnMapCount)
@@ -387,7 +508,6 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 }
 
 func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sampling.TraceData, metrics *policyMetrics) sampling.Decision {
-	finalDecision := sampling.NotSampled
 	samplingDecisions := map[sampling.Decision]*policy{
 		sampling.Error:            nil,
 		sampling.Sampled:          nil,
@@ -400,9 +520,17 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 	ctx := context.Background()
 	startTime := time.Now()
 
+	// The threshold is recorded in the tracestate only when all the policies sampling
+	// the trace did so with a consistent probability threshold. The trace is then
+	// sampled with the lowest of these thresholds.
+	threshold := pkgsampling.NeverSampleThreshold
+	thresholdOnly := true
+	// sampleRate is the rate the first policy sampling the trace sampled it at, if any.
+	var sampleRate float64
+
 	// Check all policies before making a final decision.
 	for _, p := range tsp.policies {
-		decision, err := p.evaluator.Evaluate(ctx, id, trace)
+		decision, metadata, err := sampling.EvaluateWithMetadata(ctx, p.evaluator, id, trace)
 		latency := time.Since(startTime)
 		tsp.telemetry.ProcessorTailSamplingSamplingDecisionLatency.Record(ctx, int64(latency/time.Microsecond), p.attribute)
 
@@ -415,6 +543,15 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 			continue
 		}
 
+		if decision == sampling.Sampled || decision == sampling.InvertSampled {
+			switch {
+			case !metadata.HasThreshold || decision == sampling.InvertSampled:
+				thresholdOnly = false
+			case pkgsampling.ThresholdLessThan(metadata.Threshold, threshold):
+				threshold = metadata.Threshold
+			}
+		}
+
 		tsp.telemetry.ProcessorTailSamplingCountTracesSampled.Add(ctx, 1, p.attribute, decisionToAttribute[decision])
 
 		if telemetry.IsMetricStatCountSpansSampledEnabled() {
@@ -424,6 +561,9 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 		// We associate the first policy with the sampling decision to understand what policy sampled a span
 		if samplingDecisions[decision] == nil {
 			samplingDecisions[decision] = p
+			if decision == sampling.Sampled {
+				sampleRate = metadata.SampleRate
+			}
 		}
 
 		// Break early if dropped. This can drastically reduce tick/decision latency.
@@ -432,19 +572,14 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 		}
 	}
 
-	var sampledPolicy *policy
+	finalDecision, sampledPolicy := combineDecisions(samplingDecisions)
 
-	switch {
-	case samplingDecisions[sampling.Dropped] != nil: // Dropped takes precedence
-		finalDecision = sampling.NotSampled
-	case samplingDecisions[sampling.InvertNotSampled] != nil: // Then InvertNotSampled
-		finalDecision = sampling.NotSampled
-	case samplingDecisions[sampling.Sampled] != nil:
-		finalDecision = sampling.Sampled
-		sampledPolicy = samplingDecisions[sampling.Sampled]
-	case samplingDecisions[sampling.InvertSampled] != nil && samplingDecisions[sampling.NotSampled] == nil:
-		finalDecision = sampling.Sampled
-		sampledPolicy = samplingDecisions[sampling.InvertSampled]
+	if finalDecision == sampling.Sampled && thresholdOnly && pkgsampling.ThresholdLessThan(pkgsampling.AlwaysSampleThreshold, threshold) {
+		sampling.UpdateThresholdOnSpans(trace, threshold)
+	}
+
+	if finalDecision == sampling.Sampled && sampleRate > 0 {
+		sampling.SetSampleRateOnSpans(trace, sampleRate)
 	}
 
 	if tsp.recordPolicy && sampledPolicy != nil {
@@ -461,6 +596,50 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pcommon.TraceID, trace *sa
 	return finalDecision
 }
 
+// combineDecisions returns the final decision for a trace given the first policy that
+// returned each decision, along with the policy the trace is sampled by.
+func combineDecisions(samplingDecisions map[sampling.Decision]*policy) (sampling.Decision, *policy) {
+	switch {
+	case samplingDecisions[sampling.Dropped] != nil: // Dropped takes precedence
+		return sampling.NotSampled, nil
+	case samplingDecisions[sampling.InvertNotSampled] != nil: // Then InvertNotSampled
+		return sampling.NotSampled, nil
+	case samplingDecisions[sampling.Sampled] != nil:
+		return sampling.Sampled, samplingDecisions[sampling.Sampled]
+	case samplingDecisions[sampling.InvertSampled] != nil && samplingDecisions[sampling.NotSampled] == nil:
+		return sampling.Sampled, samplingDecisions[sampling.InvertSampled]
+	}
+	return sampling.NotSampled, nil
+}
+
+// makeShadowDecision evaluates the shadow policies and reports the decisions they
+// would make for the trace. The live decision is not affected.
+func (tsp *tailSamplingSpanProcessor) makeShadowDecision(id pcommon.TraceID, trace *sampling.TraceData) {
+	samplingDecisions := make(map[sampling.Decision]*policy)
+	ctx := context.Background()
+
+	for _, p := range tsp.shadowPolicies {
+		decision, err := p.evaluator.Evaluate(ctx, id, trace)
+		if err != nil {
+			tsp.logger.Debug("Shadow sampling policy error", zap.String("policy", p.name), zap.Error(err))
+			continue
+		}
+
+		tsp.telemetry.ProcessorTailSamplingShadowCountTracesSampled.Add(ctx, 1, p.attribute, decisionToAttribute[decision])
+
+		if tsp.recordShadow {
+			sampling.SetAttrOnSpans(trace, shadowDecisionAttributePrefix+p.name, decisionToString[decision])
+		}
+
+		if samplingDecisions[decision] == nil {
+			samplingDecisions[decision] = p
+		}
+	}
+
+	finalDecision, _ := combineDecisions(samplingDecisions)
+	tsp.telemetry.ProcessorTailSamplingShadowGlobalCountTracesSampled.Add(ctx, 1, decisionToAttribute[finalDecision])
+}
+
 // ConsumeTraces is required by the processor.Traces interface.
 func (tsp *tailSamplingSpanProcessor) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
 	resourceSpans := td.ResourceSpans()
@@ -500,10 +679,7 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 		// If the trace ID is in the sampled cache, short circuit the decision
 		if _, ok := tsp.sampledIDCache.Get(id); ok {
 			tsp.logger.Debug("Trace ID is in the sampled cache", zap.Stringer("id", id))
-			traceTd := ptrace.NewTraces()
-			appendToTraces(traceTd, resourceSpans, spans)
-			tsp.releaseSampledTrace(tsp.ctx, id, traceTd)
-			metric.WithAttributeSet(attribute.NewSet())
+			tsp.releaseLateSpans(id, resourceSpans, spans, sampling.Sampled, time.Time{})
 			tsp.telemetry.ProcessorTailSamplingEarlyReleasesFromCacheDecision.
 				Add(tsp.ctx, int64(len(spans)), attrSampledTrue)
 			continue
@@ -511,10 +687,18 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 		// If the trace ID is in the non-sampled cache, short circuit the decision
 		if _, ok := tsp.nonSampledIDCache.Get(id); ok {
 			tsp.logger.Debug("Trace ID is in the non-sampled cache", zap.Stringer("id", id))
+			tsp.releaseLateSpans(id, resourceSpans, spans, sampling.NotSampled, time.Time{})
 			tsp.telemetry.ProcessorTailSamplingEarlyReleasesFromCacheDecision.
 				Add(tsp.ctx, int64(len(spans)), attrSampledFalse)
 			continue
 		}
+		// If the decision is still in the reopen window, apply it to the late spans
+		if tsp.lateDecisions != nil {
+			if late, ok := tsp.lateDecisions.get(id, currTime); ok {
+				tsp.releaseLateSpans(id, resourceSpans, spans, late.decision, late.time)
+				continue
+			}
+		}
 
 		lenSpans := int64(len(spans))
 
@@ -533,16 +717,7 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 				newTraceIDs++
 				tsp.decisionBatcher.AddToCurrentBatch(id)
 				tsp.numTracesOnMap.Add(1)
-				postDeletion := false
-				for !postDeletion {
-					select {
-					case tsp.deleteChan <- id:
-						postDeletion = true
-					default:
-						traceKeyToDrop := <-tsp.deleteChan
-						tsp.dropTrace(traceKeyToDrop, currTime)
-					}
-				}
+				tsp.enqueueForDeletion(id, td, currTime)
 			}
 		}
 
@@ -563,20 +738,7 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 
 		actualData.Unlock()
 
-		switch finalDecision {
-		case sampling.Sampled:
-			traceTd := ptrace.NewTraces()
-			appendToTraces(traceTd, resourceSpans, spans)
-			tsp.releaseSampledTrace(tsp.ctx, id, traceTd)
-		case sampling.NotSampled:
-			tsp.releaseNotSampledTrace(id)
-		default:
-			tsp.logger.Warn("Unexpected sampling decision", zap.Int("decision", int(finalDecision)))
-		}
-
-		if !actualData.DecisionTime.IsZero() {
-			tsp.telemetry.ProcessorTailSamplingSamplingLateSpanAge.Record(tsp.ctx, int64(time.Since(actualData.DecisionTime)/time.Second))
-		}
+		tsp.releaseLateSpans(id, resourceSpans, spans, finalDecision, actualData.DecisionTime)
 	}
 
 	tsp.telemetry.ProcessorTailSamplingNewTraceIDReceived.Add(tsp.ctx, newTraceIDs)
@@ -587,34 +749,99 @@ func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
+	if tsp.storageID != nil {
+		if err := tsp.startStorage(ctx, host); err != nil {
+			return err
+		}
+	}
 	tsp.policyTicker.Start(tsp.tickerFrequency)
 	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
+	if tsp.traceStore != nil {
+		return tsp.shutdownStorage(ctx)
+	}
 	return nil
 }
 
+// enqueueForDeletion adds a new trace to the queue bounding the number of traces
+// kept by the processor, dropping the oldest ones when the queue is full.
+func (tsp *tailSamplingSpanProcessor) enqueueForDeletion(id pcommon.TraceID, trace *sampling.TraceData, currTime time.Time) {
+	for {
+		select {
+		case tsp.deleteChan <- traceDeletion{id: id, trace: trace}:
+			return
+		default:
+			toDrop := <-tsp.deleteChan
+			tsp.dropTraceData(toDrop.id, toDrop.trace, currTime)
+		}
+	}
+}
+
 func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletionTime time.Time) {
-	var trace *sampling.TraceData
-	if d, ok := tsp.idToTrace.Load(traceID); ok {
-		trace = d.(*sampling.TraceData)
-		tsp.idToTrace.Delete(traceID)
-		// Subtract one from numTracesOnMap per https://godoc.org/sync/atomic#AddUint64
-		tsp.numTracesOnMap.Add(^uint64(0))
-	}
-	if trace == nil {
+	d, ok := tsp.idToTrace.Load(traceID)
+	if !ok {
 		tsp.logger.Debug("Attempt to delete trace ID not on table", zap.Stringer("id", traceID))
 		return
 	}
+	tsp.dropTraceData(traceID, d.(*sampling.TraceData), deletionTime)
+}
+
+// dropTraceData removes the trace from memory, unless the trace ID now holds
+// another instance of the trace.
+func (tsp *tailSamplingSpanProcessor) dropTraceData(traceID pcommon.TraceID, trace *sampling.TraceData, deletionTime time.Time) {
+	if !tsp.idToTrace.CompareAndDelete(traceID, trace) {
+		tsp.logger.Debug("Attempt to delete trace no longer on table", zap.Stringer("id", traceID))
+		return
+	}
+	// Subtract one from numTracesOnMap per https://godoc.org/sync/atomic#AddUint64
+	tsp.numTracesOnMap.Add(^uint64(0))
+
+	if tsp.traceStore != nil {
+		trace.Lock()
+		tsp.traceStore.Remove(traceID)
+		trace.Unlock()
+	}
 
 	tsp.telemetry.ProcessorTailSamplingSamplingTraceRemovalAge.Record(tsp.ctx, int64(deletionTime.Sub(trace.ArrivalTime)/time.Second))
 }
 
+// releaseLateSpans applies the decision taken for a trace to its spans arriving after
+// it. The decision time is zero when unknown.
+func (tsp *tailSamplingSpanProcessor) releaseLateSpans(id pcommon.TraceID, resourceSpans ptrace.ResourceSpans, spans []spanAndScope, decision sampling.Decision, decisionTime time.Time) {
+	switch decision {
+	case sampling.Sampled:
+		traceTd := ptrace.NewTraces()
+		appendToTraces(traceTd, resourceSpans, spans)
+		if tsp.markLateSpans {
+			markLateSpans(traceTd)
+		}
+		tsp.releaseSampledTrace(tsp.ctx, id, traceTd)
+	case sampling.NotSampled:
+		tsp.releaseNotSampledTrace(id)
+	default:
+		tsp.logger.Warn("Unexpected sampling decision", zap.Int("decision", int(decision)))
+		return
+	}
+
+	var serviceName string
+	if v, ok := resourceSpans.Resource().Attributes().Get(serviceNameKey); ok {
+		serviceName = v.AsString()
+	}
+	tsp.telemetry.ProcessorTailSamplingSamplingLateSpans.Add(tsp.ctx, int64(len(spans)), metric.WithAttributes(
+		attribute.String(serviceNameKey, serviceName),
+		attribute.String("sampled", strconv.FormatBool(decision == sampling.Sampled)),
+	))
+	if !decisionTime.IsZero() {
+		tsp.telemetry.ProcessorTailSamplingSamplingLateSpanAge.Record(tsp.ctx, int64(time.Since(decisionTime)/time.Second))
+	}
+}
+
 // releaseSampledTrace sends the trace data to the next consumer. It
 // additionally adds the trace ID to the cache of sampled trace IDs. If the
 // trace ID is cached, it deletes the spans from the internal map.
@@ -625,8 +852,9 @@ func (tsp *tailSamplingSpanProcessor) releaseSampledTrace(ctx context.Context, i
 			"Error sending spans to destination",
 			zap.Error(err))
 	}
+	// The trace can be removed once its decision is kept for its late spans.
 	_, ok := tsp.sampledIDCache.Get(id)
-	if ok {
+	if ok || tsp.lateDecisions != nil {
 		tsp.dropTrace(id, time.Now())
 	}
 }
@@ -636,7 +864,7 @@ func (tsp *tailSamplingSpanProcessor) releaseSampledTrace(ctx context.Context, i
 func (tsp *tailSamplingSpanProcessor) releaseNotSampledTrace(id pcommon.TraceID) {
 	tsp.nonSampledIDCache.Put(id, true)
 	_, ok := tsp.nonSampledIDCache.Get(id)
-	if ok {
+	if ok || tsp.lateDecisions != nil {
 		tsp.dropTrace(id, time.Now())
 	}
 }
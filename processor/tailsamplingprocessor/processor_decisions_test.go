@@ -6,6 +6,7 @@ package tailsamplingprocessor
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -411,6 +412,222 @@ func TestSamplingPolicyDecisionInvertNotSampled_WithRecordPolicy(t *testing.T) {
 	require.Equal(t, 0, nextConsumer.SpanCount())
 }
 
+func TestShadowPoliciesDoNotAffectDecision(t *testing.T) {
+	nextConsumer := new(consumertest.TracesSink)
+	idb := newSyncIDBatcher()
+
+	mpe := &mockPolicyEvaluator{}
+	shadow1 := &mockPolicyEvaluator{}
+	shadow2 := &mockPolicyEvaluator{}
+
+	policies := []*policy{
+		{name: "mock-policy-1", evaluator: mpe, attribute: metric.WithAttributes(attribute.String("policy", "mock-policy-1"))},
+	}
+	shadowPolicies := []*policy{
+		{name: "mock-shadow-1", evaluator: shadow1, attribute: metric.WithAttributes(attribute.String("policy", "mock-shadow-1"))},
+		{name: "mock-shadow-2", evaluator: shadow2, attribute: metric.WithAttributes(attribute.String("policy", "mock-shadow-2"))},
+	}
+
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    defaultNumTraces,
+		Shadow:       ShadowConfig{RecordDecisions: true},
+		Options:      []Option{withDecisionBatcher(idb), withPolicies(policies), withShadowPolicies(shadowPolicies)},
+	}
+	p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), nextConsumer, cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	tsp := p.(*tailSamplingSpanProcessor)
+	_, batches := generateIDsAndBatches(2)
+
+	// The shadow policies would sample the trace, the live one doesn't
+	mpe.NextDecision = sampling.NotSampled
+	shadow1.NextDecision = sampling.Sampled
+	shadow2.NextDecision = sampling.Sampled
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), batches[0]))
+	tsp.policyTicker.OnTick() // the first tick always gets an empty batch
+	tsp.policyTicker.OnTick()
+
+	require.Equal(t, 1, shadow1.EvaluationCount)
+	require.Equal(t, 1, shadow2.EvaluationCount)
+	require.Equal(t, 0, nextConsumer.SpanCount())
+
+	// The live policy samples the trace, the shadow ones record their decisions
+	mpe.NextDecision = sampling.Sampled
+	shadow1.NextDecision = sampling.NotSampled
+	shadow2.NextDecision = sampling.Dropped
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), batches[1]))
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+
+	require.Equal(t, 1, nextConsumer.SpanCount())
+	attrs := nextConsumer.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes()
+	decision, ok := attrs.Get("sampling.decision.mock-shadow-1")
+	require.True(t, ok)
+	assert.Equal(t, "not_sampled", decision.Str())
+	decision, ok = attrs.Get("sampling.decision.mock-shadow-2")
+	require.True(t, ok)
+	assert.Equal(t, "dropped", decision.Str())
+}
+
+func TestSamplingThresholdRecordedInTraceState(t *testing.T) {
+	probabilistic := PolicyCfg{
+		sharedPolicyCfg: sharedPolicyCfg{
+			Name:             "probabilistic",
+			Type:             Probabilistic,
+			ProbabilisticCfg: ProbabilisticCfg{SamplingPercentage: 50, Mode: Equalizing},
+		},
+	}
+	always := PolicyCfg{
+		sharedPolicyCfg: sharedPolicyCfg{
+			Name: "always",
+			Type: AlwaysSample,
+		},
+	}
+
+	tests := []struct {
+		name               string
+		policies           []PolicyCfg
+		expectedTraceState string
+	}{
+		{
+			name:               "sampled with a threshold",
+			policies:           []PolicyCfg{probabilistic},
+			expectedTraceState: "ot=rv:ffffffffffffff;th:8",
+		},
+		{
+			name:               "also sampled without a threshold",
+			policies:           []PolicyCfg{probabilistic, always},
+			expectedTraceState: "ot=rv:ffffffffffffff",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextConsumer := new(consumertest.TracesSink)
+			cfg := Config{
+				DecisionWait: defaultTestDecisionWait,
+				NumTraces:    defaultNumTraces,
+				PolicyCfgs:   tt.policies,
+				Options:      []Option{withDecisionBatcher(newSyncIDBatcher())},
+			}
+			p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), nextConsumer, cfg)
+			require.NoError(t, err)
+
+			require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, p.Shutdown(context.Background()))
+			}()
+
+			td := simpleTraces()
+			td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceState().FromRaw("ot=rv:ffffffffffffff")
+			require.NoError(t, p.ConsumeTraces(context.Background(), td))
+
+			tsp := p.(*tailSamplingSpanProcessor)
+			tsp.policyTicker.OnTick() // the first tick always gets an empty batch
+			tsp.policyTicker.OnTick()
+
+			require.Equal(t, 1, nextConsumer.SpanCount())
+			span := nextConsumer.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			assert.Equal(t, tt.expectedTraceState, span.TraceState().AsRaw())
+		})
+	}
+}
+
+func TestSampleRateRecordedForThroughputPolicy(t *testing.T) {
+	throughput := PolicyCfg{
+		sharedPolicyCfg: sharedPolicyCfg{
+			Name:          "throughput",
+			Type:          Throughput,
+			ThroughputCfg: ThroughputCfg{KeyAttributes: []string{"service.name"}, SpansPerSecond: 100},
+		},
+	}
+	always := PolicyCfg{
+		sharedPolicyCfg: sharedPolicyCfg{
+			Name: "always",
+			Type: AlwaysSample,
+		},
+	}
+	and := PolicyCfg{
+		sharedPolicyCfg: sharedPolicyCfg{
+			Name: "and",
+			Type: And,
+		},
+		AndCfg: AndCfg{
+			SubPolicyCfg: []AndSubPolicyCfg{
+				{sharedPolicyCfg: sharedPolicyCfg{Name: "always", Type: AlwaysSample}},
+				{sharedPolicyCfg: throughput.sharedPolicyCfg},
+			},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		policies         []PolicyCfg
+		shadowPolicies   []PolicyCfg
+		expectSampleRate bool
+	}{
+		{
+			name:             "sampled by the throughput policy",
+			policies:         []PolicyCfg{throughput},
+			expectSampleRate: true,
+		},
+		{
+			name:             "sampled by the throughput sub-policy of an and policy",
+			policies:         []PolicyCfg{and},
+			expectSampleRate: true,
+		},
+		{
+			name:     "sampled by another policy first",
+			policies: []PolicyCfg{always, throughput},
+		},
+		{
+			name:           "throughput shadow policy",
+			policies:       []PolicyCfg{always},
+			shadowPolicies: []PolicyCfg{throughput},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextConsumer := new(consumertest.TracesSink)
+			cfg := Config{
+				DecisionWait: defaultTestDecisionWait,
+				NumTraces:    defaultNumTraces,
+				PolicyCfgs:   tt.policies,
+				Shadow:       ShadowConfig{PolicyCfgs: tt.shadowPolicies},
+				Options:      []Option{withDecisionBatcher(newSyncIDBatcher())},
+			}
+			p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), nextConsumer, cfg)
+			require.NoError(t, err)
+
+			require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() {
+				require.NoError(t, p.Shutdown(context.Background()))
+			}()
+
+			require.NoError(t, p.ConsumeTraces(context.Background(), simpleTraces()))
+
+			tsp := p.(*tailSamplingSpanProcessor)
+			tsp.policyTicker.OnTick() // the first tick always gets an empty batch
+			tsp.policyTicker.OnTick()
+
+			require.Equal(t, 1, nextConsumer.SpanCount())
+			span := nextConsumer.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+			rate, ok := span.Attributes().Get(sampling.SampleRateAttribute)
+			require.Equal(t, tt.expectSampleRate, ok)
+			if ok {
+				assert.Equal(t, 1.0, rate.Double())
+			}
+		})
+	}
+}
+
 func TestLateArrivingSpansAssignedOriginalDecision(t *testing.T) {
 	nextConsumer := new(consumertest.TracesSink)
 	idb := newSyncIDBatcher()
@@ -636,3 +853,126 @@ func TestLateSpanUsesNonSampledDecisionCache(t *testing.T) {
 	require.Equal(t, 1, mpe.EvaluationCount)
 	require.Equal(t, 0, nextConsumer.SpanCount(), "original final decision not honored")
 }
+
+func TestLateSpansReopenWindow(t *testing.T) {
+	nextConsumer := new(consumertest.TracesSink)
+	idb := newSyncIDBatcher()
+
+	mpe := &mockPolicyEvaluator{}
+	policies := []*policy{
+		{name: "mock-policy-1", evaluator: mpe, attribute: metric.WithAttributes(attribute.String("policy", "mock-policy-1"))},
+	}
+
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    defaultNumTraces,
+		LateSpans:    LateSpansConfig{ReopenWindow: time.Minute, AddAttribute: true},
+		Options: []Option{
+			withDecisionBatcher(idb),
+			withPolicies(policies),
+		},
+	}
+	p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), nextConsumer, cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	traceID := uInt64ToTraceID(1)
+	spanIndexToTraces := func(spanIndex uint64) ptrace.Traces {
+		traces := ptrace.NewTraces()
+		span := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetTraceID(traceID)
+		span.SetSpanID(uInt64ToSpanID(spanIndex))
+		return traces
+	}
+
+	mpe.NextDecision = sampling.Sampled
+	require.NoError(t, p.ConsumeTraces(context.Background(), spanIndexToTraces(1)))
+
+	tsp := p.(*tailSamplingSpanProcessor)
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	require.Equal(t, 1, mpe.EvaluationCount)
+	require.Equal(t, 1, nextConsumer.SpanCount())
+
+	// The trace is removed from memory, its decision is kept for the late spans
+	_, ok := tsp.idToTrace.Load(traceID)
+	require.False(t, ok)
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), spanIndexToTraces(2)))
+	require.Equal(t, 1, mpe.EvaluationCount)
+	require.Equal(t, 2, nextConsumer.SpanCount())
+
+	lateSpan := nextConsumer.AllTraces()[1].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	marker, ok := lateSpan.Attributes().Get(lateSpanAttribute)
+	require.True(t, ok)
+	assert.True(t, marker.Bool())
+
+	// Once out of the window, a late span reopens the trace
+	tsp.lateDecisions.evict(time.Now().Add(time.Minute))
+	mpe.NextDecision = sampling.NotSampled
+	require.NoError(t, p.ConsumeTraces(context.Background(), spanIndexToTraces(3)))
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	require.Equal(t, 2, mpe.EvaluationCount)
+	require.Equal(t, 2, nextConsumer.SpanCount())
+}
+
+func TestLateSpansReopenedTraceKeptInMemory(t *testing.T) {
+	nextConsumer := new(consumertest.TracesSink)
+	idb := newSyncIDBatcher()
+
+	mpe := &mockPolicyEvaluator{}
+	policies := []*policy{
+		{name: "mock-policy-1", evaluator: mpe, attribute: metric.WithAttributes(attribute.String("policy", "mock-policy-1"))},
+	}
+
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    2,
+		LateSpans:    LateSpansConfig{ReopenWindow: time.Minute},
+		Options: []Option{
+			withDecisionBatcher(idb),
+			withPolicies(policies),
+		},
+	}
+	p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), nextConsumer, cfg)
+	require.NoError(t, err)
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	reopenedID := uInt64ToTraceID(1)
+	mpe.NextDecision = sampling.Sampled
+	require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(reopenedID)))
+
+	tsp := p.(*tailSamplingSpanProcessor)
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	require.Equal(t, 1, nextConsumer.SpanCount())
+	_, ok := tsp.idToTrace.Load(reopenedID)
+	require.False(t, ok)
+
+	// Once out of the window, a late span reopens the trace
+	tsp.lateDecisions.evict(time.Now().Add(time.Minute))
+	require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(reopenedID)))
+	_, ok = tsp.idToTrace.Load(reopenedID)
+	require.True(t, ok)
+
+	// The queue is full, the entry of the dropped trace is dequeued without
+	// evicting the reopened trace
+	require.NoError(t, p.ConsumeTraces(context.Background(), simpleTracesWithID(uInt64ToTraceID(2))))
+	_, ok = tsp.idToTrace.Load(reopenedID)
+	require.True(t, ok)
+	assert.Equal(t, uint64(2), tsp.numTracesOnMap.Load())
+
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	require.Equal(t, 3, mpe.EvaluationCount)
+	require.Equal(t, 3, nextConsumer.SpanCount())
+}
@@ -13,6 +13,7 @@ import (
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/featuregate"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
@@ -540,6 +541,100 @@ func TestProcessorTailSamplingSamplingLateSpanAge(t *testing.T) {
 	metricdatatest.AssertEqual(t, m, got, metricdatatest.IgnoreTimestamp())
 }
 
+func TestProcessorTailSamplingSamplingLateSpans(t *testing.T) {
+	// prepare
+	s := setupTestTelemetry()
+	b := newSyncIDBatcher()
+	syncBatcher := b.(*syncIDBatcher)
+
+	cfg := Config{
+		DecisionWait: 1,
+		NumTraces:    100,
+		PolicyCfgs: []PolicyCfg{
+			{
+				sharedPolicyCfg: sharedPolicyCfg{
+					Name:               "string",
+					Type:               StringAttribute,
+					StringAttributeCfg: StringAttributeCfg{Key: "key", Values: []string{"value"}},
+				},
+			},
+		},
+		Options: []Option{
+			withDecisionBatcher(syncBatcher),
+		},
+	}
+	cs := &consumertest.TracesSink{}
+	ct := s.newSettings()
+	proc, err := newTracesProcessor(context.Background(), ct, cs, cfg)
+	require.NoError(t, err)
+	defer func() {
+		err = proc.Shutdown(context.Background())
+		require.NoError(t, err)
+	}()
+
+	err = proc.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	// test
+	newTrace := func(traceID pcommon.TraceID, service string, sampled bool) ptrace.Traces {
+		traces := ptrace.NewTraces()
+		rs := traces.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", service)
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetTraceID(traceID)
+		if sampled {
+			span.Attributes().PutStr("key", "value")
+		}
+		return traces
+	}
+
+	sampledID, notSampledID := uInt64ToTraceID(1), uInt64ToTraceID(2)
+	require.NoError(t, proc.ConsumeTraces(context.Background(), newTrace(sampledID, "frontend", true)))
+	require.NoError(t, proc.ConsumeTraces(context.Background(), newTrace(notSampledID, "frontend", false)))
+
+	tsp := proc.(*tailSamplingSpanProcessor)
+	tsp.policyTicker.OnTick() // the first tick always gets an empty batch
+	tsp.policyTicker.OnTick()
+
+	require.NoError(t, proc.ConsumeTraces(context.Background(), newTrace(sampledID, "backend", false)))
+	require.NoError(t, proc.ConsumeTraces(context.Background(), newTrace(sampledID, "backend", false)))
+	require.NoError(t, proc.ConsumeTraces(context.Background(), newTrace(notSampledID, "frontend", false)))
+
+	// verify
+	var md metricdata.ResourceMetrics
+	require.NoError(t, s.reader.Collect(context.Background(), &md))
+
+	m := metricdata.Metrics{
+		Name:        "otelcol_processor_tail_sampling_sampling_late_spans",
+		Description: "Count of spans that arrived after the sampling decision of their trace was taken",
+		Unit:        "{spans}",
+		Data: metricdata.Sum[int64]{
+			IsMonotonic: true,
+			Temporality: metricdata.CumulativeTemporality,
+			DataPoints: []metricdata.DataPoint[int64]{
+				{
+					Attributes: attribute.NewSet(
+						attribute.String("service.name", "backend"),
+						attribute.String("sampled", "true"),
+					),
+					Value: 2,
+				},
+				{
+					Attributes: attribute.NewSet(
+						attribute.String("service.name", "frontend"),
+						attribute.String("sampled", "false"),
+					),
+					Value: 1,
+				},
+			},
+		},
+	}
+
+	got := s.getMetric(m.Name, md)
+
+	metricdatatest.AssertEqual(t, m, got, metricdatatest.IgnoreTimestamp())
+}
+
 func TestProcessorTailSamplingSamplingTraceDroppedTooEarly(t *testing.T) {
 	// prepare
 	s := setupTestTelemetry()
@@ -678,6 +773,123 @@ func TestProcessorTailSamplingSamplingPolicyEvaluationError(t *testing.T) {
 	metricdatatest.AssertEqual(t, m, got, metricdatatest.IgnoreTimestamp())
 }
 
+func TestProcessorTailSamplingShadowCountTracesSampled(t *testing.T) {
+	// prepare
+	s := setupTestTelemetry()
+	b := newSyncIDBatcher()
+	syncBatcher := b.(*syncIDBatcher)
+
+	cfg := Config{
+		DecisionWait: 1,
+		NumTraces:    100,
+		PolicyCfgs: []PolicyCfg{
+			{
+				sharedPolicyCfg: sharedPolicyCfg{
+					Name:               "string",
+					Type:               StringAttribute,
+					StringAttributeCfg: StringAttributeCfg{Key: "key", Values: []string{"value"}},
+				},
+			},
+		},
+		Shadow: ShadowConfig{
+			PolicyCfgs: []PolicyCfg{
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name: "always",
+						Type: AlwaysSample,
+					},
+				},
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name:               "string",
+						Type:               StringAttribute,
+						StringAttributeCfg: StringAttributeCfg{Key: "key", Values: []string{"value"}},
+					},
+				},
+			},
+		},
+		Options: []Option{
+			withDecisionBatcher(syncBatcher),
+		},
+	}
+	cs := &consumertest.TracesSink{}
+	ct := s.newSettings()
+	proc, err := newTracesProcessor(context.Background(), ct, cs, cfg)
+	require.NoError(t, err)
+	defer func() {
+		err = proc.Shutdown(context.Background())
+		require.NoError(t, err)
+	}()
+
+	err = proc.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	// test
+	_, batches := generateIDsAndBatches(2)
+	for _, batch := range batches {
+		err = proc.ConsumeTraces(context.Background(), batch)
+		require.NoError(t, err)
+	}
+
+	tsp := proc.(*tailSamplingSpanProcessor)
+	tsp.policyTicker.OnTick() // the first tick always gets an empty batch
+	tsp.policyTicker.OnTick()
+
+	// verify
+	assert.Empty(t, cs.AllTraces())
+
+	var md metricdata.ResourceMetrics
+	require.NoError(t, s.reader.Collect(context.Background(), &md))
+
+	for _, m := range []metricdata.Metrics{
+		{
+			Name:        "otelcol_processor_tail_sampling_shadow_count_traces_sampled",
+			Description: "Count of traces that would be sampled or not per shadow sampling policy",
+			Unit:        "{traces}",
+			Data: metricdata.Sum[int64]{
+				IsMonotonic: true,
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Attributes: attribute.NewSet(
+							attribute.String("policy", "always"),
+							attribute.String("sampled", "true"),
+						),
+						Value: 2,
+					},
+					{
+						Attributes: attribute.NewSet(
+							attribute.String("policy", "string"),
+							attribute.String("sampled", "false"),
+						),
+						Value: 2,
+					},
+				},
+			},
+		},
+		{
+			Name:        "otelcol_processor_tail_sampling_shadow_global_count_traces_sampled",
+			Description: "Global count of traces that would be sampled or not by at least one shadow policy",
+			Unit:        "{traces}",
+			Data: metricdata.Sum[int64]{
+				IsMonotonic: true,
+				Temporality: metricdata.CumulativeTemporality,
+				DataPoints: []metricdata.DataPoint[int64]{
+					{
+						Attributes: attribute.NewSet(
+							attribute.String("sampled", "true"),
+						),
+						Value: 2,
+					},
+				},
+			},
+		},
+	} {
+		got := s.getMetric(m.Name, md)
+		metricdatatest.AssertEqual(t, m, got, metricdatatest.IgnoreTimestamp())
+	}
+}
+
 type testTelemetry struct {
 	reader        *sdkmetric.ManualReader
 	meterProvider *sdkmetric.MeterProvider
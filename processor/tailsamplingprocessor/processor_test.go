@@ -629,6 +629,34 @@ func TestDuplicatePolicyName(t *testing.T) {
 	assert.Equal(t, err, errors.New(`duplicate policy name "always_sample"`))
 }
 
+func TestProbabilisticPolicyConfigErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         ProbabilisticCfg
+		expectedErr string
+	}{
+		{
+			name:        "unknown mode",
+			cfg:         ProbabilisticCfg{SamplingPercentage: 10, Mode: "unknown"},
+			expectedErr: `unknown probabilistic sampling mode "unknown"`,
+		},
+		{
+			name:        "precision too high",
+			cfg:         ProbabilisticCfg{SamplingPercentage: 10, Mode: Equalizing, SamplingPrecision: 15},
+			expectedErr: "sampling_precision must be between 1 and 14",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := getSharedPolicyEvaluator(componenttest.NewNopTelemetrySettings(), &sharedPolicyCfg{
+				Type:             Probabilistic,
+				ProbabilisticCfg: tt.cfg,
+			})
+			require.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
 func TestDecisionPolicyMetrics(t *testing.T) {
 	traceIDs, batches := generateIDsAndBatches(10)
 	policy := []PolicyCfg{
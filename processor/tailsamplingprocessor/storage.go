@@ -0,0 +1,237 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/cache"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/tracestore"
+)
+
+const (
+	sampledDecisionsKey    = "sampled_decisions"
+	nonSampledDecisionsKey = "non_sampled_decisions"
+)
+
+// recordingDecisionCache remembers the trace IDs most recently put in a decision
+// cache, so that its contents can be saved periodically and restored on start.
+type recordingDecisionCache struct {
+	cache.Cache[bool]
+
+	mu      sync.Mutex
+	ids     []pcommon.TraceID
+	size    int
+	next    int
+	changed bool
+}
+
+func newRecordingDecisionCache(c cache.Cache[bool], size int) *recordingDecisionCache {
+	return &recordingDecisionCache{
+		Cache: c,
+		ids:   make([]pcommon.TraceID, 0, size),
+		size:  size,
+	}
+}
+
+func (c *recordingDecisionCache) Put(id pcommon.TraceID, v bool) {
+	c.Cache.Put(id, v)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.changed = true
+	if len(c.ids) < c.size {
+		c.ids = append(c.ids, id)
+		return
+	}
+	c.ids[c.next] = id
+	c.next = (c.next + 1) % c.size
+}
+
+// recorded returns the recorded trace IDs, from the oldest to the most recent one,
+// and whether they changed since the previous call.
+func (c *recordingDecisionCache) recorded() ([]pcommon.TraceID, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	changed := c.changed
+	c.changed = false
+	ids := make([]pcommon.TraceID, 0, len(c.ids))
+	ids = append(ids, c.ids[c.next:]...)
+	return append(ids, c.ids[:c.next]...), changed
+}
+
+// markChanged makes the next call to recorded report a change, so that recorded
+// trace IDs which could not be saved are saved again.
+func (c *recordingDecisionCache) markChanged() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.changed = true
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID) (storage.Client, error) {
+	extension, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindProcessor, componentID, "")
+}
+
+// startStorage opens the storage client and recovers the pending traces and
+// decisions persisted by a previous run.
+func (tsp *tailSamplingSpanProcessor) startStorage(ctx context.Context, host component.Host) error {
+	client, err := getStorageClient(ctx, host, tsp.storageID, tsp.set.ID)
+	if err != nil {
+		return err
+	}
+	tsp.storageClient = client
+	tsp.traceStore = tracestore.New(client, tsp.maxStorageSize)
+
+	traces, err := tsp.traceStore.Recover(ctx)
+	if err != nil {
+		return err
+	}
+	currTime := time.Now()
+	for _, t := range traces {
+		spanCount := &atomic.Int64{}
+		spanCount.Store(t.SpanCount)
+		td := &sampling.TraceData{
+			ArrivalTime:     t.ArrivalTime,
+			SpanCount:       spanCount,
+			ReceivedBatches: ptrace.NewTraces(),
+		}
+		if _, loaded := tsp.idToTrace.LoadOrStore(t.ID, td); loaded {
+			continue
+		}
+		tsp.decisionBatcher.AddToCurrentBatch(t.ID)
+		tsp.numTracesOnMap.Add(1)
+		tsp.enqueueForDeletion(t.ID, td, currTime)
+	}
+	if len(traces) > 0 {
+		tsp.logger.Info("Recovered pending traces from storage", zap.Int("traces", len(traces)))
+	}
+
+	return tsp.restoreDecisions(ctx)
+}
+
+func (tsp *tailSamplingSpanProcessor) restoreDecisions(ctx context.Context) error {
+	for key, c := range map[string]cache.Cache[bool]{
+		sampledDecisionsKey:    tsp.sampledIDCache,
+		nonSampledDecisionsKey: tsp.nonSampledIDCache,
+	} {
+		if _, ok := c.(*recordingDecisionCache); !ok {
+			continue
+		}
+		ids, err := tsp.traceStore.LoadIDs(ctx, key)
+		if err != nil {
+			return fmt.Errorf("failed to restore %s: %w", key, err)
+		}
+		for _, id := range ids {
+			c.Put(id, true)
+		}
+	}
+	return nil
+}
+
+// shutdownStorage moves the spans of the pending traces and the decisions to
+// storage before closing the storage client.
+func (tsp *tailSamplingSpanProcessor) shutdownStorage(ctx context.Context) error {
+	tsp.spillTraces(ctx)
+	tsp.saveDecisions(ctx)
+
+	return tsp.storageClient.Close(ctx)
+}
+
+// saveDecisions stores the trace IDs recorded by the decision caches, if they
+// changed since the last call.
+func (tsp *tailSamplingSpanProcessor) saveDecisions(ctx context.Context) {
+	for key, c := range map[string]cache.Cache[bool]{
+		sampledDecisionsKey:    tsp.sampledIDCache,
+		nonSampledDecisionsKey: tsp.nonSampledIDCache,
+	} {
+		rc, ok := c.(*recordingDecisionCache)
+		if !ok {
+			continue
+		}
+		ids, changed := rc.recorded()
+		if !changed {
+			continue
+		}
+		if err := tsp.traceStore.SaveIDs(ctx, key, ids); err != nil {
+			tsp.logger.Warn("Failed to save sampling decisions", zap.String("key", key), zap.Error(err))
+			rc.markChanged()
+		}
+	}
+}
+
+// spillTraces moves the spans received for the traces pending a decision to
+// storage, and commits them along with the traces removed since the last call.
+func (tsp *tailSamplingSpanProcessor) spillTraces(ctx context.Context) {
+	tsp.idToTrace.Range(func(key, value any) bool {
+		id := key.(pcommon.TraceID)
+		trace := value.(*sampling.TraceData)
+
+		trace.Lock()
+		defer trace.Unlock()
+
+		if trace.FinalDecision != sampling.Unspecified || trace.ReceivedBatches.ResourceSpans().Len() == 0 {
+			return true
+		}
+		// The trace may have been dropped while waiting for the lock.
+		if _, ok := tsp.idToTrace.Load(id); !ok {
+			return true
+		}
+
+		stored, err := tsp.traceStore.Append(id, trace.ArrivalTime, trace.SpanCount.Load(), trace.ReceivedBatches)
+		if err != nil {
+			tsp.logger.Warn("Failed to store spans", zap.Stringer("id", id), zap.Error(err))
+			return true
+		}
+		if !stored {
+			tsp.logger.Debug("Storage size limit reached, keeping spans in memory")
+			return false
+		}
+		trace.ReceivedBatches = ptrace.NewTraces()
+		return true
+	})
+
+	if err := tsp.traceStore.Commit(ctx); err != nil {
+		tsp.logger.Warn("Failed to commit pending traces to storage", zap.Error(err))
+	}
+}
+
+// loadStoredSpans moves the spans held in storage for the trace back to its
+// received batches, ahead of the ones received since the last spill. When they
+// cannot be loaded, the spans stay in storage until the trace is dropped, and
+// the decision is made on the spans held in memory.
+func (tsp *tailSamplingSpanProcessor) loadStoredSpans(ctx context.Context, id pcommon.TraceID, trace *sampling.TraceData) {
+	trace.Lock()
+	defer trace.Unlock()
+
+	stored, err := tsp.traceStore.Load(ctx, id)
+	if err != nil {
+		tsp.logger.Warn("Failed to load stored spans, deciding on the spans held in memory", zap.Stringer("id", id), zap.Error(err))
+		return
+	}
+	tsp.traceStore.Remove(id)
+
+	trace.ReceivedBatches.ResourceSpans().MoveAndAppendTo(stored.ResourceSpans())
+	trace.ReceivedBatches = stored
+}
@@ -0,0 +1,252 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+var testStorageID = component.MustNewID("test_storage")
+
+type testStorageClient struct {
+	mu       sync.Mutex
+	data     map[string][]byte
+	failGets bool
+}
+
+func (c *testStorageClient) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	err := c.Batch(ctx, op)
+	return op.Value, err
+}
+
+func (c *testStorageClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+func (c *testStorageClient) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+func (c *testStorageClient) Batch(_ context.Context, ops ...*storage.Operation) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, op := range ops {
+		if op.Type == storage.Get && c.failGets {
+			return errors.New("get failed")
+		}
+	}
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = c.data[op.Key]
+		case storage.Set:
+			c.data[op.Key] = op.Value
+		case storage.Delete:
+			delete(c.data, op.Key)
+		}
+	}
+	return nil
+}
+
+func (c *testStorageClient) Close(context.Context) error {
+	return nil
+}
+
+type testStorageExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	client *testStorageClient
+}
+
+func (e *testStorageExtension) GetClient(context.Context, component.Kind, component.ID, string) (storage.Client, error) {
+	return e.client, nil
+}
+
+type storageHost struct {
+	component.Host
+	extension *testStorageExtension
+}
+
+func newStorageHost() *storageHost {
+	return &storageHost{
+		Host:      componenttest.NewNopHost(),
+		extension: &testStorageExtension{client: &testStorageClient{data: map[string][]byte{}}},
+	}
+}
+
+func (h *storageHost) GetExtensions() map[component.ID]component.Component {
+	return map[component.ID]component.Component{testStorageID: h.extension}
+}
+
+func newStorageTestProcessor(t *testing.T, sink *consumertest.TracesSink) *tailSamplingSpanProcessor {
+	cfg := Config{
+		DecisionWait:  defaultTestDecisionWait,
+		NumTraces:     defaultNumTraces,
+		PolicyCfgs:    testPolicy,
+		DecisionCache: DecisionCacheConfig{SampledCacheSize: 10},
+		Storage:       StorageConfig{Extension: &testStorageID, MaxSizeMiB: 1},
+		Options: []Option{
+			withDecisionBatcher(newSyncIDBatcher()),
+		},
+	}
+	p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), sink, cfg)
+	require.NoError(t, err)
+	return p.(*tailSamplingSpanProcessor)
+}
+
+func TestStorageRecoversPendingTraces(t *testing.T) {
+	host := newStorageHost()
+	traceIDs, batches := generateIDsAndBatches(3)
+
+	sink := new(consumertest.TracesSink)
+	tsp := newStorageTestProcessor(t, sink)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	for _, batch := range batches {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batch))
+	}
+
+	// The first tick gets an empty batch and moves the pending spans to storage
+	tsp.policyTicker.OnTick()
+	require.Empty(t, sink.AllTraces())
+	assert.Contains(t, host.extension.client.data, "trace_index")
+	assert.Positive(t, tsp.traceStore.Size())
+
+	// Simulate a crash, the processor is not shut down
+	tsp.decisionBatcher.Stop()
+	tsp.policyTicker.Stop()
+
+	restarted := newStorageTestProcessor(t, sink)
+	require.NoError(t, restarted.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, restarted.Shutdown(context.Background()))
+	}()
+	assert.Equal(t, uint64(3), restarted.numTracesOnMap.Load())
+
+	restarted.policyTicker.OnTick() // the first tick always gets an empty batch
+	restarted.policyTicker.OnTick()
+
+	require.Len(t, sink.AllTraces(), 3)
+	for i, traceID := range traceIDs {
+		assert.Equal(t, i+1, findTrace(t, sink.AllTraces(), traceID).SpanCount())
+	}
+	assert.Zero(t, restarted.traceStore.Size())
+}
+
+func TestStoragePersistsDecisions(t *testing.T) {
+	host := newStorageHost()
+	traceIDs, batches := generateIDsAndBatches(1)
+
+	sink := new(consumertest.TracesSink)
+	tsp := newStorageTestProcessor(t, sink)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[0]))
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	require.Len(t, sink.AllTraces(), 1)
+	require.NoError(t, tsp.Shutdown(context.Background()))
+
+	restarted := newStorageTestProcessor(t, sink)
+	require.NoError(t, restarted.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, restarted.Shutdown(context.Background()))
+	}()
+
+	_, ok := restarted.sampledIDCache.Get(traceIDs[0])
+	require.True(t, ok)
+
+	// Late spans of the sampled trace are released without waiting for a decision
+	require.NoError(t, restarted.ConsumeTraces(context.Background(), simpleTracesWithID(traceIDs[0])))
+	require.Len(t, sink.AllTraces(), 2)
+}
+
+func TestStorageSavesDecisionsOnTick(t *testing.T) {
+	host := newStorageHost()
+	traceIDs, batches := generateIDsAndBatches(1)
+
+	sink := new(consumertest.TracesSink)
+	tsp := newStorageTestProcessor(t, sink)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[0]))
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	require.Len(t, sink.AllTraces(), 1)
+
+	// Simulate a crash, the processor is not shut down
+	tsp.decisionBatcher.Stop()
+	tsp.policyTicker.Stop()
+
+	restarted := newStorageTestProcessor(t, sink)
+	require.NoError(t, restarted.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, restarted.Shutdown(context.Background()))
+	}()
+
+	_, ok := restarted.sampledIDCache.Get(traceIDs[0])
+	require.True(t, ok)
+}
+
+func TestStorageKeepsSpansFailingToLoad(t *testing.T) {
+	host := newStorageHost()
+	traceIDs, batches := generateIDsAndBatches(1)
+
+	sink := new(consumertest.TracesSink)
+	tsp := newStorageTestProcessor(t, sink)
+	require.NoError(t, tsp.Start(context.Background(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[0]))
+
+	tsp.policyTicker.OnTick()
+	size := tsp.traceStore.Size()
+	require.Positive(t, size)
+
+	host.extension.client.mu.Lock()
+	host.extension.client.failGets = true
+	host.extension.client.mu.Unlock()
+
+	d, ok := tsp.idToTrace.Load(traceIDs[0])
+	require.True(t, ok)
+	trace := d.(*sampling.TraceData)
+	tsp.loadStoredSpans(context.Background(), traceIDs[0], trace)
+	assert.Zero(t, trace.ReceivedBatches.SpanCount())
+	assert.Equal(t, size, tsp.traceStore.Size())
+
+	host.extension.client.mu.Lock()
+	host.extension.client.failGets = false
+	host.extension.client.mu.Unlock()
+
+	tsp.loadStoredSpans(context.Background(), traceIDs[0], trace)
+	assert.Equal(t, 1, trace.ReceivedBatches.SpanCount())
+	assert.Zero(t, tsp.traceStore.Size())
+}
+
+func TestStorageExtensionNotFound(t *testing.T) {
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    defaultNumTraces,
+		PolicyCfgs:   testPolicy,
+		Storage:      StorageConfig{Extension: &testStorageID, MaxSizeMiB: 1},
+	}
+	p, err := newTracesProcessor(context.Background(), processortest.NewNopSettings(metadata.Type), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+
+	require.EqualError(t, p.Start(context.Background(), componenttest.NewNopHost()), "storage extension 'test_storage' not found")
+	require.NoError(t, p.Shutdown(context.Background()))
+}
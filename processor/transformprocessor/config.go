@@ -43,6 +43,15 @@ type Config struct {
 	MetricStatements []common.ContextStatements `mapstructure:"metric_statements"`
 	LogStatements    []common.ContextStatements `mapstructure:"log_statements"`
 
+	// Functions declares reusable OTTL functions which can be called from any of the
+	// configured statements, like any other editor or converter.
+	Functions []ottl.FunctionDefinition `mapstructure:"functions"`
+
+	// StatementTelemetry enables the statement-level internal metrics. When enabled, the number of
+	// evaluations, condition matches and errors, and the cumulative execution time of each statement
+	// are recorded, labeled by the statement index within its group and the statement fingerprint.
+	StatementTelemetry bool `mapstructure:"statement_telemetry"`
+
 	FlattenData bool `mapstructure:"flatten_data"`
 	logger      *zap.Logger
 }
@@ -133,7 +142,7 @@ func (c *Config) Validate() error {
 	var errors error
 
 	if len(c.TraceStatements) > 0 {
-		pc, err := common.NewTraceParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithSpanParser(traces.SpanFunctions()), common.WithSpanEventParser(traces.SpanEventFunctions()))
+		pc, err := common.NewTraceParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithSpanParser(traces.SpanFunctions()), common.WithSpanEventParser(traces.SpanEventFunctions()), common.WithTraceFunctionDefinitions(c.Functions))
 		if err != nil {
 			return err
 		}
@@ -146,7 +155,7 @@ func (c *Config) Validate() error {
 	}
 
 	if len(c.MetricStatements) > 0 {
-		pc, err := common.NewMetricParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithMetricParser(metrics.MetricFunctions()), common.WithDataPointParser(metrics.DataPointFunctions()))
+		pc, err := common.NewMetricParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithMetricParser(metrics.MetricFunctions()), common.WithDataPointParser(metrics.DataPointFunctions()), common.WithMetricFunctionDefinitions(c.Functions))
 		if err != nil {
 			return err
 		}
@@ -159,7 +168,7 @@ func (c *Config) Validate() error {
 	}
 
 	if len(c.LogStatements) > 0 {
-		pc, err := common.NewLogParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithLogParser(logs.LogFunctions()))
+		pc, err := common.NewLogParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithLogParser(logs.LogFunctions()), common.WithLogFunctionDefinitions(c.Functions))
 		if err != nil {
 			return err
 		}
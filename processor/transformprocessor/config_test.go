@@ -126,6 +126,20 @@ func TestLoadConfig(t *testing.T) {
 				LogStatements:    []common.ContextStatements{},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "statement_telemetry"),
+			expected: &Config{
+				ErrorMode:          ottl.IgnoreError,
+				StatementTelemetry: true,
+				TraceStatements:    []common.ContextStatements{},
+				MetricStatements:   []common.ContextStatements{},
+				LogStatements: []common.ContextStatements{
+					{
+						Statements: []string{`set(log.attributes["name"], "bear")`},
+					},
+				},
+			},
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_trace"),
 		},
@@ -236,6 +250,50 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "with_functions"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				Functions: []ottl.FunctionDefinition{
+					{
+						Name:   "normalize_http",
+						Params: []ottl.ParameterDefinition{{Name: "target", Type: ottl.PathParameter}},
+						Statements: []string{
+							`set(target["http.method"], ToUpperCase(target["http.method"]))`,
+							`delete_key(target, "http.path")`,
+						},
+					},
+					{
+						Name:       "Route",
+						Params:     []ottl.ParameterDefinition{{Name: "url", Type: ottl.StringParameter}},
+						Expression: `Split(url, "?")[0]`,
+					},
+				},
+				TraceStatements: []common.ContextStatements{
+					{
+						Statements: []string{`normalize_http(span.attributes)`},
+					},
+				},
+				MetricStatements: []common.ContextStatements{},
+				LogStatements: []common.ContextStatements{
+					{
+						Statements: []string{`set(log.attributes["http.route"], Route(log.attributes["http.url"]))`},
+					},
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_function_definition"),
+			errors: []error{
+				errors.New(`duplicate parameter "target"`),
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_function_call"),
+			errors: []error{
+				errors.New(`invalid argument for parameter "url": expected string but got int`),
+			},
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "context_statements_error_mode"),
 			expected: &Config{
@@ -7,9 +7,25 @@ import (
 	"fmt"
 	"strings"
 
+	"go.opentelemetry.io/collector/featuregate"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
+var StaticAnalysisLoggingFeatureGate = featuregate.GlobalRegistry().MustRegister("transform.staticAnalysisLogging", featuregate.StageAlpha,
+	featuregate.WithRegisterDescription("When enabled, the processor logs a warning for each potential problem found by the OTTL static analysis of its statements and conditions."),
+	featuregate.WithRegisterFromVersion("v0.126.0"),
+)
+
+// staticAnalysisLogging returns the option making the parsers log the problems found by the
+// static analysis, which does nothing unless the transform.staticAnalysisLogging feature gate is enabled.
+func staticAnalysisLogging[K any]() ottl.Option[K] {
+	if !StaticAnalysisLoggingFeatureGate.IsEnabled() {
+		return func(*ottl.Parser[K]) {}
+	}
+	return ottl.EnableStaticAnalysisLogging[K]()
+}
+
 var _ ottl.StatementsGetter = (*ContextStatements)(nil)
 
 type ContextID string
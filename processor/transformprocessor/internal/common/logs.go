@@ -59,7 +59,7 @@ type LogParserCollectionOption ottl.ParserCollectionOption[LogsConsumer]
 
 func WithLogParser(functions map[string]ottl.Factory[ottllog.TransformContext]) LogParserCollectionOption {
 	return func(pc *ottl.ParserCollection[LogsConsumer]) error {
-		logParser, err := ottllog.NewParser(functions, pc.Settings, ottllog.EnablePathContextNames())
+		logParser, err := ottllog.NewParser(functions, pc.Settings, ottllog.EnablePathContextNames(), staticAnalysisLogging[ottllog.TransformContext]())
 		if err != nil {
 			return err
 		}
@@ -71,6 +71,14 @@ func WithLogErrorMode(errorMode ottl.ErrorMode) LogParserCollectionOption {
 	return LogParserCollectionOption(ottl.WithParserCollectionErrorMode[LogsConsumer](errorMode))
 }
 
+func WithLogStatementTelemetry(enabled bool) LogParserCollectionOption {
+	return LogParserCollectionOption(ottl.EnableParserCollectionStatementTelemetry[LogsConsumer](enabled))
+}
+
+func WithLogFunctionDefinitions(definitions []ottl.FunctionDefinition) LogParserCollectionOption {
+	return LogParserCollectionOption(ottl.WithParserCollectionFunctionDefinitions[LogsConsumer](definitions))
+}
+
 func NewLogParserCollection(settings component.TelemetrySettings, options ...LogParserCollectionOption) (*LogParserCollection, error) {
 	pcOptions := []ottl.ParserCollectionOption[LogsConsumer]{
 		withCommonContextParsers[LogsConsumer](),
@@ -99,7 +107,7 @@ func convertLogStatements(pc *ottl.ParserCollection[LogsConsumer], statements ot
 	if contextStatements.ErrorMode != "" {
 		errorMode = contextStatements.ErrorMode
 	}
-	var parserOptions []ottl.Option[ottllog.TransformContext]
+	parserOptions := []ottl.Option[ottllog.TransformContext]{staticAnalysisLogging[ottllog.TransformContext]()}
 	if contextStatements.Context == "" {
 		parserOptions = append(parserOptions, ottllog.EnablePathContextNames())
 	}
@@ -107,7 +115,11 @@ func convertLogStatements(pc *ottl.ParserCollection[LogsConsumer], statements ot
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	lStatements := ottllog.NewStatementSequence(parsedStatements, pc.Settings, ottllog.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottllog.StatementSequenceOption{ottllog.WithStatementSequenceErrorMode(errorMode)}
+	if pc.StatementTelemetry {
+		sequenceOptions = append(sequenceOptions, ottllog.WithStatementSequenceTelemetry())
+	}
+	lStatements := ottllog.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	return logStatements{lStatements, globalExpr}, nil
 }
 
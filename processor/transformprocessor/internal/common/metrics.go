@@ -169,7 +169,7 @@ type MetricParserCollectionOption ottl.ParserCollectionOption[MetricsConsumer]
 
 func WithMetricParser(functions map[string]ottl.Factory[ottlmetric.TransformContext]) MetricParserCollectionOption {
 	return func(pc *ottl.ParserCollection[MetricsConsumer]) error {
-		metricParser, err := ottlmetric.NewParser(functions, pc.Settings, ottlmetric.EnablePathContextNames())
+		metricParser, err := ottlmetric.NewParser(functions, pc.Settings, ottlmetric.EnablePathContextNames(), staticAnalysisLogging[ottlmetric.TransformContext]())
 		if err != nil {
 			return err
 		}
@@ -179,7 +179,7 @@ func WithMetricParser(functions map[string]ottl.Factory[ottlmetric.TransformCont
 
 func WithDataPointParser(functions map[string]ottl.Factory[ottldatapoint.TransformContext]) MetricParserCollectionOption {
 	return func(pc *ottl.ParserCollection[MetricsConsumer]) error {
-		dataPointParser, err := ottldatapoint.NewParser(functions, pc.Settings, ottldatapoint.EnablePathContextNames())
+		dataPointParser, err := ottldatapoint.NewParser(functions, pc.Settings, ottldatapoint.EnablePathContextNames(), staticAnalysisLogging[ottldatapoint.TransformContext]())
 		if err != nil {
 			return err
 		}
@@ -191,6 +191,14 @@ func WithMetricErrorMode(errorMode ottl.ErrorMode) MetricParserCollectionOption
 	return MetricParserCollectionOption(ottl.WithParserCollectionErrorMode[MetricsConsumer](errorMode))
 }
 
+func WithMetricStatementTelemetry(enabled bool) MetricParserCollectionOption {
+	return MetricParserCollectionOption(ottl.EnableParserCollectionStatementTelemetry[MetricsConsumer](enabled))
+}
+
+func WithMetricFunctionDefinitions(definitions []ottl.FunctionDefinition) MetricParserCollectionOption {
+	return MetricParserCollectionOption(ottl.WithParserCollectionFunctionDefinitions[MetricsConsumer](definitions))
+}
+
 func NewMetricParserCollection(settings component.TelemetrySettings, options ...MetricParserCollectionOption) (*MetricParserCollection, error) {
 	pcOptions := []ottl.ParserCollectionOption[MetricsConsumer]{
 		withCommonContextParsers[MetricsConsumer](),
@@ -219,7 +227,7 @@ func convertMetricStatements(pc *ottl.ParserCollection[MetricsConsumer], stateme
 	if contextStatements.ErrorMode != "" {
 		errorMode = contextStatements.ErrorMode
 	}
-	var parserOptions []ottl.Option[ottlmetric.TransformContext]
+	parserOptions := []ottl.Option[ottlmetric.TransformContext]{staticAnalysisLogging[ottlmetric.TransformContext]()}
 	if contextStatements.Context == "" {
 		parserOptions = append(parserOptions, ottlmetric.EnablePathContextNames())
 	}
@@ -227,7 +235,11 @@ func convertMetricStatements(pc *ottl.ParserCollection[MetricsConsumer], stateme
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	mStatements := ottlmetric.NewStatementSequence(parsedStatements, pc.Settings, ottlmetric.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottlmetric.StatementSequenceOption{ottlmetric.WithStatementSequenceErrorMode(errorMode)}
+	if pc.StatementTelemetry {
+		sequenceOptions = append(sequenceOptions, ottlmetric.WithStatementSequenceTelemetry())
+	}
+	mStatements := ottlmetric.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	return metricStatements{mStatements, globalExpr}, nil
 }
 
@@ -240,7 +252,7 @@ func convertDataPointStatements(pc *ottl.ParserCollection[MetricsConsumer], stat
 	if contextStatements.ErrorMode != "" {
 		errorMode = contextStatements.ErrorMode
 	}
-	var parserOptions []ottl.Option[ottldatapoint.TransformContext]
+	parserOptions := []ottl.Option[ottldatapoint.TransformContext]{staticAnalysisLogging[ottldatapoint.TransformContext]()}
 	if contextStatements.Context == "" {
 		parserOptions = append(parserOptions, ottldatapoint.EnablePathContextNames())
 	}
@@ -248,7 +260,11 @@ func convertDataPointStatements(pc *ottl.ParserCollection[MetricsConsumer], stat
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	dpStatements := ottldatapoint.NewStatementSequence(parsedStatements, pc.Settings, ottldatapoint.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottldatapoint.StatementSequenceOption{ottldatapoint.WithStatementSequenceErrorMode(errorMode)}
+	if pc.StatementTelemetry {
+		sequenceOptions = append(sequenceOptions, ottldatapoint.WithStatementSequenceTelemetry())
+	}
+	dpStatements := ottldatapoint.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	return dataPointStatements{dpStatements, globalExpr}, nil
 }
 
@@ -165,11 +165,11 @@ type baseContext interface {
 
 func withCommonContextParsers[R any]() ottl.ParserCollectionOption[R] {
 	return func(pc *ottl.ParserCollection[R]) error {
-		rp, err := ottlresource.NewParser(ResourceFunctions(), pc.Settings, ottlresource.EnablePathContextNames())
+		rp, err := ottlresource.NewParser(ResourceFunctions(), pc.Settings, ottlresource.EnablePathContextNames(), staticAnalysisLogging[ottlresource.TransformContext]())
 		if err != nil {
 			return err
 		}
-		sp, err := ottlscope.NewParser(ScopeFunctions(), pc.Settings, ottlscope.EnablePathContextNames())
+		sp, err := ottlscope.NewParser(ScopeFunctions(), pc.Settings, ottlscope.EnablePathContextNames(), staticAnalysisLogging[ottlscope.TransformContext]())
 		if err != nil {
 			return err
 		}
@@ -201,7 +201,7 @@ func parseResourceContextStatements[R any](
 	if contextStatements.ErrorMode != "" {
 		errorMode = contextStatements.ErrorMode
 	}
-	var parserOptions []ottl.Option[ottlresource.TransformContext]
+	parserOptions := []ottl.Option[ottlresource.TransformContext]{staticAnalysisLogging[ottlresource.TransformContext]()}
 	if contextStatements.Context == "" {
 		parserOptions = append(parserOptions, ottlresource.EnablePathContextNames())
 	}
@@ -209,7 +209,11 @@ func parseResourceContextStatements[R any](
 	if errGlobalBoolExpr != nil {
 		return *new(R), errGlobalBoolExpr
 	}
-	rStatements := ottlresource.NewStatementSequence(parsedStatements, pc.Settings, ottlresource.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottlresource.StatementSequenceOption{ottlresource.WithStatementSequenceErrorMode(errorMode)}
+	if pc.StatementTelemetry {
+		sequenceOptions = append(sequenceOptions, ottlresource.WithStatementSequenceTelemetry())
+	}
+	rStatements := ottlresource.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	result := (baseContext)(resourceStatements{rStatements, globalExpr})
 	return result.(R), nil
 }
@@ -227,7 +231,7 @@ func parseScopeContextStatements[R any](
 	if contextStatements.ErrorMode != "" {
 		errorMode = contextStatements.ErrorMode
 	}
-	var parserOptions []ottl.Option[ottlscope.TransformContext]
+	parserOptions := []ottl.Option[ottlscope.TransformContext]{staticAnalysisLogging[ottlscope.TransformContext]()}
 	if contextStatements.Context == "" {
 		parserOptions = append(parserOptions, ottlscope.EnablePathContextNames())
 	}
@@ -235,7 +239,11 @@ func parseScopeContextStatements[R any](
 	if errGlobalBoolExpr != nil {
 		return *new(R), errGlobalBoolExpr
 	}
-	sStatements := ottlscope.NewStatementSequence(parsedStatements, pc.Settings, ottlscope.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottlscope.StatementSequenceOption{ottlscope.WithStatementSequenceErrorMode(errorMode)}
+	if pc.StatementTelemetry {
+		sequenceOptions = append(sequenceOptions, ottlscope.WithStatementSequenceTelemetry())
+	}
+	sStatements := ottlscope.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	result := (baseContext)(scopeStatements{sStatements, globalExpr})
 	return result.(R), nil
 }
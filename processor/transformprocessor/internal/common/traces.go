@@ -97,7 +97,7 @@ type TraceParserCollectionOption ottl.ParserCollectionOption[TracesConsumer]
 
 func WithSpanParser(functions map[string]ottl.Factory[ottlspan.TransformContext]) TraceParserCollectionOption {
 	return func(pc *ottl.ParserCollection[TracesConsumer]) error {
-		parser, err := ottlspan.NewParser(functions, pc.Settings, ottlspan.EnablePathContextNames())
+		parser, err := ottlspan.NewParser(functions, pc.Settings, ottlspan.EnablePathContextNames(), staticAnalysisLogging[ottlspan.TransformContext]())
 		if err != nil {
 			return err
 		}
@@ -107,7 +107,7 @@ func WithSpanParser(functions map[string]ottl.Factory[ottlspan.TransformContext]
 
 func WithSpanEventParser(functions map[string]ottl.Factory[ottlspanevent.TransformContext]) TraceParserCollectionOption {
 	return func(pc *ottl.ParserCollection[TracesConsumer]) error {
-		parser, err := ottlspanevent.NewParser(functions, pc.Settings, ottlspanevent.EnablePathContextNames())
+		parser, err := ottlspanevent.NewParser(functions, pc.Settings, ottlspanevent.EnablePathContextNames(), staticAnalysisLogging[ottlspanevent.TransformContext]())
 		if err != nil {
 			return err
 		}
@@ -119,6 +119,14 @@ func WithTraceErrorMode(errorMode ottl.ErrorMode) TraceParserCollectionOption {
 	return TraceParserCollectionOption(ottl.WithParserCollectionErrorMode[TracesConsumer](errorMode))
 }
 
+func WithTraceStatementTelemetry(enabled bool) TraceParserCollectionOption {
+	return TraceParserCollectionOption(ottl.EnableParserCollectionStatementTelemetry[TracesConsumer](enabled))
+}
+
+func WithTraceFunctionDefinitions(definitions []ottl.FunctionDefinition) TraceParserCollectionOption {
+	return TraceParserCollectionOption(ottl.WithParserCollectionFunctionDefinitions[TracesConsumer](definitions))
+}
+
 func NewTraceParserCollection(settings component.TelemetrySettings, options ...TraceParserCollectionOption) (*TraceParserCollection, error) {
 	pcOptions := []ottl.ParserCollectionOption[TracesConsumer]{
 		withCommonContextParsers[TracesConsumer](),
@@ -147,7 +155,7 @@ func convertSpanStatements(pc *ottl.ParserCollection[TracesConsumer], statements
 	if contextStatements.ErrorMode != "" {
 		errorMode = contextStatements.ErrorMode
 	}
-	var parserOptions []ottl.Option[ottlspan.TransformContext]
+	parserOptions := []ottl.Option[ottlspan.TransformContext]{staticAnalysisLogging[ottlspan.TransformContext]()}
 	if contextStatements.Context == "" {
 		parserOptions = append(parserOptions, ottlspan.EnablePathContextNames())
 	}
@@ -155,7 +163,11 @@ func convertSpanStatements(pc *ottl.ParserCollection[TracesConsumer], statements
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	sStatements := ottlspan.NewStatementSequence(parsedStatements, pc.Settings, ottlspan.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottlspan.StatementSequenceOption{ottlspan.WithStatementSequenceErrorMode(errorMode)}
+	if pc.StatementTelemetry {
+		sequenceOptions = append(sequenceOptions, ottlspan.WithStatementSequenceTelemetry())
+	}
+	sStatements := ottlspan.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	return traceStatements{sStatements, globalExpr}, nil
 }
 
@@ -168,7 +180,7 @@ func convertSpanEventStatements(pc *ottl.ParserCollection[TracesConsumer], state
 	if contextStatements.ErrorMode != "" {
 		errorMode = contextStatements.ErrorMode
 	}
-	var parserOptions []ottl.Option[ottlspanevent.TransformContext]
+	parserOptions := []ottl.Option[ottlspanevent.TransformContext]{staticAnalysisLogging[ottlspanevent.TransformContext]()}
 	if contextStatements.Context == "" {
 		parserOptions = append(parserOptions, ottlspanevent.EnablePathContextNames())
 	}
@@ -176,7 +188,11 @@ func convertSpanEventStatements(pc *ottl.ParserCollection[TracesConsumer], state
 	if errGlobalBoolExpr != nil {
 		return nil, errGlobalBoolExpr
 	}
-	seStatements := ottlspanevent.NewStatementSequence(parsedStatements, pc.Settings, ottlspanevent.WithStatementSequenceErrorMode(errorMode))
+	sequenceOptions := []ottlspanevent.StatementSequenceOption{ottlspanevent.WithStatementSequenceErrorMode(errorMode)}
+	if pc.StatementTelemetry {
+		sequenceOptions = append(sequenceOptions, ottlspanevent.WithStatementSequenceTelemetry())
+	}
+	seStatements := ottlspanevent.NewStatementSequence(parsedStatements, pc.Settings, sequenceOptions...)
 	return spanEventStatements{seStatements, globalExpr}, nil
 }
 
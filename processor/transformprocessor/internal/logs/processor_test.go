@@ -12,8 +12,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
@@ -57,7 +62,7 @@ func Test_ProcessLogs_ResourceContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "resource", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "resource", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, nil, false, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
@@ -98,7 +103,7 @@ func Test_ProcessLogs_InferredResourceContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, nil, false, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
@@ -139,7 +144,7 @@ func Test_ProcessLogs_ScopeContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "scope", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "scope", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, nil, false, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
@@ -180,7 +185,7 @@ func Test_ProcessLogs_InferredScopeContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, nil, false, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
@@ -434,7 +439,7 @@ func Test_ProcessLogs_LogContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "log", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "log", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, nil, false, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
@@ -688,7 +693,7 @@ func Test_ProcessLogs_InferredLogContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.statement, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor([]common.ContextStatements{{Context: "", Statements: []string{tt.statement}}}, ottl.IgnoreError, false, nil, false, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
@@ -702,6 +707,143 @@ func Test_ProcessLogs_InferredLogContext(t *testing.T) {
 	}
 }
 
+func Test_ProcessLogs_FunctionDefinitions(t *testing.T) {
+	functions := []ottl.FunctionDefinition{
+		{
+			Name:   "normalize_http",
+			Params: []ottl.ParameterDefinition{{Name: "target", Type: ottl.PathParameter}},
+			Statements: []string{
+				`set(target["http.method"], ToUpperCase(target["http.method"])) where target["http.method"] != nil`,
+				`delete_key(target, "http.path")`,
+			},
+		},
+		{
+			Name:       "IsHealthCheck",
+			Params:     []ottl.ParameterDefinition{{Name: "path", Type: ottl.StringParameter}},
+			Expression: `path == "/health"`,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		statements []common.ContextStatements
+		want       func(td plog.Logs)
+	}{
+		{
+			name:       "editor",
+			statements: []common.ContextStatements{{Context: "log", Statements: []string{`normalize_http(attributes) where body == "operationA"`}}},
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("http.method", "GET")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Remove("http.path")
+			},
+		},
+		{
+			name:       "converter in inferred context",
+			statements: []common.ContextStatements{{Statements: []string{`set(log.attributes["test"], "pass") where IsHealthCheck(log.attributes["http.path"])`}}},
+			want: func(td plog.Logs) {
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().PutStr("test", "pass")
+				td.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1).Attributes().PutStr("test", "pass")
+			},
+		},
+		{
+			name:       "resource context",
+			statements: []common.ContextStatements{{Context: "resource", Statements: []string{`normalize_http(attributes)`}}},
+			want:       func(_ plog.Logs) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := constructLogs()
+			processor, err := NewProcessor(tt.statements, ottl.PropagateError, false, functions, false, componenttest.NewNopTelemetrySettings())
+			require.NoError(t, err)
+
+			_, err = processor.ProcessLogs(context.Background(), td)
+			require.NoError(t, err)
+
+			exTd := constructLogs()
+			tt.want(exTd)
+
+			assert.Equal(t, exTd, td)
+		})
+	}
+
+	_, err := NewProcessor([]common.ContextStatements{{Context: "log", Statements: []string{`set(attributes["test"], IsHealthCheck(1))`}}}, ottl.PropagateError, false, functions, false, componenttest.NewNopTelemetrySettings())
+	assert.ErrorContains(t, err, `invalid argument for parameter "path": expected string but got int`)
+}
+
+func Test_NewProcessor_StaticAnalysisWarnings(t *testing.T) {
+	contextStatements := []common.ContextStatements{
+		{
+			Context:    "log",
+			Conditions: []string{`severity_number == "INFO"`},
+			Statements: []string{`set(attributes["test"], cache["value"])`},
+		},
+	}
+
+	core, logs := observer.New(zap.WarnLevel)
+	settings := componenttest.NewNopTelemetrySettings()
+	settings.Logger = zap.New(core)
+	_, err := NewProcessor(contextStatements, ottl.PropagateError, false, nil, false, settings)
+	require.NoError(t, err)
+	assert.Empty(t, logs.FilterMessageSnippet("potential problem found in OTTL").All())
+
+	require.NoError(t, featuregate.GlobalRegistry().Set(common.StaticAnalysisLoggingFeatureGate.ID(), true))
+	t.Cleanup(func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(common.StaticAnalysisLoggingFeatureGate.ID(), false))
+	})
+	_, err = NewProcessor(contextStatements, ottl.PropagateError, false, nil, false, settings)
+	require.NoError(t, err)
+
+	entries := logs.FilterMessageSnippet("potential problem found in OTTL").All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, ottl.DiagnosticRuleNilPath, entries[0].ContextMap()["rule"])
+	assert.Equal(t, `set(log.attributes["test"], log.cache["value"])`, entries[0].ContextMap()["statement"])
+	assert.Equal(t, ottl.DiagnosticRuleTypeMismatch, entries[1].ContextMap()["rule"])
+	assert.Equal(t, `severity_number == "INFO"`, entries[1].ContextMap()["condition"])
+}
+
+func Test_NewProcessor_StatementTelemetry(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	settings := componenttest.NewNopTelemetrySettings()
+	settings.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	statements := []string{
+		`set(log.attributes["test"], "pass") where log.body == "operationA"`,
+		`merge_maps(log.attributes, ParseJSON(log.body), "upsert")`,
+	}
+	processor, err := NewProcessor([]common.ContextStatements{{Statements: statements}}, ottl.IgnoreError, false, nil, true, settings)
+	require.NoError(t, err)
+
+	_, err = processor.ProcessLogs(context.Background(), constructLogs())
+	require.NoError(t, err)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	got := map[string]int64{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		sum, ok := m.Data.(metricdata.Sum[int64])
+		if !ok {
+			continue
+		}
+		for _, dp := range sum.DataPoints {
+			index, _ := dp.Attributes.Value(ottl.StatementIndexAttribute)
+			fingerprint, _ := dp.Attributes.Value(ottl.StatementFingerprintAttribute)
+			assert.Equal(t, ottl.StatementFingerprint(statements[index.AsInt64()]), fingerprint.AsString())
+			got[fmt.Sprintf("%s/%d", m.Name, index.AsInt64())] = dp.Value
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"otelcol_ottl_statement_evaluations/0":       2,
+		"otelcol_ottl_statement_evaluations/1":       2,
+		"otelcol_ottl_statement_condition_matches/0": 1,
+		"otelcol_ottl_statement_condition_matches/1": 2,
+		"otelcol_ottl_statement_errors/1":            2,
+	}, got)
+}
+
 func Test_ProcessLogs_MixContext(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -805,7 +947,7 @@ func Test_ProcessLogs_MixContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor(tt.contextStatements, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor(tt.contextStatements, ottl.IgnoreError, false, nil, false, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
@@ -895,7 +1037,7 @@ func Test_ProcessLogs_InferredMixContext(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor(tt.contextStatements, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor(tt.contextStatements, ottl.IgnoreError, false, nil, false, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
@@ -928,7 +1070,7 @@ func Test_ProcessLogs_ErrorMode(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(string(tt.context), func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor([]common.ContextStatements{{Context: tt.context, Statements: []string{`set(attributes["test"], ParseJSON(1))`}}}, ottl.PropagateError, false, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor([]common.ContextStatements{{Context: tt.context, Statements: []string{`set(attributes["test"], ParseJSON(1))`}}}, ottl.PropagateError, false, nil, false, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
@@ -1010,7 +1152,7 @@ func Test_ProcessLogs_StatementsErrorMode(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor(tt.statements, tt.errorMode, false, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor(tt.statements, tt.errorMode, false, nil, false, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 			_, err = processor.ProcessLogs(context.Background(), td)
 			if tt.wantErrorWith != "" {
@@ -1137,7 +1279,7 @@ func Test_ProcessLogs_CacheAccess(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor(tt.statements, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor(tt.statements, ottl.IgnoreError, false, nil, false, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
@@ -1187,7 +1329,7 @@ func Test_ProcessLogs_InferredContextFromConditions(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			td := constructLogs()
-			processor, err := NewProcessor(tt.contextStatements, ottl.IgnoreError, false, componenttest.NewNopTelemetrySettings())
+			processor, err := NewProcessor(tt.contextStatements, ottl.IgnoreError, false, nil, false, componenttest.NewNopTelemetrySettings())
 			assert.NoError(t, err)
 
 			_, err = processor.ProcessLogs(context.Background(), td)
@@ -1257,7 +1399,7 @@ func Test_NewProcessor_ConditionsParse(t *testing.T) {
 		t.Run(ctx, func(t *testing.T) {
 			for _, tt := range tests {
 				t.Run(tt.name, func(t *testing.T) {
-					_, err := NewProcessor(tt.statements, ottl.PropagateError, false, componenttest.NewNopTelemetrySettings())
+					_, err := NewProcessor(tt.statements, ottl.PropagateError, false, nil, false, componenttest.NewNopTelemetrySettings())
 					if tt.wantErrorWith != "" {
 						if err == nil {
 							t.Errorf("expected error containing '%s', got: <nil>", tt.wantErrorWith)